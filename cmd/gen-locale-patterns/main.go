@@ -0,0 +1,100 @@
+// Command gen-locale-patterns expands seedGlossary into
+// pkg/patterns/patterns_locale_gen.go: one registerLocale*Patterns call
+// per locale, wired into patterns.getGenderPatterns/getCategoryPatterns/
+// getTagPatterns via the registry in pkg/patterns/locale.go. Every locale
+// tag in the glossary is validated (and canonicalized) through
+// golang.org/x/text/language before being emitted, and both locales and
+// their pattern groups are written in sorted order so two runs over the
+// same seedGlossary produce byte-identical output.
+//
+// Run it from the repository root after editing seed.go:
+//
+//	go run ./cmd/gen-locale-patterns -out pkg/patterns/patterns_locale_gen.go
+package main
+
+import (
+	"bytes"
+	"flag"
+	"fmt"
+	"go/format"
+	"os"
+	"sort"
+
+	"golang.org/x/text/language"
+)
+
+func main() {
+	out := flag.String("out", "pkg/patterns/patterns_locale_gen.go", "output file path")
+	flag.Parse()
+
+	src, err := generate(seedGlossary)
+	if err != nil {
+		fmt.Fprintln(os.Stderr, "gen-locale-patterns:", err)
+		os.Exit(1)
+	}
+	if err := os.WriteFile(*out, src, 0o644); err != nil {
+		fmt.Fprintln(os.Stderr, "gen-locale-patterns:", err)
+		os.Exit(1)
+	}
+}
+
+// byLocaleDimGroup is locale -> dim -> group -> synonyms, the glossary
+// reshaped for per-locale emission.
+type byLocaleDimGroup map[string]map[string]map[string][]concept
+
+func generate(concepts []concept) ([]byte, error) {
+	locales := map[string]bool{}
+	byLocale := map[string]map[string][]concept{} // locale -> dim -> []concept (one per group)
+	for _, c := range concepts {
+		for locale, synonyms := range c.translations {
+			tag, err := language.Parse(locale)
+			if err != nil {
+				return nil, fmt.Errorf("concept %s/%s: invalid locale %q: %w", c.dim, c.group, locale, err)
+			}
+			canonical := tag.String()
+			locales[canonical] = true
+			if byLocale[canonical] == nil {
+				byLocale[canonical] = map[string][]concept{}
+			}
+			byLocale[canonical][c.dim] = append(byLocale[canonical][c.dim], concept{
+				dim: c.dim, group: c.group, weight: c.weight,
+				translations: map[string][]string{canonical: append([]string(nil), synonyms...)},
+			})
+		}
+	}
+
+	localeNames := make([]string, 0, len(locales))
+	for l := range locales {
+		localeNames = append(localeNames, l)
+	}
+	sort.Strings(localeNames)
+
+	var buf bytes.Buffer
+	buf.WriteString("// Code generated by cmd/gen-locale-patterns from seed.go. DO NOT EDIT.\n\n")
+	buf.WriteString("package patterns\n\n")
+	buf.WriteString("func init() {\n")
+	for _, locale := range localeNames {
+		writeRegisterCall(&buf, locale, "registerLocaleGenderPatterns", byLocale[locale]["gender"])
+		writeRegisterCall(&buf, locale, "registerLocaleCategoryPatterns", byLocale[locale]["category"])
+		writeRegisterCall(&buf, locale, "registerLocaleTagPatterns", byLocale[locale]["tag"])
+	}
+	buf.WriteString("}\n")
+
+	return format.Source(buf.Bytes())
+}
+
+func writeRegisterCall(buf *bytes.Buffer, locale, fn string, concepts []concept) {
+	if len(concepts) == 0 {
+		return
+	}
+	sort.Slice(concepts, func(i, j int) bool { return concepts[i].group < concepts[j].group })
+
+	fmt.Fprintf(buf, "%s(%q, map[string][]PatternRule{\n", fn, locale)
+	for _, c := range concepts {
+		synonyms := append([]string(nil), c.translations[locale]...)
+		sort.Strings(synonyms)
+		fmt.Fprintf(buf, "%q: {{Patterns: %#v, Weight: %d, Language: \"both\", Locale: %q}},\n",
+			c.group, synonyms, c.weight, locale)
+	}
+	buf.WriteString("})\n")
+}
@@ -0,0 +1,76 @@
+package main
+
+// seedGlossary is the hand-maintained synonym glossary this generator
+// expands into per-locale PatternRule tables. It covers the same core
+// semantic concepts the hard-coded German/English tables in
+// pkg/patterns/detection.go cover (gender, a handful of categories, and a
+// couple of tags), one locale-neutral "concept" per entry with its
+// translations keyed by BCP-47 locale.
+//
+// This is deliberately small and hand-maintained rather than mechanically
+// extracted from CLDR: CLDR's locale data gives us authoritative locale
+// *tags* and lets golang.org/x/text/language validate/canonicalize and
+// match them (see generate in main.go and
+// pkg/patterns/locale.go's DetectGenderForLocales), but CLDR doesn't ship
+// clothing-retail vocabulary - there's no "t-shirt" annotation dataset to
+// pull synonyms from. A real seed glossary would grow from translator or
+// marketplace-catalog review, not a generator run.
+var seedGlossary = []concept{
+	{dim: "gender", group: "women", weight: 10, translations: map[string][]string{
+		"fr-FR": {"femme", "femmes"},
+		"it-IT": {"donna", "donne"},
+		"es-ES": {"mujer", "mujeres"},
+		"nl-NL": {"dames", "vrouwen"},
+	}},
+	{dim: "gender", group: "men", weight: 10, translations: map[string][]string{
+		"fr-FR": {"homme", "hommes"},
+		"it-IT": {"uomo", "uomini"},
+		"es-ES": {"hombre", "hombres"},
+		"nl-NL": {"heren", "mannen"},
+	}},
+	{dim: "gender", group: "unisex", weight: 10, translations: map[string][]string{
+		"fr-FR": {"unisexe", "mixte"},
+		"it-IT": {"unisex"},
+		"es-ES": {"unisex"},
+		"nl-NL": {"unisex"},
+	}},
+	{dim: "category", group: "T-Shirts", weight: 10, translations: map[string][]string{
+		"fr-FR": {"t-shirt", "tee-shirt"},
+		"it-IT": {"maglietta", "t-shirt"},
+		"es-ES": {"camiseta"},
+		"nl-NL": {"t-shirt"},
+	}},
+	{dim: "category", group: "Poloshirts", weight: 10, translations: map[string][]string{
+		"fr-FR": {"polo"},
+		"it-IT": {"polo"},
+		"es-ES": {"polo"},
+		"nl-NL": {"polo"},
+	}},
+	{dim: "category", group: "Hoodies", weight: 10, translations: map[string][]string{
+		"fr-FR": {"sweat à capuche", "hoodie"},
+		"it-IT": {"felpa con cappuccio", "hoodie"},
+		"es-ES": {"sudadera con capucha"},
+		"nl-NL": {"hoodie", "capuchontrui"},
+	}},
+	{dim: "tag", group: "casual", weight: 5, translations: map[string][]string{
+		"fr-FR": {"décontracté"},
+		"it-IT": {"casual"},
+		"es-ES": {"informal"},
+		"nl-NL": {"casual"},
+	}},
+	{dim: "tag", group: "sport", weight: 5, translations: map[string][]string{
+		"fr-FR": {"sport"},
+		"it-IT": {"sportivo"},
+		"es-ES": {"deportivo"},
+		"nl-NL": {"sport"},
+	}},
+}
+
+// concept is one semantic concept (e.g. "gender:women") and its
+// per-locale synonym lists.
+type concept struct {
+	dim          string
+	group        string
+	weight       int
+	translations map[string][]string
+}
@@ -0,0 +1,134 @@
+// Command streamctl prints Redis stream/consumer-group introspection via
+// pkg/redis/admin, in human-readable or JSON form, so operators can
+// diagnose stuck consumers or check cluster health without ad-hoc
+// redis-cli sessions.
+//
+// Usage:
+//
+//	streamctl groups -stream <name> [-addr <redis-addr>] [-json]
+//	streamctl streams -pattern <glob> [-pattern <glob> ...] [-addr <redis-addr>] [-json]
+//	streamctl cluster [-addr <redis-addr>] [-json]
+//	streamctl pending -stream <name> -group <name> [-consumer <name>] [-count <n>] [-addr <redis-addr>] [-json]
+package main
+
+import (
+	"context"
+	"encoding/json"
+	"flag"
+	"fmt"
+	"os"
+	"strings"
+
+	"github.com/redis/go-redis/v9"
+
+	"github.com/MalteBoehm/tall-affiliate-common/pkg/redis/admin"
+)
+
+func main() {
+	if len(os.Args) < 2 {
+		usage()
+		os.Exit(2)
+	}
+
+	cmd := os.Args[1]
+	fs := flag.NewFlagSet(cmd, flag.ExitOnError)
+	addr := fs.String("addr", "localhost:6379", "redis address")
+	asJSON := fs.Bool("json", false, "print machine-readable JSON instead of a human-readable table")
+	stream := fs.String("stream", "", "stream name")
+	group := fs.String("group", "", "consumer group name")
+	consumer := fs.String("consumer", "", "consumer name (pending command only)")
+	count := fs.Int64("count", 100, "max entries to fetch (pending command only)")
+	var patterns stringSliceFlag
+	fs.Var(&patterns, "pattern", "stream name glob (repeatable; streams command only)")
+	fs.Parse(os.Args[2:])
+
+	client := redis.NewClient(&redis.Options{Addr: *addr})
+	defer client.Close()
+	a := admin.NewStreamAdmin(client)
+	ctx := context.Background()
+
+	var out interface{}
+	var err error
+	switch cmd {
+	case "groups":
+		if *stream == "" {
+			fmt.Fprintln(os.Stderr, "streamctl: -stream is required")
+			os.Exit(2)
+		}
+		out, err = a.DescribeConsumerGroups(ctx, *stream)
+	case "streams":
+		if len(patterns) == 0 {
+			fmt.Fprintln(os.Stderr, "streamctl: at least one -pattern is required")
+			os.Exit(2)
+		}
+		out, err = a.DescribeStreams(ctx, patterns...)
+	case "cluster":
+		out, err = a.ClusterInfo(ctx)
+	case "pending":
+		if *stream == "" || *group == "" {
+			fmt.Fprintln(os.Stderr, "streamctl: -stream and -group are required")
+			os.Exit(2)
+		}
+		out, err = a.PendingMessages(ctx, *stream, *group, *consumer, *count)
+	default:
+		usage()
+		os.Exit(2)
+	}
+
+	if err != nil {
+		fmt.Fprintln(os.Stderr, "streamctl:", err)
+		os.Exit(1)
+	}
+
+	if *asJSON {
+		enc := json.NewEncoder(os.Stdout)
+		enc.SetIndent("", "  ")
+		if err := enc.Encode(out); err != nil {
+			fmt.Fprintln(os.Stderr, "streamctl:", err)
+			os.Exit(1)
+		}
+		return
+	}
+	printHuman(out)
+}
+
+func usage() {
+	fmt.Fprintln(os.Stderr, "usage: streamctl <groups|streams|cluster|pending> [flags]")
+}
+
+// stringSliceFlag implements flag.Value to collect repeated -pattern flags.
+type stringSliceFlag []string
+
+func (s *stringSliceFlag) String() string { return strings.Join(*s, ",") }
+
+func (s *stringSliceFlag) Set(v string) error {
+	*s = append(*s, v)
+	return nil
+}
+
+func printHuman(out interface{}) {
+	switch v := out.(type) {
+	case []admin.GroupDescription:
+		for _, g := range v {
+			fmt.Printf("group=%s pending=%d lag=%d last_delivered=%s\n", g.Name, g.Pending, g.Lag, g.LastDeliveredID)
+			for _, c := range g.Consumers {
+				fmt.Printf("  consumer=%s pending=%d idle=%s inactive=%s\n", c.Name, c.Pending, c.Idle, c.Inactive)
+			}
+		}
+	case []admin.StreamDescription:
+		for _, s := range v {
+			fmt.Printf("stream=%s length=%d first=%s last=%s groups=%d\n", s.Name, s.Length, s.FirstID, s.LastID, len(s.Groups))
+		}
+	case *admin.ClusterDescription:
+		fmt.Printf("state=%s known_nodes=%d\n", v.State, v.KnownNodes)
+		for _, n := range v.Nodes {
+			fmt.Printf("  node=%s addr=%s role=%s slots=%s\n", n.ID, n.Addr, n.Role, strings.Join(n.Slots, ","))
+		}
+	case []admin.PendingMessage:
+		for _, p := range v {
+			fmt.Printf("id=%s consumer=%s idle=%s retries=%d\n", p.ID, p.Consumer, p.Idle, p.RetryCount)
+		}
+	default:
+		fmt.Printf("%+v\n", out)
+	}
+}
@@ -0,0 +1,90 @@
+// Command tall-events validates recorded events against the registry in
+// pkg/events/registry, so a malformed or out-of-schema payload can be
+// caught in CI or during an incident instead of at the first consumer
+// that chokes on it.
+//
+// Usage:
+//
+//	tall-events verify <file>
+//
+// <file> is a newline-delimited JSON stream of events.Event values (one
+// per line). Each event's raw JSON payload is decoded against its
+// registered schema via registry.DefaultRegistry().Decode; any event
+// whose type is unregistered or whose payload fails validation is
+// reported on stderr, and the command exits non-zero if any were found.
+package main
+
+import (
+	"bufio"
+	"encoding/json"
+	"flag"
+	"fmt"
+	"os"
+
+	"github.com/MalteBoehm/tall-affiliate-common/pkg/events/registry"
+)
+
+func main() {
+	flag.Parse()
+	args := flag.Args()
+
+	if len(args) != 2 || args[0] != "verify" {
+		fmt.Fprintln(os.Stderr, "usage: tall-events verify <file>")
+		os.Exit(2)
+	}
+
+	violations, err := verify(args[1], registry.DefaultRegistry())
+	if err != nil {
+		fmt.Fprintln(os.Stderr, "tall-events:", err)
+		os.Exit(1)
+	}
+
+	for _, v := range violations {
+		fmt.Fprintln(os.Stderr, v)
+	}
+	if len(violations) > 0 {
+		fmt.Fprintf(os.Stderr, "tall-events: %d violation(s)\n", len(violations))
+		os.Exit(1)
+	}
+}
+
+type rawEvent struct {
+	Type    string          `json:"Type"`
+	Payload json.RawMessage `json:"Payload"`
+}
+
+// verify scans path - a newline-delimited JSON stream of events.Event
+// values - and returns one human-readable message per event whose
+// payload fails to decode against reg, in file order.
+func verify(path string, reg *registry.Registry) ([]string, error) {
+	f, err := os.Open(path)
+	if err != nil {
+		return nil, fmt.Errorf("open %s: %w", path, err)
+	}
+	defer f.Close()
+
+	var violations []string
+	scanner := bufio.NewScanner(f)
+	scanner.Buffer(make([]byte, 0, 64*1024), 1024*1024)
+	for line := 1; scanner.Scan(); line++ {
+		text := scanner.Text()
+		if text == "" {
+			continue
+		}
+
+		var evt rawEvent
+		if err := json.Unmarshal([]byte(text), &evt); err != nil {
+			violations = append(violations, fmt.Sprintf("line %d: invalid JSON: %v", line, err))
+			continue
+		}
+
+		if _, err := reg.Decode(evt.Type, evt.Payload); err != nil {
+			violations = append(violations, fmt.Sprintf("line %d: %v", line, err))
+		}
+	}
+	if err := scanner.Err(); err != nil {
+		return nil, fmt.Errorf("read %s: %w", path, err)
+	}
+
+	return violations, nil
+}
@@ -0,0 +1,132 @@
+// pkg/adapters/backend_factory.go
+package adapters
+
+import (
+	"fmt"
+	"log/slog"
+	"os"
+	"strings"
+
+	"github.com/apache/pulsar-client-go/pulsar"
+	goredis "github.com/redis/go-redis/v9"
+
+	"github.com/MalteBoehm/tall-affiliate-common/pkg/interfaces"
+	"github.com/MalteBoehm/tall-affiliate-common/pkg/redis"
+)
+
+// Backend identifies which message broker a service is configured to use.
+type Backend string
+
+const (
+	// BackendRedis selects Redis Streams, the pre-existing default.
+	BackendRedis Backend = "redis"
+	// BackendPulsar selects Apache Pulsar.
+	BackendPulsar Backend = "pulsar"
+	// BackendKafka selects Kafka.
+	BackendKafka Backend = "kafka"
+
+	// EventsBackendEnvVar is the environment variable a service sets to pick
+	// its transport, e.g. EVENTS_BACKEND=pulsar.
+	EventsBackendEnvVar = "EVENTS_BACKEND"
+)
+
+// BackendConfig holds the connection settings for every backend
+// NewBackendFactory knows how to build. Only the fields for the selected
+// Backend need to be populated.
+type BackendConfig struct {
+	Backend Backend
+
+	// RedisClient is required for BackendRedis.
+	RedisClient *goredis.Client
+	// RedisConsumerName identifies this process within its consumer group
+	// for BackendRedis. Defaults to "consumer" when empty.
+	RedisConsumerName string
+
+	// PulsarURL is required for BackendPulsar, e.g. "pulsar://localhost:6650".
+	PulsarURL string
+
+	// KafkaBrokers is required for BackendKafka, e.g. []string{"localhost:9092"}.
+	KafkaBrokers []string
+
+	Logger *slog.Logger
+}
+
+// BackendFactory builds the interfaces.StreamProducer / interfaces.StreamConsumer
+// pair for cfg.Backend, so a service can be retargeted to a different broker
+// purely through configuration.
+type BackendFactory struct {
+	cfg BackendConfig
+}
+
+// NewBackendFactory creates a BackendFactory for cfg.
+func NewBackendFactory(cfg BackendConfig) *BackendFactory {
+	return &BackendFactory{cfg: cfg}
+}
+
+// BackendFromEnv reads EVENTS_BACKEND and returns the Backend it names,
+// defaulting to BackendRedis when it is unset or not a known backend.
+func BackendFromEnv() Backend {
+	value := strings.ToLower(strings.TrimSpace(os.Getenv(EventsBackendEnvVar)))
+	switch Backend(value) {
+	case BackendPulsar:
+		return BackendPulsar
+	case BackendKafka:
+		return BackendKafka
+	default:
+		return BackendRedis
+	}
+}
+
+// Build returns the producer/consumer pair for cfg.Backend. The caller owns
+// the lifecycle of any underlying client it passed into BackendConfig
+// (RedisClient) or that Build created internally (Pulsar); Close should be
+// called on the returned io.Closer-capable adapters where applicable.
+func (f *BackendFactory) Build() (interfaces.StreamProducer, interfaces.StreamConsumer, error) {
+	switch f.cfg.Backend {
+	case BackendRedis:
+		return f.buildRedis()
+	case BackendPulsar:
+		return f.buildPulsar()
+	case BackendKafka:
+		return f.buildKafka()
+	default:
+		return nil, nil, fmt.Errorf("unknown events backend %q", f.cfg.Backend)
+	}
+}
+
+func (f *BackendFactory) buildRedis() (interfaces.StreamProducer, interfaces.StreamConsumer, error) {
+	if f.cfg.RedisClient == nil {
+		return nil, nil, fmt.Errorf("redis backend requires a RedisClient")
+	}
+	consumerName := f.cfg.RedisConsumerName
+	if consumerName == "" {
+		consumerName = "consumer"
+	}
+
+	producer := redis.NewStreamProducer(f.cfg.RedisClient, f.cfg.Logger)
+	// streamName/groupName are left blank here: redis.StreamConsumer.ConsumeStream
+	// takes both as call parameters and only uses the constructor's
+	// consumerName/logger fields internally.
+	consumer := redis.NewStreamConsumer(f.cfg.RedisClient, "", "", consumerName, f.cfg.Logger)
+	return NewRedisProducerAdapter(producer), NewRedisConsumerAdapter(consumer), nil
+}
+
+func (f *BackendFactory) buildPulsar() (interfaces.StreamProducer, interfaces.StreamConsumer, error) {
+	if f.cfg.PulsarURL == "" {
+		return nil, nil, fmt.Errorf("pulsar backend requires a PulsarURL")
+	}
+	client, err := pulsar.NewClient(pulsar.ClientOptions{URL: f.cfg.PulsarURL})
+	if err != nil {
+		return nil, nil, fmt.Errorf("failed to create pulsar client: %w", err)
+	}
+	return NewPulsarProducerAdapter(client, f.cfg.Logger), NewPulsarConsumerAdapter(client, f.cfg.Logger), nil
+}
+
+func (f *BackendFactory) buildKafka() (interfaces.StreamProducer, interfaces.StreamConsumer, error) {
+	if len(f.cfg.KafkaBrokers) == 0 {
+		return nil, nil, fmt.Errorf("kafka backend requires at least one broker in KafkaBrokers")
+	}
+	producer := NewKafkaProducerAdapter(f.cfg.KafkaBrokers, f.cfg.Logger)
+	consumer := NewKafkaConsumerAdapter(f.cfg.KafkaBrokers, f.cfg.Logger)
+	return producer, consumer, nil
+}
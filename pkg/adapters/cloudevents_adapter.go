@@ -0,0 +1,85 @@
+// pkg/adapters/cloudevents_adapter.go
+package adapters
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	cloudeventstransport "github.com/MalteBoehm/tall-affiliate-common/pkg/events/transport/cloudevents"
+
+	"github.com/MalteBoehm/tall-affiliate-common/pkg/events"
+	"github.com/MalteBoehm/tall-affiliate-common/pkg/interfaces"
+)
+
+// CloudEventsHTTPAdapter publishes and receives events.Event over the
+// CloudEvents HTTP binding (pkg/events/transport/cloudevents), satisfying
+// interfaces.StreamProducer/StreamConsumer so a CloudEventsHTTPAdapter can
+// be passed to NewServiceEventAdapter the same way a Kafka or Redis
+// adapter is - letting a service run HTTP CloudEvents alongside, or
+// instead of, a stream backend. streamName/groupName/batchSize/
+// pollInterval are accepted to satisfy the interfaces but ignored: HTTP CE
+// has no topic concept, and the receiver dispatches events as they arrive
+// rather than polling.
+type CloudEventsHTTPAdapter struct {
+	publisher *cloudeventstransport.HTTPPublisher
+	receiver  *cloudeventstransport.HTTPReceiver
+}
+
+// NewCloudEventsHTTPAdapter creates a CloudEventsHTTPAdapter. If targetURL
+// is non-empty, PublishEvent sends to it using encoding. If receiverPort is
+// non-zero, ConsumeStream listens for incoming CloudEvents on it. Either
+// may be left unset for a send-only or receive-only adapter.
+func NewCloudEventsHTTPAdapter(targetURL string, encoding cloudeventstransport.Encoding, receiverPort int) (*CloudEventsHTTPAdapter, error) {
+	a := &CloudEventsHTTPAdapter{}
+
+	if targetURL != "" {
+		publisher, err := cloudeventstransport.NewHTTPPublisher(targetURL, encoding)
+		if err != nil {
+			return nil, err
+		}
+		a.publisher = publisher
+	}
+
+	if receiverPort != 0 {
+		receiver, err := cloudeventstransport.NewHTTPReceiver(receiverPort)
+		if err != nil {
+			return nil, err
+		}
+		a.receiver = receiver
+	}
+
+	return a, nil
+}
+
+// PublishEvent sends event over the CloudEvents HTTP binding to the target
+// URL this adapter was constructed with. streamName is ignored.
+func (a *CloudEventsHTTPAdapter) PublishEvent(ctx context.Context, streamName string, event *events.Event) error {
+	if a.publisher == nil {
+		return fmt.Errorf("cloudevents http adapter: no target URL configured")
+	}
+	return a.publisher.Publish(ctx, event)
+}
+
+// ConsumeStream starts the CloudEvents HTTP receiver this adapter was
+// constructed with and blocks until ctx is cancelled, invoking handler
+// with each received event's ID in place of a stream message ID.
+// streamName, groupName, batchSize, and pollInterval are ignored.
+func (a *CloudEventsHTTPAdapter) ConsumeStream(
+	ctx context.Context,
+	streamName string,
+	groupName string,
+	batchSize int64,
+	pollInterval time.Duration,
+	handler func(context.Context, *events.Event, string) error,
+) error {
+	if a.receiver == nil {
+		return fmt.Errorf("cloudevents http adapter: no receiver port configured")
+	}
+	return a.receiver.StartReceiving(ctx, func(ctx context.Context, evt *events.Event) error {
+		return handler(ctx, evt, evt.ID)
+	})
+}
+
+var _ interfaces.StreamProducer = (*CloudEventsHTTPAdapter)(nil)
+var _ interfaces.StreamConsumer = (*CloudEventsHTTPAdapter)(nil)
@@ -0,0 +1,91 @@
+package adapters
+
+import (
+	"context"
+	"net"
+	"strconv"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	cloudeventstransport "github.com/MalteBoehm/tall-affiliate-common/pkg/events/transport/cloudevents"
+
+	"github.com/MalteBoehm/tall-affiliate-common/pkg/events"
+)
+
+func freeCloudEventsPort(t *testing.T) int {
+	t.Helper()
+	l, err := net.Listen("tcp", "127.0.0.1:0")
+	require.NoError(t, err)
+	port := l.Addr().(*net.TCPAddr).Port
+	require.NoError(t, l.Close())
+	return port
+}
+
+func TestCloudEventsHTTPAdapterPublishesAndConsumesRoundTrip(t *testing.T) {
+	port := freeCloudEventsPort(t)
+
+	receiverAdapter, err := NewCloudEventsHTTPAdapter("", cloudeventstransport.Binary, port)
+	require.NoError(t, err)
+
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	received := make(chan *events.Event, 1)
+	go func() {
+		_ = receiverAdapter.ConsumeStream(ctx, "ignored", "group", 10, time.Millisecond,
+			func(_ context.Context, evt *events.Event, _ string) error {
+				received <- evt
+				return nil
+			})
+	}()
+
+	require.Eventually(t, func() bool {
+		conn, dialErr := net.DialTimeout("tcp", "127.0.0.1:"+strconv.Itoa(port), 50*time.Millisecond)
+		if dialErr != nil {
+			return false
+		}
+		_ = conn.Close()
+		return true
+	}, 2*time.Second, 10*time.Millisecond)
+
+	publisherAdapter, err := NewCloudEventsHTTPAdapter("http://127.0.0.1:"+strconv.Itoa(port)+"/", cloudeventstransport.Binary, 0)
+	require.NoError(t, err)
+
+	evt := &events.Event{
+		ID:            "evt-1",
+		Type:          events.EVENT_01_PRODUCT_DETECTED,
+		AggregateType: "product",
+		AggregateID:   "product-1",
+		Payload:       map[string]string{"asin": "B000123"},
+	}
+
+	require.NoError(t, publisherAdapter.PublishEvent(context.Background(), "ignored", evt))
+
+	select {
+	case got := <-received:
+		assert.Equal(t, evt.Type, got.Type)
+		assert.Equal(t, "product-1", got.AggregateID)
+	case <-time.After(2 * time.Second):
+		t.Fatal("timed out waiting for received event")
+	}
+}
+
+func TestCloudEventsHTTPAdapterPublishEventWithoutTargetURLErrors(t *testing.T) {
+	a, err := NewCloudEventsHTTPAdapter("", cloudeventstransport.Binary, 0)
+	require.NoError(t, err)
+
+	err = a.PublishEvent(context.Background(), "ignored", &events.Event{ID: "x"})
+	assert.ErrorContains(t, err, "no target URL configured")
+}
+
+func TestCloudEventsHTTPAdapterConsumeStreamWithoutReceiverPortErrors(t *testing.T) {
+	a, err := NewCloudEventsHTTPAdapter("", cloudeventstransport.Binary, 0)
+	require.NoError(t, err)
+
+	err = a.ConsumeStream(context.Background(), "ignored", "group", 1, time.Millisecond,
+		func(context.Context, *events.Event, string) error { return nil })
+	assert.ErrorContains(t, err, "no receiver port configured")
+}
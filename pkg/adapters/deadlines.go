@@ -0,0 +1,36 @@
+package adapters
+
+import (
+	"fmt"
+	"time"
+)
+
+// Deadlines configures operation-level timeouts for RedisProducerAdapter and
+// RedisConsumerAdapter, independent of whatever deadline the caller's
+// context.Context already carries. A zero Deadlines disables all of them.
+type Deadlines struct {
+	// PublishTimeout bounds a single PublishEvent call, measured from the
+	// moment it's invoked.
+	PublishTimeout time.Duration
+	// ReadTimeout bounds a single ConsumeStream call, measured from the
+	// moment it's invoked. SetReadDeadline overrides it.
+	ReadTimeout time.Duration
+	// IdleTimeout aborts ConsumeStream if no message is handled for this
+	// long, even if the absolute read deadline hasn't passed yet.
+	IdleTimeout time.Duration
+}
+
+// DeadlineExceededError reports that an adapter-level deadline fired,
+// distinct from context.DeadlineExceeded so callers can tell an
+// operation-level timeout apart from the caller's own context being
+// canceled or expiring.
+type DeadlineExceededError struct {
+	// Op identifies which operation timed out: "publish" or "read".
+	Op string
+	// Deadline is the absolute time that was exceeded.
+	Deadline time.Time
+}
+
+func (e *DeadlineExceededError) Error() string {
+	return fmt.Sprintf("adapters: %s deadline %s exceeded", e.Op, e.Deadline.Format(time.RFC3339))
+}
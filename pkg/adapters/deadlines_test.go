@@ -0,0 +1,113 @@
+package adapters
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/MalteBoehm/tall-affiliate-common/pkg/events"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// slowProducer delays PublishEvent by delay, or returns ctx.Err() if the
+// context is canceled first.
+type slowProducer struct {
+	delay time.Duration
+}
+
+func (s slowProducer) PublishEvent(ctx context.Context, streamName string, event *events.Event) error {
+	select {
+	case <-time.After(s.delay):
+		return nil
+	case <-ctx.Done():
+		return ctx.Err()
+	}
+}
+
+func TestRedisProducerAdapterPublishTimeoutFires(t *testing.T) {
+	adapter := NewRedisProducerAdapterWithDeadlines(slowProducer{delay: 200 * time.Millisecond}, Deadlines{PublishTimeout: 20 * time.Millisecond})
+
+	err := adapter.PublishEvent(context.Background(), "stream", &events.Event{ID: "evt-1"})
+	require.Error(t, err)
+	var deadlineErr *DeadlineExceededError
+	require.ErrorAs(t, err, &deadlineErr)
+	assert.Equal(t, "publish", deadlineErr.Op)
+}
+
+func TestRedisProducerAdapterPublishSucceedsWithinTimeout(t *testing.T) {
+	adapter := NewRedisProducerAdapterWithDeadlines(slowProducer{delay: 5 * time.Millisecond}, Deadlines{PublishTimeout: 100 * time.Millisecond})
+
+	err := adapter.PublishEvent(context.Background(), "stream", &events.Event{ID: "evt-2"})
+	assert.NoError(t, err)
+}
+
+func TestRedisProducerAdapterPublishDoesNotMaskCallerCancellation(t *testing.T) {
+	adapter := NewRedisProducerAdapterWithDeadlines(slowProducer{delay: 50 * time.Millisecond}, Deadlines{PublishTimeout: 200 * time.Millisecond})
+
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+
+	err := adapter.PublishEvent(ctx, "stream", &events.Event{ID: "evt-3"})
+	require.Error(t, err)
+	_, isDeadlineErr := err.(*DeadlineExceededError)
+	assert.False(t, isDeadlineErr, "caller cancellation must not be reported as a DeadlineExceededError")
+}
+
+func TestRedisProducerAdapterSetPublishDeadlineOverridesTimeout(t *testing.T) {
+	adapter := NewRedisProducerAdapterWithDeadlines(slowProducer{delay: 200 * time.Millisecond}, Deadlines{PublishTimeout: time.Hour})
+	adapter.SetPublishDeadline(time.Now().Add(10 * time.Millisecond))
+
+	err := adapter.PublishEvent(context.Background(), "stream", &events.Event{ID: "evt-4"})
+	require.Error(t, err)
+	var deadlineErr *DeadlineExceededError
+	require.ErrorAs(t, err, &deadlineErr)
+}
+
+func TestRedisProducerAdapterPublishBatchWithDeadlineReturnsPartialResults(t *testing.T) {
+	adapter := NewRedisProducerAdapter(slowProducer{delay: 30 * time.Millisecond})
+
+	batch := []*events.Event{{ID: "a"}, {ID: "b"}, {ID: "c"}}
+	ids, err := adapter.PublishBatchWithDeadline(context.Background(), "stream", batch, time.Now().Add(50*time.Millisecond))
+
+	require.Error(t, err)
+	var deadlineErr *DeadlineExceededError
+	require.ErrorAs(t, err, &deadlineErr)
+	assert.Less(t, len(ids), len(batch))
+}
+
+func TestRedisConsumerAdapterIdleTimeoutAborts(t *testing.T) {
+	consumer := &fakeConsumer{blockFor: time.Hour}
+	adapter := NewRedisConsumerAdapterWithDeadlines(consumer, Deadlines{IdleTimeout: 20 * time.Millisecond})
+
+	err := adapter.ConsumeStream(context.Background(), "stream", "group", 10, time.Millisecond, func(context.Context, *events.Event, string) error {
+		return nil
+	})
+
+	require.Error(t, err)
+	var deadlineErr *DeadlineExceededError
+	require.ErrorAs(t, err, &deadlineErr)
+	assert.Equal(t, "read", deadlineErr.Op)
+}
+
+// fakeConsumer blocks until its context is canceled, simulating a consumer
+// loop that never produces a message.
+type fakeConsumer struct {
+	blockFor time.Duration
+}
+
+func (f *fakeConsumer) ConsumeStream(
+	ctx context.Context,
+	streamName string,
+	groupName string,
+	batchSize int64,
+	pollInterval time.Duration,
+	handler func(context.Context, *events.Event, string) error,
+) error {
+	select {
+	case <-time.After(f.blockFor):
+		return nil
+	case <-ctx.Done():
+		return ctx.Err()
+	}
+}
@@ -0,0 +1,232 @@
+// pkg/adapters/kafka_adapter.go
+package adapters
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"log/slog"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+
+	kafka "github.com/segmentio/kafka-go"
+
+	"github.com/MalteBoehm/tall-affiliate-common/pkg/events"
+	"github.com/MalteBoehm/tall-affiliate-common/pkg/interfaces"
+)
+
+// KafkaProducerAdapter publishes events to Kafka topics, satisfying
+// interfaces.StreamProducer. streamName maps directly to the Kafka topic
+// name; one kafka.Writer is created lazily per topic and reused.
+type KafkaProducerAdapter struct {
+	brokers []string
+	logger  *slog.Logger
+
+	mu      sync.Mutex
+	writers map[string]*kafka.Writer
+}
+
+// NewKafkaProducerAdapter creates a KafkaProducerAdapter that dials brokers
+// on demand, one writer per topic.
+func NewKafkaProducerAdapter(brokers []string, logger *slog.Logger) *KafkaProducerAdapter {
+	if logger == nil {
+		logger = slog.Default()
+	}
+	return &KafkaProducerAdapter{
+		brokers: brokers,
+		logger:  logger.With("component", "kafka-producer"),
+		writers: make(map[string]*kafka.Writer),
+	}
+}
+
+// PublishEvent publishes an event to the Kafka topic named streamName.
+func (p *KafkaProducerAdapter) PublishEvent(ctx context.Context, streamName string, event *events.Event) error {
+	if streamName == "" {
+		return fmt.Errorf("stream name cannot be empty")
+	}
+	if event == nil {
+		return fmt.Errorf("event cannot be nil")
+	}
+
+	payload, err := json.Marshal(event)
+	if err != nil {
+		return fmt.Errorf("failed to marshal event: %w", err)
+	}
+
+	writer := p.writerFor(streamName)
+	err = writer.WriteMessages(ctx, kafka.Message{
+		Key:   []byte(event.AggregateID),
+		Value: payload,
+	})
+	if err != nil {
+		return fmt.Errorf("failed to publish event to topic %s: %w", streamName, err)
+	}
+
+	p.logger.Debug("Event published to topic",
+		"topic", streamName,
+		"eventType", event.Type,
+		"eventID", event.ID)
+
+	return nil
+}
+
+func (p *KafkaProducerAdapter) writerFor(topic string) *kafka.Writer {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+
+	if writer, ok := p.writers[topic]; ok {
+		return writer
+	}
+
+	writer := &kafka.Writer{
+		Addr:                   kafka.TCP(p.brokers...),
+		Topic:                  topic,
+		Balancer:               &kafka.Hash{},
+		AllowAutoTopicCreation: true,
+	}
+	p.writers[topic] = writer
+	return writer
+}
+
+// Close releases every writer created by PublishEvent.
+func (p *KafkaProducerAdapter) Close() error {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+
+	var firstErr error
+	for topic, writer := range p.writers {
+		if err := writer.Close(); err != nil && firstErr == nil {
+			firstErr = err
+		}
+		delete(p.writers, topic)
+	}
+	return firstErr
+}
+
+// Ensure KafkaProducerAdapter implements interfaces.StreamProducer
+var _ interfaces.StreamProducer = (*KafkaProducerAdapter)(nil)
+
+// KafkaConsumerAdapter consumes events from Kafka topics, satisfying
+// interfaces.StreamConsumer. groupName maps to the Kafka consumer group.
+// Offsets are committed manually, after handler succeeds, so a failed
+// handler call leaves the message available for redelivery.
+type KafkaConsumerAdapter struct {
+	brokers []string
+	logger  *slog.Logger
+}
+
+// NewKafkaConsumerAdapter creates a KafkaConsumerAdapter that dials brokers
+// on demand.
+func NewKafkaConsumerAdapter(brokers []string, logger *slog.Logger) *KafkaConsumerAdapter {
+	if logger == nil {
+		logger = slog.Default()
+	}
+	return &KafkaConsumerAdapter{
+		brokers: brokers,
+		logger:  logger.With("component", "kafka-consumer"),
+	}
+}
+
+// ConsumeStream reads from the Kafka topic named streamName as part of
+// consumer group groupName and invokes handler for every message until ctx
+// is canceled. batchSize sets the reader's QueueCapacity; pollInterval sets
+// MaxWait between fetches.
+func (c *KafkaConsumerAdapter) ConsumeStream(
+	ctx context.Context,
+	streamName string,
+	groupName string,
+	batchSize int64,
+	pollInterval time.Duration,
+	handler func(context.Context, *events.Event, string) error,
+) error {
+	reader := kafka.NewReader(kafka.ReaderConfig{
+		Brokers:        c.brokers,
+		Topic:          streamName,
+		GroupID:        groupName,
+		QueueCapacity:  int(batchSize),
+		MaxWait:        pollInterval,
+		CommitInterval: 0, // manual commit, driven by handler success
+	})
+	defer reader.Close()
+
+	c.logger.Info("Starting to consume topic",
+		"topic", streamName,
+		"group", groupName,
+		"batchSize", batchSize,
+		"pollInterval", pollInterval)
+
+	for {
+		select {
+		case <-ctx.Done():
+			return ctx.Err()
+		default:
+		}
+
+		msg, err := reader.FetchMessage(ctx)
+		if err != nil {
+			if ctx.Err() != nil {
+				return ctx.Err()
+			}
+			c.logger.Error("Failed to fetch message", "error", err)
+			continue
+		}
+
+		if err := c.processMessage(ctx, reader, msg, handler); err != nil {
+			c.logger.Error("Failed to process message",
+				"partition", msg.Partition,
+				"offset", msg.Offset,
+				"error", err)
+		}
+	}
+}
+
+func (c *KafkaConsumerAdapter) processMessage(
+	ctx context.Context,
+	reader *kafka.Reader,
+	msg kafka.Message,
+	handler func(context.Context, *events.Event, string) error,
+) error {
+	var event events.Event
+	if err := json.Unmarshal(msg.Value, &event); err != nil {
+		return fmt.Errorf("failed to unmarshal event: %w", err)
+	}
+
+	ackToken := ackTokenFor(msg)
+	if err := handler(ctx, &event, ackToken); err != nil {
+		return fmt.Errorf("handler failed: %w", err)
+	}
+
+	if err := reader.CommitMessages(ctx, msg); err != nil {
+		return fmt.Errorf("failed to commit message %s: %w", ackToken, err)
+	}
+	return nil
+}
+
+// ackTokenFor encodes a Kafka message's position as the string ack token
+// handlers receive, in "partition:offset" form.
+func ackTokenFor(msg kafka.Message) string {
+	return strconv.Itoa(msg.Partition) + ":" + strconv.FormatInt(msg.Offset, 10)
+}
+
+// parseAckToken is the inverse of ackTokenFor, for callers that need to
+// resolve a previously-handed-out ack token back to a partition/offset pair.
+func parseAckToken(token string) (partition int, offset int64, err error) {
+	parts := strings.SplitN(token, ":", 2)
+	if len(parts) != 2 {
+		return 0, 0, fmt.Errorf("invalid kafka ack token %q", token)
+	}
+	partition, err = strconv.Atoi(parts[0])
+	if err != nil {
+		return 0, 0, fmt.Errorf("invalid kafka ack token %q: %w", token, err)
+	}
+	offset, err = strconv.ParseInt(parts[1], 10, 64)
+	if err != nil {
+		return 0, 0, fmt.Errorf("invalid kafka ack token %q: %w", token, err)
+	}
+	return partition, offset, nil
+}
+
+// Ensure KafkaConsumerAdapter implements interfaces.StreamConsumer
+var _ interfaces.StreamConsumer = (*KafkaConsumerAdapter)(nil)
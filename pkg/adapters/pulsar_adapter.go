@@ -0,0 +1,207 @@
+// pkg/adapters/pulsar_adapter.go
+package adapters
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"log/slog"
+	"sync"
+	"time"
+
+	"github.com/apache/pulsar-client-go/pulsar"
+
+	"github.com/MalteBoehm/tall-affiliate-common/pkg/events"
+	"github.com/MalteBoehm/tall-affiliate-common/pkg/interfaces"
+)
+
+// PulsarProducerAdapter publishes events to Apache Pulsar topics, satisfying
+// interfaces.StreamProducer. streamName maps directly to the Pulsar topic
+// name; one pulsar.Producer is created lazily per topic and reused.
+type PulsarProducerAdapter struct {
+	client pulsar.Client
+	logger *slog.Logger
+
+	mu        sync.Mutex
+	producers map[string]pulsar.Producer
+}
+
+// NewPulsarProducerAdapter creates a PulsarProducerAdapter backed by client.
+// The caller owns client's lifecycle (created via pulsar.NewClient) and
+// remains responsible for calling client.Close().
+func NewPulsarProducerAdapter(client pulsar.Client, logger *slog.Logger) *PulsarProducerAdapter {
+	if logger == nil {
+		logger = slog.Default()
+	}
+	return &PulsarProducerAdapter{
+		client:    client,
+		logger:    logger.With("component", "pulsar-producer"),
+		producers: make(map[string]pulsar.Producer),
+	}
+}
+
+// PublishEvent publishes an event to the Pulsar topic named streamName.
+func (p *PulsarProducerAdapter) PublishEvent(ctx context.Context, streamName string, event *events.Event) error {
+	if streamName == "" {
+		return fmt.Errorf("stream name cannot be empty")
+	}
+	if event == nil {
+		return fmt.Errorf("event cannot be nil")
+	}
+
+	producer, err := p.producerFor(streamName)
+	if err != nil {
+		return fmt.Errorf("failed to get pulsar producer for topic %s: %w", streamName, err)
+	}
+
+	payload, err := json.Marshal(event)
+	if err != nil {
+		return fmt.Errorf("failed to marshal event: %w", err)
+	}
+
+	messageID, err := producer.Send(ctx, &pulsar.ProducerMessage{
+		Payload: payload,
+		Key:     event.AggregateID,
+	})
+	if err != nil {
+		return fmt.Errorf("failed to publish event to topic %s: %w", streamName, err)
+	}
+
+	p.logger.Debug("Event published to topic",
+		"topic", streamName,
+		"eventType", event.Type,
+		"eventID", event.ID,
+		"messageID", messageID.String())
+
+	return nil
+}
+
+func (p *PulsarProducerAdapter) producerFor(topic string) (pulsar.Producer, error) {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+
+	if producer, ok := p.producers[topic]; ok {
+		return producer, nil
+	}
+
+	producer, err := p.client.CreateProducer(pulsar.ProducerOptions{Topic: topic})
+	if err != nil {
+		return nil, err
+	}
+	p.producers[topic] = producer
+	return producer, nil
+}
+
+// Close releases every producer created by PublishEvent.
+func (p *PulsarProducerAdapter) Close() {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	for topic, producer := range p.producers {
+		producer.Close()
+		delete(p.producers, topic)
+	}
+}
+
+// Ensure PulsarProducerAdapter implements interfaces.StreamProducer
+var _ interfaces.StreamProducer = (*PulsarProducerAdapter)(nil)
+
+// PulsarConsumerAdapter consumes events from Apache Pulsar topics, satisfying
+// interfaces.StreamConsumer. groupName maps to the Pulsar subscription name;
+// SubscriptionType_Shared is used so multiple consumers on the same
+// subscription compete for messages, mirroring Redis consumer groups.
+type PulsarConsumerAdapter struct {
+	client pulsar.Client
+	logger *slog.Logger
+}
+
+// NewPulsarConsumerAdapter creates a PulsarConsumerAdapter backed by client.
+func NewPulsarConsumerAdapter(client pulsar.Client, logger *slog.Logger) *PulsarConsumerAdapter {
+	if logger == nil {
+		logger = slog.Default()
+	}
+	return &PulsarConsumerAdapter{
+		client: client,
+		logger: logger.With("component", "pulsar-consumer"),
+	}
+}
+
+// ConsumeStream subscribes to the topic named streamName under subscription
+// groupName and invokes handler for every message until ctx is canceled.
+// batchSize sets the consumer's receiver queue size; pollInterval bounds how
+// long each Receive call waits for a message before checking ctx.
+func (c *PulsarConsumerAdapter) ConsumeStream(
+	ctx context.Context,
+	streamName string,
+	groupName string,
+	batchSize int64,
+	pollInterval time.Duration,
+	handler func(context.Context, *events.Event, string) error,
+) error {
+	consumer, err := c.client.Subscribe(pulsar.ConsumerOptions{
+		Topic:             streamName,
+		SubscriptionName:  groupName,
+		Type:              pulsar.Shared,
+		ReceiverQueueSize: int(batchSize),
+	})
+	if err != nil {
+		return fmt.Errorf("failed to subscribe to topic %s: %w", streamName, err)
+	}
+	defer consumer.Close()
+
+	c.logger.Info("Starting to consume topic",
+		"topic", streamName,
+		"subscription", groupName,
+		"pollInterval", pollInterval)
+
+	for {
+		select {
+		case <-ctx.Done():
+			return ctx.Err()
+		default:
+		}
+
+		receiveCtx, cancel := context.WithTimeout(ctx, pollInterval)
+		msg, err := consumer.Receive(receiveCtx)
+		cancel()
+		if err != nil {
+			if ctx.Err() != nil {
+				return ctx.Err()
+			}
+			// Receive timeout; keep polling.
+			continue
+		}
+
+		if err := c.processMessage(ctx, consumer, msg, handler); err != nil {
+			c.logger.Error("Failed to process message",
+				"messageID", msg.ID().String(),
+				"error", err)
+		}
+	}
+}
+
+func (c *PulsarConsumerAdapter) processMessage(
+	ctx context.Context,
+	consumer pulsar.Consumer,
+	msg pulsar.Message,
+	handler func(context.Context, *events.Event, string) error,
+) error {
+	var event events.Event
+	if err := json.Unmarshal(msg.Payload(), &event); err != nil {
+		consumer.Nack(msg)
+		return fmt.Errorf("failed to unmarshal event: %w", err)
+	}
+
+	ackToken := msg.ID().String()
+	if err := handler(ctx, &event, ackToken); err != nil {
+		consumer.Nack(msg)
+		return fmt.Errorf("handler failed: %w", err)
+	}
+
+	if err := consumer.Ack(msg); err != nil {
+		return fmt.Errorf("failed to acknowledge message %s: %w", ackToken, err)
+	}
+	return nil
+}
+
+// Ensure PulsarConsumerAdapter implements interfaces.StreamConsumer
+var _ interfaces.StreamConsumer = (*PulsarConsumerAdapter)(nil)
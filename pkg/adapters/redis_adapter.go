@@ -3,6 +3,7 @@ package adapters
 
 import (
 	"context"
+	"sync"
 	"time"
 
 	"github.com/MalteBoehm/tall-affiliate-common/pkg/events"
@@ -12,6 +13,10 @@ import (
 // RedisProducerAdapter adapts any Redis producer to the common StreamProducer interface
 type RedisProducerAdapter struct {
 	producer RedisProducer
+
+	mu              sync.Mutex
+	deadlines       Deadlines
+	publishDeadline time.Time
 }
 
 // RedisProducer interface that any Redis producer implementation must satisfy
@@ -26,9 +31,87 @@ func NewRedisProducerAdapter(producer RedisProducer) *RedisProducerAdapter {
 	}
 }
 
-// PublishEvent publishes an event to a stream
+// NewRedisProducerAdapterWithDeadlines creates a RedisProducerAdapter whose
+// PublishEvent enforces deadlines.PublishTimeout on every call.
+func NewRedisProducerAdapterWithDeadlines(producer RedisProducer, deadlines Deadlines) *RedisProducerAdapter {
+	return &RedisProducerAdapter{
+		producer:  producer,
+		deadlines: deadlines,
+	}
+}
+
+// SetPublishDeadline sets an absolute deadline for every subsequent
+// PublishEvent call until it is cleared, overriding Deadlines.PublishTimeout.
+// A zero time clears it.
+func (p *RedisProducerAdapter) SetPublishDeadline(t time.Time) {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	p.publishDeadline = t
+}
+
+// publishDeadlineFor returns the deadline to apply to the next
+// PublishEvent call and whether one is configured at all.
+func (p *RedisProducerAdapter) publishDeadlineFor(now time.Time) (time.Time, bool) {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	if !p.publishDeadline.IsZero() {
+		return p.publishDeadline, true
+	}
+	if p.deadlines.PublishTimeout > 0 {
+		return now.Add(p.deadlines.PublishTimeout), true
+	}
+	return time.Time{}, false
+}
+
+// PublishEvent publishes an event to a stream, aborting with a
+// *DeadlineExceededError if an adapter-level publish deadline fires before
+// the underlying producer returns.
 func (p *RedisProducerAdapter) PublishEvent(ctx context.Context, streamName string, event *events.Event) error {
-	return p.producer.PublishEvent(ctx, streamName, event)
+	deadline, ok := p.publishDeadlineFor(time.Now())
+	if !ok {
+		return p.producer.PublishEvent(ctx, streamName, event)
+	}
+
+	dctx, cancel := context.WithCancel(ctx)
+	defer cancel()
+
+	timer := time.AfterFunc(time.Until(deadline), cancel)
+	defer timer.Stop()
+
+	err := p.producer.PublishEvent(dctx, streamName, event)
+	if err != nil && dctx.Err() != nil && ctx.Err() == nil {
+		// dctx was canceled by our timer, not by the caller's own context.
+		return &DeadlineExceededError{Op: "publish", Deadline: deadline}
+	}
+	return err
+}
+
+// PublishBatchWithDeadline publishes events one at a time against producer,
+// stopping as soon as deadline passes, and returns the IDs of the events
+// that were published before it fired. If the batch completes before the
+// deadline, err is nil even though RedisProducer does not expose broker-
+// assigned message IDs.
+func (p *RedisProducerAdapter) PublishBatchWithDeadline(ctx context.Context, streamName string, events []*events.Event, deadline time.Time) (messageIDs []string, err error) {
+	for _, event := range events {
+		if event == nil {
+			continue
+		}
+		if !deadline.IsZero() && time.Now().After(deadline) {
+			return messageIDs, &DeadlineExceededError{Op: "publish", Deadline: deadline}
+		}
+
+		dctx, cancel := context.WithDeadline(ctx, deadline)
+		publishErr := p.producer.PublishEvent(dctx, streamName, event)
+		cancel()
+		if publishErr != nil {
+			if dctx.Err() == context.DeadlineExceeded && ctx.Err() == nil {
+				return messageIDs, &DeadlineExceededError{Op: "publish", Deadline: deadline}
+			}
+			return messageIDs, publishErr
+		}
+		messageIDs = append(messageIDs, event.ID)
+	}
+	return messageIDs, nil
 }
 
 // Ensure RedisProducerAdapter implements interfaces.StreamProducer
@@ -37,6 +120,10 @@ var _ interfaces.StreamProducer = (*RedisProducerAdapter)(nil)
 // RedisConsumerAdapter adapts any Redis consumer to the common StreamConsumer interface
 type RedisConsumerAdapter struct {
 	consumer RedisConsumer
+
+	mu           sync.Mutex
+	deadlines    Deadlines
+	readDeadline time.Time
 }
 
 // RedisConsumer interface that any Redis consumer implementation must satisfy
@@ -58,7 +145,40 @@ func NewRedisConsumerAdapter(consumer RedisConsumer) *RedisConsumerAdapter {
 	}
 }
 
-// ConsumeStream consumes events from a stream
+// NewRedisConsumerAdapterWithDeadlines creates a RedisConsumerAdapter whose
+// ConsumeStream enforces deadlines.ReadTimeout/IdleTimeout.
+func NewRedisConsumerAdapterWithDeadlines(consumer RedisConsumer, deadlines Deadlines) *RedisConsumerAdapter {
+	return &RedisConsumerAdapter{
+		consumer:  consumer,
+		deadlines: deadlines,
+	}
+}
+
+// SetReadDeadline sets an absolute deadline for every ConsumeStream call
+// started after it until it is cleared, overriding Deadlines.ReadTimeout; a
+// ConsumeStream call already running keeps the deadline it started with. A
+// zero time clears it.
+func (c *RedisConsumerAdapter) SetReadDeadline(t time.Time) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	c.readDeadline = t
+}
+
+func (c *RedisConsumerAdapter) readDeadlineFor(now time.Time) (time.Time, bool) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	if !c.readDeadline.IsZero() {
+		return c.readDeadline, true
+	}
+	if c.deadlines.ReadTimeout > 0 {
+		return now.Add(c.deadlines.ReadTimeout), true
+	}
+	return time.Time{}, false
+}
+
+// ConsumeStream consumes events from a stream, aborting with a
+// *DeadlineExceededError if the adapter's absolute read deadline or idle
+// timeout fires before the underlying consumer returns on its own.
 func (c *RedisConsumerAdapter) ConsumeStream(
 	ctx context.Context,
 	streamName string,
@@ -67,7 +187,52 @@ func (c *RedisConsumerAdapter) ConsumeStream(
 	pollInterval time.Duration,
 	handler func(context.Context, *events.Event, string) error,
 ) error {
-	return c.consumer.ConsumeStream(ctx, streamName, groupName, batchSize, pollInterval, handler)
+	deadline, hasDeadline := c.readDeadlineFor(time.Now())
+	if !hasDeadline && c.deadlines.IdleTimeout <= 0 {
+		return c.consumer.ConsumeStream(ctx, streamName, groupName, batchSize, pollInterval, handler)
+	}
+
+	dctx, cancel := context.WithCancel(ctx)
+	defer cancel()
+
+	var exceeded time.Time
+	var mu sync.Mutex
+	markExceeded := func(at time.Time) {
+		mu.Lock()
+		exceeded = at
+		mu.Unlock()
+		cancel()
+	}
+
+	var absoluteTimer *time.Timer
+	if hasDeadline {
+		absoluteTimer = time.AfterFunc(time.Until(deadline), func() { markExceeded(deadline) })
+		defer absoluteTimer.Stop()
+	}
+
+	var idleTimer *time.Timer
+	if c.deadlines.IdleTimeout > 0 {
+		idleTimer = time.AfterFunc(c.deadlines.IdleTimeout, func() { markExceeded(time.Now()) })
+		defer idleTimer.Stop()
+	}
+
+	wrapped := handler
+	if idleTimer != nil {
+		wrapped = func(hctx context.Context, event *events.Event, msgID string) error {
+			idleTimer.Reset(c.deadlines.IdleTimeout)
+			return handler(hctx, event, msgID)
+		}
+	}
+
+	err := c.consumer.ConsumeStream(dctx, streamName, groupName, batchSize, pollInterval, wrapped)
+
+	mu.Lock()
+	firedAt := exceeded
+	mu.Unlock()
+	if !firedAt.IsZero() && ctx.Err() == nil {
+		return &DeadlineExceededError{Op: "read", Deadline: firedAt}
+	}
+	return err
 }
 
 // Ensure RedisConsumerAdapter implements interfaces.StreamConsumer
@@ -3,8 +3,10 @@ package adapters
 
 import (
 	"context"
+	"fmt"
 	"time"
 
+	"github.com/MalteBoehm/tall-affiliate-common/pkg/authz"
 	"github.com/MalteBoehm/tall-affiliate-common/pkg/events"
 	"github.com/MalteBoehm/tall-affiliate-common/pkg/interfaces"
 )
@@ -24,8 +26,17 @@ func NewServiceEventAdapter(producer interfaces.StreamProducer, consumer interfa
 	}
 }
 
-// PublishEvent publishes an event using the common interface
+// PublishEvent publishes an event using the common interface. If
+// event.Payload implements events.Snapshotter, the snapshot is published in
+// its place, so payload types that multiplex several aggregates over a
+// shared stream can trim what actually goes out over the wire.
 func (s *ServiceEventAdapter) PublishEvent(ctx context.Context, streamName string, event *events.Event) error {
+	snapshot, err := events.SnapshotPayload(event.Payload)
+	if err != nil {
+		return fmt.Errorf("adapters: snapshot payload for %s: %w", event.Type, err)
+	}
+	event.Payload = snapshot
+
 	return s.producer.PublishEvent(ctx, streamName, event)
 }
 
@@ -41,6 +52,42 @@ func (s *ServiceEventAdapter) ConsumeStream(
 	return s.consumer.ConsumeStream(ctx, streamName, groupName, batchSize, pollInterval, handler)
 }
 
+// SubscribeForKey consumes streamName like ConsumeStream, but first filters
+// each message down to key/namespace and az's read permission using the
+// optional events.KeyMatcher and events.ReadAuthorizer interfaces on its
+// Payload, so a single stream can be multiplexed across many aggregates or
+// tenants without handler ever seeing data it isn't meant to. Payloads that
+// implement neither interface match and are allowed unconditionally, so
+// this is a drop-in replacement for ConsumeStream when no filtering is
+// needed. az may be nil, in which case it defaults to authz.AllowAll.
+func (s *ServiceEventAdapter) SubscribeForKey(
+	ctx context.Context,
+	streamName string,
+	groupName string,
+	key string,
+	namespace string,
+	az authz.Authorizer,
+	batchSize int64,
+	pollInterval time.Duration,
+	handler func(context.Context, *events.Event, string) error,
+) error {
+	if az == nil {
+		az = authz.AllowAll{}
+	}
+
+	filtered := func(ctx context.Context, evt *events.Event, messageID string) error {
+		if !events.MatchesKey(evt.Payload, key, namespace) {
+			return nil
+		}
+		if !events.HasReadPermission(evt.Payload, az) {
+			return nil
+		}
+		return handler(ctx, evt, messageID)
+	}
+
+	return s.consumer.ConsumeStream(ctx, streamName, groupName, batchSize, pollInterval, filtered)
+}
+
 // Helper functions for common event operations
 
 // PublishProductEvent publishes a product-related event
@@ -5,7 +5,8 @@ import (
 	"testing"
 	"time"
 
-	"github.com/maltedev/tall-affiliate/tall-affiliate-common/pkg/events"
+	"github.com/MalteBoehm/tall-affiliate-common/pkg/authz"
+	"github.com/MalteBoehm/tall-affiliate-common/pkg/events"
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/mock"
 )
@@ -81,6 +82,125 @@ func TestServiceEventAdapter_ConsumeStream(t *testing.T) {
 	mockConsumer.AssertExpectations(t)
 }
 
+type snapshotPayload struct {
+	Full   string
+	Public string
+}
+
+func (p snapshotPayload) Snapshot() (any, error) {
+	return map[string]string{"public": p.Public}, nil
+}
+
+func TestServiceEventAdapter_PublishEvent_SnapshotsPayload(t *testing.T) {
+	mockProducer := new(MockProducer)
+	mockConsumer := new(MockConsumer)
+	adapter := NewServiceEventAdapter(mockProducer, mockConsumer)
+
+	ctx := context.Background()
+	streamName := "test-stream"
+	event := &events.Event{
+		ID:      "test-id",
+		Type:    "some.event",
+		Payload: snapshotPayload{Full: "secret", Public: "ok"},
+	}
+
+	mockProducer.On("PublishEvent", ctx, streamName, mock.MatchedBy(func(e *events.Event) bool {
+		payload, ok := e.Payload.(map[string]string)
+		return ok && payload["public"] == "ok"
+	})).Return(nil)
+
+	err := adapter.PublishEvent(ctx, streamName, event)
+
+	assert.NoError(t, err)
+	mockProducer.AssertExpectations(t)
+}
+
+type keyedPayload struct {
+	Key       string
+	Namespace string
+}
+
+func (p keyedPayload) MatchesKey(key, namespace string) bool {
+	return p.Key == key && p.Namespace == namespace
+}
+
+func (p keyedPayload) HasReadPermission(az authz.Authorizer) bool {
+	return az.Allow(p.Key, "read")
+}
+
+func TestServiceEventAdapter_SubscribeForKey_FiltersByKeyAndPermission(t *testing.T) {
+	mockProducer := new(MockProducer)
+	mockConsumer := new(MockConsumer)
+	adapter := NewServiceEventAdapter(mockProducer, mockConsumer)
+
+	ctx := context.Background()
+	streamName := "test-stream"
+	groupName := "test-group"
+	batchSize := int64(10)
+	pollInterval := time.Second
+
+	mockConsumer.On("ConsumeStream", ctx, streamName, groupName, batchSize, pollInterval,
+		mock.AnythingOfType("func(context.Context, *events.Event, string) error")).
+		Run(func(args mock.Arguments) {
+			handler := args.Get(5).(func(context.Context, *events.Event, string) error)
+
+			incoming := []*events.Event{
+				{Payload: keyedPayload{Key: "tenant-a", Namespace: "ns"}},
+				{Payload: keyedPayload{Key: "tenant-b", Namespace: "ns"}},
+				{Payload: map[string]string{"no": "filter"}},
+			}
+			for _, evt := range incoming {
+				if err := handler(ctx, evt, "msg-1"); err != nil {
+					t.Errorf("handler: %v", err)
+				}
+			}
+		}).
+		Return(nil)
+
+	var seen []any
+	handler := func(_ context.Context, evt *events.Event, _ string) error {
+		seen = append(seen, evt.Payload)
+		return nil
+	}
+
+	err := adapter.SubscribeForKey(ctx, streamName, groupName, "tenant-a", "ns", authz.AllowAll{}, batchSize, pollInterval, handler)
+
+	assert.NoError(t, err)
+	mockConsumer.AssertExpectations(t)
+	// Only the matching keyed payload and the unfiltered payload should
+	// have reached handler; tenant-b's event doesn't match the key.
+	assert.Len(t, seen, 2)
+}
+
+func TestServiceEventAdapter_SubscribeForKey_DeniesWithoutReadPermission(t *testing.T) {
+	mockProducer := new(MockProducer)
+	mockConsumer := new(MockConsumer)
+	adapter := NewServiceEventAdapter(mockProducer, mockConsumer)
+
+	ctx := context.Background()
+
+	mockConsumer.On("ConsumeStream", ctx, "stream", "group", int64(1), time.Second,
+		mock.AnythingOfType("func(context.Context, *events.Event, string) error")).
+		Run(func(args mock.Arguments) {
+			handler := args.Get(5).(func(context.Context, *events.Event, string) error)
+			err := handler(ctx, &events.Event{Payload: keyedPayload{Key: "tenant-a", Namespace: "ns"}}, "msg-1")
+			assert.NoError(t, err)
+		}).
+		Return(nil)
+
+	called := false
+	handler := func(_ context.Context, _ *events.Event, _ string) error {
+		called = true
+		return nil
+	}
+
+	err := adapter.SubscribeForKey(ctx, "stream", "group", "tenant-a", "ns", authz.DenyAll{}, 1, time.Second, handler)
+
+	assert.NoError(t, err)
+	mockConsumer.AssertExpectations(t)
+	assert.False(t, called, "handler should not be called when HasReadPermission denies")
+}
+
 func TestServiceEventAdapter_PublishProductEvent(t *testing.T) {
 	mockProducer := new(MockProducer)
 	mockConsumer := new(MockConsumer)
@@ -0,0 +1,29 @@
+// Package authz provides a minimal authorization abstraction for code that
+// needs to gate access without depending on any particular policy engine.
+package authz
+
+// Authorizer decides whether the caller may perform action on resource.
+// Implementations are expected to be cheap enough to call from the hot
+// path of publishing or consuming a stream event.
+type Authorizer interface {
+	Allow(resource, action string) bool
+}
+
+// AllowAll is an Authorizer that permits every resource/action pair. It is
+// the default used wherever no Authorizer is supplied, so callers that
+// don't care about authorization keep working unchanged.
+type AllowAll struct{}
+
+// Allow implements Authorizer.
+func (AllowAll) Allow(resource, action string) bool { return true }
+
+// DenyAll is an Authorizer that rejects every resource/action pair.
+type DenyAll struct{}
+
+// Allow implements Authorizer.
+func (DenyAll) Allow(resource, action string) bool { return false }
+
+var (
+	_ Authorizer = AllowAll{}
+	_ Authorizer = DenyAll{}
+)
@@ -16,10 +16,19 @@ type Event struct {
     Subject         string          `json:"subject"`
     Time            string          `json:"time"`
     DataContentType string          `json:"datacontenttype"`
+    DataSchema      string          `json:"dataschema,omitempty"`
     Data            json.RawMessage `json:"data"`
     TraceID         string          `json:"trace_id,omitempty"`
     CorrelationID   string          `json:"correlation_id,omitempty"`
     Tenant          string          `json:"tenant,omitempty"`
+
+    // Traceparent, PartitionKey and RequestID are CloudEvents extension
+    // attributes (https://github.com/cloudevents/spec/blob/main/cloudevents/documented-extensions.md)
+    // carried as first-class fields instead of a free-form map, so they
+    // participate in every wire format (structured/binary/protobuf).
+    Traceparent  string `json:"traceparent,omitempty"`
+    PartitionKey string `json:"partitionkey,omitempty"`
+    RequestID    string `json:"requestid,omitempty"`
 }
 
 // New builds a new CloudEvent envelope
@@ -36,6 +45,9 @@ func New(source, subject, eventType string, data any, opts ...Option) (*Event, e
         DataContentType: "application/json",
         Data:            payload,
     }
+    if schema, ok := DataSchemaFor(eventType); ok {
+        e.DataSchema = schema
+    }
     for _, o := range opts { o(e) }
     return e, nil
 }
@@ -49,18 +61,10 @@ func WithTenant(tenant string) Option       { return func(e *Event) { e.Tenant =
 func WithID(id string) Option               { return func(e *Event) { e.ID = id } }
 func WithTime(t time.Time) Option           { return func(e *Event) { e.Time = t.UTC().Format(time.RFC3339) } }
 func WithContentType(ct string) Option      { return func(e *Event) { e.DataContentType = ct } }
+func WithDataSchema(uri string) Option      { return func(e *Event) { e.DataSchema = uri } }
+func WithTraceparent(tp string) Option      { return func(e *Event) { e.Traceparent = tp } }
+func WithPartitionKey(key string) Option    { return func(e *Event) { e.PartitionKey = key } }
+func WithRequestID(id string) Option        { return func(e *Event) { e.RequestID = id } }
 
-// Headers returns a JSON-serializable headers map for storage
-func (e *Event) Headers() map[string]any {
-    h := map[string]any{
-        "specversion":     e.SpecVersion,
-        "source":          e.Source,
-        "subject":         e.Subject,
-        "time":            e.Time,
-        "datacontenttype": e.DataContentType,
-    }
-    if e.TraceID != "" { h["trace_id"] = e.TraceID }
-    if e.CorrelationID != "" { h["correlation_id"] = e.CorrelationID }
-    if e.Tenant != "" { h["tenant"] = e.Tenant }
-    return h
-}
+// Headers is defined in format.go, where it delegates to BinaryHeaders so the
+// structured-JSON and binary-mode attribute sets can't drift apart.
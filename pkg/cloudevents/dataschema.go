@@ -0,0 +1,28 @@
+package cloudevents
+
+import "sync"
+
+// dataSchemaRegistry maps an event type (e.g.
+// "catalog.product.enrichment.requested.v1") to the URI of the JSON Schema
+// describing its Data payload, so New can populate DataSchema without every
+// call site having to know it.
+var dataSchemaRegistry = struct {
+	mu     sync.RWMutex
+	byType map[string]string
+}{byType: make(map[string]string)}
+
+// RegisterDataSchema associates eventType with schemaURI. Later calls for the
+// same eventType overwrite the previous association.
+func RegisterDataSchema(eventType, schemaURI string) {
+	dataSchemaRegistry.mu.Lock()
+	defer dataSchemaRegistry.mu.Unlock()
+	dataSchemaRegistry.byType[eventType] = schemaURI
+}
+
+// DataSchemaFor returns the schema URI registered for eventType, if any.
+func DataSchemaFor(eventType string) (string, bool) {
+	dataSchemaRegistry.mu.RLock()
+	defer dataSchemaRegistry.mu.RUnlock()
+	uri, ok := dataSchemaRegistry.byType[eventType]
+	return uri, ok
+}
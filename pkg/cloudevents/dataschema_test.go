@@ -0,0 +1,32 @@
+package cloudevents
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestRegisterDataSchemaPopulatesNewEvents(t *testing.T) {
+	RegisterDataSchema("catalog.product.detected.v1", "https://schemas.example.com/catalog.product.detected.v1.json")
+	t.Cleanup(func() { RegisterDataSchema("catalog.product.detected.v1", "") })
+
+	e, err := New("catalog", "asin-123", "catalog.product.detected.v1", map[string]string{"asin": "B0TEST"})
+	require.NoError(t, err)
+	assert.Equal(t, "https://schemas.example.com/catalog.product.detected.v1.json", e.DataSchema)
+}
+
+func TestDataSchemaForUnknownTypeReturnsFalse(t *testing.T) {
+	_, ok := DataSchemaFor("some.unregistered.type.v1")
+	assert.False(t, ok)
+}
+
+func TestWithDataSchemaOverridesRegistry(t *testing.T) {
+	RegisterDataSchema("catalog.product.detected.v1", "https://schemas.example.com/registry.json")
+	t.Cleanup(func() { RegisterDataSchema("catalog.product.detected.v1", "") })
+
+	e, err := New("catalog", "asin-123", "catalog.product.detected.v1", map[string]string{"asin": "B0TEST"},
+		WithDataSchema("https://schemas.example.com/override.json"))
+	require.NoError(t, err)
+	assert.Equal(t, "https://schemas.example.com/override.json", e.DataSchema)
+}
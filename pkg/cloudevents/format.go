@@ -0,0 +1,312 @@
+package cloudevents
+
+import (
+	"encoding/binary"
+	"encoding/json"
+	"fmt"
+)
+
+// Format identifies a CloudEvents 1.0 serialization mode.
+type Format int
+
+const (
+	// FormatJSON is the existing structured JSON content mode.
+	FormatJSON Format = iota
+	// FormatBinary is the CloudEvents "binary content mode": attributes are
+	// lifted into a ce-prefixed header bag and Data is carried raw.
+	FormatBinary
+	// FormatProtobuf is the CloudEvents protobuf format (id/source/type/
+	// spec_version/attributes + a binary_data/text_data oneof).
+	FormatProtobuf
+)
+
+// Marshal serializes the event using the given wire format.
+func (e *Event) Marshal(format Format) ([]byte, error) {
+	switch format {
+	case FormatJSON:
+		return json.Marshal(e)
+	case FormatBinary:
+		return e.marshalBinary()
+	case FormatProtobuf:
+		return e.Protobuf()
+	default:
+		return nil, fmt.Errorf("cloudevents: unknown format %d", format)
+	}
+}
+
+// Unmarshal deserializes data produced by Marshal(format) into e.
+func (e *Event) Unmarshal(format Format, data []byte) error {
+	switch format {
+	case FormatJSON:
+		return json.Unmarshal(data, e)
+	case FormatBinary:
+		return e.unmarshalBinary(data)
+	case FormatProtobuf:
+		return e.FromProtobuf(data)
+	default:
+		return fmt.Errorf("cloudevents: unknown format %d", format)
+	}
+}
+
+// BinaryHeaders returns the CloudEvents binary content mode header bag: every
+// attribute lifted to a ce-prefixed string, ready to ship as NATS/Kafka
+// message headers without re-wrapping Data.
+func (e *Event) BinaryHeaders() map[string]string {
+	h := map[string]string{
+		"ce-id":          e.ID,
+		"ce-source":      e.Source,
+		"ce-type":        e.Type,
+		"ce-specversion": e.SpecVersion,
+	}
+	if e.Subject != "" {
+		h["ce-subject"] = e.Subject
+	}
+	if e.Time != "" {
+		h["ce-time"] = e.Time
+	}
+	if e.DataContentType != "" {
+		h["ce-datacontenttype"] = e.DataContentType
+	}
+	if e.TraceID != "" {
+		h["ce-traceid"] = e.TraceID
+	}
+	if e.CorrelationID != "" {
+		h["ce-correlationid"] = e.CorrelationID
+	}
+	if e.Tenant != "" {
+		h["ce-tenant"] = e.Tenant
+	}
+	if e.DataSchema != "" {
+		h["ce-dataschema"] = e.DataSchema
+	}
+	if e.Traceparent != "" {
+		h["ce-traceparent"] = e.Traceparent
+	}
+	if e.PartitionKey != "" {
+		h["ce-partitionkey"] = e.PartitionKey
+	}
+	if e.RequestID != "" {
+		h["ce-requestid"] = e.RequestID
+	}
+	return h
+}
+
+// Headers returns a JSON-serializable headers map for storage, delegating to
+// BinaryHeaders so both entry points stay in lockstep as attributes are added.
+func (e *Event) Headers() map[string]any {
+	h := make(map[string]any, len(e.BinaryHeaders())+1)
+	for k, v := range e.BinaryHeaders() {
+		// Strip the wire "ce-" prefix: keys are the bare attribute names
+		// ("id", "traceid", "correlationid", ...).
+		h[k[len("ce-"):]] = v
+	}
+	return h
+}
+
+// binaryMessage is the transport envelope produced/consumed by
+// marshalBinary/unmarshalBinary: headers plus the raw data bytes, so a
+// NATS/Kafka adapter can split them across message headers and body.
+type binaryMessage struct {
+	Headers map[string]string `json:"headers"`
+	Data    json.RawMessage   `json:"data"`
+}
+
+func (e *Event) marshalBinary() ([]byte, error) {
+	return json.Marshal(binaryMessage{
+		Headers: e.BinaryHeaders(),
+		Data:    e.Data,
+	})
+}
+
+func (e *Event) unmarshalBinary(data []byte) error {
+	var msg binaryMessage
+	if err := json.Unmarshal(data, &msg); err != nil {
+		return fmt.Errorf("cloudevents: unmarshal binary mode: %w", err)
+	}
+
+	h := msg.Headers
+	e.ID = h["ce-id"]
+	e.Source = h["ce-source"]
+	e.Type = h["ce-type"]
+	e.SpecVersion = h["ce-specversion"]
+	e.Subject = h["ce-subject"]
+	e.Time = h["ce-time"]
+	e.DataContentType = h["ce-datacontenttype"]
+	e.TraceID = h["ce-traceid"]
+	e.CorrelationID = h["ce-correlationid"]
+	e.Tenant = h["ce-tenant"]
+	e.DataSchema = h["ce-dataschema"]
+	e.Traceparent = h["ce-traceparent"]
+	e.PartitionKey = h["ce-partitionkey"]
+	e.RequestID = h["ce-requestid"]
+	e.Data = msg.Data
+	return nil
+}
+
+// Protobuf wire field numbers, mirroring the official
+// io.cloudevents.v1.CloudEvent message (id=1, source=2, type=3,
+// spec_version=4, attributes=5, binary_data=6, text_data=7).
+const (
+	pbFieldID          = 1
+	pbFieldSource      = 2
+	pbFieldType        = 3
+	pbFieldSpecVersion = 4
+	pbFieldAttributes  = 5
+	pbFieldBinaryData  = 6
+	pbFieldTextData    = 7
+)
+
+// Protobuf encodes the event as a minimal protobuf-wire-compatible message
+// using the official CloudEvents field layout, without depending on a
+// generated protobuf runtime. Attributes (subject/time/datacontenttype/
+// traceid/correlationid/tenant/dataschema/traceparent/partitionkey/
+// requestid) are carried in the attributes map; Data is
+// emitted via the binary_data/text_data oneof depending on DataContentType.
+func (e *Event) Protobuf() ([]byte, error) {
+	var buf []byte
+	buf = appendTagString(buf, pbFieldID, e.ID)
+	buf = appendTagString(buf, pbFieldSource, e.Source)
+	buf = appendTagString(buf, pbFieldType, e.Type)
+	buf = appendTagString(buf, pbFieldSpecVersion, e.SpecVersion)
+
+	for k, v := range e.BinaryHeaders() {
+		if k == "ce-id" || k == "ce-source" || k == "ce-type" || k == "ce-specversion" {
+			continue
+		}
+		entry := appendTagString(nil, 1, k[len("ce-"):])
+		entry = appendTagString(entry, 2, v)
+		buf = appendTagBytes(buf, pbFieldAttributes, entry)
+	}
+
+	if isTextContentType(e.DataContentType) {
+		buf = appendTagString(buf, pbFieldTextData, string(e.Data))
+	} else {
+		buf = appendTagBytes(buf, pbFieldBinaryData, e.Data)
+	}
+
+	return buf, nil
+}
+
+// FromProtobuf decodes a message produced by Protobuf back into e.
+func (e *Event) FromProtobuf(data []byte) error {
+	*e = Event{}
+	attrs := make(map[string]string)
+
+	for len(data) > 0 {
+		field, wireType, n, err := decodeTag(data)
+		if err != nil {
+			return fmt.Errorf("cloudevents: decode protobuf: %w", err)
+		}
+		data = data[n:]
+		if wireType != 2 {
+			return fmt.Errorf("cloudevents: unsupported wire type %d for field %d", wireType, field)
+		}
+
+		value, n, err := decodeBytes(data)
+		if err != nil {
+			return fmt.Errorf("cloudevents: decode protobuf: %w", err)
+		}
+		data = data[n:]
+
+		switch field {
+		case pbFieldID:
+			e.ID = string(value)
+		case pbFieldSource:
+			e.Source = string(value)
+		case pbFieldType:
+			e.Type = string(value)
+		case pbFieldSpecVersion:
+			e.SpecVersion = string(value)
+		case pbFieldAttributes:
+			k, v, err := decodeAttributeEntry(value)
+			if err != nil {
+				return fmt.Errorf("cloudevents: decode protobuf attribute: %w", err)
+			}
+			attrs[k] = v
+		case pbFieldBinaryData:
+			e.Data = append(json.RawMessage(nil), value...)
+		case pbFieldTextData:
+			e.Data = json.RawMessage(value)
+		default:
+			// Unknown field: ignore, forward compatible with future attributes.
+		}
+	}
+
+	e.Subject = attrs["subject"]
+	e.Time = attrs["time"]
+	e.DataContentType = attrs["datacontenttype"]
+	e.TraceID = attrs["traceid"]
+	e.CorrelationID = attrs["correlationid"]
+	e.Tenant = attrs["tenant"]
+	e.DataSchema = attrs["dataschema"]
+	e.Traceparent = attrs["traceparent"]
+	e.PartitionKey = attrs["partitionkey"]
+	e.RequestID = attrs["requestid"]
+	return nil
+}
+
+func isTextContentType(contentType string) bool {
+	return contentType == "" || contentType == "application/json"
+}
+
+func decodeAttributeEntry(data []byte) (key, value string, err error) {
+	for len(data) > 0 {
+		field, wireType, n, derr := decodeTag(data)
+		if derr != nil {
+			return "", "", derr
+		}
+		data = data[n:]
+		if wireType != 2 {
+			return "", "", fmt.Errorf("unsupported wire type %d in attribute entry", wireType)
+		}
+		v, n, derr := decodeBytes(data)
+		if derr != nil {
+			return "", "", derr
+		}
+		data = data[n:]
+		switch field {
+		case 1:
+			key = string(v)
+		case 2:
+			value = string(v)
+		}
+	}
+	return key, value, nil
+}
+
+func appendTagString(buf []byte, field int, s string) []byte {
+	return appendTagBytes(buf, field, []byte(s))
+}
+
+func appendTagBytes(buf []byte, field int, b []byte) []byte {
+	tag := uint64(field)<<3 | 2 // length-delimited wire type
+	buf = appendVarint(buf, tag)
+	buf = appendVarint(buf, uint64(len(b)))
+	return append(buf, b...)
+}
+
+func appendVarint(buf []byte, v uint64) []byte {
+	var tmp [binary.MaxVarintLen64]byte
+	n := binary.PutUvarint(tmp[:], v)
+	return append(buf, tmp[:n]...)
+}
+
+func decodeTag(data []byte) (field int, wireType int, n int, err error) {
+	tag, n := binary.Uvarint(data)
+	if n <= 0 {
+		return 0, 0, 0, fmt.Errorf("malformed tag")
+	}
+	return int(tag >> 3), int(tag & 0x7), n, nil
+}
+
+func decodeBytes(data []byte) ([]byte, int, error) {
+	length, n := binary.Uvarint(data)
+	if n <= 0 {
+		return nil, 0, fmt.Errorf("malformed length")
+	}
+	total := n + int(length)
+	if total > len(data) {
+		return nil, 0, fmt.Errorf("truncated payload")
+	}
+	return data[n:total], total, nil
+}
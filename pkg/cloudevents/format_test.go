@@ -0,0 +1,61 @@
+package cloudevents
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestEventMarshalUnmarshalRoundTrip(t *testing.T) {
+	tests := []struct {
+		name   string
+		format Format
+	}{
+		{name: "json", format: FormatJSON},
+		{name: "binary", format: FormatBinary},
+		{name: "protobuf", format: FormatProtobuf},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			e, err := New("catalog", "asin-123", "catalog.product.detected.v1", map[string]string{"asin": "B0TEST"},
+				WithTrace("trace-1"), WithCorrelation("corr-1"), WithTenant("tenant-1"),
+				WithDataSchema("https://schemas.example.com/catalog.product.detected.v1.json"),
+				WithTraceparent("00-trace-01"), WithPartitionKey("asin-123"), WithRequestID("req-1"))
+			require.NoError(t, err)
+
+			data, err := e.Marshal(tt.format)
+			require.NoError(t, err)
+
+			var got Event
+			require.NoError(t, got.Unmarshal(tt.format, data))
+
+			assert.Equal(t, e.ID, got.ID)
+			assert.Equal(t, e.Source, got.Source)
+			assert.Equal(t, e.Type, got.Type)
+			assert.Equal(t, e.SpecVersion, got.SpecVersion)
+			assert.Equal(t, e.TraceID, got.TraceID)
+			assert.Equal(t, e.CorrelationID, got.CorrelationID)
+			assert.Equal(t, e.Tenant, got.Tenant)
+			assert.Equal(t, e.DataSchema, got.DataSchema)
+			assert.Equal(t, e.Traceparent, got.Traceparent)
+			assert.Equal(t, e.PartitionKey, got.PartitionKey)
+			assert.Equal(t, e.RequestID, got.RequestID)
+			assert.JSONEq(t, string(e.Data), string(got.Data))
+		})
+	}
+}
+
+func TestEventBinaryHeaders(t *testing.T) {
+	e, err := New("catalog", "asin-123", "catalog.product.detected.v1", map[string]string{"asin": "B0TEST"},
+		WithTrace("trace-1"))
+	require.NoError(t, err)
+
+	h := e.BinaryHeaders()
+	assert.Equal(t, e.ID, h["ce-id"])
+	assert.Equal(t, e.Source, h["ce-source"])
+	assert.Equal(t, e.Type, h["ce-type"])
+	assert.Equal(t, "1.0", h["ce-specversion"])
+	assert.Equal(t, "trace-1", h["ce-traceid"])
+}
@@ -0,0 +1,61 @@
+package cloudevents
+
+import "fmt"
+
+// KafkaHeader mirrors kafka-go's Header shape without importing the Kafka
+// client library, so this package stays dependency-free; adapters convert
+// to/from their client's native header type.
+type KafkaHeader struct {
+	Key   string
+	Value []byte
+}
+
+// ToKafkaBinary encodes the event as the CloudEvents Kafka binary binding:
+// every attribute becomes a ce_-prefixed Kafka header and Data is carried as
+// the raw message value, mirroring BinaryHeaders/marshalBinary but using the
+// Kafka binding's "ce_" header prefix instead of HTTP's "ce-".
+func (e *Event) ToKafkaBinary() (headers []KafkaHeader, value []byte) {
+	for k, v := range e.BinaryHeaders() {
+		headers = append(headers, KafkaHeader{
+			Key:   "ce_" + k[len("ce-"):],
+			Value: []byte(v),
+		})
+	}
+	if e.DataContentType != "" {
+		headers = append(headers, KafkaHeader{Key: "content-type", Value: []byte(e.DataContentType)})
+	}
+	return headers, e.Data
+}
+
+// EventFromKafkaBinary decodes an event previously encoded by ToKafkaBinary.
+func EventFromKafkaBinary(headers []KafkaHeader, value []byte) (*Event, error) {
+	attrs := make(map[string]string, len(headers))
+	for _, h := range headers {
+		if h.Key == "content-type" {
+			continue
+		}
+		attrs[h.Key] = string(h.Value)
+	}
+
+	e := &Event{
+		ID:              attrs["ce_id"],
+		Source:          attrs["ce_source"],
+		Type:            attrs["ce_type"],
+		SpecVersion:     attrs["ce_specversion"],
+		Subject:         attrs["ce_subject"],
+		Time:            attrs["ce_time"],
+		DataContentType: attrs["ce_datacontenttype"],
+		DataSchema:      attrs["ce_dataschema"],
+		TraceID:         attrs["ce_traceid"],
+		CorrelationID:   attrs["ce_correlationid"],
+		Tenant:          attrs["ce_tenant"],
+		Traceparent:     attrs["ce_traceparent"],
+		PartitionKey:    attrs["ce_partitionkey"],
+		RequestID:       attrs["ce_requestid"],
+		Data:            value,
+	}
+	if e.ID == "" || e.Type == "" {
+		return nil, fmt.Errorf("cloudevents: kafka binary message missing ce_id/ce_type headers")
+	}
+	return e, nil
+}
@@ -0,0 +1,31 @@
+package cloudevents
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestEventKafkaBinaryRoundTrip(t *testing.T) {
+	e, err := New("catalog", "asin-123", "catalog.product.detected.v1", map[string]string{"asin": "B0TEST"},
+		WithTraceparent("00-trace-01"), WithPartitionKey("asin-123"), WithRequestID("req-1"))
+	require.NoError(t, err)
+
+	headers, value := e.ToKafkaBinary()
+	assert.JSONEq(t, string(e.Data), string(value))
+
+	got, err := EventFromKafkaBinary(headers, value)
+	require.NoError(t, err)
+	assert.Equal(t, e.ID, got.ID)
+	assert.Equal(t, e.Source, got.Source)
+	assert.Equal(t, e.Type, got.Type)
+	assert.Equal(t, e.Traceparent, got.Traceparent)
+	assert.Equal(t, e.PartitionKey, got.PartitionKey)
+	assert.Equal(t, e.RequestID, got.RequestID)
+}
+
+func TestEventFromKafkaBinaryRejectsMissingRequiredHeaders(t *testing.T) {
+	_, err := EventFromKafkaBinary(nil, []byte(`{}`))
+	assert.Error(t, err)
+}
@@ -0,0 +1,189 @@
+package database
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+	"sync"
+	"sync/atomic"
+	"time"
+)
+
+// ClusterConfig configures a primary database plus a set of read replicas.
+type ClusterConfig struct {
+	Primary Config
+
+	// Readers are the replica pools read traffic may be offloaded to. A
+	// Cluster with no Readers serves reads from Primary.
+	Readers []Config
+
+	// HealthCheckInterval is how often replicas are pinged to decide
+	// whether they're eligible for Reader(). Defaults to 10s.
+	HealthCheckInterval time.Duration
+}
+
+// Cluster is a primary database plus a set of read replicas, offering
+// read/writer-split access: writes and transactions always go to Writer(),
+// while Reader() round-robins across replicas currently known to be
+// healthy, falling back to the primary if none are.
+type Cluster struct {
+	writer *sql.DB
+
+	mu       sync.RWMutex
+	readers  []*sql.DB
+	healthy  []bool
+	next     uint64
+	interval time.Duration
+
+	stop chan struct{}
+	wg   sync.WaitGroup
+}
+
+// NewCluster opens the primary and all reader connections described by
+// config and starts background health checks for the readers. Call
+// Close to release the pools and stop the health checks.
+func NewCluster(config *ClusterConfig) (*Cluster, error) {
+	writer, err := NewConnection(&config.Primary)
+	if err != nil {
+		return nil, fmt.Errorf("failed to connect to primary: %w", err)
+	}
+
+	readers := make([]*sql.DB, 0, len(config.Readers))
+	for i := range config.Readers {
+		reader, err := NewConnection(&config.Readers[i])
+		if err != nil {
+			writer.Close()
+			for _, r := range readers {
+				r.Close()
+			}
+			return nil, fmt.Errorf("failed to connect to reader %d: %w", i, err)
+		}
+		readers = append(readers, reader)
+	}
+
+	interval := config.HealthCheckInterval
+	if interval <= 0 {
+		interval = 10 * time.Second
+	}
+
+	c := &Cluster{
+		writer:   writer,
+		readers:  readers,
+		healthy:  make([]bool, len(readers)),
+		interval: interval,
+		stop:     make(chan struct{}),
+	}
+	for i := range c.healthy {
+		c.healthy[i] = true
+	}
+
+	if len(readers) > 0 {
+		c.wg.Add(1)
+		go c.healthCheckLoop()
+	}
+
+	return c, nil
+}
+
+// Writer returns the primary connection pool. Writes, DDL, and
+// transactions always use it.
+func (c *Cluster) Writer() *sql.DB {
+	return c.writer
+}
+
+// Reader returns a replica connection pool, round-robining across readers
+// currently marked healthy. Falls back to Writer() if there are no
+// readers, or none are currently healthy.
+func (c *Cluster) Reader() *sql.DB {
+	c.mu.RLock()
+	defer c.mu.RUnlock()
+
+	n := len(c.readers)
+	if n == 0 {
+		return c.writer
+	}
+
+	start := atomic.AddUint64(&c.next, 1)
+	for i := 0; i < n; i++ {
+		idx := int((start + uint64(i)) % uint64(n))
+		if c.healthy[idx] {
+			return c.readers[idx]
+		}
+	}
+	return c.writer
+}
+
+// QueryReader runs a query against Reader().
+func (c *Cluster) QueryReader(ctx context.Context, query string, args ...interface{}) (*sql.Rows, error) {
+	return c.Reader().QueryContext(ctx, query, args...)
+}
+
+// QueryRowReader runs a single-row query against Reader().
+func (c *Cluster) QueryRowReader(ctx context.Context, query string, args ...interface{}) *sql.Row {
+	return c.Reader().QueryRowContext(ctx, query, args...)
+}
+
+// ExecWriter runs a write statement against Writer().
+func (c *Cluster) ExecWriter(ctx context.Context, query string, args ...interface{}) (sql.Result, error) {
+	return c.writer.ExecContext(ctx, query, args...)
+}
+
+// ExecuteInTransaction runs fn in a transaction against Writer(). Replicas
+// never participate in transactions.
+func (c *Cluster) ExecuteInTransaction(ctx context.Context, fn func(*sql.Tx) error) error {
+	return ExecuteInTransaction(ctx, c.writer, fn)
+}
+
+// Close closes the primary and all reader pools and stops the health-check
+// goroutine.
+func (c *Cluster) Close() error {
+	close(c.stop)
+	c.wg.Wait()
+
+	var firstErr error
+	if err := c.writer.Close(); err != nil {
+		firstErr = err
+	}
+	for _, r := range c.readers {
+		if err := r.Close(); err != nil && firstErr == nil {
+			firstErr = err
+		}
+	}
+	return firstErr
+}
+
+// healthCheckLoop pings every reader on interval, marking it down on
+// failure and back up once it responds again.
+func (c *Cluster) healthCheckLoop() {
+	defer c.wg.Done()
+
+	ticker := time.NewTicker(c.interval)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-c.stop:
+			return
+		case <-ticker.C:
+			c.checkReaders()
+		}
+	}
+}
+
+func (c *Cluster) checkReaders() {
+	c.mu.RLock()
+	readers := make([]*sql.DB, len(c.readers))
+	copy(readers, c.readers)
+	c.mu.RUnlock()
+
+	results := make([]bool, len(readers))
+	for i, r := range readers {
+		ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+		results[i] = r.PingContext(ctx) == nil
+		cancel()
+	}
+
+	c.mu.Lock()
+	copy(c.healthy, results)
+	c.mu.Unlock()
+}
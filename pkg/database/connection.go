@@ -4,6 +4,9 @@ import (
 	"context"
 	"database/sql"
 	"fmt"
+	"math"
+	"sort"
+	"strings"
 	"time"
 
 	_ "github.com/lib/pq" // PostgreSQL driver
@@ -20,6 +23,42 @@ type Config struct {
 	MaxOpenConns    int
 	MaxIdleConns    int
 	ConnMaxLifetime time.Duration
+
+	// ConnectionString, when set, is passed to sql.Open verbatim - either a
+	// postgres://... URL or a libpq key=value string - bypassing BuildDSN's
+	// key/value assembly (and the rest of Config's fields) entirely. This is
+	// the escape hatch for managed-Postgres deployments that hand callers a
+	// ready-made connection string or URL-style secret.
+	ConnectionString string
+
+	// SSLRootCert, SSLCert, and SSLKey are file paths to the CA bundle,
+	// client certificate, and client key used for mTLS, passed through as
+	// libpq's sslrootcert/sslcert/sslkey.
+	SSLRootCert string
+	SSLCert     string
+	SSLKey      string
+
+	// ApplicationName is reported to the server as application_name, useful
+	// for identifying a service in pg_stat_activity.
+	ApplicationName string
+
+	// ConnectTimeout is the maximum time to wait while establishing the
+	// connection, passed through as libpq's connect_timeout (in whole
+	// seconds; fractional durations are rounded up).
+	ConnectTimeout time.Duration
+
+	// StatementTimeout aborts any statement taking longer than this,
+	// passed through as the statement_timeout run-time parameter (in whole
+	// milliseconds).
+	StatementTimeout time.Duration
+
+	// SearchPath sets the server's search_path run-time parameter, e.g.
+	// "tenant_a,public".
+	SearchPath string
+
+	// Extra carries additional libpq keyword/value parameters not covered
+	// by Config's named fields.
+	Extra map[string]string
 }
 
 // NewConnection creates a new database connection with the given configuration
@@ -28,12 +67,8 @@ func NewConnection(config *Config) (*sql.DB, error) {
 		return nil, fmt.Errorf("invalid config: %w", err)
 	}
 
-	// Build connection string
-	dsn := fmt.Sprintf("host=%s port=%d user=%s password=%s dbname=%s sslmode=%s",
-		config.Host, config.Port, config.User, config.Password, config.Database, config.SSLMode)
-
 	// Open database connection
-	db, err := sql.Open("postgres", dsn)
+	db, err := sql.Open("postgres", BuildDSN(config))
 	if err != nil {
 		return nil, fmt.Errorf("failed to open database: %w", err)
 	}
@@ -61,8 +96,13 @@ func NewConnection(config *Config) (*sql.DB, error) {
 	return db, nil
 }
 
-// validateConfig validates the database configuration
+// validateConfig validates the database configuration. A non-empty
+// ConnectionString bypasses all other field requirements, since it's
+// passed to sql.Open verbatim.
 func validateConfig(config *Config) error {
+	if config.ConnectionString != "" {
+		return nil
+	}
 	if config.Host == "" {
 		return fmt.Errorf("host cannot be empty")
 	}
@@ -135,8 +175,76 @@ func IsNoRowsError(err error) bool {
 	return err == sql.ErrNoRows
 }
 
-// BuildDSN builds a PostgreSQL DSN from configuration
+// BuildDSN builds a PostgreSQL DSN from configuration, as a libpq
+// key=value string with every value correctly quoted. If
+// config.ConnectionString is set, it's returned as-is instead, supporting
+// both postgres://... URLs and hand-assembled libpq strings.
 func BuildDSN(config *Config) string {
-	return fmt.Sprintf("host=%s port=%d user=%s password=%s dbname=%s sslmode=%s",
-		config.Host, config.Port, config.User, config.Password, config.Database, config.SSLMode)
-}
\ No newline at end of file
+	if config.ConnectionString != "" {
+		return config.ConnectionString
+	}
+
+	var b strings.Builder
+	writeDSNParam(&b, "host", config.Host)
+	writeDSNParam(&b, "port", fmt.Sprintf("%d", config.Port))
+	writeDSNParam(&b, "user", config.User)
+	writeDSNParam(&b, "password", config.Password)
+	writeDSNParam(&b, "dbname", config.Database)
+	writeDSNParam(&b, "sslmode", config.SSLMode)
+	writeDSNParam(&b, "sslrootcert", config.SSLRootCert)
+	writeDSNParam(&b, "sslcert", config.SSLCert)
+	writeDSNParam(&b, "sslkey", config.SSLKey)
+	writeDSNParam(&b, "application_name", config.ApplicationName)
+	if config.ConnectTimeout > 0 {
+		seconds := int(math.Ceil(config.ConnectTimeout.Seconds()))
+		writeDSNParam(&b, "connect_timeout", fmt.Sprintf("%d", seconds))
+	}
+	if config.StatementTimeout > 0 {
+		millis := config.StatementTimeout.Milliseconds()
+		writeDSNParam(&b, "statement_timeout", fmt.Sprintf("%d", millis))
+	}
+	writeDSNParam(&b, "search_path", config.SearchPath)
+
+	extraKeys := make([]string, 0, len(config.Extra))
+	for key := range config.Extra {
+		extraKeys = append(extraKeys, key)
+	}
+	sort.Strings(extraKeys)
+	for _, key := range extraKeys {
+		writeDSNParam(&b, key, config.Extra[key])
+	}
+
+	return strings.TrimSpace(b.String())
+}
+
+// writeDSNParam appends "key=value " to b, quoting value if it contains
+// whitespace, a single quote, or a backslash. Empty values are
+// skipped entirely, matching libpq's own behavior of ignoring blank
+// parameters.
+func writeDSNParam(b *strings.Builder, key, value string) {
+	if value == "" {
+		return
+	}
+	b.WriteString(key)
+	b.WriteByte('=')
+	b.WriteString(quoteDSNValue(value))
+	b.WriteByte(' ')
+}
+
+// quoteDSNValue quotes v for use as a libpq key=value DSN parameter value
+// if it contains characters that would otherwise be ambiguous.
+func quoteDSNValue(v string) string {
+	if !strings.ContainsAny(v, " \t\n'\\") {
+		return v
+	}
+	var b strings.Builder
+	b.WriteByte('\'')
+	for _, r := range v {
+		if r == '\'' || r == '\\' {
+			b.WriteByte('\\')
+		}
+		b.WriteRune(r)
+	}
+	b.WriteByte('\'')
+	return b.String()
+}
@@ -0,0 +1,137 @@
+package database
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/lib/pq"
+)
+
+// ParseDSN parses a PostgreSQL connection string - either a postgres://
+// or postgresql:// URL, or a libpq key=value string - into a Config.
+// It's BuildDSN's inverse: ParseDSN(BuildDSN(cfg)) reproduces cfg's
+// connection parameters (Extra included, MaxOpenConns/MaxIdleConns/
+// ConnMaxLifetime excluded, since those are pool settings BuildDSN never
+// encodes).
+func ParseDSN(dsn string) (*Config, error) {
+	keywordValue := dsn
+	if strings.HasPrefix(dsn, "postgres://") || strings.HasPrefix(dsn, "postgresql://") {
+		normalized, err := pq.ParseURL(dsn)
+		if err != nil {
+			return nil, fmt.Errorf("failed to parse connection URL: %w", err)
+		}
+		keywordValue = normalized
+	}
+
+	params, err := parseKeywordValueDSN(keywordValue)
+	if err != nil {
+		return nil, fmt.Errorf("failed to parse DSN: %w", err)
+	}
+
+	config := &Config{Extra: map[string]string{}}
+	for key, value := range params {
+		switch key {
+		case "host":
+			config.Host = value
+		case "port":
+			port, err := strconv.Atoi(value)
+			if err != nil {
+				return nil, fmt.Errorf("invalid port %q: %w", value, err)
+			}
+			config.Port = port
+		case "user":
+			config.User = value
+		case "password":
+			config.Password = value
+		case "dbname":
+			config.Database = value
+		case "sslmode":
+			config.SSLMode = value
+		case "sslrootcert":
+			config.SSLRootCert = value
+		case "sslcert":
+			config.SSLCert = value
+		case "sslkey":
+			config.SSLKey = value
+		case "application_name":
+			config.ApplicationName = value
+		case "connect_timeout":
+			seconds, err := strconv.Atoi(value)
+			if err != nil {
+				return nil, fmt.Errorf("invalid connect_timeout %q: %w", value, err)
+			}
+			config.ConnectTimeout = time.Duration(seconds) * time.Second
+		case "statement_timeout":
+			millis, err := strconv.ParseInt(value, 10, 64)
+			if err != nil {
+				return nil, fmt.Errorf("invalid statement_timeout %q: %w", value, err)
+			}
+			config.StatementTimeout = time.Duration(millis) * time.Millisecond
+		case "search_path":
+			config.SearchPath = value
+		default:
+			config.Extra[key] = value
+		}
+	}
+
+	return config, nil
+}
+
+// parseKeywordValueDSN parses a libpq key=value connection string into a
+// map. Values may be bare (no whitespace or special characters) or
+// single-quoted, with backslash-escaped quotes and backslashes inside the
+// quotes, matching the format BuildDSN/quoteDSNValue produce.
+func parseKeywordValueDSN(dsn string) (map[string]string, error) {
+	params := map[string]string{}
+	i, n := 0, len(dsn)
+
+	for i < n {
+		for i < n && isDSNSpace(dsn[i]) {
+			i++
+		}
+		if i >= n {
+			break
+		}
+
+		keyStart := i
+		for i < n && dsn[i] != '=' && !isDSNSpace(dsn[i]) {
+			i++
+		}
+		if i >= n || dsn[i] != '=' {
+			return nil, fmt.Errorf("expected '=' after %q", dsn[keyStart:i])
+		}
+		key := dsn[keyStart:i]
+		i++ // skip '='
+
+		var value strings.Builder
+		if i < n && dsn[i] == '\'' {
+			i++
+			for i < n && dsn[i] != '\'' {
+				if dsn[i] == '\\' && i+1 < n {
+					i++
+				}
+				value.WriteByte(dsn[i])
+				i++
+			}
+			if i >= n {
+				return nil, fmt.Errorf("unterminated quoted value for %q", key)
+			}
+			i++ // skip closing quote
+		} else {
+			for i < n && !isDSNSpace(dsn[i]) {
+				value.WriteByte(dsn[i])
+				i++
+			}
+		}
+
+		params[key] = value.String()
+	}
+
+	return params, nil
+}
+
+func isDSNSpace(b byte) bool {
+	return b == ' ' || b == '\t' || b == '\n' || b == '\r'
+}
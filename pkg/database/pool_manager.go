@@ -0,0 +1,220 @@
+package database
+
+import (
+	"context"
+	"database/sql"
+	"os"
+	"strconv"
+	"sync"
+	"time"
+)
+
+// PoolingFeatureGateEnv is the environment variable that enables
+// PoolManager's pooling behavior by default. Mirrors the opt-in
+// feature-gate pattern used to roll out connection pooling elsewhere: with
+// the gate off, Get opens a fresh *sql.DB per call via NewConnection, the
+// same behavior callers already depend on; turning it on lets callers A/B
+// compare against reusing named, idle-reaped pools.
+const PoolingFeatureGateEnv = "DATABASE_POOL_ENABLED"
+
+func poolingEnabledFromEnv() bool {
+	enabled, _ := strconv.ParseBool(os.Getenv(PoolingFeatureGateEnv))
+	return enabled
+}
+
+// MetricsSink receives per-pool connection stats. Implementations typically
+// forward Stats to whatever metrics backend the caller already uses
+// (Prometheus, StatsD, ...); PoolManager has no opinion on the backend.
+type MetricsSink interface {
+	ObservePoolStats(name string, stats sql.DBStats)
+}
+
+// TraceHook starts a span around a database operation and returns the
+// (possibly derived) context to run the operation in, plus a function to
+// call with the operation's result error when it completes. Implementations
+// typically wrap an OpenTelemetry tracer; PoolManager has no direct
+// OpenTelemetry dependency so callers can wire in whichever SDK version
+// their service already uses.
+type TraceHook interface {
+	StartSpan(ctx context.Context, operation string) (context.Context, func(err error))
+}
+
+// PoolManagerOption configures a PoolManager.
+type PoolManagerOption func(*PoolManager)
+
+// WithIdleTimeout sets how long a named pool may go unused before
+// PoolManager closes it. Defaults to 5 minutes.
+func WithIdleTimeout(d time.Duration) PoolManagerOption {
+	return func(pm *PoolManager) { pm.idleTimeout = d }
+}
+
+// WithMetricsSink registers a MetricsSink to receive pool stats on every
+// Get call while pooling is enabled.
+func WithMetricsSink(sink MetricsSink) PoolManagerOption {
+	return func(pm *PoolManager) { pm.metrics = sink }
+}
+
+// WithTraceHook registers a TraceHook to emit spans around NewConnection,
+// ExecuteInTransaction, and WithRetry.
+func WithTraceHook(hook TraceHook) PoolManagerOption {
+	return func(pm *PoolManager) { pm.trace = hook }
+}
+
+// WithPoolingEnabled overrides the PoolingFeatureGateEnv default, letting
+// callers gate pooling through their own config instead of the environment.
+func WithPoolingEnabled(enabled bool) PoolManagerOption {
+	return func(pm *PoolManager) { pm.enabled = enabled }
+}
+
+// managedPool is a named pool's *sql.DB plus the timer that closes it after
+// idleTimeout without a Get.
+type managedPool struct {
+	db    *sql.DB
+	timer *time.Timer
+}
+
+// PoolManager owns named *sql.DB pools, created lazily on first Get and
+// closed automatically after IdleTimeout of disuse. When the pooling
+// feature gate is off (the default), Get behaves exactly like calling
+// NewConnection directly: no pool is kept, so callers see the pre-pooling
+// behavior until they opt in.
+type PoolManager struct {
+	mu          sync.Mutex
+	pools       map[string]*managedPool
+	idleTimeout time.Duration
+	metrics     MetricsSink
+	trace       TraceHook
+	enabled     bool
+}
+
+// NewPoolManager creates a PoolManager. Pooling is enabled by default if
+// PoolingFeatureGateEnv is set to a truthy value; pass WithPoolingEnabled
+// to override that.
+func NewPoolManager(opts ...PoolManagerOption) *PoolManager {
+	pm := &PoolManager{
+		pools:       make(map[string]*managedPool),
+		idleTimeout: 5 * time.Minute,
+		enabled:     poolingEnabledFromEnv(),
+	}
+	for _, opt := range opts {
+		opt(pm)
+	}
+	return pm
+}
+
+// Get returns the *sql.DB for name, creating it from config on first use.
+// With pooling disabled, it opens a fresh connection via NewConnection on
+// every call instead of reusing one.
+func (pm *PoolManager) Get(name string, config *Config) (*sql.DB, error) {
+	if !pm.enabled {
+		return pm.newConnection(config)
+	}
+
+	pm.mu.Lock()
+	defer pm.mu.Unlock()
+
+	if p, ok := pm.pools[name]; ok {
+		pm.resetIdleTimerLocked(name, p)
+		pm.recordMetricsLocked(name, p.db)
+		return p.db, nil
+	}
+
+	db, err := pm.newConnection(config)
+	if err != nil {
+		return nil, err
+	}
+
+	p := &managedPool{db: db}
+	pm.pools[name] = p
+	pm.resetIdleTimerLocked(name, p)
+	pm.recordMetricsLocked(name, db)
+	return db, nil
+}
+
+func (pm *PoolManager) resetIdleTimerLocked(name string, p *managedPool) {
+	if p.timer != nil {
+		p.timer.Stop()
+	}
+	p.timer = time.AfterFunc(pm.idleTimeout, func() { pm.closeIdle(name) })
+}
+
+func (pm *PoolManager) recordMetricsLocked(name string, db *sql.DB) {
+	if pm.metrics == nil {
+		return
+	}
+	pm.metrics.ObservePoolStats(name, db.Stats())
+}
+
+// closeIdle closes and forgets the named pool if it hasn't been Get again
+// since its timer was started.
+func (pm *PoolManager) closeIdle(name string) {
+	pm.mu.Lock()
+	defer pm.mu.Unlock()
+
+	p, ok := pm.pools[name]
+	if !ok {
+		return
+	}
+	delete(pm.pools, name)
+	p.db.Close()
+}
+
+// Shutdown closes every pool PoolManager currently owns and stops their
+// idle timers. ctx is accepted for symmetry with other Shutdown-style APIs
+// and future cancellable-drain support; the current implementation closes
+// synchronously.
+func (pm *PoolManager) Shutdown(ctx context.Context) error {
+	pm.mu.Lock()
+	defer pm.mu.Unlock()
+
+	var firstErr error
+	for name, p := range pm.pools {
+		p.timer.Stop()
+		if err := p.db.Close(); err != nil && firstErr == nil {
+			firstErr = err
+		}
+		delete(pm.pools, name)
+	}
+	return firstErr
+}
+
+// startSpan starts a span via the registered TraceHook, or is a no-op if
+// none is registered.
+func (pm *PoolManager) startSpan(ctx context.Context, operation string) (context.Context, func(error)) {
+	if pm.trace == nil {
+		return ctx, func(error) {}
+	}
+	return pm.trace.StartSpan(ctx, operation)
+}
+
+// newConnection calls NewConnection, emitting a trace span around it if a
+// TraceHook is registered.
+func (pm *PoolManager) newConnection(config *Config) (*sql.DB, error) {
+	_, end := pm.startSpan(context.Background(), "database.NewConnection")
+	db, err := NewConnection(config)
+	end(err)
+	return db, err
+}
+
+// ExecuteInTransaction resolves name's pool via Get and runs fn in a
+// transaction against it, emitting a trace span if a TraceHook is
+// registered.
+func (pm *PoolManager) ExecuteInTransaction(ctx context.Context, name string, config *Config, fn func(*sql.Tx) error) error {
+	db, err := pm.Get(name, config)
+	if err != nil {
+		return err
+	}
+	ctx, end := pm.startSpan(ctx, "database.ExecuteInTransaction")
+	err = ExecuteInTransaction(ctx, db, fn)
+	end(err)
+	return err
+}
+
+// WithRetry runs WithRetry, emitting a trace span around it if a TraceHook
+// is registered.
+func (pm *PoolManager) WithRetry(ctx context.Context, maxRetries int, delay time.Duration, fn func() error) error {
+	ctx, end := pm.startSpan(ctx, "database.WithRetry")
+	err := WithRetry(maxRetries, delay, fn)
+	end(err)
+	return err
+}
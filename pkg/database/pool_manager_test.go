@@ -0,0 +1,109 @@
+package database
+
+import (
+	"context"
+	"database/sql"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+type fakeMetricsSink struct {
+	calls []string
+}
+
+func (f *fakeMetricsSink) ObservePoolStats(name string, stats sql.DBStats) {
+	f.calls = append(f.calls, name)
+}
+
+type fakeTraceHook struct {
+	started []string
+	ended   int
+}
+
+func (f *fakeTraceHook) StartSpan(ctx context.Context, operation string) (context.Context, func(error)) {
+	f.started = append(f.started, operation)
+	return ctx, func(error) { f.ended++ }
+}
+
+func poolConfig(t *testing.T) *Config {
+	db := getTestDB(t)
+	db.Close()
+	return &Config{
+		Host: "localhost", Port: 5432, User: "postgres", Password: "postgres",
+		Database: "postgres", SSLMode: "disable",
+	}
+}
+
+func TestPoolManagerFeatureGate(t *testing.T) {
+	t.Run("disabled pooling opens a fresh connection per Get", func(t *testing.T) {
+		config := poolConfig(t)
+		pm := NewPoolManager(WithPoolingEnabled(false))
+
+		db1, err := pm.Get("tenant-a", config)
+		assert.NoError(t, err)
+		db2, err := pm.Get("tenant-a", config)
+		assert.NoError(t, err)
+
+		assert.NotSame(t, db1, db2)
+		assert.Empty(t, pm.pools)
+
+		db1.Close()
+		db2.Close()
+	})
+
+	t.Run("enabled pooling reuses the same connection per name", func(t *testing.T) {
+		config := poolConfig(t)
+		metrics := &fakeMetricsSink{}
+		pm := NewPoolManager(WithPoolingEnabled(true), WithMetricsSink(metrics))
+		defer pm.Shutdown(context.Background())
+
+		db1, err := pm.Get("tenant-a", config)
+		assert.NoError(t, err)
+		db2, err := pm.Get("tenant-a", config)
+		assert.NoError(t, err)
+
+		assert.Same(t, db1, db2)
+		assert.Equal(t, []string{"tenant-a", "tenant-a"}, metrics.calls)
+	})
+
+	t.Run("idle pools are closed and forgotten after IdleTimeout", func(t *testing.T) {
+		config := poolConfig(t)
+		pm := NewPoolManager(WithPoolingEnabled(true), WithIdleTimeout(10*time.Millisecond))
+
+		_, err := pm.Get("tenant-a", config)
+		assert.NoError(t, err)
+		assert.Len(t, pm.pools, 1)
+
+		assert.Eventually(t, func() bool {
+			pm.mu.Lock()
+			defer pm.mu.Unlock()
+			return len(pm.pools) == 0
+		}, time.Second, 5*time.Millisecond)
+	})
+
+	t.Run("Shutdown closes every pool", func(t *testing.T) {
+		config := poolConfig(t)
+		pm := NewPoolManager(WithPoolingEnabled(true))
+
+		_, err := pm.Get("tenant-a", config)
+		assert.NoError(t, err)
+		_, err = pm.Get("tenant-b", config)
+		assert.NoError(t, err)
+
+		assert.NoError(t, pm.Shutdown(context.Background()))
+		assert.Empty(t, pm.pools)
+	})
+}
+
+func TestPoolManagerTraceHook(t *testing.T) {
+	hook := &fakeTraceHook{}
+	pm := NewPoolManager(WithPoolingEnabled(true), WithTraceHook(hook))
+	defer pm.Shutdown(context.Background())
+
+	err := pm.WithRetry(context.Background(), 1, time.Millisecond, func() error { return nil })
+	assert.NoError(t, err)
+	assert.Equal(t, []string{"database.WithRetry"}, hook.started)
+	assert.Equal(t, 1, hook.ended)
+}
@@ -5,6 +5,8 @@ import (
 	"database/sql"
 	"fmt"
 	"strings"
+
+	"github.com/MalteBoehm/tall-affiliate-common/pkg/retry"
 )
 
 // QueryBuilder helps build SQL queries safely
@@ -150,7 +152,21 @@ func BulkInsert(ctx context.Context, db *sql.DB, table string, columns []string,
 	}
 
 	queryBuilder.WriteString(strings.Join(placeholders, ", "))
-	
+
 	_, err := db.ExecContext(ctx, queryBuilder.String(), args...)
 	return err
+}
+
+// BulkInsertWithRetry is BulkInsert with classified retry: transient
+// errors (connection loss, serialization failures, deadlocks) are retried
+// per policy, while constraint violations and other permanent errors fail
+// immediately. A zero-value policy.Classifier defaults to
+// retry.SQLClassifier.
+func BulkInsertWithRetry(ctx context.Context, db *sql.DB, table string, columns []string, values [][]interface{}, policy retry.Policy) error {
+	if policy.Classifier == nil {
+		policy.Classifier = retry.SQLClassifier
+	}
+	return retry.Do(ctx, policy, func() error {
+		return BulkInsert(ctx, db, table, columns, values)
+	})
 }
\ No newline at end of file
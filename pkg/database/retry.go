@@ -0,0 +1,154 @@
+package database
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"fmt"
+	"math"
+	"math/rand"
+	"time"
+
+	"github.com/lib/pq"
+
+	"github.com/MalteBoehm/tall-affiliate-common/pkg/retry"
+)
+
+// RetryPolicy configures classified retry behavior for transient database
+// errors. Unlike WithRetry, which retries any error blindly, a RetryPolicy
+// only retries errors IsRetryable accepts - constraint violations and
+// syntax errors fail fast since retrying them can never change the
+// outcome.
+type RetryPolicy struct {
+	// MaxAttempts is the total number of calls to fn, including the first.
+	MaxAttempts int
+
+	// BaseDelay is the delay before the first retry; each subsequent retry
+	// doubles it.
+	BaseDelay time.Duration
+
+	// MaxDelay caps the computed backoff delay. Zero means uncapped.
+	MaxDelay time.Duration
+
+	// Jitter is the fraction (0-1) of additional random delay added on top
+	// of the backoff delay, to avoid synchronized retries across callers.
+	Jitter float64
+
+	// IsRetryable classifies err as transient. Defaults to
+	// IsRetryablePostgresError when nil.
+	IsRetryable func(error) bool
+}
+
+// DefaultRetryPolicy returns a RetryPolicy tuned for transient PostgreSQL
+// errors: up to 5 attempts, exponential backoff starting at 100ms and
+// capped at 5s, 20% jitter, classified by IsRetryablePostgresError.
+func DefaultRetryPolicy() RetryPolicy {
+	return RetryPolicy{
+		MaxAttempts: 5,
+		BaseDelay:   100 * time.Millisecond,
+		MaxDelay:    5 * time.Second,
+		Jitter:      0.2,
+		IsRetryable: IsRetryablePostgresError,
+	}
+}
+
+// retryableSQLStates are the SQLSTATE codes treated as transient outside
+// the connection-exception class: serialization_failure, deadlock_detected,
+// admin_shutdown, and cannot_connect_now.
+var retryableSQLStates = map[pq.ErrorCode]bool{
+	"40001": true,
+	"40P01": true,
+	"57P01": true,
+	"57P03": true,
+}
+
+// IsRetryablePostgresError classifies err as transient if it's a *pq.Error
+// with a SQLSTATE code known to be safe to retry: serialization failures,
+// deadlocks, connection-class errors (class 08), or admin shutdown. Any
+// other error, including constraint violations and syntax errors, is
+// treated as permanent.
+func IsRetryablePostgresError(err error) bool {
+	var pqErr *pq.Error
+	if !errors.As(err, &pqErr) {
+		return false
+	}
+	if retryableSQLStates[pqErr.Code] {
+		return true
+	}
+	return pqErr.Code.Class() == "08"
+}
+
+// WithRetryPolicy executes fn, retrying per policy until it succeeds, fn
+// returns a non-retryable error, or MaxAttempts is reached. The delay
+// between attempts grows exponentially from policy.BaseDelay, capped at
+// policy.MaxDelay, with up to policy.Jitter fraction of random jitter
+// added. Returns ctx.Err() immediately if ctx is cancelled while waiting.
+func WithRetryPolicy(ctx context.Context, policy RetryPolicy, fn func() error) error {
+	isRetryable := policy.IsRetryable
+	if isRetryable == nil {
+		isRetryable = IsRetryablePostgresError
+	}
+
+	var err error
+	for attempt := 0; attempt < policy.MaxAttempts; attempt++ {
+		if err = fn(); err == nil {
+			return nil
+		}
+		if !isRetryable(err) {
+			return err
+		}
+		if attempt == policy.MaxAttempts-1 {
+			break
+		}
+		select {
+		case <-ctx.Done():
+			return ctx.Err()
+		case <-time.After(backoffDelay(policy, attempt)):
+		}
+	}
+	return fmt.Errorf("failed after %d attempts: %w", policy.MaxAttempts, err)
+}
+
+// backoffDelay returns the exponential-backoff-with-jitter delay before the
+// retry following attempt (0-indexed), doubling policy.BaseDelay per prior
+// attempt and capping at policy.MaxDelay.
+func backoffDelay(policy RetryPolicy, attempt int) time.Duration {
+	delay := float64(policy.BaseDelay) * math.Pow(2, float64(attempt))
+	if policy.MaxDelay > 0 {
+		if max := float64(policy.MaxDelay); delay > max {
+			delay = max
+		}
+	}
+	if policy.Jitter > 0 {
+		delay += delay * policy.Jitter * rand.Float64()
+	}
+	return time.Duration(delay)
+}
+
+// ExecuteInTransactionWithRetry is ExecuteInTransaction with classified
+// retry: fn runs in a fresh transaction on every attempt, and the whole
+// transaction is retried per policy on a retryable error (e.g. a
+// serialization failure under SERIALIZABLE isolation). Returns immediately
+// on success or a non-retryable error.
+func ExecuteInTransactionWithRetry(ctx context.Context, db *sql.DB, policy RetryPolicy, fn func(*sql.Tx) error) error {
+	return WithRetryPolicy(ctx, policy, func() error {
+		return ExecuteInTransaction(ctx, db, fn)
+	})
+}
+
+// ExecuteInTransactionWithClassifiedRetry is ExecuteInTransactionWithRetry
+// built on the shared pkg/retry utility instead of RetryPolicy: it accepts
+// a retry.Policy, whose Classifier can carry a backoffHint per error (e.g.
+// a longer wait after an admin-shutdown), and defaults Classifier to
+// retry.SQLClassifier when unset. Prefer this over
+// ExecuteInTransactionWithRetry when the caller already standardizes on
+// pkg/retry elsewhere (e.g. alongside BulkInsertWithRetry or a
+// StreamConsumer's classified read retry).
+func ExecuteInTransactionWithClassifiedRetry(ctx context.Context, db *sql.DB, policy retry.Policy, fn func(*sql.Tx) error) error {
+	if policy.Classifier == nil {
+		policy.Classifier = retry.SQLClassifier
+	}
+	return retry.Do(ctx, policy, func() error {
+		return ExecuteInTransaction(ctx, db, fn)
+	})
+}
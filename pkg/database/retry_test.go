@@ -0,0 +1,130 @@
+package database
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/lib/pq"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestIsRetryablePostgresError(t *testing.T) {
+	t.Run("retries serialization failure", func(t *testing.T) {
+		assert.True(t, IsRetryablePostgresError(&pq.Error{Code: "40001"}))
+	})
+
+	t.Run("retries deadlock", func(t *testing.T) {
+		assert.True(t, IsRetryablePostgresError(&pq.Error{Code: "40P01"}))
+	})
+
+	t.Run("retries connection-class errors", func(t *testing.T) {
+		assert.True(t, IsRetryablePostgresError(&pq.Error{Code: "08006"}))
+	})
+
+	t.Run("retries admin shutdown", func(t *testing.T) {
+		assert.True(t, IsRetryablePostgresError(&pq.Error{Code: "57P01"}))
+	})
+
+	t.Run("does not retry constraint violations", func(t *testing.T) {
+		assert.False(t, IsRetryablePostgresError(&pq.Error{Code: "23505"}))
+	})
+
+	t.Run("does not retry non-postgres errors", func(t *testing.T) {
+		assert.False(t, IsRetryablePostgresError(assert.AnError))
+	})
+}
+
+func TestWithRetryPolicy(t *testing.T) {
+	policy := RetryPolicy{
+		MaxAttempts: 3,
+		BaseDelay:   time.Millisecond,
+		MaxDelay:    10 * time.Millisecond,
+		IsRetryable: func(err error) bool { return errors.Is(err, assert.AnError) },
+	}
+
+	t.Run("succeeds on first try", func(t *testing.T) {
+		attempts := 0
+		err := WithRetryPolicy(context.Background(), policy, func() error {
+			attempts++
+			return nil
+		})
+
+		assert.NoError(t, err)
+		assert.Equal(t, 1, attempts)
+	})
+
+	t.Run("retries retryable errors", func(t *testing.T) {
+		attempts := 0
+		err := WithRetryPolicy(context.Background(), policy, func() error {
+			attempts++
+			if attempts < 3 {
+				return assert.AnError
+			}
+			return nil
+		})
+
+		assert.NoError(t, err)
+		assert.Equal(t, 3, attempts)
+	})
+
+	t.Run("fails fast on non-retryable errors", func(t *testing.T) {
+		attempts := 0
+		nonRetryable := errors.New("syntax error")
+		err := WithRetryPolicy(context.Background(), policy, func() error {
+			attempts++
+			return nonRetryable
+		})
+
+		assert.ErrorIs(t, err, nonRetryable)
+		assert.Equal(t, 1, attempts)
+	})
+
+	t.Run("gives up after MaxAttempts", func(t *testing.T) {
+		attempts := 0
+		err := WithRetryPolicy(context.Background(), policy, func() error {
+			attempts++
+			return assert.AnError
+		})
+
+		assert.Error(t, err)
+		assert.Equal(t, 3, attempts)
+	})
+
+	t.Run("returns context error when cancelled while waiting", func(t *testing.T) {
+		ctx, cancel := context.WithCancel(context.Background())
+		cancel()
+
+		attempts := 0
+		err := WithRetryPolicy(ctx, RetryPolicy{
+			MaxAttempts: 3,
+			BaseDelay:   time.Millisecond,
+			IsRetryable: func(error) bool { return true },
+		}, func() error {
+			attempts++
+			return assert.AnError
+		})
+
+		assert.ErrorIs(t, err, context.Canceled)
+		assert.Equal(t, 1, attempts)
+	})
+}
+
+func TestExecuteInTransactionWithRetry(t *testing.T) {
+	db := getTestDB(t)
+	defer db.Close()
+
+	ctx := context.Background()
+
+	t.Run("commits on success", func(t *testing.T) {
+		var result int
+		err := ExecuteInTransactionWithRetry(ctx, db, DefaultRetryPolicy(), func(tx *sql.Tx) error {
+			return tx.QueryRow("SELECT 1").Scan(&result)
+		})
+
+		assert.NoError(t, err)
+		assert.Equal(t, 1, result)
+	})
+}
@@ -0,0 +1,131 @@
+package database
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+)
+
+// Tx wraps *sql.Tx, tracking how many SAVEPOINTs have been opened on it so
+// each nested ExecuteNested call gets a distinct name.
+type Tx struct {
+	*sql.Tx
+	savepoints int
+}
+
+// TxOptions configures the isolation level and read-only mode of a new
+// transaction opened by ExecuteInTransactionWithOptions. The zero value
+// uses the driver's default isolation (READ COMMITTED for PostgreSQL) and
+// a read/write transaction.
+type TxOptions struct {
+	Isolation sql.IsolationLevel
+	ReadOnly  bool
+}
+
+type txContextKey struct{}
+
+// ContextWithTx returns a context carrying tx. ExecuteInTransactionWithOptions
+// does this automatically for the ctx it passes to fn, so a nested call
+// that reuses that ctx is detected via TxFromContext instead of opening an
+// unrelated second top-level transaction.
+func ContextWithTx(ctx context.Context, tx *Tx) context.Context {
+	return context.WithValue(ctx, txContextKey{}, tx)
+}
+
+// TxFromContext returns the transaction ctx carries, if any.
+func TxFromContext(ctx context.Context) (*Tx, bool) {
+	tx, ok := ctx.Value(txContextKey{}).(*Tx)
+	return tx, ok
+}
+
+// ExecuteInTransactionWithOptions runs fn in a transaction against db,
+// opened with opts' isolation level and read-only mode. If ctx already
+// carries a transaction - because this call is nested inside another
+// ExecuteInTransactionWithOptions or ExecuteNested call that passed its
+// ctx down to fn - fn instead runs inside a SAVEPOINT on that outer
+// transaction via ExecuteNested, giving nested calls proper subtransaction
+// isolation (RELEASE on success, ROLLBACK TO on error) instead of silently
+// sharing the outer *sql.Tx with no rollback boundary of their own.
+func ExecuteInTransactionWithOptions(ctx context.Context, db *sql.DB, opts TxOptions, fn func(context.Context, *Tx) error) (err error) {
+	if outer, ok := TxFromContext(ctx); ok {
+		return ExecuteNested(ctx, outer, fn)
+	}
+
+	tx, err := db.BeginTx(ctx, &sql.TxOptions{Isolation: opts.Isolation, ReadOnly: opts.ReadOnly})
+	if err != nil {
+		return fmt.Errorf("failed to begin transaction: %w", err)
+	}
+	wrapped := &Tx{Tx: tx}
+	nestedCtx := ContextWithTx(ctx, wrapped)
+
+	// Ensure rollback on panic
+	defer func() {
+		if p := recover(); p != nil {
+			tx.Rollback()
+			err = fmt.Errorf("panic in transaction: %v", p)
+		}
+	}()
+
+	// Rollback on error
+	defer func() {
+		if err != nil {
+			tx.Rollback()
+		}
+	}()
+
+	if err = fn(nestedCtx, wrapped); err != nil {
+		return err
+	}
+
+	if err = tx.Commit(); err != nil {
+		return fmt.Errorf("failed to commit transaction: %w", err)
+	}
+
+	return nil
+}
+
+// ExecuteNested runs fn inside a SAVEPOINT on tx: RELEASE SAVEPOINT on
+// success, ROLLBACK TO SAVEPOINT on error or panic, matching PostgreSQL's
+// subtransaction semantics. Exported so callers already holding a *Tx can
+// open a subtransaction explicitly instead of relying on
+// ExecuteInTransactionWithOptions' context-based detection.
+func ExecuteNested(ctx context.Context, tx *Tx, fn func(context.Context, *Tx) error) (err error) {
+	tx.savepoints++
+	name := fmt.Sprintf("sp_%d", tx.savepoints)
+
+	if _, err = tx.ExecContext(ctx, "SAVEPOINT "+name); err != nil {
+		return fmt.Errorf("failed to create savepoint %s: %w", name, err)
+	}
+
+	nestedCtx := ContextWithTx(ctx, tx)
+
+	defer func() {
+		if p := recover(); p != nil {
+			_, _ = tx.ExecContext(ctx, "ROLLBACK TO SAVEPOINT "+name)
+			err = fmt.Errorf("panic in nested transaction: %v", p)
+		}
+	}()
+
+	if err = fn(nestedCtx, tx); err != nil {
+		if _, rbErr := tx.ExecContext(ctx, "ROLLBACK TO SAVEPOINT "+name); rbErr != nil {
+			return fmt.Errorf("failed to roll back to savepoint %s: %w (original error: %v)", name, rbErr, err)
+		}
+		return err
+	}
+
+	if _, err = tx.ExecContext(ctx, "RELEASE SAVEPOINT "+name); err != nil {
+		return fmt.Errorf("failed to release savepoint %s: %w", name, err)
+	}
+
+	return nil
+}
+
+// ExecuteInTransactionWithRetryAndOptions is ExecuteInTransactionWithOptions
+// with classified retry: it pairs naturally with opts.Isolation set to
+// sql.LevelSerializable or sql.LevelRepeatableRead, whose serialization
+// failures IsRetryablePostgresError already classifies as retryable.
+func ExecuteInTransactionWithRetryAndOptions(ctx context.Context, db *sql.DB, policy RetryPolicy, opts TxOptions, fn func(context.Context, *Tx) error) error {
+	return WithRetryPolicy(ctx, policy, func() error {
+		return ExecuteInTransactionWithOptions(ctx, db, opts, fn)
+	})
+}
@@ -0,0 +1,95 @@
+package database
+
+import (
+	"context"
+	"database/sql"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestExecuteInTransactionWithOptions(t *testing.T) {
+	db := getTestDB(t)
+	defer db.Close()
+
+	ctx := context.Background()
+
+	t.Run("commits on success", func(t *testing.T) {
+		var result int
+		err := ExecuteInTransactionWithOptions(ctx, db, TxOptions{}, func(_ context.Context, tx *Tx) error {
+			return tx.QueryRow("SELECT 1").Scan(&result)
+		})
+
+		assert.NoError(t, err)
+		assert.Equal(t, 1, result)
+	})
+
+	t.Run("rollback on error", func(t *testing.T) {
+		err := ExecuteInTransactionWithOptions(ctx, db, TxOptions{}, func(_ context.Context, tx *Tx) error {
+			_, err := tx.Exec("INSERT INTO non_existent_table (id) VALUES (1)")
+			return err
+		})
+
+		assert.Error(t, err)
+	})
+
+	t.Run("nested call on reused ctx opens a savepoint instead of a new transaction", func(t *testing.T) {
+		var outer, inner int
+		err := ExecuteInTransactionWithOptions(ctx, db, TxOptions{}, func(nestedCtx context.Context, tx *Tx) error {
+			if err := tx.QueryRow("SELECT 1").Scan(&outer); err != nil {
+				return err
+			}
+			return ExecuteInTransactionWithOptions(nestedCtx, db, TxOptions{}, func(_ context.Context, inner2 *Tx) error {
+				assert.Same(t, tx, inner2, "nested call should reuse the outer *Tx")
+				return inner2.QueryRow("SELECT 2").Scan(&inner)
+			})
+		})
+
+		assert.NoError(t, err)
+		assert.Equal(t, 1, outer)
+		assert.Equal(t, 2, inner)
+	})
+
+	t.Run("nested error rolls back to savepoint without aborting the outer transaction", func(t *testing.T) {
+		var result int
+		err := ExecuteInTransactionWithOptions(ctx, db, TxOptions{}, func(nestedCtx context.Context, tx *Tx) error {
+			nestedErr := ExecuteInTransactionWithOptions(nestedCtx, db, TxOptions{}, func(_ context.Context, inner *Tx) error {
+				_, err := inner.Exec("INSERT INTO non_existent_table (id) VALUES (1)")
+				return err
+			})
+			assert.Error(t, nestedErr)
+
+			return tx.QueryRow("SELECT 1").Scan(&result)
+		})
+
+		assert.NoError(t, err)
+		assert.Equal(t, 1, result)
+	})
+
+	t.Run("handles panic", func(t *testing.T) {
+		err := ExecuteInTransactionWithOptions(ctx, db, TxOptions{}, func(_ context.Context, tx *Tx) error {
+			panic("test panic")
+		})
+
+		assert.Error(t, err)
+		assert.Contains(t, err.Error(), "panic")
+	})
+}
+
+func TestExecuteInTransactionWithRetryAndOptions(t *testing.T) {
+	db := getTestDB(t)
+	defer db.Close()
+
+	ctx := context.Background()
+
+	t.Run("succeeds under serializable isolation", func(t *testing.T) {
+		var result int
+		opts := TxOptions{Isolation: sql.LevelSerializable}
+		err := ExecuteInTransactionWithRetryAndOptions(ctx, db, DefaultRetryPolicy(), opts, func(_ context.Context, tx *Tx) error {
+			return tx.QueryRow("SELECT 1").Scan(&result)
+		})
+
+		assert.NoError(t, err)
+		assert.Equal(t, 1, result)
+	})
+}
@@ -0,0 +1,44 @@
+package events
+
+// Metadata keys used to trace an event back to the event that triggered
+// it, across service boundaries.
+const (
+	// MetadataCorrelationID groups every event descended from the same
+	// originating request or job under one value.
+	MetadataCorrelationID = "correlation_id"
+	// MetadataCausationID names the ID of the event that directly caused
+	// this one, forming a parent/child chain within a correlation group.
+	MetadataCausationID = "causation_id"
+	// MetadataTraceID carries a distributed tracing identifier (e.g. a
+	// W3C traceparent trace ID) alongside the event.
+	MetadataTraceID = "trace_id"
+)
+
+// NewCausedEvent creates a new event the same way NewEvent does, then
+// propagates parent's correlation_id (or parent.ID, if parent started the
+// correlation group) and trace_id into the new event's Metadata, and sets
+// its causation_id to parent.ID. Use this instead of NewEvent whenever the
+// new event is emitted in direct response to an existing one, so replay
+// and observability tooling can reconstruct the causal chain.
+func NewCausedEvent(parent *Event, eventType, aggregateType, aggregateID string, payload any) (*Event, error) {
+	evt, err := NewEvent(eventType, aggregateType, aggregateID, payload)
+	if err != nil {
+		return nil, err
+	}
+
+	evt.Metadata[MetadataCorrelationID] = correlationIDOf(parent)
+	evt.Metadata[MetadataCausationID] = parent.ID
+	if traceID, ok := parent.Metadata[MetadataTraceID]; ok {
+		evt.Metadata[MetadataTraceID] = traceID
+	}
+	return evt, nil
+}
+
+// correlationIDOf returns parent's correlation_id, or parent.ID if parent
+// is itself the start of a new correlation group.
+func correlationIDOf(parent *Event) any {
+	if id, ok := parent.Metadata[MetadataCorrelationID]; ok {
+		return id
+	}
+	return parent.ID
+}
@@ -0,0 +1,35 @@
+package events
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestNewCausedEventPropagatesCorrelationAndCausation(t *testing.T) {
+	parent, err := NewEvent("product.enrichment.requested.v1", "product", "asin_1", nil)
+	require.NoError(t, err)
+	parent.Metadata[MetadataTraceID] = "trace_1"
+
+	child, err := NewCausedEvent(parent, "product.enrichment.completed.v1", "product", "asin_1", nil)
+	require.NoError(t, err)
+
+	assert.Equal(t, parent.ID, child.Metadata[MetadataCorrelationID])
+	assert.Equal(t, parent.ID, child.Metadata[MetadataCausationID])
+	assert.Equal(t, "trace_1", child.Metadata[MetadataTraceID])
+}
+
+func TestNewCausedEventPropagatesExistingCorrelationID(t *testing.T) {
+	root, err := NewEvent("product.enrichment.requested.v1", "product", "asin_1", nil)
+	require.NoError(t, err)
+
+	mid, err := NewCausedEvent(root, "product.enrichment.completed.v1", "product", "asin_1", nil)
+	require.NoError(t, err)
+
+	leaf, err := NewCausedEvent(mid, "product.published", "product", "asin_1", nil)
+	require.NoError(t, err)
+
+	assert.Equal(t, root.ID, leaf.Metadata[MetadataCorrelationID])
+	assert.Equal(t, mid.ID, leaf.Metadata[MetadataCausationID])
+}
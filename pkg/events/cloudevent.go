@@ -0,0 +1,101 @@
+package events
+
+import (
+	"fmt"
+	"time"
+
+	"github.com/MalteBoehm/tall-affiliate-common/pkg/cloudevents"
+)
+
+// metadataTraceparentKey, metadataPartitionKeyKey and metadataRequestIDKey
+// are the Metadata keys used to round-trip CloudEvents extension attributes
+// through Event, since Event itself has no dedicated fields for them.
+const (
+	metadataTraceparentKey  = "traceparent"
+	metadataPartitionKeyKey = "partitionkey"
+	metadataRequestIDKey    = "requestid"
+)
+
+// ToCloudEvent maps e onto a CloudEvents 1.0 envelope: Type becomes type,
+// AggregateType+AggregateID become source+subject, ID and Timestamp map
+// directly, and Payload is carried as data with datacontenttype=application/json.
+// DataSchema is populated from cloudevents' registry when one is registered
+// for e.Type. The traceparent/partitionkey/requestid extension attributes
+// are read from Metadata, if present.
+func (e *Event) ToCloudEvent() (*cloudevents.Event, error) {
+	opts := []cloudevents.Option{
+		cloudevents.WithID(e.ID),
+		cloudevents.WithTime(e.Timestamp),
+	}
+	if tp, ok := metadataString(e.Metadata, metadataTraceparentKey); ok {
+		opts = append(opts, cloudevents.WithTraceparent(tp))
+	}
+	if pk, ok := metadataString(e.Metadata, metadataPartitionKeyKey); ok {
+		opts = append(opts, cloudevents.WithPartitionKey(pk))
+	}
+	if rid, ok := metadataString(e.Metadata, metadataRequestIDKey); ok {
+		opts = append(opts, cloudevents.WithRequestID(rid))
+	}
+
+	return cloudevents.New(e.AggregateType, e.AggregateID, e.Type, e.Payload, opts...)
+}
+
+// FromCloudEvent is the inverse of ToCloudEvent: it reconstructs an Event
+// from a CloudEvents envelope, unpacking Source/Subject back into
+// AggregateType/AggregateID and surfacing any traceparent/partitionkey/
+// requestid extension attributes into Metadata.
+func FromCloudEvent(ce *cloudevents.Event) (*Event, error) {
+	if ce == nil {
+		return nil, fmt.Errorf("events: cannot convert nil cloudevents.Event")
+	}
+
+	timestamp := time.Now().UTC()
+	if ce.Time != "" {
+		parsed, err := time.Parse(time.RFC3339, ce.Time)
+		if err != nil {
+			return nil, fmt.Errorf("events: parse cloudevents time %q: %w", ce.Time, err)
+		}
+		timestamp = parsed
+	}
+
+	var payload any
+	if len(ce.Data) > 0 {
+		if err := ParsePayload(ce.Data, &payload); err != nil {
+			return nil, fmt.Errorf("events: unmarshal cloudevents data: %w", err)
+		}
+	}
+
+	e := &Event{
+		ID:            ce.ID,
+		Type:          ce.Type,
+		AggregateType: ce.Source,
+		AggregateID:   ce.Subject,
+		Payload:       payload,
+		Timestamp:     timestamp,
+	}
+
+	metadata := make(map[string]any)
+	if ce.Traceparent != "" {
+		metadata[metadataTraceparentKey] = ce.Traceparent
+	}
+	if ce.PartitionKey != "" {
+		metadata[metadataPartitionKeyKey] = ce.PartitionKey
+	}
+	if ce.RequestID != "" {
+		metadata[metadataRequestIDKey] = ce.RequestID
+	}
+	if len(metadata) > 0 {
+		e.Metadata = metadata
+	}
+
+	return e, nil
+}
+
+func metadataString(metadata map[string]any, key string) (string, bool) {
+	v, ok := metadata[key]
+	if !ok {
+		return "", false
+	}
+	s, ok := v.(string)
+	return s, ok
+}
@@ -0,0 +1,152 @@
+// Package cloudevents wires CloudEvents 1.0 encoding into the
+// interfaces.StreamProducer/StreamConsumer flow, so a producer can publish
+// in either JSON structured mode or binary mode (one field per attribute,
+// as Redis Streams and similar backends expect) without its callers
+// changing how they build events.Event values.
+//
+// The actual envelope mapping (Event.ID/Type/AggregateType/AggregateID/
+// Timestamp/Payload/Metadata onto CloudEvents id/type/source/subject/time/
+// data/extensions, including schema-URL lookup via
+// pkg/cloudevents.DataSchemaFor) already lives on events.Event itself as
+// ToCloudEvent/FromCloudEvent; ToCloudEvent and FromCloudEvent here just
+// re-export that mapping under this package so callers that only need the
+// envelope conversion don't have to reach into events for it. The fixed
+// traceparent/partitionkey/requestid extension attributes are the only
+// ones round-tripped today - arbitrary Metadata keys are not lifted onto
+// the envelope, since cloudevents.Event carries extensions as dedicated
+// fields rather than an open map.
+package cloudevents
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+
+	cloudevents "github.com/MalteBoehm/tall-affiliate-common/pkg/cloudevents"
+	"github.com/MalteBoehm/tall-affiliate-common/pkg/events"
+	"github.com/MalteBoehm/tall-affiliate-common/pkg/interfaces"
+)
+
+// CloudEvent is the wire envelope this package encodes/decodes events.Event
+// as, re-exported from pkg/cloudevents so callers of this package don't
+// need a second import for the type.
+type CloudEvent = cloudevents.Event
+
+// ToCloudEvent maps ev onto a CloudEvents 1.0 envelope. See
+// (*events.Event).ToCloudEvent for the field mapping.
+func ToCloudEvent(ev *events.Event) (*CloudEvent, error) {
+	return ev.ToCloudEvent()
+}
+
+// FromCloudEvent is the inverse of ToCloudEvent. See events.FromCloudEvent
+// for the field mapping.
+func FromCloudEvent(ce *CloudEvent) (*events.Event, error) {
+	return events.FromCloudEvent(ce)
+}
+
+// Mode selects the CloudEvents wire format an EncodingProducer/WrapHandler
+// pair uses.
+type Mode int
+
+const (
+	// ModeStructured carries the whole CloudEvents envelope as one JSON
+	// document (cloudevents.FormatJSON).
+	ModeStructured Mode = iota
+	// ModeBinary lifts every attribute into its own field alongside the
+	// raw data (cloudevents.FormatBinary) - the shape Redis Streams and
+	// similar field-per-attribute backends expect.
+	ModeBinary
+)
+
+func (m Mode) wireFormat() cloudevents.Format {
+	if m == ModeBinary {
+		return cloudevents.FormatBinary
+	}
+	return cloudevents.FormatJSON
+}
+
+// envelope is the events.Event.Payload shape an EncodingProducer emits and
+// WrapHandler decodes: the CloudEvents encoding of the original event,
+// tagged with the Mode it was encoded in so a consumer doesn't need to be
+// configured with the same Mode as its producer.
+type envelope struct {
+	Mode Mode            `json:"cloudevents_mode"`
+	Data json.RawMessage `json:"cloudevents_data"`
+}
+
+// EncodingProducer wraps an interfaces.StreamProducer, transparently
+// re-encoding every published event's payload as a CloudEvents envelope in
+// the configured Mode before delegating to the wrapped producer.
+type EncodingProducer struct {
+	producer interfaces.StreamProducer
+	mode     Mode
+}
+
+var _ interfaces.StreamProducer = (*EncodingProducer)(nil)
+
+// WithCloudEventsEncoding wraps producer so every event it publishes is
+// first encoded as a CloudEvents envelope in mode.
+func WithCloudEventsEncoding(producer interfaces.StreamProducer, mode Mode) *EncodingProducer {
+	return &EncodingProducer{producer: producer, mode: mode}
+}
+
+// PublishEvent encodes event as a CloudEvents envelope and publishes a copy
+// of it carrying that envelope as its Payload, leaving the caller's event
+// untouched.
+func (p *EncodingProducer) PublishEvent(ctx context.Context, streamName string, event *events.Event) error {
+	ce, err := event.ToCloudEvent()
+	if err != nil {
+		return fmt.Errorf("cloudevents: encode event %s: %w", event.Type, err)
+	}
+	data, err := ce.Marshal(p.mode.wireFormat())
+	if err != nil {
+		return fmt.Errorf("cloudevents: marshal event %s: %w", event.Type, err)
+	}
+
+	encoded := *event
+	encoded.Payload = envelope{Mode: p.mode, Data: data}
+	return p.producer.PublishEvent(ctx, streamName, &encoded)
+}
+
+// WrapHandler wraps a ConsumeStream handler so it receives events decoded
+// back out of the CloudEvents envelope an EncodingProducer produced,
+// regardless of which Mode encoded them. An event whose Payload isn't a
+// recognized envelope (for example, one published without
+// WithCloudEventsEncoding) is passed through unchanged.
+func WrapHandler(handler func(context.Context, *events.Event, string) error) func(context.Context, *events.Event, string) error {
+	return func(ctx context.Context, evt *events.Event, messageID string) error {
+		decoded, err := decode(evt)
+		if err != nil {
+			return fmt.Errorf("cloudevents: decode event: %w", err)
+		}
+		return handler(ctx, decoded, messageID)
+	}
+}
+
+func decode(evt *events.Event) (*events.Event, error) {
+	env, ok := asEnvelope(evt.Payload)
+	if !ok {
+		return evt, nil
+	}
+
+	var ce CloudEvent
+	if err := ce.Unmarshal(env.Mode.wireFormat(), env.Data); err != nil {
+		return nil, fmt.Errorf("unmarshal %s envelope: %w", evt.Type, err)
+	}
+	return events.FromCloudEvent(&ce)
+}
+
+// asEnvelope reports whether payload is an envelope produced by
+// EncodingProducer. payload may already be an envelope value or a
+// map[string]any it round-tripped through JSON as, so it's decoded via
+// events.ParsePayload rather than a direct type assertion.
+func asEnvelope(payload any) (envelope, bool) {
+	var env envelope
+	if err := events.ParsePayload(payload, &env); err != nil {
+		return envelope{}, false
+	}
+	if len(env.Data) == 0 {
+		return envelope{}, false
+	}
+	return env, true
+}
@@ -0,0 +1,99 @@
+package cloudevents
+
+import (
+	"context"
+	"testing"
+
+	"github.com/MalteBoehm/tall-affiliate-common/pkg/events"
+)
+
+// fakeProducer records the last event PublishEvent was called with, so
+// tests can inspect how EncodingProducer re-encoded it.
+type fakeProducer struct {
+	lastStream string
+	lastEvent  *events.Event
+}
+
+func (p *fakeProducer) PublishEvent(ctx context.Context, streamName string, event *events.Event) error {
+	p.lastStream = streamName
+	p.lastEvent = event
+	return nil
+}
+
+func testEvent() *events.Event {
+	return &events.Event{
+		ID:            "evt-1",
+		Type:          "product.priced",
+		AggregateType: "product",
+		AggregateID:   "p-1",
+		Payload:       map[string]any{"price": float64(1999)},
+	}
+}
+
+func TestEncodingProducerRoundTripsStructuredMode(t *testing.T) {
+	fake := &fakeProducer{}
+	producer := WithCloudEventsEncoding(fake, ModeStructured)
+
+	original := testEvent()
+	if err := producer.PublishEvent(context.Background(), "stream:products", original); err != nil {
+		t.Fatalf("PublishEvent: %v", err)
+	}
+	if fake.lastEvent == original {
+		t.Fatal("PublishEvent must not mutate the caller's event in place")
+	}
+
+	var decoded *events.Event
+	handler := WrapHandler(func(ctx context.Context, evt *events.Event, messageID string) error {
+		decoded = evt
+		return nil
+	})
+	if err := handler(context.Background(), fake.lastEvent, "msg-1"); err != nil {
+		t.Fatalf("handler: %v", err)
+	}
+
+	if decoded.ID != original.ID || decoded.Type != original.Type {
+		t.Errorf("decoded = %+v, want ID/Type matching %+v", decoded, original)
+	}
+	if decoded.AggregateType != original.AggregateType || decoded.AggregateID != original.AggregateID {
+		t.Errorf("decoded aggregate = %s/%s, want %s/%s", decoded.AggregateType, decoded.AggregateID, original.AggregateType, original.AggregateID)
+	}
+}
+
+func TestEncodingProducerRoundTripsBinaryMode(t *testing.T) {
+	fake := &fakeProducer{}
+	producer := WithCloudEventsEncoding(fake, ModeBinary)
+
+	original := testEvent()
+	if err := producer.PublishEvent(context.Background(), "stream:products", original); err != nil {
+		t.Fatalf("PublishEvent: %v", err)
+	}
+
+	var decoded *events.Event
+	handler := WrapHandler(func(ctx context.Context, evt *events.Event, messageID string) error {
+		decoded = evt
+		return nil
+	})
+	if err := handler(context.Background(), fake.lastEvent, "msg-1"); err != nil {
+		t.Fatalf("handler: %v", err)
+	}
+
+	if decoded.ID != original.ID || decoded.Type != original.Type {
+		t.Errorf("decoded = %+v, want ID/Type matching %+v", decoded, original)
+	}
+}
+
+func TestWrapHandlerPassesThroughUnrecognizedPayload(t *testing.T) {
+	plain := &events.Event{ID: "evt-2", Type: "product.created", Payload: map[string]any{"name": "widget"}}
+
+	var decoded *events.Event
+	handler := WrapHandler(func(ctx context.Context, evt *events.Event, messageID string) error {
+		decoded = evt
+		return nil
+	})
+	if err := handler(context.Background(), plain, "msg-2"); err != nil {
+		t.Fatalf("handler: %v", err)
+	}
+	if decoded != plain {
+		t.Error("expected an event without a recognized envelope to pass through unchanged")
+	}
+}
@@ -0,0 +1,80 @@
+package events
+
+import "encoding/json"
+
+// Codec marshals and unmarshals an entire Event envelope for the wire,
+// replacing a hard-coded json.Unmarshal in StreamConsumer.processMessage
+// with a pluggable format selected by the content-type a StreamProducer
+// wrote alongside the payload bytes (see CodecRegistry). Unlike
+// pkg/events/schema.Codec, which only encodes an Event's Payload field for
+// schema-registry purposes, a Codec here encodes the full Event - ID,
+// Type, AggregateID, Timestamp, Metadata, and Payload.
+type Codec interface {
+	Marshal(e *Event) ([]byte, error)
+	Unmarshal(data []byte, e *Event) error
+	// ContentType identifies the wire format, written to a stream entry's
+	// "content-type" field so a consumer can pick the matching Codec back
+	// out of a CodecRegistry.
+	ContentType() string
+}
+
+// JSONCodec is the default Codec: a plain json.Marshal/Unmarshal of the
+// Event struct, matching the wire format every consumer already
+// understands.
+type JSONCodec struct{}
+
+// ContentType implements Codec.
+func (JSONCodec) ContentType() string { return "application/json" }
+
+// Marshal implements Codec.
+func (JSONCodec) Marshal(e *Event) ([]byte, error) { return json.Marshal(e) }
+
+// Unmarshal implements Codec.
+func (JSONCodec) Unmarshal(data []byte, e *Event) error { return json.Unmarshal(data, e) }
+
+var _ Codec = JSONCodec{}
+
+// CodecRegistry maps a content-type string to the Codec that decodes it,
+// so a StreamConsumer can dispatch an incoming message by the
+// "content-type" field a StreamProducer wrote, instead of assuming JSON.
+type CodecRegistry struct {
+	codecs map[string]Codec
+}
+
+// NewCodecRegistry creates a CodecRegistry pre-populated with JSONCodec,
+// ProtobufCodec, and MsgPackCodec under their respective content types.
+func NewCodecRegistry() *CodecRegistry {
+	r := &CodecRegistry{codecs: make(map[string]Codec)}
+	r.Register(JSONCodec{})
+	r.Register(ProtobufCodec{})
+	r.Register(MsgPackCodec{})
+	return r
+}
+
+// Register adds or replaces the Codec responsible for codec.ContentType().
+func (r *CodecRegistry) Register(codec Codec) {
+	r.codecs[codec.ContentType()] = codec
+}
+
+// Get returns the Codec registered for contentType, and whether one was
+// found. An empty contentType is treated as "application/json" so
+// messages written before content-type tagging existed still decode.
+func (r *CodecRegistry) Get(contentType string) (Codec, bool) {
+	if contentType == "" {
+		contentType = JSONCodec{}.ContentType()
+	}
+	codec, ok := r.codecs[contentType]
+	return codec, ok
+}
+
+// ParsePayloadAs decodes e's Payload into a fresh T, the generic
+// counterpart to ParsePayload: e.Payload is round-tripped through JSON
+// regardless of which Codec decoded the envelope, since Payload is stored
+// as the decoded `any` rather than raw bytes.
+func ParsePayloadAs[T any](e *Event) (T, error) {
+	var target T
+	if err := ParsePayload(e.Payload, &target); err != nil {
+		return target, err
+	}
+	return target, nil
+}
@@ -0,0 +1,247 @@
+package events
+
+import (
+	"encoding/json"
+	"fmt"
+	"time"
+)
+
+// MsgPackCodec encodes an Event as a 7-entry MessagePack map (no external
+// msgpack library dependency, same hand-rolled approach as ProtobufCodec
+// and pkg/events/schema.AvroCodec): {id, type, aggregate_type,
+// aggregate_id, payload, timestamp, metadata}, with id/type/aggregate_type/
+// aggregate_id/timestamp as MessagePack strings and payload/metadata as
+// MessagePack binary (JSON-encoded). The output is standard MessagePack
+// and decodes with any compliant library.
+type MsgPackCodec struct{}
+
+// ContentType implements Codec.
+func (MsgPackCodec) ContentType() string { return "application/msgpack" }
+
+var msgpackEventKeys = []string{"id", "type", "aggregate_type", "aggregate_id", "payload", "timestamp", "metadata"}
+
+// Marshal implements Codec.
+func (MsgPackCodec) Marshal(e *Event) ([]byte, error) {
+	payload, err := json.Marshal(e.Payload)
+	if err != nil {
+		return nil, fmt.Errorf("events: msgpack encode payload: %w", err)
+	}
+	metadata, err := json.Marshal(e.Metadata)
+	if err != nil {
+		return nil, fmt.Errorf("events: msgpack encode metadata: %w", err)
+	}
+
+	buf := appendMsgpackMapHeader(nil, len(msgpackEventKeys))
+	buf = appendMsgpackStr(buf, "id")
+	buf = appendMsgpackStr(buf, e.ID)
+	buf = appendMsgpackStr(buf, "type")
+	buf = appendMsgpackStr(buf, e.Type)
+	buf = appendMsgpackStr(buf, "aggregate_type")
+	buf = appendMsgpackStr(buf, e.AggregateType)
+	buf = appendMsgpackStr(buf, "aggregate_id")
+	buf = appendMsgpackStr(buf, e.AggregateID)
+	buf = appendMsgpackStr(buf, "payload")
+	buf = appendMsgpackBin(buf, payload)
+	buf = appendMsgpackStr(buf, "timestamp")
+	buf = appendMsgpackStr(buf, e.Timestamp.UTC().Format(time.RFC3339Nano))
+	buf = appendMsgpackStr(buf, "metadata")
+	buf = appendMsgpackBin(buf, metadata)
+	return buf, nil
+}
+
+// Unmarshal implements Codec.
+func (MsgPackCodec) Unmarshal(data []byte, e *Event) error {
+	count, data, err := decodeMsgpackMapHeader(data)
+	if err != nil {
+		return fmt.Errorf("events: msgpack decode: %w", err)
+	}
+
+	var payload, metadata []byte
+	for i := 0; i < count; i++ {
+		key, rest, err := decodeMsgpackStr(data)
+		if err != nil {
+			return fmt.Errorf("events: msgpack decode key: %w", err)
+		}
+		data = rest
+
+		switch key {
+		case "id", "type", "aggregate_type", "aggregate_id", "timestamp":
+			value, rest, err := decodeMsgpackStr(data)
+			if err != nil {
+				return fmt.Errorf("events: msgpack decode field %q: %w", key, err)
+			}
+			data = rest
+			switch key {
+			case "id":
+				e.ID = value
+			case "type":
+				e.Type = value
+			case "aggregate_type":
+				e.AggregateType = value
+			case "aggregate_id":
+				e.AggregateID = value
+			case "timestamp":
+				ts, err := time.Parse(time.RFC3339Nano, value)
+				if err != nil {
+					return fmt.Errorf("events: msgpack decode timestamp: %w", err)
+				}
+				e.Timestamp = ts
+			}
+		case "payload", "metadata":
+			value, rest, err := decodeMsgpackBin(data)
+			if err != nil {
+				return fmt.Errorf("events: msgpack decode field %q: %w", key, err)
+			}
+			data = rest
+			if key == "payload" {
+				payload = value
+			} else {
+				metadata = value
+			}
+		default:
+			return fmt.Errorf("events: msgpack decode: unknown field %q", key)
+		}
+	}
+
+	if len(payload) > 0 {
+		if err := json.Unmarshal(payload, &e.Payload); err != nil {
+			return fmt.Errorf("events: msgpack decode payload: %w", err)
+		}
+	}
+	if len(metadata) > 0 {
+		if err := json.Unmarshal(metadata, &e.Metadata); err != nil {
+			return fmt.Errorf("events: msgpack decode metadata: %w", err)
+		}
+	}
+	return nil
+}
+
+var _ Codec = MsgPackCodec{}
+
+func appendMsgpackMapHeader(buf []byte, n int) []byte {
+	switch {
+	case n <= 15:
+		return append(buf, 0x80|byte(n))
+	case n <= 0xffff:
+		return append(buf, 0xde, byte(n>>8), byte(n))
+	default:
+		return append(buf, 0xdf, byte(n>>24), byte(n>>16), byte(n>>8), byte(n))
+	}
+}
+
+func decodeMsgpackMapHeader(data []byte) (int, []byte, error) {
+	if len(data) == 0 {
+		return 0, nil, fmt.Errorf("truncated map header")
+	}
+	b := data[0]
+	switch {
+	case b&0xf0 == 0x80:
+		return int(b & 0x0f), data[1:], nil
+	case b == 0xde:
+		if len(data) < 3 {
+			return 0, nil, fmt.Errorf("truncated map16 header")
+		}
+		return int(data[1])<<8 | int(data[2]), data[3:], nil
+	case b == 0xdf:
+		if len(data) < 5 {
+			return 0, nil, fmt.Errorf("truncated map32 header")
+		}
+		return int(data[1])<<24 | int(data[2])<<16 | int(data[3])<<8 | int(data[4]), data[5:], nil
+	default:
+		return 0, nil, fmt.Errorf("expected map, got byte 0x%x", b)
+	}
+}
+
+func appendMsgpackStr(buf []byte, s string) []byte {
+	n := len(s)
+	switch {
+	case n <= 31:
+		buf = append(buf, 0xa0|byte(n))
+	case n <= 0xff:
+		buf = append(buf, 0xd9, byte(n))
+	case n <= 0xffff:
+		buf = append(buf, 0xda, byte(n>>8), byte(n))
+	default:
+		buf = append(buf, 0xdb, byte(n>>24), byte(n>>16), byte(n>>8), byte(n))
+	}
+	return append(buf, s...)
+}
+
+func decodeMsgpackStr(data []byte) (string, []byte, error) {
+	if len(data) == 0 {
+		return "", nil, fmt.Errorf("truncated string")
+	}
+	b := data[0]
+	var length, headerLen int
+	switch {
+	case b&0xe0 == 0xa0:
+		length, headerLen = int(b&0x1f), 1
+	case b == 0xd9:
+		if len(data) < 2 {
+			return "", nil, fmt.Errorf("truncated str8 header")
+		}
+		length, headerLen = int(data[1]), 2
+	case b == 0xda:
+		if len(data) < 3 {
+			return "", nil, fmt.Errorf("truncated str16 header")
+		}
+		length, headerLen = int(data[1])<<8|int(data[2]), 3
+	case b == 0xdb:
+		if len(data) < 5 {
+			return "", nil, fmt.Errorf("truncated str32 header")
+		}
+		length, headerLen = int(data[1])<<24|int(data[2])<<16|int(data[3])<<8|int(data[4]), 5
+	default:
+		return "", nil, fmt.Errorf("expected string, got byte 0x%x", b)
+	}
+	data = data[headerLen:]
+	if len(data) < length {
+		return "", nil, fmt.Errorf("truncated string body")
+	}
+	return string(data[:length]), data[length:], nil
+}
+
+func appendMsgpackBin(buf []byte, b []byte) []byte {
+	n := len(b)
+	switch {
+	case n <= 0xff:
+		buf = append(buf, 0xc4, byte(n))
+	case n <= 0xffff:
+		buf = append(buf, 0xc5, byte(n>>8), byte(n))
+	default:
+		buf = append(buf, 0xc6, byte(n>>24), byte(n>>16), byte(n>>8), byte(n))
+	}
+	return append(buf, b...)
+}
+
+func decodeMsgpackBin(data []byte) ([]byte, []byte, error) {
+	if len(data) == 0 {
+		return nil, nil, fmt.Errorf("truncated binary")
+	}
+	b := data[0]
+	var length, headerLen int
+	switch b {
+	case 0xc4:
+		if len(data) < 2 {
+			return nil, nil, fmt.Errorf("truncated bin8 header")
+		}
+		length, headerLen = int(data[1]), 2
+	case 0xc5:
+		if len(data) < 3 {
+			return nil, nil, fmt.Errorf("truncated bin16 header")
+		}
+		length, headerLen = int(data[1])<<8|int(data[2]), 3
+	case 0xc6:
+		if len(data) < 5 {
+			return nil, nil, fmt.Errorf("truncated bin32 header")
+		}
+		length, headerLen = int(data[1])<<24|int(data[2])<<16|int(data[3])<<8|int(data[4]), 5
+	default:
+		return nil, nil, fmt.Errorf("expected binary, got byte 0x%x", b)
+	}
+	data = data[headerLen:]
+	if len(data) < length {
+		return nil, nil, fmt.Errorf("truncated binary body")
+	}
+	return data[:length], data[length:], nil
+}
@@ -0,0 +1,157 @@
+package events
+
+import (
+	"encoding/json"
+	"fmt"
+	"time"
+)
+
+// ProtobufCodec encodes an Event using the proto3 wire format directly,
+// without a .proto file or protoc-generated types (this repo has no
+// protobuf toolchain dependency - see pkg/events/schema.AvroCodec for the
+// same hand-rolled approach taken for Avro). Every field is encoded as a
+// length-delimited (wire type 2) value, so it decodes correctly against a
+// .proto message whose fields are all `string`/`bytes`:
+//
+//	message Event {
+//	  string id = 1;
+//	  string type = 2;
+//	  string aggregate_type = 3;
+//	  string aggregate_id = 4;
+//	  bytes payload = 5;   // JSON-encoded
+//	  string timestamp = 6; // RFC3339Nano
+//	  bytes metadata = 7;  // JSON-encoded map<string, ...>
+//	}
+type ProtobufCodec struct{}
+
+// ContentType implements Codec.
+func (ProtobufCodec) ContentType() string { return "application/protobuf" }
+
+// Marshal implements Codec.
+func (ProtobufCodec) Marshal(e *Event) ([]byte, error) {
+	payload, err := json.Marshal(e.Payload)
+	if err != nil {
+		return nil, fmt.Errorf("events: protobuf encode payload: %w", err)
+	}
+	metadata, err := json.Marshal(e.Metadata)
+	if err != nil {
+		return nil, fmt.Errorf("events: protobuf encode metadata: %w", err)
+	}
+
+	var buf []byte
+	buf = appendProtoField(buf, 1, []byte(e.ID))
+	buf = appendProtoField(buf, 2, []byte(e.Type))
+	buf = appendProtoField(buf, 3, []byte(e.AggregateType))
+	buf = appendProtoField(buf, 4, []byte(e.AggregateID))
+	buf = appendProtoField(buf, 5, payload)
+	buf = appendProtoField(buf, 6, []byte(e.Timestamp.UTC().Format(time.RFC3339Nano)))
+	buf = appendProtoField(buf, 7, metadata)
+	return buf, nil
+}
+
+// Unmarshal implements Codec.
+func (ProtobufCodec) Unmarshal(data []byte, e *Event) error {
+	var payload, metadata []byte
+	for len(data) > 0 {
+		fieldNum, wireType, n, err := decodeProtoTag(data)
+		if err != nil {
+			return fmt.Errorf("events: protobuf decode tag: %w", err)
+		}
+		data = data[n:]
+		if wireType != 2 {
+			return fmt.Errorf("events: protobuf decode: unsupported wire type %d for field %d", wireType, fieldNum)
+		}
+		value, n, err := decodeProtoBytes(data)
+		if err != nil {
+			return fmt.Errorf("events: protobuf decode field %d: %w", fieldNum, err)
+		}
+		data = data[n:]
+
+		switch fieldNum {
+		case 1:
+			e.ID = string(value)
+		case 2:
+			e.Type = string(value)
+		case 3:
+			e.AggregateType = string(value)
+		case 4:
+			e.AggregateID = string(value)
+		case 5:
+			payload = value
+		case 6:
+			ts, err := time.Parse(time.RFC3339Nano, string(value))
+			if err != nil {
+				return fmt.Errorf("events: protobuf decode timestamp: %w", err)
+			}
+			e.Timestamp = ts
+		case 7:
+			metadata = value
+		}
+	}
+
+	if len(payload) > 0 {
+		if err := json.Unmarshal(payload, &e.Payload); err != nil {
+			return fmt.Errorf("events: protobuf decode payload: %w", err)
+		}
+	}
+	if len(metadata) > 0 {
+		if err := json.Unmarshal(metadata, &e.Metadata); err != nil {
+			return fmt.Errorf("events: protobuf decode metadata: %w", err)
+		}
+	}
+	return nil
+}
+
+var _ Codec = ProtobufCodec{}
+
+// appendProtoField appends a proto3 length-delimited field: a varint tag
+// ((fieldNum<<3)|2), a varint length, then the raw bytes.
+func appendProtoField(buf []byte, fieldNum int, value []byte) []byte {
+	buf = appendProtoVarint(buf, uint64(fieldNum<<3|2))
+	buf = appendProtoVarint(buf, uint64(len(value)))
+	return append(buf, value...)
+}
+
+func appendProtoVarint(buf []byte, v uint64) []byte {
+	for v >= 0x80 {
+		buf = append(buf, byte(v)|0x80)
+		v >>= 7
+	}
+	return append(buf, byte(v))
+}
+
+func decodeProtoVarint(data []byte) (uint64, int, error) {
+	var v uint64
+	var shift uint
+	for i, b := range data {
+		v |= uint64(b&0x7f) << shift
+		if b&0x80 == 0 {
+			return v, i + 1, nil
+		}
+		shift += 7
+		if shift >= 64 {
+			return 0, 0, fmt.Errorf("varint overflow")
+		}
+	}
+	return 0, 0, fmt.Errorf("truncated varint")
+}
+
+func decodeProtoTag(data []byte) (fieldNum int, wireType int, n int, err error) {
+	tag, n, err := decodeProtoVarint(data)
+	if err != nil {
+		return 0, 0, 0, err
+	}
+	return int(tag >> 3), int(tag & 0x7), n, nil
+}
+
+func decodeProtoBytes(data []byte) ([]byte, int, error) {
+	length, n, err := decodeProtoVarint(data)
+	if err != nil {
+		return nil, 0, err
+	}
+	data = data[n:]
+	if uint64(len(data)) < length {
+		return nil, 0, fmt.Errorf("truncated field")
+	}
+	return data[:length], n + int(length), nil
+}
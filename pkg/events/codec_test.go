@@ -0,0 +1,75 @@
+package events
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func testCodecEvent() *Event {
+	return &Event{
+		ID:            "evt-1",
+		Type:          "catalog.product.scored.v1",
+		AggregateType: "product",
+		AggregateID:   "B001234567",
+		Payload:       map[string]any{"score": 4.5},
+		Timestamp:     time.Date(2026, 1, 2, 3, 4, 5, 0, time.UTC),
+		Metadata:      map[string]any{"traceparent": "00-abc-def-01"},
+	}
+}
+
+func assertCodecRoundTrips(t *testing.T, codec Codec) {
+	t.Helper()
+	in := testCodecEvent()
+
+	data, err := codec.Marshal(in)
+	require.NoError(t, err)
+
+	var out Event
+	require.NoError(t, codec.Unmarshal(data, &out))
+
+	assert.Equal(t, in.ID, out.ID)
+	assert.Equal(t, in.Type, out.Type)
+	assert.Equal(t, in.AggregateType, out.AggregateType)
+	assert.Equal(t, in.AggregateID, out.AggregateID)
+	assert.True(t, in.Timestamp.Equal(out.Timestamp))
+
+	score, err := ParsePayloadAs[struct {
+		Score float64 `json:"score"`
+	}](&out)
+	require.NoError(t, err)
+	assert.Equal(t, 4.5, score.Score)
+}
+
+func TestJSONCodecRoundTrip(t *testing.T) {
+	assertCodecRoundTrips(t, JSONCodec{})
+}
+
+func TestProtobufCodecRoundTrip(t *testing.T) {
+	assertCodecRoundTrips(t, ProtobufCodec{})
+}
+
+func TestMsgPackCodecRoundTrip(t *testing.T) {
+	assertCodecRoundTrips(t, MsgPackCodec{})
+}
+
+func TestCodecRegistryGet(t *testing.T) {
+	registry := NewCodecRegistry()
+
+	for _, contentType := range []string{"application/json", "application/protobuf", "application/msgpack"} {
+		codec, ok := registry.Get(contentType)
+		require.True(t, ok, contentType)
+		assert.Equal(t, contentType, codec.ContentType())
+	}
+
+	// An empty content-type defaults to JSON, so messages written before
+	// content-type tagging existed still decode.
+	codec, ok := registry.Get("")
+	require.True(t, ok)
+	assert.Equal(t, "application/json", codec.ContentType())
+
+	_, ok = registry.Get("application/x-unknown")
+	assert.False(t, ok)
+}
@@ -28,6 +28,9 @@ var CodeToCE = map[string]string{
     "04D_CONTENT_GENERATION_FAILED":       ContentGenerationFailedV1,
     "04E_CONTENT_GENERATION_RETRIED":      ContentGenerationRetriedV1,
 
+    // Phase 4b: Scoring
+    "17_PRODUCT_SCORED": CatalogProductScoredV1,
+
     // Phase 5: Portal Publishing (new canonical CE types)
     "05A_PORTAL_PUBLICATION_REQUESTED":    "portal.publication.requested.v1",
     "05B_PORTAL_PUBLICATION_COMPLETED":    "portal.publication.completed.v1",
@@ -70,6 +73,9 @@ var CodeToCE = map[string]string{
     // Monitoring — supply chain
     "MON_SUPPLY_CHAIN_ALERT_RAISED":       "supply_chain.alert.raised.v1",
     "MON_SUPPLY_CHAIN_ALERT_RESOLVED":     "supply_chain.alert.resolved.v1",
+
+    // Monitoring — billing
+    "MON_BILLING_USAGE_RECORDED":          "monitoring.billing.usage.recorded.v1",
 }
 
 // CEToCode is the inverse mapping for lookups
@@ -0,0 +1,29 @@
+package deprecation
+
+import "sync/atomic"
+
+var defaultTracker atomic.Value // *Tracker
+
+func init() {
+	defaultTracker.Store(NewTracker(nil, nil))
+}
+
+// SetDefault replaces the package-level Tracker that Warn reports through.
+// pkg/events' own deprecated constructors and NormalizeEventType call
+// Warn, so services that want counters/log routing/removal enforcement
+// for those call SetDefault once at startup instead of threading a
+// Tracker through every call site.
+func SetDefault(t *Tracker) {
+	defaultTracker.Store(t)
+}
+
+// Default returns the package-level Tracker most recently passed to
+// SetDefault, or a no-op Tracker if SetDefault was never called.
+func Default() *Tracker {
+	return defaultTracker.Load().(*Tracker)
+}
+
+// Warn calls Default().Warn(legacyName, replacement). See Tracker.Warn.
+func Warn(legacyName, replacement string) error {
+	return Default().Warn(legacyName, replacement)
+}
@@ -0,0 +1,87 @@
+package deprecation
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+)
+
+// PolicyEntry declares the retirement schedule for one legacy name:
+// DeprecatedSince and RemoveAfter are "vMAJOR.MINOR.PATCH" version
+// strings, and Replacement is the canonical name callers should migrate
+// to. Once RemovalPolicy.CurrentVersion passes RemoveAfter, Warn for this
+// legacyName fails instead of just logging.
+type PolicyEntry struct {
+	Replacement     string
+	DeprecatedSince string
+	RemoveAfter     string
+}
+
+// RemovalPolicy maps legacy names to their retirement schedule, evaluated
+// against CurrentVersion. A legacyName with no entry is never past
+// removal - add an entry to actually enforce retirement for it.
+type RemovalPolicy struct {
+	CurrentVersion string
+	Entries        map[string]PolicyEntry
+}
+
+// pastRemoval reports whether legacyName's RemoveAfter version is at or
+// before p.CurrentVersion. A legacyName with no entry, or a policy/version
+// that fails to parse, is never considered past removal.
+func (p RemovalPolicy) pastRemoval(legacyName string) bool {
+	entry, ok := p.Entries[legacyName]
+	if !ok || entry.RemoveAfter == "" || p.CurrentVersion == "" {
+		return false
+	}
+	cmp, err := compareVersions(p.CurrentVersion, entry.RemoveAfter)
+	if err != nil {
+		return false
+	}
+	return cmp >= 0
+}
+
+func (p RemovalPolicy) replacementFor(legacyName string) (string, bool) {
+	entry, ok := p.Entries[legacyName]
+	return entry.Replacement, ok
+}
+
+// compareVersions compares two "vMAJOR.MINOR.PATCH" (the leading "v" is
+// optional) version strings, returning -1, 0, or 1 the way strings.Compare
+// does. It only supports this repo's plain numeric versioning scheme -
+// pre-release/build metadata suffixes are rejected.
+func compareVersions(a, b string) (int, error) {
+	av, err := parseVersion(a)
+	if err != nil {
+		return 0, err
+	}
+	bv, err := parseVersion(b)
+	if err != nil {
+		return 0, err
+	}
+	for i := 0; i < 3; i++ {
+		if av[i] != bv[i] {
+			if av[i] < bv[i] {
+				return -1, nil
+			}
+			return 1, nil
+		}
+	}
+	return 0, nil
+}
+
+func parseVersion(v string) ([3]int, error) {
+	var out [3]int
+	v = strings.TrimPrefix(v, "v")
+	parts := strings.SplitN(v, ".", 3)
+	if len(parts) != 3 {
+		return out, fmt.Errorf("deprecation: invalid version %q, want vMAJOR.MINOR.PATCH", v)
+	}
+	for i, part := range parts {
+		n, err := strconv.Atoi(part)
+		if err != nil {
+			return out, fmt.Errorf("deprecation: invalid version %q: %w", v, err)
+		}
+		out[i] = n
+	}
+	return out, nil
+}
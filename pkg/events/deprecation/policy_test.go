@@ -0,0 +1,35 @@
+package deprecation
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestCompareVersionsOrdersByMajorMinorPatch(t *testing.T) {
+	cases := []struct {
+		a, b string
+		want int
+	}{
+		{"v1.0.0", "v1.0.0", 0},
+		{"v1.0.0", "v1.0.1", -1},
+		{"v1.2.0", "v1.1.9", 1},
+		{"v2.0.0", "v1.99.99", 1},
+		{"1.0.0", "v1.0.0", 0},
+	}
+	for _, c := range cases {
+		got, err := compareVersions(c.a, c.b)
+		assert.NoError(t, err)
+		assert.Equal(t, c.want, got, "compareVersions(%q, %q)", c.a, c.b)
+	}
+}
+
+func TestCompareVersionsRejectsMalformedInput(t *testing.T) {
+	_, err := compareVersions("not-a-version", "v1.0.0")
+	assert.Error(t, err)
+}
+
+func TestRemovalPolicyPastRemovalWithNoEntryIsFalse(t *testing.T) {
+	p := RemovalPolicy{CurrentVersion: "v9.0.0", Entries: map[string]PolicyEntry{}}
+	assert.False(t, p.pastRemoval("AnythingUnregistered"))
+}
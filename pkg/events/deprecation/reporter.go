@@ -0,0 +1,33 @@
+// Package deprecation tracks calls to deprecated event APIs (legacy
+// constructors, legacy strings accepted by NormalizeEventType) so services
+// can see how much traffic still depends on them, get a rate-limited log
+// line naming the caller, and - once a legacy name's RemoveAfter version
+// has passed - actually have the call fail instead of quietly living on
+// forever. See Tracker for the pieces wired together, and Default/Warn for
+// the package-level tracker pkg/events itself reports through.
+package deprecation
+
+// Reporter receives one increment per call to a deprecated API, labeled
+// with the legacy name and its canonical replacement. Implementations are
+// expected to be cheap and non-blocking (e.g. incrementing a Prometheus or
+// OpenTelemetry counter); Tracker does not buffer or batch calls.
+type Reporter interface {
+	IncDeprecatedCall(legacyName, replacement string)
+}
+
+// FuncReporter adapts a plain function to Reporter, so callers can wire in
+// a Prometheus *CounterVec.WithLabelValues(...).Inc or an OpenTelemetry
+// counter's Add without this package depending on either client library.
+type FuncReporter func(legacyName, replacement string)
+
+// IncDeprecatedCall calls r.
+func (r FuncReporter) IncDeprecatedCall(legacyName, replacement string) {
+	r(legacyName, replacement)
+}
+
+// NoopReporter discards every call. NewTracker uses it when given a nil
+// Reporter.
+type NoopReporter struct{}
+
+// IncDeprecatedCall does nothing.
+func (NoopReporter) IncDeprecatedCall(string, string) {}
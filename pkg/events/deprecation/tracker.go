@@ -0,0 +1,142 @@
+package deprecation
+
+import (
+	"fmt"
+	"log/slog"
+	"runtime"
+	"sync"
+	"time"
+)
+
+// RemovedError is returned by Warn (and, in strict mode, embedded in the
+// panic value) once a legacy name's RemoveAfter version has passed.
+type RemovedError struct {
+	LegacyName  string
+	Replacement string
+	RemoveAfter string
+}
+
+func (e *RemovedError) Error() string {
+	return fmt.Sprintf("deprecation: %s was removed after %s; use %s instead", e.LegacyName, e.RemoveAfter, e.Replacement)
+}
+
+// Tracker records deprecated-API usage: it increments a Reporter, logs a
+// rate-limited structured warning naming the legacy API, its replacement,
+// and the caller, and enforces a RemovalPolicy once one is set.
+type Tracker struct {
+	mu       sync.Mutex
+	reporter Reporter
+	logger   *slog.Logger
+	policy   RemovalPolicy
+	strict   bool
+
+	logWindow time.Duration
+	lastLogAt map[string]time.Time
+}
+
+// NewTracker creates a Tracker. If reporter is nil, calls are counted
+// nowhere but still logged and policy-enforced. If logger is nil,
+// slog.Default() is used.
+func NewTracker(reporter Reporter, logger *slog.Logger) *Tracker {
+	if reporter == nil {
+		reporter = NoopReporter{}
+	}
+	if logger == nil {
+		logger = slog.Default()
+	}
+	return &Tracker{
+		reporter:  reporter,
+		logger:    logger,
+		logWindow: time.Minute,
+		lastLogAt: make(map[string]time.Time),
+	}
+}
+
+// SetLogWindow changes how often Warn logs for the same legacyName+caller
+// pair. The default is one minute.
+func (t *Tracker) SetLogWindow(d time.Duration) {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+	t.logWindow = d
+}
+
+// SetPolicy installs the RemovalPolicy Warn enforces.
+func (t *Tracker) SetPolicy(policy RemovalPolicy) {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+	t.policy = policy
+}
+
+// SetStrict controls whether a call past its RemoveAfter version panics
+// (true) or returns a *RemovedError (false, the default).
+func (t *Tracker) SetStrict(strict bool) {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+	t.strict = strict
+}
+
+// Warn records one call to the deprecated API legacyName, whose canonical
+// replacement is replacement. It always increments the Reporter; it logs
+// at most once per logWindow per (legacyName, caller) pair; and if
+// legacyName is past its RemoveAfter version in the current policy, it
+// returns a *RemovedError - or panics with one, if strict mode is on.
+func (t *Tracker) Warn(legacyName, replacement string) error {
+	t.reporter.IncDeprecatedCall(legacyName, replacement)
+
+	caller := captureCaller(2)
+	t.maybeLog(legacyName, replacement, caller)
+
+	t.mu.Lock()
+	policy := t.policy
+	strict := t.strict
+	t.mu.Unlock()
+
+	if !policy.pastRemoval(legacyName) {
+		return nil
+	}
+	if repl, ok := policy.replacementFor(legacyName); ok && repl != "" {
+		replacement = repl
+	}
+	err := &RemovedError{LegacyName: legacyName, Replacement: replacement, RemoveAfter: policy.Entries[legacyName].RemoveAfter}
+	if strict {
+		panic(err)
+	}
+	return err
+}
+
+func (t *Tracker) maybeLog(legacyName, replacement, caller string) {
+	key := legacyName + "|" + caller
+
+	t.mu.Lock()
+	now := time.Now()
+	last, logged := t.lastLogAt[key]
+	due := !logged || now.Sub(last) >= t.logWindow
+	if due {
+		t.lastLogAt[key] = now
+	}
+	t.mu.Unlock()
+
+	if !due {
+		return
+	}
+	t.logger.Warn("deprecated event API called",
+		"legacy_name", legacyName,
+		"replacement", replacement,
+		"caller", caller,
+	)
+}
+
+// captureCaller returns "file:line function" for the caller skip frames
+// above captureCaller itself, or "unknown" if it can't be determined.
+func captureCaller(skip int) string {
+	pc, file, line, ok := runtime.Caller(skip + 1)
+	if !ok {
+		return "unknown"
+	}
+	fn := runtime.FuncForPC(pc)
+	name := "unknown"
+	if fn != nil {
+		name = fn.Name()
+	}
+	return fmt.Sprintf("%s:%d %s", file, line, name)
+}
@@ -0,0 +1,104 @@
+package deprecation
+
+import (
+	"bytes"
+	"log/slog"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+type recordingReporter struct {
+	counts map[string]int
+}
+
+func newRecordingReporter() *recordingReporter {
+	return &recordingReporter{counts: make(map[string]int)}
+}
+
+func (r *recordingReporter) IncDeprecatedCall(legacyName, replacement string) {
+	r.counts[legacyName+"->"+replacement]++
+}
+
+func TestTrackerWarnIncrementsReporterEveryCall(t *testing.T) {
+	reporter := newRecordingReporter()
+	tr := NewTracker(reporter, slog.New(slog.NewTextHandler(&bytes.Buffer{}, nil)))
+
+	for i := 0; i < 3; i++ {
+		require.NoError(t, tr.Warn("LegacyFoo", "NewFoo"))
+	}
+
+	assert.Equal(t, 3, reporter.counts["LegacyFoo->NewFoo"])
+}
+
+func TestTrackerWarnLogsAtMostOncePerWindow(t *testing.T) {
+	var buf bytes.Buffer
+	tr := NewTracker(nil, slog.New(slog.NewTextHandler(&buf, nil)))
+	tr.SetLogWindow(time.Hour)
+
+	require.NoError(t, tr.Warn("LegacyFoo", "NewFoo"))
+	require.NoError(t, tr.Warn("LegacyFoo", "NewFoo"))
+
+	logged := strings.Count(buf.String(), "deprecated event API called")
+	assert.Equal(t, 1, logged)
+}
+
+func TestTrackerWarnLogsAgainAfterWindowElapses(t *testing.T) {
+	var buf bytes.Buffer
+	tr := NewTracker(nil, slog.New(slog.NewTextHandler(&buf, nil)))
+	tr.SetLogWindow(time.Millisecond)
+
+	require.NoError(t, tr.Warn("LegacyFoo", "NewFoo"))
+	time.Sleep(5 * time.Millisecond)
+	require.NoError(t, tr.Warn("LegacyFoo", "NewFoo"))
+
+	logged := strings.Count(buf.String(), "deprecated event API called")
+	assert.Equal(t, 2, logged)
+}
+
+func TestTrackerWarnReturnsErrorPastRemoveAfter(t *testing.T) {
+	tr := NewTracker(nil, slog.New(slog.NewTextHandler(&bytes.Buffer{}, nil)))
+	tr.SetPolicy(RemovalPolicy{
+		CurrentVersion: "v2.0.0",
+		Entries: map[string]PolicyEntry{
+			"LegacyFoo": {Replacement: "NewFoo", DeprecatedSince: "v1.0.0", RemoveAfter: "v2.0.0"},
+		},
+	})
+
+	err := tr.Warn("LegacyFoo", "NewFoo")
+	require.Error(t, err)
+	var removed *RemovedError
+	require.ErrorAs(t, err, &removed)
+	assert.Equal(t, "LegacyFoo", removed.LegacyName)
+	assert.Equal(t, "NewFoo", removed.Replacement)
+}
+
+func TestTrackerWarnPanicsInStrictModePastRemoveAfter(t *testing.T) {
+	tr := NewTracker(nil, slog.New(slog.NewTextHandler(&bytes.Buffer{}, nil)))
+	tr.SetPolicy(RemovalPolicy{
+		CurrentVersion: "v2.1.0",
+		Entries: map[string]PolicyEntry{
+			"LegacyFoo": {Replacement: "NewFoo", RemoveAfter: "v2.0.0"},
+		},
+	})
+	tr.SetStrict(true)
+
+	assert.Panics(t, func() {
+		_ = tr.Warn("LegacyFoo", "NewFoo")
+	})
+}
+
+func TestTrackerWarnAllowsCallBeforeRemoveAfter(t *testing.T) {
+	tr := NewTracker(nil, slog.New(slog.NewTextHandler(&bytes.Buffer{}, nil)))
+	tr.SetPolicy(RemovalPolicy{
+		CurrentVersion: "v1.5.0",
+		Entries: map[string]PolicyEntry{
+			"LegacyFoo": {Replacement: "NewFoo", RemoveAfter: "v2.0.0"},
+		},
+	})
+
+	assert.NoError(t, tr.Warn("LegacyFoo", "NewFoo"))
+}
@@ -0,0 +1,56 @@
+package eventrules
+
+import (
+	"context"
+	"sync/atomic"
+
+	"github.com/MalteBoehm/tall-affiliate-common/pkg/events"
+)
+
+var defaultChain atomic.Value // Rule
+
+func init() {
+	defaultChain.Store(Chain())
+}
+
+// SetDefaultChain replaces the Rule every call in this file routes
+// through. Call it once at startup (e.g. Chain(SchemaValidationRule(reg),
+// MetricsRule(sink))); the zero value is an empty, no-op chain.
+func SetDefaultChain(rule Rule) {
+	defaultChain.Store(rule)
+}
+
+// Default returns the Rule most recently passed to SetDefaultChain.
+func Default() Rule {
+	return defaultChain.Load().(Rule)
+}
+
+// NewColorEnrichmentFailedEvent is the instrumented equivalent of
+// events.NewColorEnrichmentFailedEvent: it builds the same event, then
+// runs it through Default() before returning, so every caller that
+// switches to this constructor gets whatever rules SetDefaultChain
+// installed (none, until it is called).
+func NewColorEnrichmentFailedEvent(ctx context.Context, asin, productID, reason string) (*events.Event, error) {
+	evt := events.NewColorEnrichmentFailedEvent(asin, productID, reason)
+	return Default()(ctx, evt, terminal)
+}
+
+// NewProductEnrichmentFailedEvent is the instrumented equivalent of
+// events.NewProductEnrichmentFailedEvent.
+func NewProductEnrichmentFailedEvent(ctx context.Context, source string, data *events.ProductEnrichmentFailedData) (*events.Event, error) {
+	evt, err := events.NewProductEnrichmentFailedEvent(source, data)
+	if err != nil {
+		return nil, err
+	}
+	return Default()(ctx, evt, terminal)
+}
+
+// NewCatalogProductEnrichmentFailedEvent is the instrumented equivalent of
+// events.NewCatalogProductEnrichmentFailedEvent.
+func NewCatalogProductEnrichmentFailedEvent(ctx context.Context, source string, data *events.ProductEnrichmentFailedData) (*events.Event, error) {
+	evt, err := events.NewCatalogProductEnrichmentFailedEvent(source, data)
+	if err != nil {
+		return nil, err
+	}
+	return Default()(ctx, evt, terminal)
+}
@@ -0,0 +1,33 @@
+package eventrules
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/MalteBoehm/tall-affiliate-common/pkg/events"
+)
+
+func TestNewColorEnrichmentFailedEventRoutesThroughDefaultChain(t *testing.T) {
+	sink := newRecordingMetricsSink()
+	SetDefaultChain(Chain(MetricsRule(sink)))
+	t.Cleanup(func() { SetDefaultChain(Chain()) })
+
+	evt, err := NewColorEnrichmentFailedEvent(context.Background(), "asin-1", "prod-1", "timeout")
+	require.NoError(t, err)
+	assert.Equal(t, events.ColorEnrichmentFailed, evt.Type)
+	assert.Equal(t, 1, sink.counts[events.ColorEnrichmentFailed])
+}
+
+func TestNewProductEnrichmentFailedEventRoutesThroughDefaultChain(t *testing.T) {
+	sink := newRecordingMetricsSink()
+	SetDefaultChain(Chain(MetricsRule(sink)))
+	t.Cleanup(func() { SetDefaultChain(Chain()) })
+
+	evt, err := NewProductEnrichmentFailedEvent(context.Background(), "pa-api", &events.ProductEnrichmentFailedData{ASIN: "asin-1"})
+	require.NoError(t, err)
+	assert.Equal(t, events.PRODUCT_ENRICHMENT_FAILED_V1, evt.Type)
+	assert.Equal(t, 1, sink.counts[events.PRODUCT_ENRICHMENT_FAILED_V1])
+}
@@ -0,0 +1,30 @@
+package eventrules
+
+import "context"
+
+type correlationIDKey struct{}
+type traceIDKey struct{}
+
+// ContextWithCorrelationID returns a context carrying id, for
+// CorrelationPropagationRule to copy onto every event created from it.
+func ContextWithCorrelationID(ctx context.Context, id string) context.Context {
+	return context.WithValue(ctx, correlationIDKey{}, id)
+}
+
+// CorrelationIDFromContext returns the correlation ID ctx carries, if any.
+func CorrelationIDFromContext(ctx context.Context) (string, bool) {
+	id, ok := ctx.Value(correlationIDKey{}).(string)
+	return id, ok
+}
+
+// ContextWithTraceID returns a context carrying id, for
+// CorrelationPropagationRule to copy onto every event created from it.
+func ContextWithTraceID(ctx context.Context, id string) context.Context {
+	return context.WithValue(ctx, traceIDKey{}, id)
+}
+
+// TraceIDFromContext returns the trace ID ctx carries, if any.
+func TraceIDFromContext(ctx context.Context) (string, bool) {
+	id, ok := ctx.Value(traceIDKey{}).(string)
+	return id, ok
+}
@@ -0,0 +1,54 @@
+package eventrules
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/MalteBoehm/tall-affiliate-common/pkg/events"
+)
+
+func TestChainRunsRulesInOrder(t *testing.T) {
+	var order []string
+	mark := func(name string) Rule {
+		return func(ctx context.Context, evt *events.Event, next Next) (*events.Event, error) {
+			order = append(order, name)
+			return next(ctx, evt)
+		}
+	}
+
+	b := Build(mark("first"), mark("second"), mark("third"))
+	_, err := b.New(context.Background(), "TEST_EVENT", "product", "asin-1", map[string]any{})
+	require.NoError(t, err)
+
+	assert.Equal(t, []string{"first", "second", "third"}, order)
+}
+
+func TestChainShortCircuitsWhenARuleDropsTheEvent(t *testing.T) {
+	dropper := func(ctx context.Context, evt *events.Event, next Next) (*events.Event, error) {
+		return nil, nil
+	}
+	var reached bool
+	after := func(ctx context.Context, evt *events.Event, next Next) (*events.Event, error) {
+		reached = true
+		return next(ctx, evt)
+	}
+
+	b := Build(dropper, after)
+	evt, err := b.New(context.Background(), "TEST_EVENT", "product", "asin-1", map[string]any{})
+	require.NoError(t, err)
+	assert.Nil(t, evt)
+	assert.False(t, reached)
+}
+
+func TestBuilderNewProducesEventWithGivenFields(t *testing.T) {
+	b := Build()
+	evt, err := b.New(context.Background(), "TEST_EVENT", "product", "asin-1", map[string]any{"k": "v"})
+	require.NoError(t, err)
+	require.NotNil(t, evt)
+	assert.Equal(t, "TEST_EVENT", evt.Type)
+	assert.Equal(t, "product", evt.AggregateType)
+	assert.Equal(t, "asin-1", evt.AggregateID)
+}
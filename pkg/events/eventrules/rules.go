@@ -0,0 +1,142 @@
+package eventrules
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"sync"
+	"sync/atomic"
+	"time"
+
+	"github.com/MalteBoehm/tall-affiliate-common/pkg/events"
+	"github.com/MalteBoehm/tall-affiliate-common/pkg/events/registry"
+)
+
+// SchemaValidationRule rejects an event whose payload does not match the
+// Schema reg has registered for its event type, short-circuiting the
+// chain before the event is ever handed to a caller.
+func SchemaValidationRule(reg *registry.Registry) Rule {
+	return func(ctx context.Context, evt *events.Event, next Next) (*events.Event, error) {
+		raw, err := json.Marshal(evt.Payload)
+		if err != nil {
+			return nil, fmt.Errorf("eventrules: marshal payload for %s: %w", evt.Type, err)
+		}
+		if _, err := reg.Decode(evt.Type, raw); err != nil {
+			return nil, fmt.Errorf("eventrules: schema validation: %w", err)
+		}
+		return next(ctx, evt)
+	}
+}
+
+// PIIRedactionRule replaces the named top-level payload fields with a
+// fixed redaction marker before the event continues down the chain. Field
+// names match the payload's JSON tags, not its Go struct field names.
+func PIIRedactionRule(fields ...string) Rule {
+	redact := make(map[string]struct{}, len(fields))
+	for _, f := range fields {
+		redact[f] = struct{}{}
+	}
+
+	return func(ctx context.Context, evt *events.Event, next Next) (*events.Event, error) {
+		if len(redact) == 0 {
+			return next(ctx, evt)
+		}
+
+		raw, err := json.Marshal(evt.Payload)
+		if err != nil {
+			return nil, fmt.Errorf("eventrules: marshal payload for %s: %w", evt.Type, err)
+		}
+		var fieldsMap map[string]any
+		if err := json.Unmarshal(raw, &fieldsMap); err != nil {
+			// Not an object-shaped payload (e.g. a scalar or array); nothing
+			// to redact by field name.
+			return next(ctx, evt)
+		}
+
+		changed := false
+		for f := range redact {
+			if _, ok := fieldsMap[f]; ok {
+				fieldsMap[f] = "[REDACTED]"
+				changed = true
+			}
+		}
+		if !changed {
+			return next(ctx, evt)
+		}
+
+		redacted := *evt
+		redacted.Payload = fieldsMap
+		return next(ctx, &redacted)
+	}
+}
+
+// SamplingRule keeps roughly one in every n events of a given type,
+// dropping the rest before they reach a caller. n <= 1 keeps every event.
+// Dropped events return (nil, nil) rather than an error, since being
+// sampled out isn't a failure.
+func SamplingRule(n int) Rule {
+	if n < 1 {
+		n = 1
+	}
+	var counters sync.Map // eventType -> *int64
+
+	return func(ctx context.Context, evt *events.Event, next Next) (*events.Event, error) {
+		if n == 1 {
+			return next(ctx, evt)
+		}
+		v, _ := counters.LoadOrStore(evt.Type, new(int64))
+		count := atomic.AddInt64(v.(*int64), 1)
+		if (count-1)%int64(n) != 0 {
+			return nil, nil
+		}
+		return next(ctx, evt)
+	}
+}
+
+// CorrelationPropagationRule copies the correlation ID and trace ID
+// carried on ctx (see ContextWithCorrelationID / ContextWithTraceID) onto
+// evt.Metadata, the same keys events.NewCausedEvent uses, so consumers see
+// one consistent pair of metadata keys regardless of which constructor
+// produced the event.
+func CorrelationPropagationRule() Rule {
+	return func(ctx context.Context, evt *events.Event, next Next) (*events.Event, error) {
+		if id, ok := CorrelationIDFromContext(ctx); ok {
+			if evt.Metadata == nil {
+				evt.Metadata = make(map[string]any)
+			}
+			evt.Metadata[events.MetadataCorrelationID] = id
+		}
+		if id, ok := TraceIDFromContext(ctx); ok {
+			if evt.Metadata == nil {
+				evt.Metadata = make(map[string]any)
+			}
+			evt.Metadata[events.MetadataTraceID] = id
+		}
+		return next(ctx, evt)
+	}
+}
+
+// MetricsSink receives per-event-type counts and constructor latency.
+// Implementations are expected to be cheap and non-blocking (e.g.
+// incrementing a Prometheus counter/histogram).
+type MetricsSink interface {
+	IncEvent(eventType string)
+	ObserveLatency(eventType string, d time.Duration)
+}
+
+// MetricsRule records one IncEvent and one ObserveLatency call to sink per
+// event that reaches the end of the chain successfully. It does not record
+// events dropped by an earlier rule (e.g. SamplingRule) or rejected with
+// an error.
+func MetricsRule(sink MetricsSink) Rule {
+	return func(ctx context.Context, evt *events.Event, next Next) (*events.Event, error) {
+		start := time.Now()
+		result, err := next(ctx, evt)
+		if err != nil || result == nil {
+			return result, err
+		}
+		sink.IncEvent(evt.Type)
+		sink.ObserveLatency(evt.Type, time.Since(start))
+		return result, nil
+	}
+}
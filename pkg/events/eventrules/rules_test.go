@@ -0,0 +1,114 @@
+package eventrules
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/MalteBoehm/tall-affiliate-common/pkg/events"
+	"github.com/MalteBoehm/tall-affiliate-common/pkg/events/registry"
+)
+
+type testPayload struct {
+	ASIN  string `json:"asin"`
+	Email string `json:"email"`
+}
+
+func TestSchemaValidationRuleRejectsUnregisteredEventType(t *testing.T) {
+	reg := registry.NewRegistry()
+	b := Build(SchemaValidationRule(reg))
+
+	_, err := b.New(context.Background(), "UNKNOWN_EVENT", "product", "asin-1", testPayload{ASIN: "asin-1"})
+	assert.Error(t, err)
+}
+
+func TestSchemaValidationRulePassesRegisteredPayload(t *testing.T) {
+	reg := registry.NewRegistry()
+	reg.Register("TEST_EVENT", testPayload{})
+	b := Build(SchemaValidationRule(reg))
+
+	evt, err := b.New(context.Background(), "TEST_EVENT", "product", "asin-1", testPayload{ASIN: "asin-1"})
+	require.NoError(t, err)
+	assert.NotNil(t, evt)
+}
+
+func TestPIIRedactionRuleRedactsNamedField(t *testing.T) {
+	b := Build(PIIRedactionRule("email"))
+
+	evt, err := b.New(context.Background(), "TEST_EVENT", "product", "asin-1", testPayload{ASIN: "asin-1", Email: "a@example.com"})
+	require.NoError(t, err)
+
+	fields, ok := evt.Payload.(map[string]any)
+	require.True(t, ok)
+	assert.Equal(t, "[REDACTED]", fields["email"])
+	assert.Equal(t, "asin-1", fields["asin"])
+}
+
+func TestSamplingRuleKeepsOneInN(t *testing.T) {
+	b := Build(SamplingRule(3))
+
+	var kept int
+	for i := 0; i < 9; i++ {
+		evt, err := b.New(context.Background(), "TEST_EVENT", "product", "asin-1", testPayload{})
+		require.NoError(t, err)
+		if evt != nil {
+			kept++
+		}
+	}
+	assert.Equal(t, 3, kept)
+}
+
+func TestCorrelationPropagationRuleCopiesContextValuesOntoMetadata(t *testing.T) {
+	b := Build(CorrelationPropagationRule())
+
+	ctx := ContextWithCorrelationID(context.Background(), "corr-1")
+	ctx = ContextWithTraceID(ctx, "trace-1")
+
+	evt, err := b.New(ctx, "TEST_EVENT", "product", "asin-1", testPayload{})
+	require.NoError(t, err)
+	assert.Equal(t, "corr-1", evt.Metadata[events.MetadataCorrelationID])
+	assert.Equal(t, "trace-1", evt.Metadata[events.MetadataTraceID])
+}
+
+type recordingMetricsSink struct {
+	counts    map[string]int
+	latencies map[string][]time.Duration
+}
+
+func newRecordingMetricsSink() *recordingMetricsSink {
+	return &recordingMetricsSink{counts: make(map[string]int), latencies: make(map[string][]time.Duration)}
+}
+
+func (s *recordingMetricsSink) IncEvent(eventType string) {
+	s.counts[eventType]++
+}
+
+func (s *recordingMetricsSink) ObserveLatency(eventType string, d time.Duration) {
+	s.latencies[eventType] = append(s.latencies[eventType], d)
+}
+
+func TestMetricsRuleRecordsCountAndLatencyForSuccessfulEvents(t *testing.T) {
+	sink := newRecordingMetricsSink()
+	b := Build(MetricsRule(sink))
+
+	_, err := b.New(context.Background(), "TEST_EVENT", "product", "asin-1", testPayload{})
+	require.NoError(t, err)
+
+	assert.Equal(t, 1, sink.counts["TEST_EVENT"])
+	assert.Len(t, sink.latencies["TEST_EVENT"], 1)
+}
+
+func TestMetricsRuleSkipsDroppedEvents(t *testing.T) {
+	sink := newRecordingMetricsSink()
+	b := Build(SamplingRule(2), MetricsRule(sink))
+
+	_, err := b.New(context.Background(), "TEST_EVENT", "product", "asin-1", testPayload{})
+	require.NoError(t, err)
+	_, err = b.New(context.Background(), "TEST_EVENT", "product", "asin-1", testPayload{})
+	require.NoError(t, err)
+
+	assert.Equal(t, 1, sink.counts["TEST_EVENT"])
+}
@@ -7,6 +7,8 @@ import (
 	"time"
 
 	"github.com/google/uuid"
+
+	"github.com/MalteBoehm/tall-affiliate-common/pkg/events/deprecation"
 )
 
 // Event types with numbered convention (Phase_Option_Name)
@@ -81,6 +83,231 @@ const (
 	REVIEWS_ERROR_TYPE_DATABASE   = "database_error"
 )
 
+// Canonical CloudEvents types (domain.subdomain.action.v1), CAPS naming.
+// Several of the numbered EVENT_* constants above and of the CamelCase
+// aliases below resolve to these values; the rest keep their own literals.
+const (
+	CATALOG_PRODUCT_ENRICHMENT_REQUESTED_V1 = "catalog.product.enrichment.requested.v1"
+	CATALOG_PRODUCT_ENRICHMENT_COMPLETED_V1 = "catalog.product.enrichment.completed.v1"
+	CATALOG_PRODUCT_ENRICHMENT_FAILED_V1    = "catalog.product.enrichment.failed.v1"
+
+	ENRICHMENT_COMPLETED_V1 = "enrichment.completed.v1"
+	ENRICHMENT_FAILED_V1    = "enrichment.failed.v1"
+	ENRICHMENT_RETRY_V1     = "enrichment.retry.v1"
+	VARIANTS_ENRICHED_V1    = "variants.enriched.v1"
+
+	QUALITY_ASSESSMENT_REQUESTED_V1 = "quality.assessment.requested.v1"
+	QUALITY_ASSESSMENT_COMPLETED_V1 = "quality.assessment.completed.v1"
+	QUALITY_ASSESSMENT_FAILED_V1    = "quality.assessment.failed.v1"
+
+	CONTENT_GENERATION_RETRIED_V1 = "content.generation.retried.v1"
+
+	REVIEWS_REQUESTED_V1    = "reviews.requested.v1"
+	REVIEWS_FETCHED_V1      = "reviews.fetched.v1"
+	REVIEWS_FETCH_FAILED_V1 = "reviews.fetch_failed.v1"
+	REVIEWS_PROCESSED_V1    = "reviews.processed.v1"
+	REVIEWS_VALIDATED_V1    = "reviews.validated.v1"
+	REVIEWS_STORED_V1       = "reviews.stored.v1"
+	REVIEWS_ERROR_V1        = "reviews.error.v1"
+
+	PRODUCT_READY_FOR_PUBLICATION_V1 = "product.ready_for_publication.v1"
+	PRICE_MONITORING_SCHEDULED_V1    = "price.monitoring.scheduled.v1"
+	AVAILABILITY_CHECK_SCHEDULED_V1  = "product.availability_check.scheduled.v1"
+	PERIODIC_UPDATE_SCHEDULED_V1     = "product.periodic_update.scheduled.v1"
+
+	PRICE_UPDATED_V1          = "price.updated.v1"
+	PRICE_UPDATE_FAILED_V1    = "price.update_failed.v1"
+	PRODUCT_UPDATED_V1        = "product.updated.v1"
+	PRODUCT_UPDATE_FAILED_V1  = "product.update_failed.v1"
+	PRODUCT_STATUS_CHANGED_V1 = "product.status.changed.v1"
+	PRODUCT_DELETED_V1        = "product.deleted.v1"
+
+	// PRODUCT_ENRICHMENT_FAILED_V1 rounds out the legacy (non-catalog)
+	// PA-API enrichment family alongside PRODUCT_ENRICHMENT_REQUESTED_V1
+	// and PRODUCT_ENRICHMENT_COMPLETED_V1 defined below.
+	PRODUCT_ENRICHMENT_FAILED_V1 = "product_enrichment_failed_v1"
+)
+
+// CamelCase aliases for the CAPS canonical types above, used where a
+// Go-identifier-cased constant reads better (e.g. codes.go's CodeToCE map).
+const (
+	CatalogProductEnrichmentRequestedV1 = CATALOG_PRODUCT_ENRICHMENT_REQUESTED_V1
+	CatalogProductEnrichmentCompletedV1 = CATALOG_PRODUCT_ENRICHMENT_COMPLETED_V1
+	CatalogProductEnrichmentFailedV1    = CATALOG_PRODUCT_ENRICHMENT_FAILED_V1
+
+	// ProductEnrichmentRequestedV1/CompletedV1/FailedV1 are the CamelCase
+	// counterparts of the legacy (non-catalog) PRODUCT_ENRICHMENT_*_V1
+	// constants, for the same reason the Catalog* aliases above exist.
+	ProductEnrichmentRequestedV1 = PRODUCT_ENRICHMENT_REQUESTED_V1
+	ProductEnrichmentCompletedV1 = PRODUCT_ENRICHMENT_COMPLETED_V1
+	ProductEnrichmentFailedV1    = PRODUCT_ENRICHMENT_FAILED_V1
+
+	CatalogProductDetectedV1       = "catalog.product.detected.v1"
+	CatalogProductValidatedV1      = "catalog.product.validated.v1"
+	CatalogProductIgnoredV1        = "catalog.product.ignored.v1"
+	CatalogProductReviewRequiredV1 = "catalog.product.review_required.v1"
+
+	ContentGenerationRequestedV1 = "content.generation.requested.v1"
+	ContentGenerationStartedV1   = "content.generation.started.v1"
+	ContentGeneratedV1           = "content.generated.v1"
+	ContentGenerationFailedV1    = "content.generation.failed.v1"
+	ContentGenerationRetriedV1   = CONTENT_GENERATION_RETRIED_V1
+
+	ReviewsRequestedV1   = REVIEWS_REQUESTED_V1
+	ReviewsFetchedV1     = REVIEWS_FETCHED_V1
+	ReviewsProcessedV1   = REVIEWS_PROCESSED_V1
+	ReviewsValidatedV1   = REVIEWS_VALIDATED_V1
+	ReviewsFetchFailedV1 = REVIEWS_FETCH_FAILED_V1
+	ReviewsStoredV1      = REVIEWS_STORED_V1
+	ReviewsErrorV1       = REVIEWS_ERROR_V1
+
+	PriceUpdatedV1               = PRICE_UPDATED_V1
+	PriceUpdateFailedV1          = PRICE_UPDATE_FAILED_V1
+	ProductStatusChangedV1       = PRODUCT_STATUS_CHANGED_V1
+	ProductAvailabilityChangedV1 = "product.availability.changed.v1"
+)
+
+// Event-type aliases used by the "EventType*" constructor helpers
+// (NewScraperJobRequestedEvent, NewReviewsRequestedEvent, ...) and by
+// IsReviewsEvent/GetReviewsEventPriority below. Where a reviews lifecycle
+// stage has no numbered EVENT_* counterpart, its value is its own
+// canonical dotted CE type.
+const (
+	EventTypeScraperJobRequested = EVENT_00A_SCRAPER_JOB_REQUESTED
+
+	EventTypeReviewsRequested   = REVIEWS_REQUESTED_V1
+	EventTypeReviewsFetched     = REVIEWS_FETCHED_V1
+	EventTypeReviewsFetchFailed = REVIEWS_FETCH_FAILED_V1
+	EventTypeReviewsProcessed   = REVIEWS_PROCESSED_V1
+	EventTypeReviewsValidated   = REVIEWS_VALIDATED_V1
+	EventTypeReviewsStored      = REVIEWS_STORED_V1
+	EventTypeReviewsError       = REVIEWS_ERROR_V1
+
+	EventTypeReviewsCollected = "reviews.collected.v1"
+	EventTypeReviewsEnriched  = "reviews.enriched.v1"
+	EventTypeReviewsCached    = "reviews.cached.v1"
+	EventTypeReviewsExpired   = "reviews.expired.v1"
+	EventTypeReviewsDeleted   = "reviews.deleted.v1"
+)
+
+// Event_<phase><letter>_<Name> identifiers are the CamelCase counterparts
+// of the numbered EVENT_<PHASE><LETTER>_<NAME> constants above, used as
+// the return values of legacyEventTypeMapping.
+const (
+	Event_00A_ScraperJobRequested   = EVENT_00A_SCRAPER_JOB_REQUESTED
+	Event_02A_ProductValidated      = EVENT_02A_PRODUCT_VALIDATED
+	Event_02B_ProductIgnored        = EVENT_02B_PRODUCT_IGNORED
+	Event_02C_ProductReviewRequired = EVENT_02C_PRODUCT_REVIEW_REQUIRED
+	Event_05D_EnrichmentRetry       = EVENT_05D_ENRICHMENT_RETRY
+
+	Event_06_QualityAssessmentRequested  = EVENT_06_QUALITY_ASSESSMENT_REQUESTED
+	Event_07A_QualityAssessmentCompleted = EVENT_07A_QUALITY_ASSESSMENT_COMPLETED
+	Event_07B_QualityAssessmentFailed    = EVENT_07B_QUALITY_ASSESSMENT_FAILED
+
+	Event_08A_ContentGenerationRequested = EVENT_08A_CONTENT_GENERATION_REQUESTED
+	Event_08B_ReviewsRequested           = EVENT_08B_REVIEWS_REQUESTED
+	Event_09A_ContentGenerationStarted   = EVENT_09A_CONTENT_GENERATION_STARTED
+	Event_09B_ReviewsFetched             = EVENT_09B_REVIEWS_FETCHED
+	Event_10A_ContentGenerated           = EVENT_10A_CONTENT_GENERATED
+	Event_10B_ContentGenerationFailed    = EVENT_10B_CONTENT_GENERATION_FAILED
+	Event_10C_ReviewsProcessed           = EVENT_10C_REVIEWS_PROCESSED
+	Event_10D_ContentGenerationRetried   = EVENT_10D_CONTENT_GENERATION_RETRIED
+	Event_11A_ReviewsValidated           = EVENT_11A_REVIEWS_VALIDATED
+	Event_11B_ReviewsFetchFailed         = EVENT_11B_REVIEWS_FETCH_FAILED
+	Event_12A_ReviewsStored              = EVENT_12A_REVIEWS_STORED
+	Event_12B_ReviewsError               = EVENT_12B_REVIEWS_ERROR
+
+	Event_13_ProductReadyForPublication  = EVENT_13_PRODUCT_READY_FOR_PUBLICATION
+	Event_14A_PriceMonitoringScheduled   = EVENT_14A_PRICE_MONITORING_SCHEDULED
+	Event_14B_AvailabilityCheckScheduled = EVENT_14B_AVAILABILITY_CHECK_SCHEDULED
+	Event_14C_PeriodicUpdateScheduled    = EVENT_14C_PERIODIC_UPDATE_SCHEDULED
+
+	Event_15A_PriceUpdated              = EVENT_15A_PRICE_UPDATED
+	Event_15B_PriceUpdateFailed         = EVENT_15B_PRICE_UPDATE_FAILED
+	Event_16A_ProductUpdated            = EVENT_16A_PRODUCT_UPDATED
+	Event_16B_ProductUpdateFailed       = EVENT_16B_PRODUCT_UPDATE_FAILED
+	Event_17_ProductAvailabilityChanged = EVENT_17_PRODUCT_AVAILABILITY_CHANGED
+	Event_18_ProductStatusChanged       = EVENT_18_PRODUCT_STATUS_CHANGED
+	Event_19_ProductDeleted             = EVENT_19_PRODUCT_DELETED
+)
+
+// Scheduling/enrichment event-type aliases used directly by their New*Event
+// constructors below, where the wire type is simplest expressed without an
+// EVENT_*/V1 prefix or suffix. The VariationEnrichment* aliases have no
+// constructor in this package.
+const (
+	QualityAssessmentRequested = EVENT_06_QUALITY_ASSESSMENT_REQUESTED
+	QualityAssessmentCompleted = EVENT_07A_QUALITY_ASSESSMENT_COMPLETED
+	QualityAssessmentFailed    = EVENT_07B_QUALITY_ASSESSMENT_FAILED
+
+	ColorEnrichmentRequested = EVENT_04B_COLOR_ENRICHMENT_REQUESTED
+	ColorEnrichmentCompleted = "04B_COLOR_ENRICHMENT_COMPLETED"
+	ColorEnrichmentFailed    = "04B_COLOR_ENRICHMENT_FAILED"
+
+	VariationEnrichmentRequested = EVENT_04D_VARIANTS_ENRICHMENT_REQUESTED
+	VariationEnrichmentCompleted = "04D_VARIANTS_ENRICHMENT_COMPLETED"
+	VariationEnrichmentFailed    = "04D_VARIANTS_ENRICHMENT_FAILED"
+
+	PriceMonitoringScheduled   = EVENT_14A_PRICE_MONITORING_SCHEDULED
+	AvailabilityCheckScheduled = EVENT_14B_AVAILABILITY_CHECK_SCHEDULED
+	PeriodicUpdateScheduled    = EVENT_14C_PERIODIC_UPDATE_SCHEDULED
+)
+
+// CAPS counterparts of the catalog/content CamelCase V1 types above, used
+// alongside the EventType* family by pkg/adapters' DetermineTargetStream
+// classifiers, which check both namings for backward compatibility.
+const (
+	CATALOG_PRODUCT_DETECTED_V1        = "catalog.product.detected.v1"
+	CATALOG_PRODUCT_VALIDATED_V1       = "catalog.product.validated.v1"
+	CATALOG_PRODUCT_IGNORED_V1         = "catalog.product.ignored.v1"
+	CATALOG_PRODUCT_REVIEW_REQUIRED_V1 = "catalog.product.review_required.v1"
+	PRODUCT_AVAILABILITY_CHANGED_V1    = "product.availability.changed.v1"
+
+	CONTENT_GENERATION_REQUESTED_V1 = "content.generation.requested.v1"
+	CONTENT_GENERATION_STARTED_V1   = "content.generation.started.v1"
+	CONTENT_GENERATED_V1            = "content.generated.v1"
+	CONTENT_GENERATION_FAILED_V1    = "content.generation.failed.v1"
+)
+
+// EventType<Domain><Action> aliases predating the catalog.*/content.* dotted
+// rename; DetermineTargetStream's classifiers in pkg/adapters check these
+// alongside the CAPS constants above so older publishers still classify
+// correctly. Aliased to their dotted successor where one already covers the
+// same event; given their own literal otherwise.
+const (
+	EventTypeNewProductDetected         = "product.detected.v1"
+	EventTypeProductValidated           = "product.validated.v1"
+	EventTypeProductUnavailable         = "product.unavailable.v1"
+	EventTypeProductDeleted             = PRODUCT_DELETED_V1
+	EventTypeProductCreated             = "product.created.v1"
+	EventTypeProductUpdated             = PRODUCT_UPDATED_V1
+	EventTypeProductAvailabilityChanged = PRODUCT_AVAILABILITY_CHANGED_V1
+	EventTypeProductStatusChanged       = PRODUCT_STATUS_CHANGED_V1
+	EventTypeProductIgnored             = "product.ignored.v1"
+	EventTypeProductReviewRequired      = "product.review_required.v1"
+	EventTypeProductUpdateRequested     = "product.update.requested.v1"
+
+	EventTypeContentGenerationRequested = CONTENT_GENERATION_REQUESTED_V1
+	EventTypeContentGenerationStarted   = CONTENT_GENERATION_STARTED_V1
+	EventTypeContentGenerated           = CONTENT_GENERATED_V1
+	EventTypeContentGenerationFailed    = CONTENT_GENERATION_FAILED_V1
+	EventTypeContentGenerationRetried   = CONTENT_GENERATION_RETRIED_V1
+	EventTypeContentUpdateRequested     = "content.update.requested.v1"
+	EventTypeContentUpdated             = "content.updated.v1"
+	EventTypeContentAnalysisFailed      = "content.analysis.failed.v1"
+
+	// EventTypeBrowseNodeRequested/Resolved predate the PA-API browse-node
+	// removal (see the DEPRECATED notes on PublishBrowseNodeEvent and
+	// isBrowseNodeEvent); kept resolvable for any caller still holding a
+	// reference to them.
+	EventTypeBrowseNodeRequested = "product.browse_node.requested.v1"
+	EventTypeBrowseNodeResolved  = "product.browse_node.resolved.v1"
+
+	EventTypeCheckPrice        = "price.check.requested.v1"
+	EventTypePriceUpdated      = PRICE_UPDATED_V1
+	EventTypePriceUpdateFailed = PRICE_UPDATE_FAILED_V1
+)
+
 // Event represents a domain event
 type Event struct {
 	ID            string         `json:"id"`
@@ -329,6 +556,16 @@ type ImageSet struct {
 	Large  string `json:"large"`
 }
 
+// PRODUCT_ENRICHMENT_REQUESTED_V1 and PRODUCT_ENRICHMENT_COMPLETED_V1 are
+// the legacy (pre-CatalogProductEnrichmentRequestedV1) event types for
+// ProductEnrichmentRequestedData/ProductEnrichedData, kept resolvable for
+// NewProductEnrichmentRequestedEvent/NewProductEnrichedEvent and anything
+// still consuming them directly.
+const (
+	PRODUCT_ENRICHMENT_REQUESTED_V1 = "product_enrichment_requested_v1"
+	PRODUCT_ENRICHMENT_COMPLETED_V1 = "product_enrichment_completed_v1"
+)
+
 // ProductEnrichmentRequestedData represents a PA-API enrichment request
 type ProductEnrichmentRequestedData struct {
 	ASIN       string `json:"asin"`
@@ -684,6 +921,9 @@ func NewProductIgnoredEvent(asin, reason string) *Event {
 // NewProductEnrichmentRequestedEvent creates a new PA-API enrichment request event
 // DEPRECATED: Use NewCatalogProductEnrichmentRequestedEvent instead
 func NewProductEnrichmentRequestedEvent(source string, data *ProductEnrichmentRequestedData) (*Event, error) {
+	if err := deprecation.Default().Warn("NewProductEnrichmentRequestedEvent", "NewCatalogProductEnrichmentRequestedEvent"); err != nil {
+		return nil, err
+	}
 	if err := data.Validate(); err != nil {
 		return nil, err
 	}
@@ -693,6 +933,9 @@ func NewProductEnrichmentRequestedEvent(source string, data *ProductEnrichmentRe
 // NewProductEnrichedEvent creates a new PA-API enrichment success event
 // DEPRECATED: Use NewCatalogProductEnrichmentCompletedEvent instead
 func NewProductEnrichedEvent(source string, data *ProductEnrichedData) (*Event, error) {
+	if err := deprecation.Default().Warn("NewProductEnrichedEvent", "NewCatalogProductEnrichmentCompletedEvent"); err != nil {
+		return nil, err
+	}
 	return NewEvent(PRODUCT_ENRICHMENT_COMPLETED_V1, "product", data.ASIN, data)
 }
 
@@ -700,6 +943,17 @@ func NewProductEnrichedEvent(source string, data *ProductEnrichedData) (*Event,
 // Returns the normalized event type and true if normalization occurred, false otherwise.
 // DEPRECATED: This function will be removed in a future release. Use CAPS constants directly.
 func NormalizeEventType(s string) (string, bool) {
+	normalized, ok := legacyEventTypeMapping(s)
+	if ok {
+		// NormalizeEventType has no error return to propagate a RemovedError
+		// through, so a strict-mode Tracker enforces removal by panicking
+		// here instead.
+		_ = deprecation.Default().Warn(s, normalized)
+	}
+	return normalized, ok
+}
+
+func legacyEventTypeMapping(s string) (string, bool) {
 	switch s {
 	case "CONTENT_GENERATION_REQUESTED":
 		return Event_08A_ContentGenerationRequested, true
@@ -810,6 +1064,9 @@ func NormalizeEventType(s string) (string, bool) {
 // NewProductEnrichmentFailedEvent creates a new PA-API enrichment failure event
 // DEPRECATED: Use NewCatalogProductEnrichmentFailedEvent instead
 func NewProductEnrichmentFailedEvent(source string, data *ProductEnrichmentFailedData) (*Event, error) {
+	if err := deprecation.Default().Warn("NewProductEnrichmentFailedEvent", "NewCatalogProductEnrichmentFailedEvent"); err != nil {
+		return nil, err
+	}
 	return NewEvent(PRODUCT_ENRICHMENT_FAILED_V1, "product", data.ASIN, data)
 }
 
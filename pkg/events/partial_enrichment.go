@@ -0,0 +1,150 @@
+package events
+
+import "time"
+
+// EVENT_05E_PRODUCT_ENRICHMENT_INCOMPLETE marks an enrichment run that
+// finished with at least one stage failure but still produced usable
+// partial data. Consumers that receive this event should retry only the
+// stages named in MissingStages, not the whole enrichment.
+const EVENT_05E_PRODUCT_ENRICHMENT_INCOMPLETE = "05E_PRODUCT_ENRICHMENT_INCOMPLETE"
+
+// EnrichmentErrorEntry records one stage failure accumulated by a
+// PartialEnrichmentBuilder. Cause is the underlying error's message, not
+// the error itself, so the entry stays JSON-serializable.
+type EnrichmentErrorEntry struct {
+	Stage   string `json:"stage"`
+	Code    string `json:"code"`
+	Message string `json:"message"`
+	Cause   string `json:"cause,omitempty"`
+}
+
+// ProductEnrichmentIncompletePayload is the payload of an
+// EVENT_05E_PRODUCT_ENRICHMENT_INCOMPLETE event: whatever fields were
+// successfully enriched, plus the list of stages that were not.
+type ProductEnrichmentIncompletePayload struct {
+	ASIN            string                 `json:"asin"`
+	Region          string                 `json:"region"`
+	RequestID       string                 `json:"request_id"`
+	ColorVariants   []ColorVariant         `json:"color_variants,omitempty"`
+	DerivedContexts []EnrichmentErrorEntry `json:"derived_contexts"`
+	EnrichedAt      time.Time              `json:"enriched_at"`
+}
+
+// PartialEnrichmentBuilder accumulates enrichment errors alongside
+// whatever data was successfully enriched, instead of short-circuiting on
+// the first failure the way NewProductEnrichmentFailedEvent and its kin
+// do. Call AddError for every stage that fails, then Build: with no
+// errors added it returns a normal completion event, otherwise an
+// EVENT_05E_PRODUCT_ENRICHMENT_INCOMPLETE event carrying the partial
+// payload and the accumulated DerivedContexts.
+type PartialEnrichmentBuilder struct {
+	asin          string
+	region        string
+	requestID     string
+	colorVariants []ColorVariant
+	processingMS  int64
+	errors        []EnrichmentErrorEntry
+
+	completedTopic  string
+	incompleteTopic string
+}
+
+// NewPartialEnrichmentBuilder starts a builder for the enrichment run
+// identified by asin/region/requestID. completedTopic and incompleteTopic
+// let callers route the two outcomes to different topics, e.g. so
+// downstream consumers only need to subscribe to the incomplete topic to
+// retry missing stages.
+func NewPartialEnrichmentBuilder(asin, region, requestID, completedTopic, incompleteTopic string) *PartialEnrichmentBuilder {
+	return &PartialEnrichmentBuilder{
+		asin:            asin,
+		region:          region,
+		requestID:       requestID,
+		completedTopic:  completedTopic,
+		incompleteTopic: incompleteTopic,
+	}
+}
+
+// SetColorVariants records the color variants successfully enriched so
+// far, overwriting any previous value.
+func (b *PartialEnrichmentBuilder) SetColorVariants(variants []ColorVariant) *PartialEnrichmentBuilder {
+	b.colorVariants = variants
+	return b
+}
+
+// SetProcessingMS records how long enrichment took up to the point it
+// was built.
+func (b *PartialEnrichmentBuilder) SetProcessingMS(ms int64) *PartialEnrichmentBuilder {
+	b.processingMS = ms
+	return b
+}
+
+// AddError records that stage failed with code/message, optionally
+// wrapping cause. It does not stop the caller from continuing to enrich
+// other stages.
+func (b *PartialEnrichmentBuilder) AddError(stage, code, message string, cause error) *PartialEnrichmentBuilder {
+	entry := EnrichmentErrorEntry{Stage: stage, Code: code, Message: message}
+	if cause != nil {
+		entry.Cause = cause.Error()
+	}
+	b.errors = append(b.errors, entry)
+	return b
+}
+
+// HasErrors reports whether any stage error has been added so far.
+func (b *PartialEnrichmentBuilder) HasErrors() bool {
+	return len(b.errors) > 0
+}
+
+// Build produces the completion or incomplete event for everything
+// accumulated so far, along with the topic it should be published to.
+func (b *PartialEnrichmentBuilder) Build() (evt *Event, topic string, err error) {
+	if !b.HasErrors() {
+		data := &ProductEnrichedData{
+			ASIN:          b.asin,
+			Region:        b.region,
+			RequestID:     b.requestID,
+			ColorVariants: b.colorVariants,
+			ProcessingMS:  b.processingMS,
+			EnrichedAt:    time.Now().UTC(),
+		}
+		evt, err = NewProductEnrichedEvent("pa-api", data)
+		return evt, b.completedTopic, err
+	}
+
+	payload := ProductEnrichmentIncompletePayload{
+		ASIN:            b.asin,
+		Region:          b.region,
+		RequestID:       b.requestID,
+		ColorVariants:   b.colorVariants,
+		DerivedContexts: b.errors,
+		EnrichedAt:      time.Now().UTC(),
+	}
+	evt, err = NewEvent(EVENT_05E_PRODUCT_ENRICHMENT_INCOMPLETE, "product", b.asin, payload)
+	return evt, b.incompleteTopic, err
+}
+
+// IsIncomplete reports whether evt is an
+// EVENT_05E_PRODUCT_ENRICHMENT_INCOMPLETE event.
+func IsIncomplete(evt *Event) bool {
+	return evt != nil && evt.Type == EVENT_05E_PRODUCT_ENRICHMENT_INCOMPLETE
+}
+
+// MissingStages returns the stage names recorded in an incomplete event's
+// DerivedContexts, in the order they were added. It returns nil if evt is
+// not an incomplete event or carries no derived contexts.
+func MissingStages(evt *Event) []string {
+	if !IsIncomplete(evt) {
+		return nil
+	}
+
+	var payload ProductEnrichmentIncompletePayload
+	if err := evt.UnmarshalPayload(&payload); err != nil {
+		return nil
+	}
+
+	stages := make([]string, 0, len(payload.DerivedContexts))
+	for _, ctx := range payload.DerivedContexts {
+		stages = append(stages, ctx.Stage)
+	}
+	return stages
+}
@@ -0,0 +1,53 @@
+package events
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestPartialEnrichmentBuilderBuildsCompletedEventWithNoErrors(t *testing.T) {
+	b := NewPartialEnrichmentBuilder("B07PXGQC1Q", "de", "req-1", "topic.completed", "topic.incomplete")
+	b.SetColorVariants([]ColorVariant{{ColorName: "red", ASIN: "B07PXGQC1Q"}})
+
+	evt, topic, err := b.Build()
+	require.NoError(t, err)
+	assert.Equal(t, "topic.completed", topic)
+	assert.Equal(t, PRODUCT_ENRICHMENT_COMPLETED_V1, evt.Type)
+	assert.False(t, IsIncomplete(evt))
+}
+
+func TestPartialEnrichmentBuilderBuildsIncompleteEventWithAccumulatedErrors(t *testing.T) {
+	b := NewPartialEnrichmentBuilder("B07PXGQC1Q", "de", "req-2", "topic.completed", "topic.incomplete")
+	b.SetColorVariants([]ColorVariant{{ColorName: "red", ASIN: "B07PXGQC1Q"}})
+	b.AddError("color_variants", "COLOR_TIMEOUT", "pa-api timed out", errors.New("context deadline exceeded"))
+	b.AddError("pricing", "PRICE_UNAVAILABLE", "no offer found", nil)
+
+	evt, topic, err := b.Build()
+	require.NoError(t, err)
+	assert.Equal(t, "topic.incomplete", topic)
+	assert.Equal(t, EVENT_05E_PRODUCT_ENRICHMENT_INCOMPLETE, evt.Type)
+	assert.True(t, IsIncomplete(evt))
+
+	var payload ProductEnrichmentIncompletePayload
+	require.NoError(t, evt.UnmarshalPayload(&payload))
+	require.Len(t, payload.DerivedContexts, 2)
+	assert.Equal(t, "color_variants", payload.DerivedContexts[0].Stage)
+	assert.Equal(t, "context deadline exceeded", payload.DerivedContexts[0].Cause)
+	assert.Equal(t, "pricing", payload.DerivedContexts[1].Stage)
+	assert.Empty(t, payload.DerivedContexts[1].Cause)
+	assert.Len(t, payload.ColorVariants, 1)
+
+	assert.Equal(t, []string{"color_variants", "pricing"}, MissingStages(evt))
+}
+
+func TestIsIncompleteAndMissingStagesOnNonIncompleteEvent(t *testing.T) {
+	evt, err := NewProductEnrichedEvent("pa-api", &ProductEnrichedData{ASIN: "B07PXGQC1Q"})
+	require.NoError(t, err)
+
+	assert.False(t, IsIncomplete(evt))
+	assert.Nil(t, MissingStages(evt))
+	assert.Nil(t, MissingStages(nil))
+}
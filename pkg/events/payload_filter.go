@@ -0,0 +1,57 @@
+package events
+
+import "github.com/MalteBoehm/tall-affiliate-common/pkg/authz"
+
+// KeyMatcher is implemented by Event.Payload types that multiplex many
+// aggregates over a single stream. SubscribeForKey-style consumers use
+// MatchesKey to filter the stream down to one key/namespace before a
+// message ever reaches the caller's handler.
+type KeyMatcher interface {
+	MatchesKey(key, namespace string) bool
+}
+
+// ReadAuthorizer is implemented by Event.Payload types that can decide for
+// themselves whether an Authorizer may read them - for example, scoping a
+// row to the tenant that owns it.
+type ReadAuthorizer interface {
+	HasReadPermission(az authz.Authorizer) bool
+}
+
+// Snapshotter is implemented by Event.Payload types that can produce a
+// lighter-weight representation for callers that only need a point-in-time
+// view rather than the full payload.
+type Snapshotter interface {
+	Snapshot() (any, error)
+}
+
+// MatchesKey reports whether payload should be delivered for the given
+// key/namespace. Payloads that don't implement KeyMatcher match
+// unconditionally, so existing payload types keep working unchanged.
+func MatchesKey(payload any, key, namespace string) bool {
+	m, ok := payload.(KeyMatcher)
+	if !ok {
+		return true
+	}
+	return m.MatchesKey(key, namespace)
+}
+
+// HasReadPermission reports whether az may read payload. Payloads that
+// don't implement ReadAuthorizer are readable by anyone, so existing
+// payload types keep working unchanged.
+func HasReadPermission(payload any, az authz.Authorizer) bool {
+	r, ok := payload.(ReadAuthorizer)
+	if !ok {
+		return true
+	}
+	return r.HasReadPermission(az)
+}
+
+// SnapshotPayload returns payload's Snapshot if it implements Snapshotter,
+// or payload itself otherwise.
+func SnapshotPayload(payload any) (any, error) {
+	s, ok := payload.(Snapshotter)
+	if !ok {
+		return payload, nil
+	}
+	return s.Snapshot()
+}
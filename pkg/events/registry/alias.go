@@ -0,0 +1,42 @@
+package registry
+
+import (
+	"fmt"
+	"reflect"
+)
+
+// RegisterAlias binds alias - typically a renamed or legacy EVENT_*
+// constant's string value - to eventType's existing registration, so
+// Resolve can translate it without a hand-maintained switch like
+// events.NormalizeEventType's. eventType must already be registered.
+func (r *Registry) RegisterAlias(alias, eventType string) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	if _, ok := r.entries[eventType]; !ok {
+		return fmt.Errorf("registry: cannot alias %q to unregistered event type %q", alias, eventType)
+	}
+	if existing, ok := r.aliases[alias]; ok && existing != eventType {
+		return fmt.Errorf("registry: alias %q already bound to %q", alias, existing)
+	}
+	r.aliases[alias] = eventType
+	return nil
+}
+
+// Resolve translates name - a canonical event type or an alias bound via
+// RegisterAlias - to its canonical event type and current (highest
+// registered version) payload type.
+func (r *Registry) Resolve(name string) (eventType string, payloadType reflect.Type, ok bool) {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+
+	canonical := name
+	if mapped, isAlias := r.aliases[name]; isAlias {
+		canonical = mapped
+	}
+	entry, ok := r.currentEntryLocked(canonical)
+	if !ok {
+		return "", nil, false
+	}
+	return canonical, entry.PayloadType, true
+}
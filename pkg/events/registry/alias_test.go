@@ -0,0 +1,50 @@
+package registry
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestRegisterAliasRejectsUnregisteredEventType(t *testing.T) {
+	r := NewRegistry()
+	err := r.RegisterAlias("legacy_greeting", "greeting")
+	assert.ErrorContains(t, err, "unregistered")
+}
+
+func TestRegisterAliasRejectsConflictingRebind(t *testing.T) {
+	r := NewRegistry()
+	r.Register("greeting", greetingV1{})
+	r.Register("farewell", greetingV1{})
+	require.NoError(t, r.RegisterAlias("legacy_greeting", "greeting"))
+
+	err := r.RegisterAlias("legacy_greeting", "farewell")
+	assert.ErrorContains(t, err, "already bound")
+}
+
+func TestResolveReturnsCanonicalTypeAndPayloadType(t *testing.T) {
+	r := NewRegistry()
+	r.Register("greeting", greetingV1{})
+	require.NoError(t, r.RegisterAlias("legacy_greeting", "greeting"))
+
+	eventType, payloadType, ok := r.Resolve("legacy_greeting")
+	require.True(t, ok)
+	assert.Equal(t, "greeting", eventType)
+	assert.Equal(t, "greetingV1", payloadType.Name())
+}
+
+func TestResolvePassesThroughCanonicalName(t *testing.T) {
+	r := NewRegistry()
+	r.Register("greeting", greetingV1{})
+
+	eventType, _, ok := r.Resolve("greeting")
+	require.True(t, ok)
+	assert.Equal(t, "greeting", eventType)
+}
+
+func TestResolveUnknownNameReturnsFalse(t *testing.T) {
+	r := NewRegistry()
+	_, _, ok := r.Resolve("missing")
+	assert.False(t, ok)
+}
@@ -0,0 +1,66 @@
+package registry
+
+// AsyncAPIDocument is a minimal AsyncAPI 2.x-shaped document generated from
+// a Registry's current registrations, giving downstream teams a
+// machine-readable contract for every EVENT_* constant instead of reading
+// the Go payload structs directly.
+type AsyncAPIDocument struct {
+	AsyncAPI   string                     `json:"asyncapi"`
+	Info       AsyncAPIInfo               `json:"info"`
+	Channels   map[string]AsyncAPIChannel `json:"channels"`
+	Components AsyncAPIComponents         `json:"components"`
+}
+
+// AsyncAPIInfo is the document's required "info" object.
+type AsyncAPIInfo struct {
+	Title   string `json:"title"`
+	Version string `json:"version"`
+}
+
+// AsyncAPIChannel describes one event type as a subscribable channel.
+type AsyncAPIChannel struct {
+	Subscribe AsyncAPIOperation `json:"subscribe"`
+}
+
+// AsyncAPIOperation wraps the message published on a channel.
+type AsyncAPIOperation struct {
+	Message AsyncAPIMessage `json:"message"`
+}
+
+// AsyncAPIMessage names an event type and points at its payload Schema.
+type AsyncAPIMessage struct {
+	Name    string  `json:"name"`
+	Payload *Schema `json:"payload"`
+}
+
+// AsyncAPIComponents collects the Schema of every documented event type,
+// keyed by event type, under "components/schemas".
+type AsyncAPIComponents struct {
+	Schemas map[string]*Schema `json:"schemas"`
+}
+
+// GenerateAsyncAPIDocument builds an AsyncAPIDocument from r's current
+// (highest registered version of each) event types, titled title at
+// apiVersion.
+func GenerateAsyncAPIDocument(r *Registry, title, apiVersion string) *AsyncAPIDocument {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+
+	doc := &AsyncAPIDocument{
+		AsyncAPI:   "2.6.0",
+		Info:       AsyncAPIInfo{Title: title, Version: apiVersion},
+		Channels:   make(map[string]AsyncAPIChannel, len(r.entries)),
+		Components: AsyncAPIComponents{Schemas: make(map[string]*Schema, len(r.entries))},
+	}
+
+	for eventType, versions := range r.entries {
+		entry := versions[r.latest[eventType]]
+		doc.Channels[eventType] = AsyncAPIChannel{
+			Subscribe: AsyncAPIOperation{
+				Message: AsyncAPIMessage{Name: eventType, Payload: entry.Schema},
+			},
+		}
+		doc.Components.Schemas[eventType] = entry.Schema
+	}
+	return doc
+}
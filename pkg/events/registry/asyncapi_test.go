@@ -0,0 +1,33 @@
+package registry
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestGenerateAsyncAPIDocumentIncludesEveryRegisteredEventType(t *testing.T) {
+	r := NewRegistry()
+	r.Register("greeting", greetingV1{})
+
+	doc := GenerateAsyncAPIDocument(r, "catalog events", "1.0.0")
+
+	assert.Equal(t, "catalog events", doc.Info.Title)
+	assert.Equal(t, "1.0.0", doc.Info.Version)
+	require.Contains(t, doc.Channels, "greeting")
+	assert.Equal(t, "greeting", doc.Channels["greeting"].Subscribe.Message.Name)
+	require.Contains(t, doc.Components.Schemas, "greeting")
+	assert.Equal(t, "object", doc.Components.Schemas["greeting"].Type)
+}
+
+func TestGenerateAsyncAPIDocumentUsesLatestRegisteredVersionSchema(t *testing.T) {
+	r := NewRegistry()
+	r.Register("greeting", greetingV1{})
+	r.RegisterV2("greeting", greetingV2{}, func(prev any) (any, error) { return prev, nil })
+
+	doc := GenerateAsyncAPIDocument(r, "catalog events", "1.0.0")
+
+	_, hasTitle := doc.Channels["greeting"].Subscribe.Message.Payload.Properties["title"]
+	assert.True(t, hasTitle)
+}
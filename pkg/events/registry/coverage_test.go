@@ -0,0 +1,43 @@
+package registry
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/MalteBoehm/tall-affiliate-common/pkg/events"
+)
+
+// knownEventTypeConstants lists every EVENT_*/PRODUCT_*/CanonicalXxx event
+// type constant DefaultRegistry is expected to cover. Go has no way to
+// enumerate a package's exported constants at runtime, so this list is
+// maintained by hand; TestRegistryCoverage exists to make an omission here
+// (or in DefaultRegistry) a visible, failing test rather than a silent gap.
+var knownEventTypeConstants = []string{
+	events.EVENT_01_PRODUCT_DETECTED,
+	events.EVENT_02B_PRODUCT_IGNORED,
+	events.EVENT_02C_PRODUCT_REVIEW_REQUIRED,
+	events.EVENT_04B_COLOR_ENRICHMENT_REQUESTED,
+	events.EVENT_04D_VARIANTS_ENRICHMENT_REQUESTED,
+	events.EVENT_06_QUALITY_ASSESSMENT_REQUESTED,
+	events.EVENT_07A_QUALITY_ASSESSMENT_COMPLETED,
+	events.EVENT_07B_QUALITY_ASSESSMENT_FAILED,
+	events.EVENT_08A_CONTENT_GENERATION_REQUESTED,
+	events.EVENT_08B_REVIEWS_REQUESTED,
+	events.EVENT_10A_CONTENT_GENERATED,
+	events.EVENT_10B_CONTENT_GENERATION_FAILED,
+	events.EVENT_14A_PRICE_MONITORING_SCHEDULED,
+	events.EVENT_14B_AVAILABILITY_CHECK_SCHEDULED,
+	events.EVENT_14C_PERIODIC_UPDATE_SCHEDULED,
+	events.PRODUCT_ENRICHMENT_REQUESTED_V1,
+	events.PRODUCT_ENRICHMENT_COMPLETED_V1,
+	CanonicalProductDetectedV1,
+}
+
+func TestRegistryCoverage(t *testing.T) {
+	r := DefaultRegistry()
+	for _, eventType := range knownEventTypeConstants {
+		_, _, ok := r.Resolve(eventType)
+		assert.True(t, ok, "event type %q is missing from DefaultRegistry", eventType)
+	}
+}
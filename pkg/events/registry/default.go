@@ -0,0 +1,118 @@
+package registry
+
+import (
+	"encoding/json"
+	"fmt"
+	"time"
+
+	"github.com/MalteBoehm/tall-affiliate-common/pkg/events"
+)
+
+// ProductEnrichedDataV2 is the version-2 successor to
+// events.ProductEnrichedData, adding a required VariantGroupID so
+// downstream consumers can group color/size variants of the same listing
+// without re-deriving it from ASIN. Registered via RegisterV2 so publishers
+// still emitting version 1 keep working; migrateProductEnrichedDataV1ToV2
+// upgrades their payloads on read.
+type ProductEnrichedDataV2 struct {
+	ASIN           string                `json:"asin"`
+	Region         string                `json:"region"`
+	RequestID      string                `json:"request_id"`
+	ColorVariants  []events.ColorVariant `json:"color_variants"`
+	ProcessingMS   int64                 `json:"processing_ms"`
+	EnrichedAt     time.Time             `json:"enriched_at"`
+	VariantGroupID string                `json:"variant_group_id"`
+}
+
+// DefaultRegistry returns a Registry pre-populated with version-1 bindings
+// for the catalog's most commonly consumed event types, plus a version-2
+// binding for PRODUCT_ENRICHMENT_COMPLETED_V1. Services with
+// additional or versioned payloads should call RegisterV2/RegisterVersion
+// on the returned Registry rather than forking it.
+func DefaultRegistry() *Registry {
+	r := NewRegistry()
+
+	r.Register(events.EVENT_01_PRODUCT_DETECTED, events.NewProductDetectedPayload{})
+	r.Register(events.EVENT_02B_PRODUCT_IGNORED, events.ProductIgnoredPayload{})
+	r.Register(events.EVENT_02C_PRODUCT_REVIEW_REQUIRED, events.ProductReviewRequiredPayload{})
+
+	r.Register(events.EVENT_04B_COLOR_ENRICHMENT_REQUESTED, events.ColorEnrichmentRequestedPayload{})
+	r.Register(events.EVENT_04D_VARIANTS_ENRICHMENT_REQUESTED, events.VariationEnrichmentRequestedPayload{})
+
+	r.Register(events.EVENT_06_QUALITY_ASSESSMENT_REQUESTED, events.QualityAssessmentRequestedPayload{})
+	r.Register(events.EVENT_07A_QUALITY_ASSESSMENT_COMPLETED, events.QualityAssessmentCompletedPayload{})
+	r.Register(events.EVENT_07B_QUALITY_ASSESSMENT_FAILED, events.QualityAssessmentFailedPayload{})
+
+	r.Register(events.EVENT_08A_CONTENT_GENERATION_REQUESTED, events.ContentGenerationRequestedPayload{})
+	r.Register(events.EVENT_08B_REVIEWS_REQUESTED, events.ReviewsRequestedPayload{})
+	r.Register(events.EVENT_10A_CONTENT_GENERATED, events.ContentGeneratedPayload{})
+	r.Register(events.EVENT_10B_CONTENT_GENERATION_FAILED, events.ContentGenerationFailedPayload{})
+
+	r.Register(events.EVENT_14A_PRICE_MONITORING_SCHEDULED, events.PriceMonitoringScheduledPayload{})
+	r.Register(events.EVENT_14B_AVAILABILITY_CHECK_SCHEDULED, events.AvailabilityCheckScheduledPayload{})
+	r.Register(events.EVENT_14C_PERIODIC_UPDATE_SCHEDULED, events.PeriodicUpdateScheduledPayload{})
+
+	r.Register(events.PRODUCT_ENRICHMENT_REQUESTED_V1, events.ProductEnrichmentRequestedData{})
+	r.Register(events.PRODUCT_ENRICHMENT_COMPLETED_V1, events.ProductEnrichedData{})
+	r.RegisterV2(events.PRODUCT_ENRICHMENT_COMPLETED_V1, ProductEnrichedDataV2{}, migrateProductEnrichedDataV1ToV2)
+
+	// CanonicalProductDetectedV1 is the dotted-naming-convention successor
+	// to events.EVENT_01_PRODUCT_DETECTED ("01_PRODUCT_DETECTED"), which
+	// predates ValidateName and so cannot be passed to RegisterEvent
+	// directly. The legacy value is kept resolvable via RegisterAlias so
+	// existing publishers' event types still validate.
+	if err := RegisterEvent(r, CanonicalProductDetectedV1, events.NewProductDetectedPayload{}, WithSemVer("1.0.0")); err != nil {
+		panic(err) // only fails if CanonicalProductDetectedV1 itself violates ValidateName
+	}
+	if err := r.RegisterAlias(events.EVENT_01_PRODUCT_DETECTED, CanonicalProductDetectedV1); err != nil {
+		panic(err)
+	}
+
+	return r
+}
+
+// CanonicalProductDetectedV1 is the canonical dotted name for the event
+// legacy code knows as events.EVENT_01_PRODUCT_DETECTED.
+const CanonicalProductDetectedV1 = "product.lifecycle.detected.v1"
+
+// migrateProductEnrichedDataV1ToV2 upgrades a version-1
+// events.ProductEnrichedData payload to ProductEnrichedDataV2, defaulting
+// the new, version-2-required VariantGroupID to ASIN so events published
+// before VariantGroupID existed still upgrade cleanly.
+func migrateProductEnrichedDataV1ToV2(prev any) (any, error) {
+	v1, err := remarshalProductEnrichedDataV1(prev)
+	if err != nil {
+		return nil, err
+	}
+
+	return ProductEnrichedDataV2{
+		ASIN:           v1.ASIN,
+		Region:         v1.Region,
+		RequestID:      v1.RequestID,
+		ColorVariants:  v1.ColorVariants,
+		ProcessingMS:   v1.ProcessingMS,
+		EnrichedAt:     v1.EnrichedAt,
+		VariantGroupID: v1.ASIN,
+	}, nil
+}
+
+// remarshalProductEnrichedDataV1 normalizes prev - which may already be an
+// events.ProductEnrichedData, or a map[string]any if it arrived via
+// json.Unmarshal into an any field - into a concrete
+// events.ProductEnrichedData.
+func remarshalProductEnrichedDataV1(prev any) (events.ProductEnrichedData, error) {
+	if v1, ok := prev.(events.ProductEnrichedData); ok {
+		return v1, nil
+	}
+
+	data, err := json.Marshal(prev)
+	if err != nil {
+		return events.ProductEnrichedData{}, fmt.Errorf("remarshal ProductEnrichedData: %w", err)
+	}
+
+	var v1 events.ProductEnrichedData
+	if err := json.Unmarshal(data, &v1); err != nil {
+		return events.ProductEnrichedData{}, fmt.Errorf("remarshal ProductEnrichedData: %w", err)
+	}
+	return v1, nil
+}
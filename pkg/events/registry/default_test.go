@@ -0,0 +1,43 @@
+package registry
+
+import (
+	"encoding/json"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/MalteBoehm/tall-affiliate-common/pkg/events"
+)
+
+func TestDefaultRegistryDecodesKnownEventType(t *testing.T) {
+	r := DefaultRegistry()
+
+	decoded, err := r.Decode(events.EVENT_01_PRODUCT_DETECTED, json.RawMessage(`{"asin":"B000123","title":"Test Product"}`))
+	require.NoError(t, err)
+	assert.Equal(t, &events.NewProductDetectedPayload{ASIN: "B000123", Title: "Test Product"}, decoded)
+}
+
+func TestDefaultRegistryUpgradesProductEnrichedDataToV2(t *testing.T) {
+	r := DefaultRegistry()
+
+	evt := &events.Event{
+		Type: events.PRODUCT_ENRICHMENT_COMPLETED_V1,
+		Payload: events.ProductEnrichedData{
+			ASIN:       "B000999",
+			Region:     "us",
+			RequestID:  "req_1",
+			EnrichedAt: time.Unix(0, 0),
+		},
+	}
+
+	upgraded, err := r.Upgrade(evt)
+	require.NoError(t, err)
+
+	v2, ok := upgraded.Payload.(ProductEnrichedDataV2)
+	require.True(t, ok)
+	assert.Equal(t, "B000999", v2.ASIN)
+	assert.Equal(t, "B000999", v2.VariantGroupID)
+	assert.Equal(t, 2, upgraded.Metadata["schema_version"])
+}
@@ -0,0 +1,75 @@
+package registry
+
+import (
+	"encoding/json"
+	"sort"
+)
+
+// ChangedEventType describes how one event type's registration differs
+// between two Registry snapshots.
+type ChangedEventType struct {
+	EventType        string
+	OldLatestVersion int
+	NewLatestVersion int
+	SchemaChanged    bool
+}
+
+// RegistryDiff is the result of comparing two Registry snapshots, e.g. a
+// consumer's compiled-against version and the version a CI pipeline is
+// about to deploy, so incompatible payload changes fail the build instead
+// of surfacing in production.
+type RegistryDiff struct {
+	Added   []string
+	Removed []string
+	Changed []ChangedEventType
+}
+
+// Diff compares oldReg against newReg: event types present only in newReg
+// are Added, present only in oldReg are Removed, and present in both but
+// whose latest registered version or Schema differs are Changed.
+func Diff(oldReg, newReg *Registry) RegistryDiff {
+	oldReg.mu.RLock()
+	newReg.mu.RLock()
+	defer oldReg.mu.RUnlock()
+	defer newReg.mu.RUnlock()
+
+	var d RegistryDiff
+	for eventType := range newReg.entries {
+		if _, ok := oldReg.entries[eventType]; !ok {
+			d.Added = append(d.Added, eventType)
+		}
+	}
+	for eventType := range oldReg.entries {
+		if _, ok := newReg.entries[eventType]; !ok {
+			d.Removed = append(d.Removed, eventType)
+		}
+	}
+	for eventType, oldVersions := range oldReg.entries {
+		newVersions, ok := newReg.entries[eventType]
+		if !ok {
+			continue
+		}
+		oldLatest := oldReg.latest[eventType]
+		newLatest := newReg.latest[eventType]
+		schemaChanged := !schemasEqual(oldVersions[oldLatest].Schema, newVersions[newLatest].Schema)
+		if oldLatest != newLatest || schemaChanged {
+			d.Changed = append(d.Changed, ChangedEventType{
+				EventType:        eventType,
+				OldLatestVersion: oldLatest,
+				NewLatestVersion: newLatest,
+				SchemaChanged:    schemaChanged,
+			})
+		}
+	}
+
+	sort.Strings(d.Added)
+	sort.Strings(d.Removed)
+	sort.Slice(d.Changed, func(i, j int) bool { return d.Changed[i].EventType < d.Changed[j].EventType })
+	return d
+}
+
+func schemasEqual(a, b *Schema) bool {
+	aj, _ := json.Marshal(a)
+	bj, _ := json.Marshal(b)
+	return string(aj) == string(bj)
+}
@@ -0,0 +1,69 @@
+package registry
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+type greetingV2 struct {
+	Name  string `json:"name"`
+	Title string `json:"title"`
+}
+
+func TestDiffReportsAddedAndRemovedEventTypes(t *testing.T) {
+	old := NewRegistry()
+	old.Register("greeting", greetingV1{})
+	old.Register("farewell", greetingV1{})
+
+	next := NewRegistry()
+	next.Register("greeting", greetingV1{})
+	next.Register("welcome", greetingV1{})
+
+	d := Diff(old, next)
+	assert.Equal(t, []string{"welcome"}, d.Added)
+	assert.Equal(t, []string{"farewell"}, d.Removed)
+	assert.Empty(t, d.Changed)
+}
+
+func TestDiffReportsSchemaChangeOnSameVersion(t *testing.T) {
+	old := NewRegistry()
+	old.Register("greeting", greetingV1{})
+
+	next := NewRegistry()
+	next.Register("greeting", greetingV2{})
+
+	d := Diff(old, next)
+	require := assert.New(t)
+	require.Len(d.Changed, 1)
+	require.Equal("greeting", d.Changed[0].EventType)
+	require.True(d.Changed[0].SchemaChanged)
+	require.Equal(1, d.Changed[0].OldLatestVersion)
+	require.Equal(1, d.Changed[0].NewLatestVersion)
+}
+
+func TestDiffReportsVersionBumpWithoutSchemaChange(t *testing.T) {
+	old := NewRegistry()
+	old.Register("greeting", greetingV1{})
+
+	next := NewRegistry()
+	next.Register("greeting", greetingV1{})
+	next.RegisterV2("greeting", greetingV1{}, func(prev any) (any, error) { return prev, nil })
+
+	d := Diff(old, next)
+	assert.Len(t, d.Changed, 1)
+	assert.Equal(t, 2, d.Changed[0].NewLatestVersion)
+}
+
+func TestDiffOfIdenticalRegistriesIsEmpty(t *testing.T) {
+	old := NewRegistry()
+	old.Register("greeting", greetingV1{})
+
+	next := NewRegistry()
+	next.Register("greeting", greetingV1{})
+
+	d := Diff(old, next)
+	assert.Empty(t, d.Added)
+	assert.Empty(t, d.Removed)
+	assert.Empty(t, d.Changed)
+}
@@ -0,0 +1,35 @@
+package registry
+
+import (
+	"fmt"
+	"regexp"
+)
+
+// maxEventTypeLength mirrors Kafka's topic name length cap (see the
+// AccelByte event-naming conventions this package follows), since event
+// types in this codebase often end up as literal stream/topic names.
+const maxEventTypeLength = 249
+
+// eventTypePattern matches a canonical dotted event type: two or more
+// lowercase alphanumeric segments (each allowing '_' and '-') joined by
+// single dots, e.g. "product.lifecycle.detected.v1". Legacy event type
+// strings such as "01_PRODUCT_DETECTED" deliberately do not satisfy this
+// and must be bound to a canonical name via RegisterAlias instead of
+// passed to RegisterEvent directly.
+var eventTypePattern = regexp.MustCompile(`^[a-z0-9_-]+(\.[a-z0-9_-]+)+$`)
+
+// ValidateName reports whether eventType follows this package's naming
+// convention: lowercase dot-separated segments of `[a-z0-9_-]`, no empty
+// segment, and no longer than maxEventTypeLength.
+func ValidateName(eventType string) error {
+	if len(eventType) == 0 {
+		return fmt.Errorf("registry: event type name must not be empty")
+	}
+	if len(eventType) > maxEventTypeLength {
+		return fmt.Errorf("registry: event type name %q exceeds %d characters", eventType, maxEventTypeLength)
+	}
+	if !eventTypePattern.MatchString(eventType) {
+		return fmt.Errorf("registry: event type name %q must be lowercase dot-separated segments of letters, digits, '_' or '-' (e.g. \"product.lifecycle.detected.v1\")", eventType)
+	}
+	return nil
+}
@@ -0,0 +1,33 @@
+package registry
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestValidateNameAccepts(t *testing.T) {
+	for _, name := range []string{
+		"product.lifecycle.detected.v1",
+		"reviews.requested.v1",
+		"a.b",
+		"product-catalog.item_created.v2",
+	} {
+		assert.NoError(t, ValidateName(name), name)
+	}
+}
+
+func TestValidateNameRejects(t *testing.T) {
+	for _, name := range []string{
+		"",
+		"01_PRODUCT_DETECTED",         // legacy style, no dots, uppercase
+		"Product.Detected.v1",         // uppercase
+		"product..detected",           // empty segment
+		"product",                     // no dot at all
+		"product.detected!",           // disallowed character
+		strings.Repeat("a.", 130) + "a", // too long
+	} {
+		assert.Error(t, ValidateName(name), name)
+	}
+}
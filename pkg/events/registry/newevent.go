@@ -0,0 +1,44 @@
+package registry
+
+import (
+	"encoding/json"
+	"fmt"
+
+	"github.com/MalteBoehm/tall-affiliate-common/pkg/events"
+)
+
+// NewEvent builds an *events.Event the same way events.NewEvent does, but
+// first validates payload against eventType's registered Schema and stamps
+// the result's Metadata with "dataschema" (the event type) and
+// "specversion" (the registered version), so a consumer can tell which
+// contract a payload was checked against without re-deriving it.
+//
+// This package already imports pkg/events (for Upgrade's *events.Event
+// parameter), so pkg/events cannot import it back - the same constraint
+// eventrules documents. Validated, stamped construction therefore lives
+// here as Registry.NewEvent rather than inside events.NewEvent itself;
+// callers that want it opt in by constructing through a Registry.
+func (r *Registry) NewEvent(eventType, aggregateType, aggregateID string, payload any) (*events.Event, error) {
+	r.mu.RLock()
+	entry, ok := r.currentEntryLocked(eventType)
+	r.mu.RUnlock()
+	if !ok {
+		return nil, fmt.Errorf("registry: event type %q is not registered", eventType)
+	}
+
+	raw, err := json.Marshal(payload)
+	if err != nil {
+		return nil, fmt.Errorf("registry: marshal %s payload: %w", eventType, err)
+	}
+	if err := Validate(entry.Schema, raw); err != nil {
+		return nil, fmt.Errorf("registry: %s: %w", eventType, err)
+	}
+
+	evt, err := events.NewEvent(eventType, aggregateType, aggregateID, payload)
+	if err != nil {
+		return nil, err
+	}
+	evt.Metadata["dataschema"] = eventType
+	evt.Metadata["specversion"] = entry.Version
+	return evt, nil
+}
@@ -0,0 +1,32 @@
+package registry
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestRegistryNewEventStampsDataschemaAndSpecversion(t *testing.T) {
+	r := NewRegistry()
+	r.Register("greeting", greetingV1{})
+
+	evt, err := r.NewEvent("greeting", "greeting.aggregate", "agg-1", greetingV1{Name: "ada"})
+	require.NoError(t, err)
+	assert.Equal(t, "greeting", evt.Metadata["dataschema"])
+	assert.Equal(t, 1, evt.Metadata["specversion"])
+}
+
+func TestRegistryNewEventRejectsPayloadViolatingSchema(t *testing.T) {
+	r := NewRegistry()
+	r.Register("greeting", greetingV1{})
+
+	_, err := r.NewEvent("greeting", "greeting.aggregate", "agg-1", map[string]any{"name": "ada", "extra": 1})
+	assert.ErrorContains(t, err, "unknown field")
+}
+
+func TestRegistryNewEventRejectsUnregisteredEventType(t *testing.T) {
+	r := NewRegistry()
+	_, err := r.NewEvent("missing", "x", "1", greetingV1{})
+	assert.ErrorContains(t, err, "not registered")
+}
@@ -0,0 +1,23 @@
+package registry
+
+import (
+	"fmt"
+
+	"github.com/MalteBoehm/tall-affiliate-common/pkg/database"
+	"github.com/MalteBoehm/tall-affiliate-common/pkg/events"
+)
+
+// NewOutboxEvent builds a *database.OutboxEvent the same way
+// database.NewOutboxEvent does, but first runs payload through ValidateEvent
+// so a row can never land in the outbox table with an unregistered event
+// type, a schema-violating payload, or a missing `validate:"required"`
+// field. database.NewOutboxEvent itself is left untouched - it has no
+// dependency on this package - so existing callers are unaffected; services
+// that hold a Registry opt into the stricter path by calling this instead.
+func (r *Registry) NewOutboxEvent(aggregateType, aggregateID, eventType string, payload any, streamName string) (*database.OutboxEvent, error) {
+	if err := r.ValidateEvent(&events.Event{Type: eventType, Payload: payload}); err != nil {
+		return nil, fmt.Errorf("registry: new outbox event: %w", err)
+	}
+
+	return database.NewOutboxEvent(aggregateType, aggregateID, eventType, payload, streamName)
+}
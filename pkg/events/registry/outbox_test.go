@@ -0,0 +1,27 @@
+package registry
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestNewOutboxEventValidatesPayload(t *testing.T) {
+	r := NewRegistry()
+	require.NoError(t, RegisterEvent(r, "orders.placed.v1", orderPlacedV1{}))
+
+	_, err := r.NewOutboxEvent("order", "o-1", "orders.placed.v1", orderPlacedV1{}, "orders-stream")
+	assert.ErrorContains(t, err, "order_id")
+
+	evt, err := r.NewOutboxEvent("order", "o-1", "orders.placed.v1", orderPlacedV1{OrderID: "o-1"}, "orders-stream")
+	require.NoError(t, err)
+	assert.Equal(t, "orders.placed.v1", evt.EventType)
+	assert.Equal(t, "orders-stream", evt.StreamName)
+}
+
+func TestNewOutboxEventRejectsUnregisteredType(t *testing.T) {
+	r := NewRegistry()
+	_, err := r.NewOutboxEvent("order", "o-1", "unknown.v1", orderPlacedV1{OrderID: "o-1"}, "orders-stream")
+	assert.Error(t, err)
+}
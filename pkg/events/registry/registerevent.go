@@ -0,0 +1,93 @@
+package registry
+
+import (
+	"encoding/json"
+	"fmt"
+
+	"github.com/MalteBoehm/tall-affiliate-common/pkg/events"
+)
+
+// Option configures a RegisterEvent call.
+type Option func(*Entry)
+
+// WithSemVer records eventType's contract version as a semver string
+// (independent of the integer schema version Register/RegisterV2 track,
+// which only counts migrations), e.g. "1.2.0".
+func WithSemVer(version string) Option {
+	return func(e *Entry) { e.SemVer = version }
+}
+
+// WithRequiredMetadata declares Event.Metadata keys that ValidateEvent
+// must find present on any event of this type (a key set to nil still
+// counts as present), e.g. a
+// "tenant" or "traceparent" key every publisher is expected to set.
+func WithRequiredMetadata(keys ...string) Option {
+	return func(e *Entry) { e.RequiredMetadata = append(e.RequiredMetadata, keys...) }
+}
+
+// RegisterEvent binds typeName's version-1 payload type like Register,
+// additionally enforcing this package's dotted naming convention (see
+// ValidateName) on typeName and recording opts - a semver contract version
+// and/or required metadata keys - for ValidateEvent to enforce later.
+//
+// Legacy event type constants that predate the naming convention (e.g.
+// "01_PRODUCT_DETECTED") cannot be passed to RegisterEvent directly; bind
+// them to a RegisterEvent-registered canonical name with RegisterAlias
+// instead.
+func RegisterEvent(r *Registry, typeName string, payloadProto any, opts ...Option) error {
+	if err := ValidateName(typeName); err != nil {
+		return err
+	}
+
+	r.register(typeName, 1, payloadProto, nil)
+
+	r.mu.Lock()
+	entry := r.entries[typeName][1]
+	for _, opt := range opts {
+		opt(&entry)
+	}
+	r.entries[typeName][1] = entry
+	r.mu.Unlock()
+
+	return nil
+}
+
+// ValidateEvent checks ev against its registered Entry: the event type
+// must be registered (directly or via an alias), ev.Metadata must contain
+// every key the entry's RequiredMetadata declares, and ev.Payload must
+// satisfy both the entry's JSON Schema and any `validate:"required"`
+// struct tags - the latter catching fields a schema's omitempty leaves
+// optional for decoding but that construction still requires.
+func (r *Registry) ValidateEvent(ev *events.Event) error {
+	eventType, _, ok := r.Resolve(ev.Type)
+	if !ok {
+		return fmt.Errorf("registry: event type %q is not registered", ev.Type)
+	}
+
+	r.mu.RLock()
+	entry, ok := r.currentEntryLocked(eventType)
+	r.mu.RUnlock()
+	if !ok {
+		return fmt.Errorf("registry: event type %q is not registered", eventType)
+	}
+
+	for _, key := range entry.RequiredMetadata {
+		if _, ok := ev.Metadata[key]; !ok {
+			return fmt.Errorf("registry: %s: missing required metadata key %q", eventType, key)
+		}
+	}
+
+	raw, err := json.Marshal(ev.Payload)
+	if err != nil {
+		return fmt.Errorf("registry: %s: marshal payload: %w", eventType, err)
+	}
+	if err := Validate(entry.Schema, raw); err != nil {
+		return fmt.Errorf("registry: %s: %w", eventType, err)
+	}
+
+	if err := validateRequiredTags(ev.Payload); err != nil {
+		return fmt.Errorf("registry: %s: %w", eventType, err)
+	}
+
+	return nil
+}
@@ -0,0 +1,74 @@
+package registry
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/MalteBoehm/tall-affiliate-common/pkg/events"
+)
+
+type orderPlacedV1 struct {
+	OrderID string `json:"order_id" validate:"required"`
+	Total   int    `json:"total,omitempty"`
+}
+
+func TestRegisterEventRejectsBadName(t *testing.T) {
+	r := NewRegistry()
+	err := RegisterEvent(r, "ORDER_PLACED", orderPlacedV1{})
+	assert.Error(t, err)
+}
+
+func TestRegisterEventRecordsSemVerAndRequiredMetadata(t *testing.T) {
+	r := NewRegistry()
+	require.NoError(t, RegisterEvent(r, "orders.placed.v1", orderPlacedV1{},
+		WithSemVer("1.0.0"),
+		WithRequiredMetadata("tenant"),
+	))
+
+	_, _, ok := r.Resolve("orders.placed.v1")
+	require.True(t, ok)
+
+	r.mu.RLock()
+	entry := r.entries["orders.placed.v1"][1]
+	r.mu.RUnlock()
+	assert.Equal(t, "1.0.0", entry.SemVer)
+	assert.Equal(t, []string{"tenant"}, entry.RequiredMetadata)
+}
+
+func TestValidateEventChecksRequiredMetadata(t *testing.T) {
+	r := NewRegistry()
+	require.NoError(t, RegisterEvent(r, "orders.placed.v1", orderPlacedV1{}, WithRequiredMetadata("tenant")))
+
+	ev := &events.Event{Type: "orders.placed.v1", Payload: orderPlacedV1{OrderID: "o-1"}}
+	err := r.ValidateEvent(ev)
+	assert.ErrorContains(t, err, "tenant")
+
+	ev.Metadata = map[string]any{"tenant": "acme"}
+	assert.NoError(t, r.ValidateEvent(ev))
+}
+
+func TestValidateEventChecksRequiredTag(t *testing.T) {
+	r := NewRegistry()
+	require.NoError(t, RegisterEvent(r, "orders.placed.v1", orderPlacedV1{}))
+
+	ev := &events.Event{Type: "orders.placed.v1", Payload: orderPlacedV1{}}
+	err := r.ValidateEvent(ev)
+	assert.ErrorContains(t, err, "order_id")
+}
+
+func TestValidateEventResolvesAlias(t *testing.T) {
+	r := NewRegistry()
+	require.NoError(t, RegisterEvent(r, "orders.placed.v1", orderPlacedV1{}))
+	require.NoError(t, r.RegisterAlias("ORDER_PLACED", "orders.placed.v1"))
+
+	ev := &events.Event{Type: "ORDER_PLACED", Payload: orderPlacedV1{OrderID: "o-1"}}
+	assert.NoError(t, r.ValidateEvent(ev))
+}
+
+func TestValidateEventRejectsUnregisteredType(t *testing.T) {
+	r := NewRegistry()
+	err := r.ValidateEvent(&events.Event{Type: "unknown.v1"})
+	assert.Error(t, err)
+}
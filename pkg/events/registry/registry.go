@@ -0,0 +1,183 @@
+package registry
+
+import (
+	"encoding/json"
+	"fmt"
+	"reflect"
+	"sync"
+
+	"github.com/MalteBoehm/tall-affiliate-common/pkg/events"
+)
+
+// Entry binds one version of an event type's payload to its generated
+// Schema and, for versions after the first, the migration that produces it
+// from the previous version's payload.
+type Entry struct {
+	PayloadType reflect.Type
+	Version     int
+	Schema      *Schema
+	Migrate     func(prev any) (any, error)
+
+	// SemVer and RequiredMetadata are populated by RegisterEvent, not by
+	// Register/RegisterV2/RegisterVersion - they're left zero for entries
+	// registered the original way.
+	SemVer           string
+	RequiredMetadata []string
+}
+
+// Registry binds EVENT_* constants to versioned payload types.
+type Registry struct {
+	mu      sync.RWMutex
+	entries map[string]map[int]Entry
+	latest  map[string]int
+	aliases map[string]string
+}
+
+// NewRegistry creates an empty Registry.
+func NewRegistry() *Registry {
+	return &Registry{
+		entries: make(map[string]map[int]Entry),
+		latest:  make(map[string]int),
+		aliases: make(map[string]string),
+	}
+}
+
+// Register binds eventType's version-1 payload type, generating its Schema
+// via reflection over payload's struct tags. payload should be a zero value
+// of the payload type, e.g. events.NewProductDetectedPayload{}.
+func (r *Registry) Register(eventType string, payload any) {
+	r.register(eventType, 1, payload, nil)
+}
+
+// RegisterV2 binds eventType's version-2 successor payload type and the
+// migration that upgrades a version-1 payload to it - e.g. for
+// ProductEnrichedData gaining a required variant_group_id field - without
+// breaking publishers still emitting version 1.
+func (r *Registry) RegisterV2(eventType string, payload any, migrate func(prev any) (any, error)) {
+	r.register(eventType, 2, payload, migrate)
+}
+
+// RegisterVersion binds an arbitrary version (>= 1) and its migration from
+// the previous version, for payloads that evolve past V2.
+func (r *Registry) RegisterVersion(eventType string, version int, payload any, migrate func(prev any) (any, error)) {
+	r.register(eventType, version, payload, migrate)
+}
+
+func (r *Registry) register(eventType string, version int, payload any, migrate func(prev any) (any, error)) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	payloadType := reflect.TypeOf(payload)
+	entry := Entry{
+		PayloadType: payloadType,
+		Version:     version,
+		Schema:      GenerateSchema(payloadType),
+		Migrate:     migrate,
+	}
+
+	if r.entries[eventType] == nil {
+		r.entries[eventType] = make(map[int]Entry)
+	}
+	r.entries[eventType][version] = entry
+
+	if version > r.latest[eventType] {
+		r.latest[eventType] = version
+	}
+}
+
+// Decode validates raw against eventType's current (highest registered)
+// version's Schema and unmarshals it into a fresh instance of that
+// version's payload type.
+func (r *Registry) Decode(eventType string, raw json.RawMessage) (any, error) {
+	r.mu.RLock()
+	entry, ok := r.currentEntryLocked(eventType)
+	r.mu.RUnlock()
+	if !ok {
+		return nil, fmt.Errorf("registry: event type %q is not registered", eventType)
+	}
+
+	if err := Validate(entry.Schema, raw); err != nil {
+		return nil, fmt.Errorf("registry: %s: %w", eventType, err)
+	}
+
+	payload := reflect.New(entry.PayloadType).Interface()
+	if err := json.Unmarshal(raw, payload); err != nil {
+		return nil, fmt.Errorf("registry: decode %s: %w", eventType, err)
+	}
+	return payload, nil
+}
+
+// Upgrade walks evt's payload through registered Migrate functions from its
+// declared schema version - evt.Metadata["schema_version"], defaulting to 1
+// when absent - up to the latest registered version for evt.Type. evt is
+// left unmodified; Upgrade returns a new *Event carrying the upgraded
+// payload and updated schema_version metadata, or evt itself if it is
+// already at the latest version.
+func (r *Registry) Upgrade(evt *events.Event) (*events.Event, error) {
+	r.mu.RLock()
+	latest := r.latest[evt.Type]
+	versions := r.entries[evt.Type]
+	r.mu.RUnlock()
+
+	if latest == 0 {
+		return nil, fmt.Errorf("registry: event type %q is not registered", evt.Type)
+	}
+
+	current := declaredVersion(evt)
+	if current > latest {
+		return nil, fmt.Errorf("registry: %s declares schema version %d, newer than the latest registered version %d", evt.Type, current, latest)
+	}
+	if current == latest {
+		return evt, nil
+	}
+
+	payload := evt.Payload
+	for v := current + 1; v <= latest; v++ {
+		entry, ok := versions[v]
+		if !ok || entry.Migrate == nil {
+			return nil, fmt.Errorf("registry: no migration registered from version %d to %d for %q", v-1, v, evt.Type)
+		}
+		upgraded, err := entry.Migrate(payload)
+		if err != nil {
+			return nil, fmt.Errorf("registry: migrate %s v%d->v%d: %w", evt.Type, v-1, v, err)
+		}
+		payload = upgraded
+	}
+
+	upgraded := *evt
+	upgraded.Payload = payload
+	upgraded.Metadata = cloneMetadata(evt.Metadata)
+	upgraded.Metadata["schema_version"] = latest
+	return &upgraded, nil
+}
+
+func (r *Registry) currentEntryLocked(eventType string) (Entry, bool) {
+	latest, ok := r.latest[eventType]
+	if !ok {
+		return Entry{}, false
+	}
+	entry, ok := r.entries[eventType][latest]
+	return entry, ok
+}
+
+func declaredVersion(evt *events.Event) int {
+	if evt.Metadata == nil {
+		return 1
+	}
+	switch v := evt.Metadata["schema_version"].(type) {
+	case int:
+		return v
+	case float64:
+		return int(v)
+	default:
+		return 1
+	}
+}
+
+func cloneMetadata(m map[string]any) map[string]any {
+	out := make(map[string]any, len(m)+1)
+	for k, v := range m {
+		out[k] = v
+	}
+	return out
+}
@@ -0,0 +1,85 @@
+package registry
+
+import (
+	"encoding/json"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/MalteBoehm/tall-affiliate-common/pkg/events"
+)
+
+type greetingV1 struct {
+	Name string `json:"name"`
+}
+
+func TestDecodeValidatesAndUnmarshals(t *testing.T) {
+	r := NewRegistry()
+	r.Register("greeting", greetingV1{})
+
+	decoded, err := r.Decode("greeting", json.RawMessage(`{"name":"ada"}`))
+	require.NoError(t, err)
+	assert.Equal(t, &greetingV1{Name: "ada"}, decoded)
+}
+
+func TestDecodeRejectsUnknownField(t *testing.T) {
+	r := NewRegistry()
+	r.Register("greeting", greetingV1{})
+
+	_, err := r.Decode("greeting", json.RawMessage(`{"name":"ada","extra":1}`))
+	assert.ErrorContains(t, err, "unknown field")
+}
+
+func TestDecodeUnregisteredEventType(t *testing.T) {
+	r := NewRegistry()
+	_, err := r.Decode("missing", json.RawMessage(`{}`))
+	assert.ErrorContains(t, err, "not registered")
+}
+
+func TestUpgradeAppliesMigrationAndLeavesOriginalUnmodified(t *testing.T) {
+	r := NewRegistry()
+	r.Register(events.PRODUCT_ENRICHMENT_COMPLETED_V1, events.ProductEnrichedData{})
+	r.RegisterV2(events.PRODUCT_ENRICHMENT_COMPLETED_V1, ProductEnrichedDataV2{}, migrateProductEnrichedDataV1ToV2)
+
+	original := &events.Event{
+		Type:     events.PRODUCT_ENRICHMENT_COMPLETED_V1,
+		Metadata: map[string]any{"trace_id": "abc"},
+		Payload: events.ProductEnrichedData{
+			ASIN:       "B000123",
+			EnrichedAt: time.Unix(0, 0),
+		},
+	}
+
+	upgraded, err := r.Upgrade(original)
+	require.NoError(t, err)
+
+	v2, ok := upgraded.Payload.(ProductEnrichedDataV2)
+	require.True(t, ok)
+	assert.Equal(t, "B000123", v2.VariantGroupID)
+	assert.Equal(t, 2, upgraded.Metadata["schema_version"])
+
+	assert.Nil(t, original.Metadata["schema_version"])
+	assert.Equal(t, "abc", original.Metadata["trace_id"])
+}
+
+func TestUpgradeNoOpAtLatestVersion(t *testing.T) {
+	r := NewRegistry()
+	r.Register("greeting", greetingV1{})
+
+	evt := &events.Event{Type: "greeting", Payload: greetingV1{Name: "ada"}}
+	upgraded, err := r.Upgrade(evt)
+	require.NoError(t, err)
+	assert.Same(t, evt, upgraded)
+}
+
+func TestUpgradeMissingMigrationPath(t *testing.T) {
+	r := NewRegistry()
+	r.RegisterVersion("greeting", 1, greetingV1{}, nil)
+	r.RegisterVersion("greeting", 2, greetingV1{}, nil)
+
+	evt := &events.Event{Type: "greeting", Payload: greetingV1{Name: "ada"}}
+	_, err := r.Upgrade(evt)
+	assert.ErrorContains(t, err, "no migration registered")
+}
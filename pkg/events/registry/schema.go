@@ -0,0 +1,133 @@
+// Package registry binds pkg/events' EVENT_* constants to their payload
+// types by more than convention: each registration generates a JSON Schema
+// from the payload struct's tags, so Decode can validate incoming data
+// (including rejecting unknown fields) before handing callers a typed
+// value, and successive versions can declare a migration path so an old
+// publisher's payloads still upgrade cleanly for new consumers.
+package registry
+
+import (
+	"encoding/json"
+	"fmt"
+	"reflect"
+	"sort"
+	"strings"
+)
+
+// Schema is the subset of JSON Schema this package generates and validates
+// against: object/array/primitive typing, required fields, and a closed
+// "additionalProperties: false" for structs, so an unexpected field is
+// treated as a schema violation rather than silently ignored.
+type Schema struct {
+	Type                 string             `json:"type"`
+	Properties           map[string]*Schema `json:"properties,omitempty"`
+	Items                *Schema            `json:"items,omitempty"`
+	Required             []string           `json:"required,omitempty"`
+	AdditionalProperties *bool              `json:"additionalProperties,omitempty"`
+}
+
+// GenerateSchema derives a Schema from t's Go type, following the same
+// json.Marshal rules the struct's tags already describe: fields tagged
+// json:"-" are skipped, an omitempty field or pointer field is optional,
+// everything else is required.
+func GenerateSchema(t reflect.Type) *Schema {
+	for t.Kind() == reflect.Ptr {
+		t = t.Elem()
+	}
+
+	switch t.Kind() {
+	case reflect.Struct:
+		return generateStructSchema(t)
+	case reflect.Slice, reflect.Array:
+		return &Schema{Type: "array", Items: GenerateSchema(t.Elem())}
+	case reflect.Map:
+		return &Schema{Type: "object"}
+	case reflect.String:
+		return &Schema{Type: "string"}
+	case reflect.Bool:
+		return &Schema{Type: "boolean"}
+	case reflect.Float32, reflect.Float64:
+		return &Schema{Type: "number"}
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64,
+		reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64:
+		return &Schema{Type: "integer"}
+	default:
+		return &Schema{}
+	}
+}
+
+func generateStructSchema(t reflect.Type) *Schema {
+	closed := false
+	s := &Schema{Type: "object", Properties: map[string]*Schema{}, AdditionalProperties: &closed}
+
+	for i := 0; i < t.NumField(); i++ {
+		field := t.Field(i)
+		if field.PkgPath != "" {
+			continue // unexported
+		}
+
+		name, omitempty, skip := parseJSONTag(field)
+		if skip {
+			continue
+		}
+
+		s.Properties[name] = GenerateSchema(field.Type)
+		if !omitempty && field.Type.Kind() != reflect.Ptr {
+			s.Required = append(s.Required, name)
+		}
+	}
+
+	sort.Strings(s.Required)
+	return s
+}
+
+// parseJSONTag mirrors encoding/json's handling of the "json" struct tag.
+func parseJSONTag(field reflect.StructField) (name string, omitempty bool, skip bool) {
+	tag := field.Tag.Get("json")
+	if tag == "-" {
+		return "", false, true
+	}
+
+	parts := strings.Split(tag, ",")
+	name = parts[0]
+	if name == "" {
+		name = field.Name
+	}
+	for _, opt := range parts[1:] {
+		if opt == "omitempty" {
+			omitempty = true
+		}
+	}
+	return name, omitempty, false
+}
+
+// Validate checks raw (a JSON object) against schema: every required field
+// must be present, and - since Properties is a closed set - no field
+// outside Properties may appear. Non-object schemas aren't validated at
+// this level.
+func Validate(schema *Schema, raw json.RawMessage) error {
+	if schema == nil || schema.Type != "object" {
+		return nil
+	}
+
+	var obj map[string]json.RawMessage
+	if err := json.Unmarshal(raw, &obj); err != nil {
+		return fmt.Errorf("payload is not a JSON object: %w", err)
+	}
+
+	for _, required := range schema.Required {
+		if _, ok := obj[required]; !ok {
+			return fmt.Errorf("missing required field %q", required)
+		}
+	}
+
+	if schema.AdditionalProperties != nil && !*schema.AdditionalProperties {
+		for key := range obj {
+			if _, ok := schema.Properties[key]; !ok {
+				return fmt.Errorf("unknown field %q", key)
+			}
+		}
+	}
+
+	return nil
+}
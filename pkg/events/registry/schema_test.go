@@ -0,0 +1,53 @@
+package registry
+
+import (
+	"encoding/json"
+	"reflect"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+type schemaFixture struct {
+	Required string `json:"required"`
+	Optional string `json:"optional,omitempty"`
+	Pointer  *int   `json:"pointer,omitempty"`
+	Nested   struct {
+		Inner string `json:"inner"`
+	} `json:"nested"`
+	unexported string //nolint:unused
+}
+
+func TestGenerateSchemaRequiredFields(t *testing.T) {
+	schema := GenerateSchema(reflect.TypeOf(schemaFixture{}))
+
+	assert.Equal(t, "object", schema.Type)
+	assert.ElementsMatch(t, []string{"required", "nested"}, schema.Required)
+	assert.NotContains(t, schema.Properties, "unexported")
+	require.NotNil(t, schema.AdditionalProperties)
+	assert.False(t, *schema.AdditionalProperties)
+}
+
+func TestValidateRejectsUnknownField(t *testing.T) {
+	schema := GenerateSchema(reflect.TypeOf(schemaFixture{}))
+	raw := json.RawMessage(`{"required":"x","nested":{"inner":"y"},"surprise":true}`)
+
+	err := Validate(schema, raw)
+	assert.ErrorContains(t, err, `unknown field "surprise"`)
+}
+
+func TestValidateRejectsMissingRequiredField(t *testing.T) {
+	schema := GenerateSchema(reflect.TypeOf(schemaFixture{}))
+	raw := json.RawMessage(`{"nested":{"inner":"y"}}`)
+
+	err := Validate(schema, raw)
+	assert.ErrorContains(t, err, `missing required field "required"`)
+}
+
+func TestValidateAcceptsWellFormedPayload(t *testing.T) {
+	schema := GenerateSchema(reflect.TypeOf(schemaFixture{}))
+	raw := json.RawMessage(`{"required":"x","nested":{"inner":"y"}}`)
+
+	assert.NoError(t, Validate(schema, raw))
+}
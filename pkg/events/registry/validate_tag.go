@@ -0,0 +1,40 @@
+package registry
+
+import (
+	"fmt"
+	"reflect"
+)
+
+// validateRequiredTags checks every field of payload (after dereferencing
+// pointers) tagged `validate:"required"` for its zero value, complementing
+// GenerateSchema's omitempty-derived Schema.Required: the "validate" tag
+// lets a field be required for construction even when it's also
+// json:",omitempty" for backward-compatible decoding of older payloads.
+func validateRequiredTags(payload any) error {
+	v := reflect.ValueOf(payload)
+	for v.Kind() == reflect.Ptr {
+		if v.IsNil() {
+			return nil
+		}
+		v = v.Elem()
+	}
+	if v.Kind() != reflect.Struct {
+		return nil
+	}
+
+	t := v.Type()
+	for i := 0; i < t.NumField(); i++ {
+		field := t.Field(i)
+		if field.PkgPath != "" {
+			continue // unexported
+		}
+		if field.Tag.Get("validate") != "required" {
+			continue
+		}
+		if v.Field(i).IsZero() {
+			name, _, _ := parseJSONTag(field)
+			return fmt.Errorf("registry: field %q is required", name)
+		}
+	}
+	return nil
+}
@@ -0,0 +1,294 @@
+package schema
+
+import (
+	"encoding"
+	"encoding/binary"
+	"encoding/json"
+	"fmt"
+	"math"
+)
+
+// Codec marshals and unmarshals a payload's body for a particular wire
+// format. Codec implementations are intentionally narrow: they operate on
+// the body bytes wrapped by EncodeConfluent/DecodeConfluent, not the full
+// wire envelope.
+type Codec interface {
+	// Name identifies the format, used as Schema.Format when registering.
+	Name() Format
+	Marshal(payload any) ([]byte, error)
+	Unmarshal(data []byte, target any) error
+}
+
+// JSONCodec is the degenerate Codec used when a schema-aware payload still
+// wants plain JSON bodies, e.g. while a service is migrating to Avro.
+type JSONCodec struct{}
+
+// Name implements Codec.
+func (JSONCodec) Name() Format { return "json" }
+
+// Marshal implements Codec.
+func (JSONCodec) Marshal(payload any) ([]byte, error) { return json.Marshal(payload) }
+
+// Unmarshal implements Codec.
+func (JSONCodec) Unmarshal(data []byte, target any) error { return json.Unmarshal(data, target) }
+
+// AvroCodec encodes a payload's fields per an Avro JSON record schema,
+// supporting the primitive types string, long (int64), double (float64) and
+// boolean. Payloads are first round-tripped through JSON into
+// map[string]interface{}, so both structs and maps are accepted; fields are
+// written in schema order.
+type AvroCodec struct {
+	Schema []byte
+}
+
+// Name implements Codec.
+func (AvroCodec) Name() Format { return FormatAvro }
+
+type avroFieldDef struct {
+	Name string `json:"name"`
+	Type string `json:"type"`
+}
+
+func (c AvroCodec) fieldDefs() ([]avroFieldDef, error) {
+	var doc struct {
+		Fields []avroFieldDef `json:"fields"`
+	}
+	if err := json.Unmarshal(c.Schema, &doc); err != nil {
+		return nil, fmt.Errorf("schema: parse avro schema: %w", err)
+	}
+	return doc.Fields, nil
+}
+
+// Marshal implements Codec.
+func (c AvroCodec) Marshal(payload any) ([]byte, error) {
+	fields, err := c.fieldDefs()
+	if err != nil {
+		return nil, err
+	}
+
+	values, err := toFieldMap(payload)
+	if err != nil {
+		return nil, err
+	}
+
+	var buf []byte
+	for _, f := range fields {
+		v, ok := values[f.Name]
+		if !ok {
+			return nil, fmt.Errorf("schema: avro encode: missing required field %q", f.Name)
+		}
+		encoded, err := encodeAvroPrimitive(f.Type, v)
+		if err != nil {
+			return nil, fmt.Errorf("schema: avro encode field %q: %w", f.Name, err)
+		}
+		buf = append(buf, encoded...)
+	}
+	return buf, nil
+}
+
+// Unmarshal implements Codec.
+func (c AvroCodec) Unmarshal(data []byte, target any) error {
+	fields, err := c.fieldDefs()
+	if err != nil {
+		return err
+	}
+
+	values := make(map[string]any, len(fields))
+	for _, f := range fields {
+		v, n, err := decodeAvroPrimitive(f.Type, data)
+		if err != nil {
+			return fmt.Errorf("schema: avro decode field %q: %w", f.Name, err)
+		}
+		values[f.Name] = v
+		data = data[n:]
+	}
+
+	raw, err := json.Marshal(values)
+	if err != nil {
+		return fmt.Errorf("schema: avro decode: %w", err)
+	}
+	return json.Unmarshal(raw, target)
+}
+
+func toFieldMap(payload any) (map[string]any, error) {
+	if m, ok := payload.(map[string]any); ok {
+		return m, nil
+	}
+	raw, err := json.Marshal(payload)
+	if err != nil {
+		return nil, fmt.Errorf("schema: avro encode: %w", err)
+	}
+	var m map[string]any
+	if err := json.Unmarshal(raw, &m); err != nil {
+		return nil, fmt.Errorf("schema: avro encode: payload is not a JSON object: %w", err)
+	}
+	return m, nil
+}
+
+func encodeAvroPrimitive(avroType string, v any) ([]byte, error) {
+	switch avroType {
+	case "string":
+		s, ok := v.(string)
+		if !ok {
+			return nil, fmt.Errorf("expected string, got %T", v)
+		}
+		return appendAvroBytes(nil, []byte(s)), nil
+	case "long", "int":
+		n, ok := toInt64(v)
+		if !ok {
+			return nil, fmt.Errorf("expected integer, got %T", v)
+		}
+		return appendAvroLong(nil, n), nil
+	case "double", "float":
+		f, ok := toFloat64(v)
+		if !ok {
+			return nil, fmt.Errorf("expected number, got %T", v)
+		}
+		buf := make([]byte, 8)
+		binary.LittleEndian.PutUint64(buf, math.Float64bits(f))
+		return buf, nil
+	case "boolean":
+		b, ok := v.(bool)
+		if !ok {
+			return nil, fmt.Errorf("expected bool, got %T", v)
+		}
+		if b {
+			return []byte{1}, nil
+		}
+		return []byte{0}, nil
+	default:
+		return nil, fmt.Errorf("unsupported avro type %q", avroType)
+	}
+}
+
+func decodeAvroPrimitive(avroType string, data []byte) (any, int, error) {
+	switch avroType {
+	case "string":
+		b, n, err := decodeAvroBytes(data)
+		if err != nil {
+			return nil, 0, err
+		}
+		return string(b), n, nil
+	case "long", "int":
+		n, read, err := decodeAvroLong(data)
+		if err != nil {
+			return nil, 0, err
+		}
+		return n, read, nil
+	case "double", "float":
+		if len(data) < 8 {
+			return nil, 0, fmt.Errorf("truncated double")
+		}
+		return math.Float64frombits(binary.LittleEndian.Uint64(data[:8])), 8, nil
+	case "boolean":
+		if len(data) < 1 {
+			return nil, 0, fmt.Errorf("truncated boolean")
+		}
+		return data[0] != 0, 1, nil
+	default:
+		return nil, 0, fmt.Errorf("unsupported avro type %q", avroType)
+	}
+}
+
+func toInt64(v any) (int64, bool) {
+	switch n := v.(type) {
+	case int64:
+		return n, true
+	case int:
+		return int64(n), true
+	case float64:
+		return int64(n), true
+	case json.Number:
+		i, err := n.Int64()
+		return i, err == nil
+	default:
+		return 0, false
+	}
+}
+
+func toFloat64(v any) (float64, bool) {
+	switch n := v.(type) {
+	case float64:
+		return n, true
+	case int64:
+		return float64(n), true
+	case int:
+		return float64(n), true
+	case json.Number:
+		f, err := n.Float64()
+		return f, err == nil
+	default:
+		return 0, false
+	}
+}
+
+// appendAvroLong zigzag-encodes n as an Avro "long" (variable-length zigzag varint).
+func appendAvroLong(buf []byte, n int64) []byte {
+	zigzag := uint64(n<<1) ^ uint64(n>>63)
+	for zigzag >= 0x80 {
+		buf = append(buf, byte(zigzag)|0x80)
+		zigzag >>= 7
+	}
+	return append(buf, byte(zigzag))
+}
+
+func decodeAvroLong(data []byte) (int64, int, error) {
+	var zigzag uint64
+	var shift uint
+	for i, b := range data {
+		zigzag |= uint64(b&0x7f) << shift
+		if b&0x80 == 0 {
+			n := int64(zigzag>>1) ^ -(int64(zigzag) & 1)
+			return n, i + 1, nil
+		}
+		shift += 7
+	}
+	return 0, 0, fmt.Errorf("truncated avro long")
+}
+
+// appendAvroBytes writes an Avro "bytes"/"string" value: a zigzag-encoded
+// length followed by the raw bytes.
+func appendAvroBytes(buf, b []byte) []byte {
+	buf = appendAvroLong(buf, int64(len(b)))
+	return append(buf, b...)
+}
+
+func decodeAvroBytes(data []byte) ([]byte, int, error) {
+	length, n, err := decodeAvroLong(data)
+	if err != nil {
+		return nil, 0, err
+	}
+	data = data[n:]
+	if int64(len(data)) < length || length < 0 {
+		return nil, 0, fmt.Errorf("truncated avro bytes")
+	}
+	return data[:length], n + int(length), nil
+}
+
+// ProtobufCodec serializes a payload by delegating to the standard
+// encoding.BinaryMarshaler/encoding.BinaryUnmarshaler interfaces, which is
+// what generated protobuf message types implement. It does not itself
+// understand .proto descriptors; pass a Schema.Raw describing the message
+// for documentation/registry purposes only.
+type ProtobufCodec struct{}
+
+// Name implements Codec.
+func (ProtobufCodec) Name() Format { return FormatProtobuf }
+
+// Marshal implements Codec.
+func (ProtobufCodec) Marshal(payload any) ([]byte, error) {
+	m, ok := payload.(encoding.BinaryMarshaler)
+	if !ok {
+		return nil, fmt.Errorf("schema: protobuf codec requires payload to implement encoding.BinaryMarshaler, got %T", payload)
+	}
+	return m.MarshalBinary()
+}
+
+// Unmarshal implements Codec.
+func (ProtobufCodec) Unmarshal(data []byte, target any) error {
+	u, ok := target.(encoding.BinaryUnmarshaler)
+	if !ok {
+		return fmt.Errorf("schema: protobuf codec requires target to implement encoding.BinaryUnmarshaler, got %T", target)
+	}
+	return u.UnmarshalBinary(data)
+}
@@ -0,0 +1,56 @@
+package schema
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestAvroCodecRoundTrip(t *testing.T) {
+	codec := AvroCodec{Schema: []byte(productSchemaV1)}
+
+	body, err := codec.Marshal(map[string]any{"asin": "B001234567", "title": "Test Product"})
+	require.NoError(t, err)
+
+	var out struct {
+		Asin  string `json:"asin"`
+		Title string `json:"title"`
+	}
+	require.NoError(t, codec.Unmarshal(body, &out))
+	assert.Equal(t, "B001234567", out.Asin)
+	assert.Equal(t, "Test Product", out.Title)
+}
+
+func TestAvroCodecMarshalRejectsMissingField(t *testing.T) {
+	codec := AvroCodec{Schema: []byte(productSchemaV1)}
+	_, err := codec.Marshal(map[string]any{"asin": "B001234567"})
+	assert.Error(t, err)
+}
+
+func TestConfluentWireRoundTrip(t *testing.T) {
+	body := []byte("hello")
+	wire := EncodeConfluent(42, body)
+
+	id, decoded, err := DecodeConfluent(wire)
+	require.NoError(t, err)
+	assert.Equal(t, uint32(42), id)
+	assert.Equal(t, body, decoded)
+}
+
+func TestDecodeConfluentRejectsBadMagicByte(t *testing.T) {
+	wire := EncodeConfluent(1, []byte("x"))
+	wire[0] = 0x01
+	_, _, err := DecodeConfluent(wire)
+	assert.Error(t, err)
+}
+
+func TestJSONCodecRoundTrip(t *testing.T) {
+	codec := JSONCodec{}
+	body, err := codec.Marshal(map[string]string{"asin": "B001"})
+	require.NoError(t, err)
+
+	var out map[string]string
+	require.NoError(t, codec.Unmarshal(body, &out))
+	assert.Equal(t, "B001", out["asin"])
+}
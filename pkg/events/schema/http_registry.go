@@ -0,0 +1,160 @@
+package schema
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"strconv"
+	"strings"
+)
+
+// HTTPRegistry is a SchemaRegistry backed by a Confluent-compatible schema
+// registry REST endpoint. It only depends on the standard library.
+type HTTPRegistry struct {
+	// BaseURL is the registry's root, e.g. "https://schema-registry:8081".
+	BaseURL string
+	// Subject is used as the registry subject name; when empty, Register and
+	// Compatible derive it as eventType + "-value", matching Confluent's
+	// TopicNameStrategy for Kafka value schemas.
+	Subject    string
+	HTTPClient *http.Client
+}
+
+// NewHTTPRegistry creates an HTTPRegistry rooted at baseURL.
+func NewHTTPRegistry(baseURL string) *HTTPRegistry {
+	return &HTTPRegistry{BaseURL: strings.TrimSuffix(baseURL, "/")}
+}
+
+func (r *HTTPRegistry) client() *http.Client {
+	if r.HTTPClient != nil {
+		return r.HTTPClient
+	}
+	return http.DefaultClient
+}
+
+func (r *HTTPRegistry) subjectFor(eventType string) string {
+	if r.Subject != "" {
+		return r.Subject
+	}
+	return eventType + "-value"
+}
+
+type registerRequestBody struct {
+	Schema     string `json:"schema"`
+	SchemaType string `json:"schemaType,omitempty"`
+}
+
+type registerResponseBody struct {
+	ID uint32 `json:"id"`
+}
+
+// Register implements SchemaRegistry against POST /subjects/{subject}/versions.
+func (r *HTTPRegistry) Register(eventType string, version int, format Format, newSchema []byte) (uint32, error) {
+	body, err := json.Marshal(registerRequestBody{Schema: string(newSchema), SchemaType: schemaTypeFor(format)})
+	if err != nil {
+		return 0, &RegistryError{Operation: "register", Err: err}
+	}
+
+	url := fmt.Sprintf("%s/subjects/%s/versions", r.BaseURL, r.subjectFor(eventType))
+	var resp registerResponseBody
+	if err := r.doJSON(context.Background(), http.MethodPost, url, body, &resp); err != nil {
+		return 0, err
+	}
+	return resp.ID, nil
+}
+
+type schemaByIDResponse struct {
+	Schema     string `json:"schema"`
+	SchemaType string `json:"schemaType"`
+}
+
+// Lookup implements SchemaRegistry against GET /schemas/ids/{id}.
+func (r *HTTPRegistry) Lookup(id uint32) (Schema, error) {
+	url := fmt.Sprintf("%s/schemas/ids/%s", r.BaseURL, strconv.FormatUint(uint64(id), 10))
+	var resp schemaByIDResponse
+	if err := r.doJSON(context.Background(), http.MethodGet, url, nil, &resp); err != nil {
+		return Schema{}, err
+	}
+	return Schema{ID: id, Format: formatFor(resp.SchemaType), Raw: []byte(resp.Schema)}, nil
+}
+
+type compatibilityResponse struct {
+	IsCompatible bool `json:"is_compatible"`
+}
+
+// Compatible implements SchemaRegistry against
+// POST /compatibility/subjects/{subject}/versions/latest.
+func (r *HTTPRegistry) Compatible(eventType string, format Format, newSchema []byte) error {
+	body, err := json.Marshal(registerRequestBody{Schema: string(newSchema), SchemaType: schemaTypeFor(format)})
+	if err != nil {
+		return &RegistryError{Operation: "compatibility", Err: err}
+	}
+
+	url := fmt.Sprintf("%s/compatibility/subjects/%s/versions/latest", r.BaseURL, r.subjectFor(eventType))
+	var resp compatibilityResponse
+	if err := r.doJSON(context.Background(), http.MethodPost, url, body, &resp); err != nil {
+		return err
+	}
+	if !resp.IsCompatible {
+		return &RegistryError{Operation: "compatibility", Err: fmt.Errorf("registry rejected schema for subject %q as incompatible", r.subjectFor(eventType))}
+	}
+	return nil
+}
+
+func (r *HTTPRegistry) doJSON(ctx context.Context, method, url string, body []byte, out any) error {
+	var reader io.Reader
+	if body != nil {
+		reader = bytes.NewReader(body)
+	}
+	req, err := http.NewRequestWithContext(ctx, method, url, reader)
+	if err != nil {
+		return &RegistryError{Operation: "http", Err: err}
+	}
+	req.Header.Set("Content-Type", "application/vnd.schemaregistry.v1+json")
+	req.Header.Set("Accept", "application/vnd.schemaregistry.v1+json")
+
+	resp, err := r.client().Do(req)
+	if err != nil {
+		return &RegistryError{Operation: "http", Err: err}
+	}
+	defer resp.Body.Close()
+
+	data, err := io.ReadAll(resp.Body)
+	if err != nil {
+		return &RegistryError{Operation: "http", Err: err}
+	}
+	if resp.StatusCode >= 300 {
+		return &RegistryError{Operation: "http", Err: fmt.Errorf("registry request failed with status %d: %s", resp.StatusCode, data)}
+	}
+	if out == nil {
+		return nil
+	}
+	if err := json.Unmarshal(data, out); err != nil {
+		return &RegistryError{Operation: "http", Err: fmt.Errorf("decode registry response: %w", err)}
+	}
+	return nil
+}
+
+func schemaTypeFor(format Format) string {
+	switch format {
+	case FormatProtobuf:
+		return "PROTOBUF"
+	case FormatAvro:
+		return "AVRO"
+	default:
+		return "AVRO"
+	}
+}
+
+func formatFor(schemaType string) Format {
+	if strings.EqualFold(schemaType, "PROTOBUF") {
+		return FormatProtobuf
+	}
+	return FormatAvro
+}
+
+var _ SchemaRegistry = (*HTTPRegistry)(nil)
+var _ SchemaRegistry = (*InMemoryRegistry)(nil)
@@ -0,0 +1,232 @@
+// Package schema provides a Confluent-style schema registry and wire codec
+// for event payloads, so services can evolve ProductEnrichedData and similar
+// payloads without silently breaking consumers on the other end of a stream.
+package schema
+
+import (
+	"encoding/json"
+	"fmt"
+	"sync"
+)
+
+// Format identifies the serialization a Schema's body describes.
+type Format string
+
+const (
+	// FormatAvro indicates Raw is an Avro JSON schema document.
+	FormatAvro Format = "avro"
+	// FormatProtobuf indicates Raw is a protobuf .proto-derived descriptor.
+	FormatProtobuf Format = "protobuf"
+)
+
+// Compatibility selects which evolution rule Register enforces against the
+// latest previously registered version for an event type.
+type Compatibility string
+
+const (
+	// CompatibilityNone performs no check; any schema is accepted.
+	CompatibilityNone Compatibility = "NONE"
+	// CompatibilityBackward requires that a consumer using the new schema can
+	// read data written with the previous schema (fields may be added only if
+	// they carry a default, and existing fields may not be removed).
+	CompatibilityBackward Compatibility = "BACKWARD"
+	// CompatibilityForward requires that a consumer using the previous schema
+	// can still read data written with the new schema.
+	CompatibilityForward Compatibility = "FORWARD"
+	// CompatibilityFull enforces both BACKWARD and FORWARD.
+	CompatibilityFull Compatibility = "FULL"
+)
+
+// Schema is a single registered version of an event type's payload schema.
+type Schema struct {
+	ID        uint32 `json:"id"`
+	EventType string `json:"event_type"`
+	Version   int    `json:"version"`
+	Format    Format `json:"format"`
+	Raw       []byte `json:"raw"`
+}
+
+// RegistryError wraps a registry operation failure, mirroring outbox.PayloadError.
+type RegistryError struct {
+	Operation string
+	Err       error
+}
+
+func (e *RegistryError) Error() string {
+	return fmt.Sprintf("schema registry %s error: %v", e.Operation, e.Err)
+}
+
+func (e *RegistryError) Unwrap() error { return e.Err }
+
+// SchemaRegistry registers and looks up versioned event payload schemas.
+type SchemaRegistry interface {
+	// Register validates newSchema against the registry's compatibility rule
+	// for eventType and, if compatible, stores it as the next version,
+	// returning its assigned ID.
+	Register(eventType string, version int, format Format, newSchema []byte) (id uint32, err error)
+	// Lookup returns the schema previously registered under id.
+	Lookup(id uint32) (Schema, error)
+	// Compatible reports whether newSchema would be accepted by Register for
+	// eventType without actually registering it.
+	Compatible(eventType string, format Format, newSchema []byte) error
+}
+
+// InMemoryRegistry is a SchemaRegistry backed by in-process maps. It is safe
+// for concurrent use and is intended for tests and single-process services.
+type InMemoryRegistry struct {
+	mu            sync.RWMutex
+	compatibility Compatibility
+	nextID        uint32
+	byID          map[uint32]Schema
+	byEventType   map[string][]Schema // ordered oldest to newest
+}
+
+// NewInMemoryRegistry creates a registry enforcing compatibility on Register.
+// An empty compatibility defaults to CompatibilityBackward.
+func NewInMemoryRegistry(compatibility Compatibility) *InMemoryRegistry {
+	if compatibility == "" {
+		compatibility = CompatibilityBackward
+	}
+	return &InMemoryRegistry{
+		compatibility: compatibility,
+		byID:          make(map[uint32]Schema),
+		byEventType:   make(map[string][]Schema),
+	}
+}
+
+// Register implements SchemaRegistry.
+func (r *InMemoryRegistry) Register(eventType string, version int, format Format, newSchema []byte) (uint32, error) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	if err := r.compatibleLocked(eventType, format, newSchema); err != nil {
+		return 0, err
+	}
+
+	r.nextID++
+	id := r.nextID
+	s := Schema{ID: id, EventType: eventType, Version: version, Format: format, Raw: newSchema}
+	r.byID[id] = s
+	r.byEventType[eventType] = append(r.byEventType[eventType], s)
+	return id, nil
+}
+
+// Lookup implements SchemaRegistry.
+func (r *InMemoryRegistry) Lookup(id uint32) (Schema, error) {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+
+	s, ok := r.byID[id]
+	if !ok {
+		return Schema{}, &RegistryError{Operation: "lookup", Err: fmt.Errorf("unknown schema id %d", id)}
+	}
+	return s, nil
+}
+
+// Compatible implements SchemaRegistry.
+func (r *InMemoryRegistry) Compatible(eventType string, format Format, newSchema []byte) error {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+	return r.compatibleLocked(eventType, format, newSchema)
+}
+
+func (r *InMemoryRegistry) compatibleLocked(eventType string, format Format, newSchema []byte) error {
+	if r.compatibility == CompatibilityNone {
+		return nil
+	}
+
+	versions := r.byEventType[eventType]
+	if len(versions) == 0 {
+		return nil
+	}
+	previous := versions[len(versions)-1]
+	if previous.Format != format {
+		return &RegistryError{
+			Operation: "compatibility",
+			Err:       fmt.Errorf("event type %q already registered with format %q, got %q", eventType, previous.Format, format),
+		}
+	}
+
+	if format != FormatAvro {
+		// Field-level compatibility analysis below only understands Avro's
+		// JSON schema shape; other formats are accepted as-is once the
+		// format check above passes.
+		return nil
+	}
+
+	oldFields, err := avroFields(previous.Raw)
+	if err != nil {
+		return &RegistryError{Operation: "compatibility", Err: fmt.Errorf("parse previous schema: %w", err)}
+	}
+	newFields, err := avroFields(newSchema)
+	if err != nil {
+		return &RegistryError{Operation: "compatibility", Err: fmt.Errorf("parse new schema: %w", err)}
+	}
+
+	if r.compatibility == CompatibilityBackward || r.compatibility == CompatibilityFull {
+		if err := checkBackward(oldFields, newFields); err != nil {
+			return &RegistryError{Operation: "compatibility", Err: err}
+		}
+	}
+	if r.compatibility == CompatibilityForward || r.compatibility == CompatibilityFull {
+		if err := checkBackward(newFields, oldFields); err != nil {
+			return &RegistryError{Operation: "compatibility", Err: fmt.Errorf("forward: %w", err)}
+		}
+	}
+	return nil
+}
+
+// avroField is the subset of an Avro record field definition needed for
+// compatibility checks.
+type avroField struct {
+	Name        string          `json:"name"`
+	HasDefault  bool            `json:"-"`
+	DefaultJSON json.RawMessage `json:"default"`
+}
+
+// avroFields parses an Avro JSON record schema's top-level fields.
+func avroFields(raw []byte) (map[string]avroField, error) {
+	var doc struct {
+		Fields []json.RawMessage `json:"fields"`
+	}
+	if err := json.Unmarshal(raw, &doc); err != nil {
+		return nil, err
+	}
+
+	fields := make(map[string]avroField, len(doc.Fields))
+	for _, rf := range doc.Fields {
+		var f avroField
+		if err := json.Unmarshal(rf, &f); err != nil {
+			return nil, err
+		}
+		var probe map[string]json.RawMessage
+		if err := json.Unmarshal(rf, &probe); err != nil {
+			return nil, err
+		}
+		_, f.HasDefault = probe["default"]
+		fields[f.Name] = f
+	}
+	return fields, nil
+}
+
+// checkBackward verifies that a reader using readerFields can consume data
+// written with writerFields: every field the writer does not have must carry
+// a default on the reader's side, and the reader must still understand every
+// field actually present.
+func checkBackward(writerFields, readerFields map[string]avroField) error {
+	for name := range writerFields {
+		if _, ok := readerFields[name]; ok {
+			continue
+		}
+		return fmt.Errorf("field %q was removed without the reader schema defaulting it", name)
+	}
+	for name, rf := range readerFields {
+		if _, ok := writerFields[name]; ok {
+			continue
+		}
+		if !rf.HasDefault {
+			return fmt.Errorf("new field %q has no default and is absent from data written with the previous schema", name)
+		}
+	}
+	return nil
+}
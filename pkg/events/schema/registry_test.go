@@ -0,0 +1,90 @@
+package schema
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+const productSchemaV1 = `{
+	"type": "record",
+	"name": "ProductEnriched",
+	"fields": [
+		{"name": "asin", "type": "string"},
+		{"name": "title", "type": "string"}
+	]
+}`
+
+const productSchemaV2Compatible = `{
+	"type": "record",
+	"name": "ProductEnriched",
+	"fields": [
+		{"name": "asin", "type": "string"},
+		{"name": "title", "type": "string"},
+		{"name": "brand", "type": "string", "default": "unknown"}
+	]
+}`
+
+const productSchemaV2Breaking = `{
+	"type": "record",
+	"name": "ProductEnriched",
+	"fields": [
+		{"name": "asin", "type": "string"}
+	]
+}`
+
+func TestInMemoryRegistryRegisterAndLookup(t *testing.T) {
+	r := NewInMemoryRegistry(CompatibilityNone)
+
+	id, err := r.Register("catalog.product.enriched.v1", 1, FormatAvro, []byte(productSchemaV1))
+	require.NoError(t, err)
+	assert.NotZero(t, id)
+
+	got, err := r.Lookup(id)
+	require.NoError(t, err)
+	assert.Equal(t, "catalog.product.enriched.v1", got.EventType)
+	assert.Equal(t, FormatAvro, got.Format)
+}
+
+func TestInMemoryRegistryLookupUnknownIDFails(t *testing.T) {
+	r := NewInMemoryRegistry(CompatibilityNone)
+	_, err := r.Lookup(999)
+	require.Error(t, err)
+	var regErr *RegistryError
+	require.ErrorAs(t, err, &regErr)
+	assert.Equal(t, "lookup", regErr.Operation)
+}
+
+func TestInMemoryRegistryBackwardCompatibilityAllowsDefaultedAddition(t *testing.T) {
+	r := NewInMemoryRegistry(CompatibilityBackward)
+
+	_, err := r.Register("catalog.product.enriched.v1", 1, FormatAvro, []byte(productSchemaV1))
+	require.NoError(t, err)
+
+	_, err = r.Register("catalog.product.enriched.v1", 2, FormatAvro, []byte(productSchemaV2Compatible))
+	assert.NoError(t, err)
+}
+
+func TestInMemoryRegistryBackwardCompatibilityRejectsFieldRemoval(t *testing.T) {
+	r := NewInMemoryRegistry(CompatibilityBackward)
+
+	_, err := r.Register("catalog.product.enriched.v1", 1, FormatAvro, []byte(productSchemaV1))
+	require.NoError(t, err)
+
+	_, err = r.Register("catalog.product.enriched.v1", 2, FormatAvro, []byte(productSchemaV2Breaking))
+	require.Error(t, err)
+	var regErr *RegistryError
+	require.ErrorAs(t, err, &regErr)
+	assert.Equal(t, "compatibility", regErr.Operation)
+}
+
+func TestInMemoryRegistryCompatibleDoesNotRegister(t *testing.T) {
+	r := NewInMemoryRegistry(CompatibilityBackward)
+
+	_, err := r.Register("catalog.product.enriched.v1", 1, FormatAvro, []byte(productSchemaV1))
+	require.NoError(t, err)
+
+	require.NoError(t, r.Compatible("catalog.product.enriched.v1", FormatAvro, []byte(productSchemaV2Compatible)))
+	require.Error(t, r.Compatible("catalog.product.enriched.v1", FormatAvro, []byte(productSchemaV2Breaking)))
+}
@@ -0,0 +1,36 @@
+package schema
+
+import (
+	"encoding/binary"
+	"fmt"
+)
+
+// ConfluentMagicByte is the leading byte of the Confluent wire format.
+const ConfluentMagicByte byte = 0x00
+
+// confluentHeaderSize is the magic byte plus the 4-byte big-endian schema ID.
+const confluentHeaderSize = 5
+
+// EncodeConfluent prepends the Confluent wire-format header (magic byte +
+// 4-byte big-endian schema ID) to body.
+func EncodeConfluent(schemaID uint32, body []byte) []byte {
+	out := make([]byte, confluentHeaderSize+len(body))
+	out[0] = ConfluentMagicByte
+	binary.BigEndian.PutUint32(out[1:5], schemaID)
+	copy(out[5:], body)
+	return out
+}
+
+// DecodeConfluent splits data produced by EncodeConfluent back into its
+// schema ID and body.
+func DecodeConfluent(data []byte) (schemaID uint32, body []byte, err error) {
+	if len(data) < confluentHeaderSize {
+		return 0, nil, fmt.Errorf("schema: wire data too short for confluent header: %d bytes", len(data))
+	}
+	if data[0] != ConfluentMagicByte {
+		return 0, nil, fmt.Errorf("schema: unexpected magic byte 0x%02x", data[0])
+	}
+	schemaID = binary.BigEndian.Uint32(data[1:5])
+	body = data[confluentHeaderSize:]
+	return schemaID, body, nil
+}
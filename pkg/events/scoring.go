@@ -0,0 +1,32 @@
+package events
+
+import "time"
+
+// CatalogProductScoredV1 is the canonical CloudEvents type emitted whenever a
+// product's tall-friendly score changes materially.
+const CatalogProductScoredV1 = "catalog.product.scored.v1"
+
+// ProductScoredPayload represents the payload for a CatalogProductScoredV1 event.
+type ProductScoredPayload struct {
+	ASIN          string    `json:"asin"`
+	ProductID     string    `json:"product_id"`
+	Score         float64   `json:"score"`
+	PreviousScore float64   `json:"previous_score"`
+	Version       string    `json:"version"`
+	ScoredAt      time.Time `json:"scored_at"`
+}
+
+// NewProductScoredEvent creates a new catalog.product.scored.v1 event.
+func NewProductScoredEvent(asin, productID string, score, previousScore float64, version string) *Event {
+	payload := ProductScoredPayload{
+		ASIN:          asin,
+		ProductID:     productID,
+		Score:         score,
+		PreviousScore: previousScore,
+		Version:       version,
+		ScoredAt:      time.Now().UTC(),
+	}
+
+	event, _ := NewEvent(CatalogProductScoredV1, "catalog.product", productID, payload)
+	return event
+}
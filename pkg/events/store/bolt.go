@@ -0,0 +1,208 @@
+package store
+
+import (
+	"context"
+	"encoding/binary"
+	"encoding/json"
+	"fmt"
+	"time"
+
+	bolt "go.etcd.io/bbolt"
+
+	"github.com/MalteBoehm/tall-affiliate-common/pkg/events"
+)
+
+var (
+	eventsBucket    = []byte("events")
+	idsBucket       = []byte("ids")
+	sequencesBucket = []byte("sequences")
+	byTimeBucket    = []byte("by_time")
+)
+
+// BoltStore is a Store backed by a single BoltDB file, for services that
+// want an embedded event log without a Postgres dependency (e.g. a CLI or
+// a sidecar rebuilding a local Projection). It keeps four buckets: the
+// event records themselves, keyed by aggregate and sequence; an id-to-key
+// index enforcing Append's idempotency; a per-aggregate sequence counter;
+// and an occurred-time (event Timestamp) index that Replay scans.
+type BoltStore struct {
+	db *bolt.DB
+}
+
+// OpenBoltStore opens (creating if necessary) a BoltDB file at path and
+// prepares its buckets.
+func OpenBoltStore(path string) (*BoltStore, error) {
+	db, err := bolt.Open(path, 0o600, &bolt.Options{Timeout: time.Second})
+	if err != nil {
+		return nil, fmt.Errorf("store: open bolt db: %w", err)
+	}
+
+	err = db.Update(func(tx *bolt.Tx) error {
+		for _, name := range [][]byte{eventsBucket, idsBucket, sequencesBucket, byTimeBucket} {
+			if _, err := tx.CreateBucketIfNotExists(name); err != nil {
+				return err
+			}
+		}
+		return nil
+	})
+	if err != nil {
+		db.Close()
+		return nil, fmt.Errorf("store: init bolt buckets: %w", err)
+	}
+
+	return &BoltStore{db: db}, nil
+}
+
+// Close closes the underlying BoltDB file.
+func (s *BoltStore) Close() error {
+	return s.db.Close()
+}
+
+type boltRecord struct {
+	ID            string          `json:"id"`
+	Type          string          `json:"type"`
+	AggregateType string          `json:"aggregate_type"`
+	AggregateID   string          `json:"aggregate_id"`
+	Payload       json.RawMessage `json:"payload"`
+	Metadata      map[string]any  `json:"metadata,omitempty"`
+	OccurredAt    time.Time       `json:"occurred_at"`
+	RecordedAt    time.Time       `json:"recorded_at"`
+}
+
+func eventKey(aggregateType, aggregateID string, sequence uint64) []byte {
+	return []byte(fmt.Sprintf("%s\x00%s\x00%020d", aggregateType, aggregateID, sequence))
+}
+
+func sequenceKey(aggregateType, aggregateID string) []byte {
+	return []byte(aggregateType + "\x00" + aggregateID)
+}
+
+// timeKeyLayout has a fixed-width fraction so that byTimeBucket keys sort
+// lexically in time order (RFC3339Nano trims trailing zeros and does not).
+const timeKeyLayout = "2006-01-02T15:04:05.000000000Z07:00"
+
+func timeKey(occurredAt time.Time, id string) []byte {
+	return []byte(occurredAt.UTC().Format(timeKeyLayout) + "\x00" + id)
+}
+
+// Append implements Store.
+func (s *BoltStore) Append(ctx context.Context, evt *events.Event) error {
+	payload, err := json.Marshal(evt.Payload)
+	if err != nil {
+		return fmt.Errorf("store: marshal payload: %w", err)
+	}
+
+	return s.db.Update(func(tx *bolt.Tx) error {
+		ids := tx.Bucket(idsBucket)
+		if ids.Get([]byte(evt.ID)) != nil {
+			return nil // already recorded; Append is idempotent.
+		}
+
+		seqBucket := tx.Bucket(sequencesBucket)
+		seqKey := sequenceKey(evt.AggregateType, evt.AggregateID)
+		sequence := uint64(1)
+		if raw := seqBucket.Get(seqKey); raw != nil {
+			sequence = binary.BigEndian.Uint64(raw) + 1
+		}
+
+		record := boltRecord{
+			ID:            evt.ID,
+			Type:          evt.Type,
+			AggregateType: evt.AggregateType,
+			AggregateID:   evt.AggregateID,
+			Payload:       payload,
+			Metadata:      evt.Metadata,
+			OccurredAt:    evt.Timestamp,
+			RecordedAt:    time.Now().UTC(),
+		}
+		data, err := json.Marshal(record)
+		if err != nil {
+			return fmt.Errorf("store: marshal record: %w", err)
+		}
+
+		key := eventKey(evt.AggregateType, evt.AggregateID, sequence)
+		if err := tx.Bucket(eventsBucket).Put(key, data); err != nil {
+			return err
+		}
+		if err := ids.Put([]byte(evt.ID), key); err != nil {
+			return err
+		}
+		if err := tx.Bucket(byTimeBucket).Put(timeKey(record.OccurredAt, evt.ID), key); err != nil {
+			return err
+		}
+
+		seqValue := make([]byte, 8)
+		binary.BigEndian.PutUint64(seqValue, sequence)
+		return seqBucket.Put(seqKey, seqValue)
+	})
+}
+
+// Load implements Store.
+func (s *BoltStore) Load(ctx context.Context, aggregateID string) ([]*events.Event, error) {
+	var out []*events.Event
+
+	err := s.db.View(func(tx *bolt.Tx) error {
+		c := tx.Bucket(eventsBucket).Cursor()
+		for k, v := c.First(); k != nil; k, v = c.Next() {
+			var record boltRecord
+			if err := json.Unmarshal(v, &record); err != nil {
+				return fmt.Errorf("store: unmarshal record: %w", err)
+			}
+			if record.AggregateID != aggregateID {
+				continue
+			}
+			out = append(out, recordToEvent(record))
+		}
+		return nil
+	})
+	if err != nil {
+		return nil, fmt.Errorf("store: load aggregate %s: %w", aggregateID, err)
+	}
+	return out, nil
+}
+
+// Replay implements Store.
+func (s *BoltStore) Replay(ctx context.Context, since time.Time, filter Filter, handler func(*events.Event) error) error {
+	return s.db.View(func(tx *bolt.Tx) error {
+		events := tx.Bucket(eventsBucket)
+		c := tx.Bucket(byTimeBucket).Cursor()
+
+		prefix := []byte(since.UTC().Format(timeKeyLayout))
+		for k, eventKey := c.Seek(prefix); k != nil; k, eventKey = c.Next() {
+			data := events.Get(eventKey)
+			if data == nil {
+				continue
+			}
+			var record boltRecord
+			if err := json.Unmarshal(data, &record); err != nil {
+				return fmt.Errorf("store: unmarshal record: %w", err)
+			}
+			if !record.OccurredAt.After(since) {
+				continue
+			}
+
+			evt := recordToEvent(record)
+			if !filter.Matches(evt) {
+				continue
+			}
+			if err := handler(evt); err != nil {
+				return err
+			}
+		}
+		return nil
+	})
+}
+
+func recordToEvent(record boltRecord) *events.Event {
+	return &events.Event{
+		ID:            record.ID,
+		Type:          record.Type,
+		AggregateType: record.AggregateType,
+		AggregateID:   record.AggregateID,
+		Payload:       record.Payload,
+		Metadata:      record.Metadata,
+		Timestamp:     record.OccurredAt,
+	}
+}
+
+var _ Store = (*BoltStore)(nil)
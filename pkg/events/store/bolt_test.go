@@ -0,0 +1,98 @@
+package store
+
+import (
+	"context"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/MalteBoehm/tall-affiliate-common/pkg/events"
+)
+
+func openTestBoltStore(t *testing.T) *BoltStore {
+	t.Helper()
+	path := filepath.Join(t.TempDir(), "events.db")
+	s, err := OpenBoltStore(path)
+	require.NoError(t, err)
+	t.Cleanup(func() { s.Close() })
+	return s
+}
+
+func TestBoltStoreAppendAndLoadOrdersBySequence(t *testing.T) {
+	s := openTestBoltStore(t)
+	ctx := context.Background()
+
+	for i := 0; i < 3; i++ {
+		evt, err := events.NewEvent("product.updated", "product", "asin_1", map[string]any{"n": i})
+		require.NoError(t, err)
+		require.NoError(t, s.Append(ctx, evt))
+	}
+
+	loaded, err := s.Load(ctx, "asin_1")
+	require.NoError(t, err)
+	require.Len(t, loaded, 3)
+	for i, evt := range loaded {
+		var payload map[string]any
+		require.NoError(t, evt.UnmarshalPayload(&payload))
+		assert.Equal(t, float64(i), payload["n"])
+	}
+}
+
+func TestBoltStoreAppendIsIdempotent(t *testing.T) {
+	s := openTestBoltStore(t)
+	ctx := context.Background()
+
+	evt, err := events.NewEvent("product.updated", "product", "asin_1", map[string]any{})
+	require.NoError(t, err)
+
+	require.NoError(t, s.Append(ctx, evt))
+	require.NoError(t, s.Append(ctx, evt))
+
+	loaded, err := s.Load(ctx, "asin_1")
+	require.NoError(t, err)
+	assert.Len(t, loaded, 1)
+}
+
+func TestBoltStoreReplayFiltersByEventTypeAndSince(t *testing.T) {
+	s := openTestBoltStore(t)
+	ctx := context.Background()
+
+	matching, err := events.NewEvent("product.updated", "product", "asin_1", map[string]any{})
+	require.NoError(t, err)
+	require.NoError(t, s.Append(ctx, matching))
+
+	other, err := events.NewEvent("product.deleted", "product", "asin_2", map[string]any{})
+	require.NoError(t, err)
+	require.NoError(t, s.Append(ctx, other))
+
+	var seen []string
+	err = s.Replay(ctx, time.Time{}, Filter{EventTypes: []string{"product.updated"}}, func(evt *events.Event) error {
+		seen = append(seen, evt.ID)
+		return nil
+	})
+	require.NoError(t, err)
+	assert.Equal(t, []string{matching.ID}, seen)
+}
+
+func TestBoltStoreReplayStopsOnHandlerError(t *testing.T) {
+	s := openTestBoltStore(t)
+	ctx := context.Background()
+
+	for i := 0; i < 2; i++ {
+		evt, err := events.NewEvent("product.updated", "product", "asin_1", map[string]any{})
+		require.NoError(t, err)
+		require.NoError(t, s.Append(ctx, evt))
+	}
+
+	boom := assert.AnError
+	calls := 0
+	err := s.Replay(ctx, time.Time{}, Filter{}, func(evt *events.Event) error {
+		calls++
+		return boom
+	})
+	assert.ErrorIs(t, err, boom)
+	assert.Equal(t, 1, calls)
+}
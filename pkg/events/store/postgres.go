@@ -0,0 +1,122 @@
+package store
+
+import (
+	"context"
+	"database/sql"
+	"encoding/json"
+	"fmt"
+	"time"
+
+	"github.com/MalteBoehm/tall-affiliate-common/pkg/events"
+)
+
+// PostgresStore is a Store backed by an event_log table:
+//
+//	CREATE TABLE event_log (
+//		id               TEXT PRIMARY KEY,
+//		aggregate_type   TEXT NOT NULL,
+//		aggregate_id     TEXT NOT NULL,
+//		sequence         BIGINT NOT NULL,
+//		event_type       TEXT NOT NULL,
+//		payload          JSONB NOT NULL,
+//		metadata         JSONB NOT NULL,
+//		occurred_at      TIMESTAMPTZ NOT NULL,
+//		recorded_at      TIMESTAMPTZ NOT NULL,
+//		UNIQUE (aggregate_type, aggregate_id, sequence)
+//	);
+//	CREATE INDEX event_log_aggregate_id_idx ON event_log (aggregate_id, sequence);
+//	CREATE INDEX event_log_recorded_at_idx ON event_log (recorded_at);
+type PostgresStore struct {
+	db *sql.DB
+}
+
+// NewPostgresStore creates a PostgresStore using db.
+func NewPostgresStore(db *sql.DB) *PostgresStore {
+	return &PostgresStore{db: db}
+}
+
+// Append implements Store. The next sequence number for evt's aggregate
+// is computed in the same statement as the insert; callers that append
+// concurrently for the same aggregate are expected to serialize those
+// writes themselves (e.g. via the outbox pattern), as this does not take
+// an explicit row lock.
+func (s *PostgresStore) Append(ctx context.Context, evt *events.Event) error {
+	payload, err := json.Marshal(evt.Payload)
+	if err != nil {
+		return fmt.Errorf("store: marshal payload: %w", err)
+	}
+	metadata, err := json.Marshal(evt.Metadata)
+	if err != nil {
+		return fmt.Errorf("store: marshal metadata: %w", err)
+	}
+
+	_, err = s.db.ExecContext(ctx, `
+		INSERT INTO event_log (id, aggregate_type, aggregate_id, sequence, event_type, payload, metadata, occurred_at, recorded_at)
+		SELECT $1, $2, $3, COALESCE(MAX(sequence), 0) + 1, $4, $5, $6, $7, now()
+		FROM event_log WHERE aggregate_type = $2 AND aggregate_id = $3
+		ON CONFLICT (id) DO NOTHING`,
+		evt.ID, evt.AggregateType, evt.AggregateID, evt.Type, payload, metadata, evt.Timestamp)
+	if err != nil {
+		return fmt.Errorf("store: append event %s: %w", evt.ID, err)
+	}
+	return nil
+}
+
+// Load implements Store.
+func (s *PostgresStore) Load(ctx context.Context, aggregateID string) ([]*events.Event, error) {
+	rows, err := s.db.QueryContext(ctx, `
+		SELECT id, aggregate_type, aggregate_id, event_type, payload, metadata, occurred_at
+		FROM event_log WHERE aggregate_id = $1 ORDER BY sequence ASC`, aggregateID)
+	if err != nil {
+		return nil, fmt.Errorf("store: load aggregate %s: %w", aggregateID, err)
+	}
+	defer rows.Close()
+
+	return scanEvents(rows)
+}
+
+// Replay implements Store.
+func (s *PostgresStore) Replay(ctx context.Context, since time.Time, filter Filter, handler func(*events.Event) error) error {
+	rows, err := s.db.QueryContext(ctx, `
+		SELECT id, aggregate_type, aggregate_id, event_type, payload, metadata, occurred_at
+		FROM event_log WHERE occurred_at > $1 ORDER BY occurred_at ASC`, since)
+	if err != nil {
+		return fmt.Errorf("store: replay since %s: %w", since, err)
+	}
+	defer rows.Close()
+
+	evts, err := scanEvents(rows)
+	if err != nil {
+		return err
+	}
+	for _, evt := range evts {
+		if !filter.Matches(evt) {
+			continue
+		}
+		if err := handler(evt); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func scanEvents(rows *sql.Rows) ([]*events.Event, error) {
+	var out []*events.Event
+	for rows.Next() {
+		var evt events.Event
+		var payload, metadata json.RawMessage
+		if err := rows.Scan(&evt.ID, &evt.AggregateType, &evt.AggregateID, &evt.Type, &payload, &metadata, &evt.Timestamp); err != nil {
+			return nil, fmt.Errorf("store: scan event: %w", err)
+		}
+		evt.Payload = payload
+		if len(metadata) > 0 {
+			if err := json.Unmarshal(metadata, &evt.Metadata); err != nil {
+				return nil, fmt.Errorf("store: unmarshal metadata: %w", err)
+			}
+		}
+		out = append(out, &evt)
+	}
+	return out, rows.Err()
+}
+
+var _ Store = (*PostgresStore)(nil)
@@ -0,0 +1,126 @@
+package store
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+	"sync"
+	"time"
+
+	"github.com/MalteBoehm/tall-affiliate-common/pkg/events"
+)
+
+// Projection derives a read-optimized view - e.g. a product_state table
+// built from the phase-01 through phase-14 event stream - from the
+// events a Store has recorded. Name identifies the projection for
+// checkpointing; Filter scopes Run's Replay to the event types the
+// projection cares about.
+type Projection interface {
+	Name() string
+	Filter() Filter
+	Apply(ctx context.Context, evt *events.Event) error
+}
+
+// CheckpointStore tracks, per projection name, the position of the last
+// event that projection successfully applied, so Run can resume after a
+// restart instead of reprocessing the whole log.
+type CheckpointStore interface {
+	Load(ctx context.Context, projectionName string) (time.Time, error)
+	Save(ctx context.Context, projectionName string, position time.Time) error
+}
+
+// Run replays every event in store matching proj's Filter, starting just
+// after checkpoints' saved position for proj (or from the zero time, if
+// none is saved), applying each to proj and advancing the checkpoint
+// after every successful Apply. Run stops and returns the first error
+// either Replay or Apply produces; on the next Run, the projection
+// resumes from the last successfully applied event rather than
+// reprocessing it.
+func Run(ctx context.Context, st Store, checkpoints CheckpointStore, proj Projection) error {
+	position, err := checkpoints.Load(ctx, proj.Name())
+	if err != nil {
+		return fmt.Errorf("store: load checkpoint for %s: %w", proj.Name(), err)
+	}
+
+	return st.Replay(ctx, position, proj.Filter(), func(evt *events.Event) error {
+		if err := proj.Apply(ctx, evt); err != nil {
+			return fmt.Errorf("store: projection %s: apply %s: %w", proj.Name(), evt.ID, err)
+		}
+		return checkpoints.Save(ctx, proj.Name(), evt.Timestamp)
+	})
+}
+
+// InMemoryCheckpointStore is a CheckpointStore for tests and
+// single-process projections that don't need their checkpoint to survive
+// a restart.
+type InMemoryCheckpointStore struct {
+	mu        sync.Mutex
+	positions map[string]time.Time
+}
+
+// NewInMemoryCheckpointStore creates an empty InMemoryCheckpointStore.
+func NewInMemoryCheckpointStore() *InMemoryCheckpointStore {
+	return &InMemoryCheckpointStore{positions: make(map[string]time.Time)}
+}
+
+// Load implements CheckpointStore.
+func (s *InMemoryCheckpointStore) Load(ctx context.Context, projectionName string) (time.Time, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	return s.positions[projectionName], nil
+}
+
+// Save implements CheckpointStore.
+func (s *InMemoryCheckpointStore) Save(ctx context.Context, projectionName string, position time.Time) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.positions[projectionName] = position
+	return nil
+}
+
+var _ CheckpointStore = (*InMemoryCheckpointStore)(nil)
+
+// PostgresCheckpointStore is a CheckpointStore backed by a
+// projection_checkpoints table:
+//
+//	CREATE TABLE projection_checkpoints (
+//		projection_name TEXT PRIMARY KEY,
+//		position        TIMESTAMPTZ NOT NULL
+//	);
+type PostgresCheckpointStore struct {
+	db *sql.DB
+}
+
+// NewPostgresCheckpointStore creates a PostgresCheckpointStore using db.
+func NewPostgresCheckpointStore(db *sql.DB) *PostgresCheckpointStore {
+	return &PostgresCheckpointStore{db: db}
+}
+
+// Load implements CheckpointStore.
+func (s *PostgresCheckpointStore) Load(ctx context.Context, projectionName string) (time.Time, error) {
+	var position time.Time
+	err := s.db.QueryRowContext(ctx,
+		`SELECT position FROM projection_checkpoints WHERE projection_name = $1`, projectionName,
+	).Scan(&position)
+	if err == sql.ErrNoRows {
+		return time.Time{}, nil
+	}
+	if err != nil {
+		return time.Time{}, fmt.Errorf("store: load checkpoint %s: %w", projectionName, err)
+	}
+	return position, nil
+}
+
+// Save implements CheckpointStore.
+func (s *PostgresCheckpointStore) Save(ctx context.Context, projectionName string, position time.Time) error {
+	_, err := s.db.ExecContext(ctx, `
+		INSERT INTO projection_checkpoints (projection_name, position) VALUES ($1, $2)
+		ON CONFLICT (projection_name) DO UPDATE SET position = EXCLUDED.position`,
+		projectionName, position)
+	if err != nil {
+		return fmt.Errorf("store: save checkpoint %s: %w", projectionName, err)
+	}
+	return nil
+}
+
+var _ CheckpointStore = (*PostgresCheckpointStore)(nil)
@@ -0,0 +1,75 @@
+package store
+
+import (
+	"context"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/MalteBoehm/tall-affiliate-common/pkg/events"
+)
+
+type recordingProjection struct {
+	applied []string
+}
+
+func (p *recordingProjection) Name() string { return "recording" }
+func (p *recordingProjection) Filter() Filter {
+	return Filter{EventTypes: []string{"product.updated"}}
+}
+func (p *recordingProjection) Apply(ctx context.Context, evt *events.Event) error {
+	p.applied = append(p.applied, evt.ID)
+	return nil
+}
+
+func TestRunAppliesMatchingEventsAndCheckpoints(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "events.db")
+	s, err := OpenBoltStore(path)
+	require.NoError(t, err)
+	defer s.Close()
+	ctx := context.Background()
+
+	updated, err := events.NewEvent("product.updated", "product", "asin_1", map[string]any{})
+	require.NoError(t, err)
+	require.NoError(t, s.Append(ctx, updated))
+
+	deleted, err := events.NewEvent("product.deleted", "product", "asin_1", map[string]any{})
+	require.NoError(t, err)
+	require.NoError(t, s.Append(ctx, deleted))
+
+	checkpoints := NewInMemoryCheckpointStore()
+	proj := &recordingProjection{}
+
+	require.NoError(t, Run(ctx, s, checkpoints, proj))
+	assert.Equal(t, []string{updated.ID}, proj.applied)
+
+	position, err := checkpoints.Load(ctx, proj.Name())
+	require.NoError(t, err)
+	assert.False(t, position.IsZero())
+}
+
+func TestRunIsResumableAfterCheckpoint(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "events.db")
+	s, err := OpenBoltStore(path)
+	require.NoError(t, err)
+	defer s.Close()
+	ctx := context.Background()
+
+	first, err := events.NewEvent("product.updated", "product", "asin_1", map[string]any{})
+	require.NoError(t, err)
+	require.NoError(t, s.Append(ctx, first))
+
+	checkpoints := NewInMemoryCheckpointStore()
+	proj := &recordingProjection{}
+	require.NoError(t, Run(ctx, s, checkpoints, proj))
+	require.Len(t, proj.applied, 1)
+
+	second, err := events.NewEvent("product.updated", "product", "asin_1", map[string]any{})
+	require.NoError(t, err)
+	require.NoError(t, s.Append(ctx, second))
+
+	require.NoError(t, Run(ctx, s, checkpoints, proj))
+	assert.Equal(t, []string{first.ID, second.ID}, proj.applied)
+}
@@ -0,0 +1,53 @@
+// Package store persists every emitted events.Event to an append-only
+// log, keyed per aggregate so a consumer can load an aggregate's full
+// history or replay the whole log (optionally filtered) from a point in
+// time - e.g. to rebuild a search index or a Projection from scratch.
+package store
+
+import (
+	"context"
+	"time"
+
+	"github.com/MalteBoehm/tall-affiliate-common/pkg/events"
+)
+
+// Store is an append-only, per-aggregate event log. Append is idempotent:
+// appending an event whose ID has already been recorded is a no-op, so
+// callers may safely retry a failed or ambiguous publish.
+type Store interface {
+	// Append records evt, assigning it the next sequence number for its
+	// (AggregateType, AggregateID). Appending an event whose ID already
+	// exists in the log is a no-op.
+	Append(ctx context.Context, evt *events.Event) error
+	// Load returns every event recorded for aggregateID, ordered by
+	// sequence.
+	Load(ctx context.Context, aggregateID string) ([]*events.Event, error)
+	// Replay invokes handler, in chronological order, for every event
+	// whose Timestamp is after since and that matches filter. Replay
+	// stops and returns the first error handler returns.
+	Replay(ctx context.Context, since time.Time, filter Filter, handler func(*events.Event) error) error
+}
+
+// Filter narrows Replay to a subset of the log. A nil or empty slice
+// matches every value for that dimension.
+type Filter struct {
+	AggregateTypes []string
+	EventTypes     []string
+}
+
+// Matches reports whether evt satisfies f.
+func (f Filter) Matches(evt *events.Event) bool {
+	return matchesAny(f.AggregateTypes, evt.AggregateType) && matchesAny(f.EventTypes, evt.Type)
+}
+
+func matchesAny(allowed []string, value string) bool {
+	if len(allowed) == 0 {
+		return true
+	}
+	for _, a := range allowed {
+		if a == value {
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,23 @@
+package store
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/MalteBoehm/tall-affiliate-common/pkg/events"
+)
+
+func TestFilterMatchesEmptyFilterMatchesEverything(t *testing.T) {
+	evt := &events.Event{AggregateType: "product", Type: "product.updated"}
+	assert.True(t, Filter{}.Matches(evt))
+}
+
+func TestFilterMatchesRestrictsByAggregateTypeAndEventType(t *testing.T) {
+	evt := &events.Event{AggregateType: "product", Type: "product.updated"}
+
+	assert.True(t, Filter{AggregateTypes: []string{"product"}}.Matches(evt))
+	assert.False(t, Filter{AggregateTypes: []string{"order"}}.Matches(evt))
+	assert.True(t, Filter{EventTypes: []string{"product.updated"}}.Matches(evt))
+	assert.False(t, Filter{EventTypes: []string{"product.deleted"}}.Matches(evt))
+}
@@ -0,0 +1,47 @@
+package events
+
+import "strings"
+
+// MetadataTenant is the Metadata key usage-metering and quota enforcement
+// (see pkg/metering) read the owning tenant from.
+const MetadataTenant = "tenant"
+
+// TenantExtractor derives a tenant identifier from an event, for callers
+// that don't set Metadata[MetadataTenant] explicitly.
+type TenantExtractor func(evt *Event) string
+
+// DefaultTenantExtractor takes the tenant to be the substring of
+// AggregateID before its first "-" or ":" (e.g. "acme-123" and
+// "acme:product:123" both yield "acme"), or the whole AggregateID if
+// neither separator is present.
+func DefaultTenantExtractor(evt *Event) string {
+	id := evt.AggregateID
+	if i := strings.IndexAny(id, "-:"); i >= 0 {
+		return id[:i]
+	}
+	return id
+}
+
+// Tenant returns evt's tenant: Metadata[MetadataTenant] if set, otherwise
+// extractor(evt). Pass DefaultTenantExtractor to fall back on the
+// AggregateID-prefix convention, or nil to skip extraction and return "".
+func Tenant(evt *Event, extractor TenantExtractor) string {
+	if evt.Metadata != nil {
+		if t, ok := evt.Metadata[MetadataTenant].(string); ok && t != "" {
+			return t
+		}
+	}
+	if extractor == nil {
+		return ""
+	}
+	return extractor(evt)
+}
+
+// SetTenant stamps tenant onto evt.Metadata[MetadataTenant], initializing
+// Metadata if necessary.
+func SetTenant(evt *Event, tenant string) {
+	if evt.Metadata == nil {
+		evt.Metadata = make(map[string]any)
+	}
+	evt.Metadata[MetadataTenant] = tenant
+}
@@ -0,0 +1,37 @@
+package events
+
+import "testing"
+
+func TestDefaultTenantExtractor(t *testing.T) {
+	cases := map[string]string{
+		"acme-123":         "acme",
+		"acme:product:123": "acme",
+		"solo":             "solo",
+	}
+	for id, want := range cases {
+		evt := &Event{AggregateID: id}
+		if got := DefaultTenantExtractor(evt); got != want {
+			t.Errorf("DefaultTenantExtractor(%q) = %q, want %q", id, got, want)
+		}
+	}
+}
+
+func TestTenantPrefersMetadataOverExtractor(t *testing.T) {
+	evt := &Event{AggregateID: "acme-123"}
+	SetTenant(evt, "explicit-tenant")
+
+	if got := Tenant(evt, DefaultTenantExtractor); got != "explicit-tenant" {
+		t.Errorf("Tenant() = %q, want %q", got, "explicit-tenant")
+	}
+}
+
+func TestTenantFallsBackToExtractor(t *testing.T) {
+	evt := &Event{AggregateID: "acme-123"}
+
+	if got := Tenant(evt, DefaultTenantExtractor); got != "acme" {
+		t.Errorf("Tenant() = %q, want %q", got, "acme")
+	}
+	if got := Tenant(evt, nil); got != "" {
+		t.Errorf("Tenant() with nil extractor = %q, want empty", got)
+	}
+}
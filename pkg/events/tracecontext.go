@@ -0,0 +1,147 @@
+package events
+
+import (
+	"context"
+	"fmt"
+	"strings"
+)
+
+// W3C Trace Context (https://www.w3.org/TR/trace-context/) Metadata keys.
+// These are the wire format InjectTraceContext writes and
+// ExtractTraceContext reads; they carry a trace across the outbox ->
+// stream -> handler boundary without this package depending on any
+// particular tracing SDK.
+const (
+	MetadataTraceParent = "traceparent"
+	MetadataTraceState  = "tracestate"
+	MetadataBaggage     = "baggage"
+)
+
+// SpanContext is a W3C-trace-context-compatible carrier: a 16-byte trace
+// ID, 8-byte span ID, and sampled flag, plus the opaque tracestate and
+// baggage strings passed through unmodified. It deliberately mirrors the
+// wire format rather than any specific SDK's in-memory representation, so
+// a service using a real tracer (OpenTelemetry, Datadog, ...) converts
+// between its own SpanContext and this one only at the Inject/Extract
+// boundary.
+type SpanContext struct {
+	TraceID    string
+	SpanID     string
+	Sampled    bool
+	TraceState string
+	Baggage    string
+}
+
+// IsValid reports whether sc has a non-zero trace ID and span ID, per the
+// W3C spec's definition of an invalid traceparent.
+func (sc SpanContext) IsValid() bool {
+	return len(sc.TraceID) == 32 && sc.TraceID != strings.Repeat("0", 32) &&
+		len(sc.SpanID) == 16 && sc.SpanID != strings.Repeat("0", 16)
+}
+
+// traceParentHeader formats sc as a "traceparent" header value
+// ("version-traceID-spanID-flags").
+func (sc SpanContext) traceParentHeader() string {
+	flags := "00"
+	if sc.Sampled {
+		flags = "01"
+	}
+	return fmt.Sprintf("00-%s-%s-%s", sc.TraceID, sc.SpanID, flags)
+}
+
+// ParseTraceParent parses a W3C "traceparent" header value into a
+// SpanContext. It accepts any version prefix (per the spec, parsers must
+// not reject unknown future versions) but requires the trace ID, span ID,
+// and flags fields to have the version-00 lengths.
+func ParseTraceParent(header string) (SpanContext, error) {
+	parts := strings.Split(header, "-")
+	if len(parts) < 4 {
+		return SpanContext{}, fmt.Errorf("events: malformed traceparent %q", header)
+	}
+	traceID, spanID, flags := parts[1], parts[2], parts[3]
+	if len(traceID) != 32 || len(spanID) != 16 || len(flags) != 2 {
+		return SpanContext{}, fmt.Errorf("events: malformed traceparent %q", header)
+	}
+
+	sc := SpanContext{TraceID: traceID, SpanID: spanID}
+	if flags != "00" && flags != "01" {
+		return SpanContext{}, fmt.Errorf("events: malformed traceparent flags %q", flags)
+	}
+	sc.Sampled = flags == "01"
+	if !sc.IsValid() {
+		return SpanContext{}, fmt.Errorf("events: traceparent %q has an all-zero trace or span ID", header)
+	}
+	return sc, nil
+}
+
+// spanContextKey is the context.Value key InjectTraceContext/
+// ExtractTraceContext and the tracing package's span wrappers use to carry
+// a SpanContext on a context.Context.
+type spanContextKey struct{}
+
+// ContextWithSpanContext returns a copy of ctx carrying sc, retrievable
+// with SpanContextFromContext. A tracer's StartSpan (see pkg/tracing)
+// calls this so the span it just started is what InjectTraceContext later
+// picks up for an outgoing event.
+func ContextWithSpanContext(ctx context.Context, sc SpanContext) context.Context {
+	return context.WithValue(ctx, spanContextKey{}, sc)
+}
+
+// SpanContextFromContext returns the SpanContext previously attached to
+// ctx with ContextWithSpanContext, if any.
+func SpanContextFromContext(ctx context.Context) (SpanContext, bool) {
+	sc, ok := ctx.Value(spanContextKey{}).(SpanContext)
+	return sc, ok
+}
+
+// InjectTraceContext writes ctx's current SpanContext (if any, via
+// ContextWithSpanContext) as W3C traceparent/tracestate/baggage entries
+// into ev.Metadata, initializing Metadata if necessary. It is a no-op if
+// ctx carries no valid SpanContext, so existing publishers that never set
+// one see no change in ev.Metadata.
+func InjectTraceContext(ctx context.Context, ev *Event) {
+	sc, ok := SpanContextFromContext(ctx)
+	if !ok || !sc.IsValid() {
+		return
+	}
+	if ev.Metadata == nil {
+		ev.Metadata = make(map[string]any)
+	}
+	ev.Metadata[MetadataTraceParent] = sc.traceParentHeader()
+	if sc.TraceState != "" {
+		ev.Metadata[MetadataTraceState] = sc.TraceState
+	}
+	if sc.Baggage != "" {
+		ev.Metadata[MetadataBaggage] = sc.Baggage
+	}
+}
+
+// ExtractTraceContext reads ev.Metadata's W3C trace headers (written by
+// InjectTraceContext on the publishing side) and returns a
+// context.Background() carrying the resulting SpanContext, so a consumer
+// handler can start a span linked to the same trace. If ev carries no
+// traceparent at all, it returns context.Background() unchanged and a nil
+// error; it returns a non-nil error only when a traceparent is present
+// but malformed.
+func ExtractTraceContext(ev *Event) (context.Context, error) {
+	raw, present := ev.Metadata[MetadataTraceParent]
+	if !present {
+		return context.Background(), nil
+	}
+	header, isString := raw.(string)
+	if !isString {
+		return context.Background(), fmt.Errorf("events: traceparent metadata is not a string")
+	}
+
+	sc, err := ParseTraceParent(header)
+	if err != nil {
+		return context.Background(), err
+	}
+	if ts, ok := ev.Metadata[MetadataTraceState].(string); ok {
+		sc.TraceState = ts
+	}
+	if bg, ok := ev.Metadata[MetadataBaggage].(string); ok {
+		sc.Baggage = bg
+	}
+	return ContextWithSpanContext(context.Background(), sc), nil
+}
@@ -0,0 +1,69 @@
+package events
+
+import (
+	"context"
+	"testing"
+)
+
+func TestInjectTraceContextWritesMetadata(t *testing.T) {
+	sc := SpanContext{TraceID: "4bf92f3577b34da6a3ce929d0e0e4736", SpanID: "00f067aa0ba902b7", Sampled: true}
+	ctx := ContextWithSpanContext(context.Background(), sc)
+
+	evt := &Event{}
+	InjectTraceContext(ctx, evt)
+
+	want := "00-4bf92f3577b34da6a3ce929d0e0e4736-00f067aa0ba902b7-01"
+	if got := evt.Metadata[MetadataTraceParent]; got != want {
+		t.Errorf("Metadata[traceparent] = %q, want %q", got, want)
+	}
+}
+
+func TestInjectTraceContextNoopWithoutSpanContext(t *testing.T) {
+	evt := &Event{}
+	InjectTraceContext(context.Background(), evt)
+
+	if evt.Metadata != nil {
+		t.Errorf("Metadata = %v, want nil", evt.Metadata)
+	}
+}
+
+func TestExtractTraceContextRoundTrips(t *testing.T) {
+	sc := SpanContext{TraceID: "4bf92f3577b34da6a3ce929d0e0e4736", SpanID: "00f067aa0ba902b7", Sampled: true, TraceState: "congo=t61rcWkgMzE"}
+	src := &Event{}
+	InjectTraceContext(ContextWithSpanContext(context.Background(), sc), src)
+
+	ctx, err := ExtractTraceContext(src)
+	if err != nil {
+		t.Fatalf("ExtractTraceContext: %v", err)
+	}
+	got, ok := SpanContextFromContext(ctx)
+	if !ok {
+		t.Fatal("SpanContextFromContext: not found")
+	}
+	if got.TraceID != sc.TraceID || got.SpanID != sc.SpanID || got.Sampled != sc.Sampled || got.TraceState != sc.TraceState {
+		t.Errorf("ExtractTraceContext() = %+v, want %+v", got, sc)
+	}
+}
+
+func TestExtractTraceContextWithoutTraceparentReturnsBackground(t *testing.T) {
+	ctx, err := ExtractTraceContext(&Event{})
+	if err != nil {
+		t.Fatalf("ExtractTraceContext: %v", err)
+	}
+	if _, ok := SpanContextFromContext(ctx); ok {
+		t.Error("SpanContextFromContext: expected none")
+	}
+}
+
+func TestExtractTraceContextRejectsMalformedTraceparent(t *testing.T) {
+	evt := &Event{Metadata: map[string]any{MetadataTraceParent: "not-a-traceparent"}}
+	if _, err := ExtractTraceContext(evt); err == nil {
+		t.Error("ExtractTraceContext: expected error for malformed traceparent")
+	}
+}
+
+func TestParseTraceParentRejectsAllZeroIDs(t *testing.T) {
+	if _, err := ParseTraceParent("00-00000000000000000000000000000000-0000000000000000-01"); err == nil {
+		t.Error("ParseTraceParent: expected error for all-zero trace/span ID")
+	}
+}
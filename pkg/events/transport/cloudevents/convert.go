@@ -0,0 +1,57 @@
+// Package cloudevents bridges events.Event onto github.com/cloudevents/sdk-go/v2,
+// so services can publish and receive over standard CloudEvents HTTP and
+// Kafka bindings (ce-type, ce-source, ce-subject, ce-id, ce-time,
+// ce-specversion) instead of our own hand-rolled JSON envelope.
+package cloudevents
+
+import (
+	"fmt"
+
+	cloudevents "github.com/cloudevents/sdk-go/v2"
+
+	"github.com/MalteBoehm/tall-affiliate-common/pkg/events"
+)
+
+// ToSDKEvent converts evt into a github.com/cloudevents/sdk-go/v2 Event:
+// AggregateType becomes source, AggregateID becomes subject, ID and Type
+// map directly, and Payload is carried as data with
+// datacontenttype=application/json.
+func ToSDKEvent(evt *events.Event) (cloudevents.Event, error) {
+	ce := cloudevents.NewEvent()
+	ce.SetID(evt.ID)
+	ce.SetType(evt.Type)
+	ce.SetSource(evt.AggregateType)
+	ce.SetSubject(evt.AggregateID)
+	ce.SetTime(evt.Timestamp)
+
+	if err := ce.SetData(cloudevents.ApplicationJSON, evt.Payload); err != nil {
+		return cloudevents.Event{}, fmt.Errorf("cloudevents: set data for %s: %w", evt.ID, err)
+	}
+	return ce, nil
+}
+
+// FromSDKEvent is the inverse of ToSDKEvent: it reconstructs an
+// events.Event from a github.com/cloudevents/sdk-go/v2 Event, unpacking
+// source/subject back into AggregateType/AggregateID. ce's type is passed
+// through events.NormalizeEventType first, so a sender still emitting a
+// legacy type string (e.g. "product.enrichment.requested.v1") is
+// transparently accepted alongside the canonical numbered constants.
+func FromSDKEvent(ce cloudevents.Event) (*events.Event, error) {
+	eventType, _ := events.NormalizeEventType(ce.Type())
+
+	var payload any
+	if len(ce.Data()) > 0 {
+		if err := ce.DataAs(&payload); err != nil {
+			return nil, fmt.Errorf("cloudevents: decode data for %s: %w", ce.ID(), err)
+		}
+	}
+
+	return &events.Event{
+		ID:            ce.ID(),
+		Type:          eventType,
+		AggregateType: ce.Source(),
+		AggregateID:   ce.Subject(),
+		Payload:       payload,
+		Timestamp:     ce.Time(),
+	}, nil
+}
@@ -0,0 +1,70 @@
+package cloudevents
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/MalteBoehm/tall-affiliate-common/pkg/events"
+)
+
+func TestToSDKEventMapsAggregateFieldsToSourceAndSubject(t *testing.T) {
+	evt := &events.Event{
+		ID:            "evt_1",
+		Type:          "product.enrichment.completed.v1",
+		AggregateType: "product",
+		AggregateID:   "asin_1",
+		Payload:       map[string]any{"asin": "asin_1"},
+		Timestamp:     time.Date(2026, 1, 1, 0, 0, 0, 0, time.UTC),
+	}
+
+	ce, err := ToSDKEvent(evt)
+	require.NoError(t, err)
+
+	assert.Equal(t, "evt_1", ce.ID())
+	assert.Equal(t, "product.enrichment.completed.v1", ce.Type())
+	assert.Equal(t, "product", ce.Source())
+	assert.Equal(t, "asin_1", ce.Subject())
+	assert.Equal(t, "application/json", ce.DataContentType())
+	assert.True(t, evt.Timestamp.Equal(ce.Time()))
+}
+
+func TestFromSDKEventNormalizesLegacyType(t *testing.T) {
+	ce, err := ToSDKEvent(&events.Event{
+		ID:            "evt_2",
+		Type:          "product.enrichment.requested.v1",
+		AggregateType: "product",
+		AggregateID:   "asin_2",
+		Payload:       map[string]any{"region": "us"},
+		Timestamp:     time.Now(),
+	})
+	require.NoError(t, err)
+
+	evt, err := FromSDKEvent(ce)
+	require.NoError(t, err)
+
+	assert.Equal(t, events.PRODUCT_ENRICHMENT_REQUESTED_V1, evt.Type)
+	assert.Equal(t, "product", evt.AggregateType)
+	assert.Equal(t, "asin_2", evt.AggregateID)
+}
+
+func TestFromSDKEventRoundTripsPayload(t *testing.T) {
+	ce, err := ToSDKEvent(&events.Event{
+		ID:            "evt_3",
+		Type:          "custom.type",
+		AggregateType: "product",
+		AggregateID:   "asin_3",
+		Payload:       map[string]any{"n": float64(42)},
+		Timestamp:     time.Now(),
+	})
+	require.NoError(t, err)
+
+	evt, err := FromSDKEvent(ce)
+	require.NoError(t, err)
+
+	payload, ok := evt.Payload.(map[string]any)
+	require.True(t, ok)
+	assert.Equal(t, float64(42), payload["n"])
+}
@@ -0,0 +1,42 @@
+package cloudevents
+
+import (
+	cloudevents "github.com/cloudevents/sdk-go/v2"
+
+	"github.com/MalteBoehm/tall-affiliate-common/pkg/events"
+)
+
+// Converter turns one specific CloudEvents type into an events.Event, for
+// services whose incoming CE payload needs handling beyond FromSDKEvent's
+// generic JSON unmarshal - e.g. a third-party CE source whose data shape
+// differs from our own payload structs.
+type Converter interface {
+	// Accepts reports whether this Converter handles ceType.
+	Accepts(ceType string) bool
+	// Convert converts ce into an events.Event.
+	Convert(ce cloudevents.Event) (*events.Event, error)
+}
+
+// ConverterRegistry dispatches an incoming CloudEvent to the first
+// registered Converter whose Accepts matches its type, falling back to
+// FromSDKEvent when none do.
+type ConverterRegistry struct {
+	converters []Converter
+}
+
+// NewConverterRegistry creates a ConverterRegistry trying converters in
+// order.
+func NewConverterRegistry(converters ...Converter) *ConverterRegistry {
+	return &ConverterRegistry{converters: converters}
+}
+
+// Convert runs ce through the first Converter that accepts its type, or
+// FromSDKEvent if none do.
+func (r *ConverterRegistry) Convert(ce cloudevents.Event) (*events.Event, error) {
+	for _, conv := range r.converters {
+		if conv.Accepts(ce.Type()) {
+			return conv.Convert(ce)
+		}
+	}
+	return FromSDKEvent(ce)
+}
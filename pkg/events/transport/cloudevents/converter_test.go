@@ -0,0 +1,47 @@
+package cloudevents
+
+import (
+	"testing"
+
+	cloudevents "github.com/cloudevents/sdk-go/v2"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/MalteBoehm/tall-affiliate-common/pkg/events"
+)
+
+type upperCaseConverter struct{}
+
+func (upperCaseConverter) Accepts(ceType string) bool { return ceType == "legacy.partner.v1" }
+
+func (upperCaseConverter) Convert(ce cloudevents.Event) (*events.Event, error) {
+	return &events.Event{ID: ce.ID(), Type: "CONVERTED", AggregateID: "partner"}, nil
+}
+
+func TestConverterRegistryDispatchesToMatchingConverter(t *testing.T) {
+	reg := NewConverterRegistry(upperCaseConverter{})
+
+	ce := cloudevents.NewEvent()
+	ce.SetID("evt-1")
+	ce.SetType("legacy.partner.v1")
+	ce.SetSource("partner")
+
+	evt, err := reg.Convert(ce)
+	require.NoError(t, err)
+	assert.Equal(t, "CONVERTED", evt.Type)
+}
+
+func TestConverterRegistryFallsBackToFromSDKEvent(t *testing.T) {
+	reg := NewConverterRegistry(upperCaseConverter{})
+
+	ce := cloudevents.NewEvent()
+	ce.SetID("evt-2")
+	ce.SetType("some.other.type")
+	ce.SetSource("catalog")
+	ce.SetSubject("product-1")
+
+	evt, err := reg.Convert(ce)
+	require.NoError(t, err)
+	assert.Equal(t, "some.other.type", evt.Type)
+	assert.Equal(t, "product-1", evt.AggregateID)
+}
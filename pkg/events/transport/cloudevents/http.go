@@ -0,0 +1,116 @@
+package cloudevents
+
+import (
+	"context"
+	"fmt"
+
+	cloudevents "github.com/cloudevents/sdk-go/v2"
+
+	"github.com/MalteBoehm/tall-affiliate-common/pkg/events"
+)
+
+// Encoding selects which CloudEvents HTTP/Kafka encoding a Publisher uses:
+// Binary carries attributes as ce-* headers and the payload as the body,
+// Structured carries the whole event as one application/cloudevents+json
+// body.
+type Encoding int
+
+const (
+	Binary Encoding = iota
+	Structured
+)
+
+// HTTPPublisher sends events to a single HTTP target using the
+// CloudEvents HTTP binding, in either binary or structured mode.
+type HTTPPublisher struct {
+	client   cloudevents.Client
+	encoding Encoding
+}
+
+// NewHTTPPublisher creates an HTTPPublisher that POSTs to targetURL.
+func NewHTTPPublisher(targetURL string, encoding Encoding) (*HTTPPublisher, error) {
+	client, err := cloudevents.NewClientHTTP(cloudevents.WithTarget(targetURL))
+	if err != nil {
+		return nil, fmt.Errorf("cloudevents: new http client: %w", err)
+	}
+	return &HTTPPublisher{client: client, encoding: encoding}, nil
+}
+
+// Publish converts evt to a CloudEvent and sends it over HTTP, encoded
+// per p's configured Encoding.
+func (p *HTTPPublisher) Publish(ctx context.Context, evt *events.Event) error {
+	ce, err := ToSDKEvent(evt)
+	if err != nil {
+		return err
+	}
+
+	if p.encoding == Structured {
+		ctx = cloudevents.WithEncodingStructured(ctx)
+	} else {
+		ctx = cloudevents.WithEncodingBinary(ctx)
+	}
+
+	if result := p.client.Send(ctx, ce); !cloudevents.IsACK(result) {
+		return fmt.Errorf("cloudevents: send %s: %w", evt.ID, result)
+	}
+	return nil
+}
+
+// Handler processes one received event. Returning an error NACKs the
+// delivery so the sender's transport can retry it.
+type Handler func(ctx context.Context, evt *events.Event) error
+
+// HTTPReceiver accepts CloudEvents over HTTP (either binary or structured
+// mode - the SDK detects the incoming mode from Content-Type) and
+// dispatches them to a Handler.
+type HTTPReceiver struct {
+	client    cloudevents.Client
+	converter *ConverterRegistry
+}
+
+// NewHTTPReceiver creates an HTTPReceiver listening on port. Incoming
+// events are converted with FromSDKEvent; use NewHTTPReceiverWithConverter
+// for per-type conversion.
+func NewHTTPReceiver(port int) (*HTTPReceiver, error) {
+	client, err := cloudevents.NewClientHTTP(cloudevents.WithPort(port))
+	if err != nil {
+		return nil, fmt.Errorf("cloudevents: new http receiver: %w", err)
+	}
+	return &HTTPReceiver{client: client}, nil
+}
+
+// NewHTTPReceiverWithConverter creates an HTTPReceiver like NewHTTPReceiver,
+// but dispatches incoming events through converter - see ConverterRegistry -
+// instead of always calling FromSDKEvent directly.
+func NewHTTPReceiverWithConverter(port int, converter *ConverterRegistry) (*HTTPReceiver, error) {
+	r, err := NewHTTPReceiver(port)
+	if err != nil {
+		return nil, err
+	}
+	r.converter = converter
+	return r, nil
+}
+
+// StartReceiving blocks, invoking handler for every event received,
+// until ctx is cancelled. An event whose type is not registered with any
+// consumer is still delivered - callers that care should check
+// evt.Type themselves.
+func (r *HTTPReceiver) StartReceiving(ctx context.Context, handler Handler) error {
+	return r.client.StartReceiver(ctx, func(ctx context.Context, ce cloudevents.Event) cloudevents.Result {
+		evt, err := r.convert(ce)
+		if err != nil {
+			return cloudevents.NewHTTPResult(400, "cloudevents: %v", err)
+		}
+		if err := handler(ctx, evt); err != nil {
+			return cloudevents.NewHTTPResult(500, "cloudevents: handler: %v", err)
+		}
+		return cloudevents.NewHTTPResult(200, "ok")
+	})
+}
+
+func (r *HTTPReceiver) convert(ce cloudevents.Event) (*events.Event, error) {
+	if r.converter != nil {
+		return r.converter.Convert(ce)
+	}
+	return FromSDKEvent(ce)
+}
@@ -0,0 +1,121 @@
+package cloudevents
+
+import (
+	"context"
+	"fmt"
+	"net"
+	"net/http"
+	"net/http/httptest"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	cloudevents "github.com/cloudevents/sdk-go/v2"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/MalteBoehm/tall-affiliate-common/pkg/events"
+)
+
+// freePort finds a currently-unused TCP port by briefly binding to :0 and
+// releasing it; NewHTTPReceiver then rebinds to the same port.
+func freePort(t *testing.T) int {
+	t.Helper()
+	l, err := net.Listen("tcp", "127.0.0.1:0")
+	require.NoError(t, err)
+	defer l.Close()
+	return l.Addr().(*net.TCPAddr).Port
+}
+
+func testHTTPEvent() *events.Event {
+	return &events.Event{
+		ID:            "evt_http_1",
+		Type:          "product.enrichment.completed.v1",
+		AggregateType: "product",
+		AggregateID:   "asin_1",
+		Payload:       map[string]any{"asin": "asin_1"},
+		Timestamp:     time.Now().UTC(),
+	}
+}
+
+func TestHTTPPublisherSendsBinaryEncoding(t *testing.T) {
+	var gotID string
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		ce, err := cloudevents.NewEventFromHTTPRequest(r)
+		require.NoError(t, err)
+		gotID = ce.ID()
+		w.WriteHeader(http.StatusOK)
+	}))
+	defer server.Close()
+
+	pub, err := NewHTTPPublisher(server.URL, Binary)
+	require.NoError(t, err)
+
+	err = pub.Publish(context.Background(), testHTTPEvent())
+	require.NoError(t, err)
+	assert.Equal(t, "evt_http_1", gotID)
+}
+
+func TestHTTPPublisherSendsStructuredEncoding(t *testing.T) {
+	var gotContentType string
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		gotContentType = r.Header.Get("Content-Type")
+		w.WriteHeader(http.StatusOK)
+	}))
+	defer server.Close()
+
+	pub, err := NewHTTPPublisher(server.URL, Structured)
+	require.NoError(t, err)
+
+	err = pub.Publish(context.Background(), testHTTPEvent())
+	require.NoError(t, err)
+	assert.Equal(t, "application/cloudevents+json", gotContentType)
+}
+
+func TestHTTPPublisherReturnsErrorOnUndelivered(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusInternalServerError)
+	}))
+	defer server.Close()
+
+	pub, err := NewHTTPPublisher(server.URL, Binary)
+	require.NoError(t, err)
+
+	err = pub.Publish(context.Background(), testHTTPEvent())
+	assert.Error(t, err)
+}
+
+func TestHTTPReceiverDispatchesReceivedEventToHandler(t *testing.T) {
+	port := freePort(t)
+	receiver, err := NewHTTPReceiver(port)
+	require.NoError(t, err)
+
+	var received atomic.Pointer[events.Event]
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	errCh := make(chan error, 1)
+	go func() {
+		errCh <- receiver.StartReceiving(ctx, func(_ context.Context, evt *events.Event) error {
+			received.Store(evt)
+			return nil
+		})
+	}()
+
+	// Give the receiver's HTTP server a moment to start listening, then
+	// publish to it like any other CloudEvents HTTP target would.
+	time.Sleep(50 * time.Millisecond)
+
+	pub, err := NewHTTPPublisher(fmt.Sprintf("http://127.0.0.1:%d/", port), Binary)
+	require.NoError(t, err)
+	require.NoError(t, pub.Publish(context.Background(), testHTTPEvent()))
+
+	require.Eventually(t, func() bool {
+		return received.Load() != nil
+	}, 2*time.Second, 10*time.Millisecond)
+
+	assert.Equal(t, "evt_http_1", received.Load().ID)
+
+	cancel()
+	<-errCh
+}
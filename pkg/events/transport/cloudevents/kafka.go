@@ -0,0 +1,225 @@
+package cloudevents
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"log/slog"
+	"time"
+
+	cloudevents "github.com/cloudevents/sdk-go/v2"
+	kafka "github.com/segmentio/kafka-go"
+
+	"github.com/MalteBoehm/tall-affiliate-common/pkg/events"
+)
+
+const structuredContentType = "application/cloudevents+json"
+
+// EncodeKafkaBinary encodes ce as the CloudEvents Kafka binary binding:
+// every attribute becomes a ce_-prefixed Kafka header (mirroring the HTTP
+// binding's ce- prefix) and the event's data becomes the message value.
+func EncodeKafkaBinary(ce cloudevents.Event) kafka.Message {
+	headers := []kafka.Header{
+		{Key: "ce_id", Value: []byte(ce.ID())},
+		{Key: "ce_source", Value: []byte(ce.Source())},
+		{Key: "ce_type", Value: []byte(ce.Type())},
+		{Key: "ce_specversion", Value: []byte(ce.SpecVersion())},
+	}
+	if subject := ce.Subject(); subject != "" {
+		headers = append(headers, kafka.Header{Key: "ce_subject", Value: []byte(subject)})
+	}
+	if !ce.Time().IsZero() {
+		headers = append(headers, kafka.Header{Key: "ce_time", Value: []byte(ce.Time().Format(time.RFC3339Nano))})
+	}
+	if ct := ce.DataContentType(); ct != "" {
+		headers = append(headers, kafka.Header{Key: "content-type", Value: []byte(ct)})
+	}
+
+	return kafka.Message{Headers: headers, Value: ce.Data()}
+}
+
+// DecodeKafkaBinary decodes a message previously encoded by
+// EncodeKafkaBinary.
+func DecodeKafkaBinary(msg kafka.Message) (cloudevents.Event, error) {
+	attrs := make(map[string]string, len(msg.Headers))
+	for _, h := range msg.Headers {
+		attrs[h.Key] = string(h.Value)
+	}
+
+	if attrs["ce_id"] == "" || attrs["ce_type"] == "" {
+		return cloudevents.Event{}, fmt.Errorf("cloudevents: kafka binary message missing ce_id/ce_type headers")
+	}
+
+	ce := cloudevents.NewEvent()
+	if v, ok := attrs["ce_specversion"]; ok {
+		ce.SetSpecVersion(v)
+	}
+	ce.SetID(attrs["ce_id"])
+	ce.SetSource(attrs["ce_source"])
+	ce.SetType(attrs["ce_type"])
+	if subject, ok := attrs["ce_subject"]; ok {
+		ce.SetSubject(subject)
+	}
+	if t, ok := attrs["ce_time"]; ok {
+		parsed, err := time.Parse(time.RFC3339Nano, t)
+		if err != nil {
+			return cloudevents.Event{}, fmt.Errorf("cloudevents: parse ce_time %q: %w", t, err)
+		}
+		ce.SetTime(parsed)
+	}
+	if ct, ok := attrs["content-type"]; ok {
+		ce.SetDataContentType(ct)
+	}
+	if err := ce.SetData(ce.DataContentType(), json.RawMessage(msg.Value)); err != nil {
+		return cloudevents.Event{}, fmt.Errorf("cloudevents: set data: %w", err)
+	}
+	return ce, nil
+}
+
+// EncodeKafkaStructured encodes ce as the CloudEvents Kafka structured
+// binding: the whole event, serialized as CloudEvents JSON, becomes the
+// message value, with a single content-type header identifying the
+// format.
+func EncodeKafkaStructured(ce cloudevents.Event) (kafka.Message, error) {
+	data, err := ce.MarshalJSON()
+	if err != nil {
+		return kafka.Message{}, fmt.Errorf("cloudevents: marshal structured event: %w", err)
+	}
+	return kafka.Message{
+		Headers: []kafka.Header{{Key: "content-type", Value: []byte(structuredContentType)}},
+		Value:   data,
+	}, nil
+}
+
+// DecodeKafkaStructured decodes a message previously encoded by
+// EncodeKafkaStructured.
+func DecodeKafkaStructured(msg kafka.Message) (cloudevents.Event, error) {
+	var ce cloudevents.Event
+	if err := ce.UnmarshalJSON(msg.Value); err != nil {
+		return cloudevents.Event{}, fmt.Errorf("cloudevents: unmarshal structured event: %w", err)
+	}
+	return ce, nil
+}
+
+// KafkaPublisher publishes events to a single Kafka topic using the
+// CloudEvents Kafka binding, in either binary or structured mode.
+type KafkaPublisher struct {
+	writer   *kafka.Writer
+	encoding Encoding
+}
+
+// NewKafkaPublisher creates a KafkaPublisher writing to topic on brokers.
+func NewKafkaPublisher(brokers []string, topic string, encoding Encoding) *KafkaPublisher {
+	return &KafkaPublisher{
+		writer:   &kafka.Writer{Addr: kafka.TCP(brokers...), Topic: topic, Balancer: &kafka.Hash{}},
+		encoding: encoding,
+	}
+}
+
+// Publish converts evt to a CloudEvent, encodes it per p's configured
+// Encoding, and writes it keyed by evt.AggregateID.
+func (p *KafkaPublisher) Publish(ctx context.Context, evt *events.Event) error {
+	ce, err := ToSDKEvent(evt)
+	if err != nil {
+		return err
+	}
+
+	var msg kafka.Message
+	if p.encoding == Structured {
+		msg, err = EncodeKafkaStructured(ce)
+		if err != nil {
+			return err
+		}
+	} else {
+		msg = EncodeKafkaBinary(ce)
+	}
+	msg.Key = []byte(evt.AggregateID)
+
+	if err := p.writer.WriteMessages(ctx, msg); err != nil {
+		return fmt.Errorf("cloudevents: publish %s to kafka: %w", evt.ID, err)
+	}
+	return nil
+}
+
+// Close closes the underlying Kafka writer.
+func (p *KafkaPublisher) Close() error {
+	return p.writer.Close()
+}
+
+// KafkaReceiver consumes CloudEvents from a Kafka topic, transparently
+// accepting either binary or structured mode (detected per message from
+// its content-type header), and dispatches them to a Handler.
+type KafkaReceiver struct {
+	reader *kafka.Reader
+	logger *slog.Logger
+}
+
+// NewKafkaReceiver creates a KafkaReceiver reading topic as part of
+// groupID on brokers.
+func NewKafkaReceiver(brokers []string, topic, groupID string, logger *slog.Logger) *KafkaReceiver {
+	if logger == nil {
+		logger = slog.Default()
+	}
+	return &KafkaReceiver{
+		reader: kafka.NewReader(kafka.ReaderConfig{
+			Brokers:        brokers,
+			Topic:          topic,
+			GroupID:        groupID,
+			CommitInterval: 0, // manual commit, driven by handler success
+		}),
+		logger: logger.With("component", "cloudevents-kafka-receiver"),
+	}
+}
+
+// Close closes the underlying Kafka reader.
+func (r *KafkaReceiver) Close() error {
+	return r.reader.Close()
+}
+
+// StartReceiving blocks, invoking handler for every event received and
+// committing its offset only after handler succeeds, until ctx is
+// canceled.
+func (r *KafkaReceiver) StartReceiving(ctx context.Context, handler Handler) error {
+	for {
+		msg, err := r.reader.FetchMessage(ctx)
+		if err != nil {
+			if ctx.Err() != nil {
+				return ctx.Err()
+			}
+			r.logger.Error("failed to fetch message", "error", err)
+			continue
+		}
+
+		if err := r.dispatch(ctx, msg, handler); err != nil {
+			r.logger.Error("failed to process message",
+				"partition", msg.Partition, "offset", msg.Offset, "error", err)
+			continue
+		}
+
+		if err := r.reader.CommitMessages(ctx, msg); err != nil {
+			r.logger.Error("failed to commit message", "error", err)
+		}
+	}
+}
+
+func (r *KafkaReceiver) dispatch(ctx context.Context, msg kafka.Message, handler Handler) error {
+	ce, err := decodeKafkaMessage(msg)
+	if err != nil {
+		return err
+	}
+
+	evt, err := FromSDKEvent(ce)
+	if err != nil {
+		return err
+	}
+	return handler(ctx, evt)
+}
+
+func decodeKafkaMessage(msg kafka.Message) (cloudevents.Event, error) {
+	for _, h := range msg.Headers {
+		if h.Key == "content-type" && string(h.Value) == structuredContentType {
+			return DecodeKafkaStructured(msg)
+		}
+	}
+	return DecodeKafkaBinary(msg)
+}
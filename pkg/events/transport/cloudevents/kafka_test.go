@@ -0,0 +1,98 @@
+package cloudevents
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/MalteBoehm/tall-affiliate-common/pkg/events"
+)
+
+func TestKafkaBinaryRoundTrip(t *testing.T) {
+	evt := &events.Event{
+		ID:            "evt_kafka_1",
+		Type:          "product.enrichment.completed.v1",
+		AggregateType: "product",
+		AggregateID:   "asin_1",
+		Payload:       map[string]any{"asin": "asin_1"},
+		Timestamp:     time.Now().UTC(),
+	}
+	ce, err := ToSDKEvent(evt)
+	require.NoError(t, err)
+
+	msg := EncodeKafkaBinary(ce)
+
+	var hasCeID, hasContentType bool
+	for _, h := range msg.Headers {
+		if h.Key == "ce_id" {
+			hasCeID = true
+			assert.Equal(t, evt.ID, string(h.Value))
+		}
+		if h.Key == "content-type" {
+			hasContentType = true
+		}
+	}
+	assert.True(t, hasCeID)
+	assert.True(t, hasContentType)
+
+	decoded, err := DecodeKafkaBinary(msg)
+	require.NoError(t, err)
+	assert.Equal(t, evt.ID, decoded.ID())
+	assert.Equal(t, evt.Type, decoded.Type())
+	assert.Equal(t, evt.AggregateID, decoded.Subject())
+
+	roundTripped, err := FromSDKEvent(decoded)
+	require.NoError(t, err)
+	assert.Equal(t, evt.ID, roundTripped.ID)
+	assert.Equal(t, evt.AggregateID, roundTripped.AggregateID)
+}
+
+func TestKafkaStructuredRoundTrip(t *testing.T) {
+	evt := &events.Event{
+		ID:            "evt_kafka_2",
+		Type:          "product.enrichment.completed.v1",
+		AggregateType: "product",
+		AggregateID:   "asin_2",
+		Payload:       map[string]any{"asin": "asin_2"},
+		Timestamp:     time.Now().UTC(),
+	}
+	ce, err := ToSDKEvent(evt)
+	require.NoError(t, err)
+
+	msg, err := EncodeKafkaStructured(ce)
+	require.NoError(t, err)
+	require.Len(t, msg.Headers, 1)
+	assert.Equal(t, "content-type", msg.Headers[0].Key)
+	assert.Equal(t, structuredContentType, string(msg.Headers[0].Value))
+
+	decoded, err := DecodeKafkaStructured(msg)
+	require.NoError(t, err)
+	assert.Equal(t, evt.ID, decoded.ID())
+	assert.Equal(t, evt.Type, decoded.Type())
+}
+
+func TestDecodeKafkaMessageDetectsStructuredByContentType(t *testing.T) {
+	evt := &events.Event{
+		ID:            "evt_kafka_3",
+		Type:          "product.enrichment.completed.v1",
+		AggregateType: "product",
+		AggregateID:   "asin_3",
+		Payload:       map[string]any{},
+		Timestamp:     time.Now().UTC(),
+	}
+	ce, err := ToSDKEvent(evt)
+	require.NoError(t, err)
+
+	structuredMsg, err := EncodeKafkaStructured(ce)
+	require.NoError(t, err)
+	decoded, err := decodeKafkaMessage(structuredMsg)
+	require.NoError(t, err)
+	assert.Equal(t, evt.ID, decoded.ID())
+
+	binaryMsg := EncodeKafkaBinary(ce)
+	decoded, err = decodeKafkaMessage(binaryMsg)
+	require.NoError(t, err)
+	assert.Equal(t, evt.ID, decoded.ID())
+}
@@ -0,0 +1,30 @@
+// Package nats wires pkg/events' Event struct and EVENT_* catalog onto NATS
+// JetStream, so pipeline services can publish and subscribe to domain
+// events without hand-rolling the subject naming, message ID, and payload
+// decoding that NewEvent/UnmarshalPayload otherwise leaves to each caller.
+package nats
+
+import "strings"
+
+// subjectPrefix namespaces every subject this package derives, so JetStream
+// streams can bind a single "tall.product.>" filter.
+const subjectPrefix = "tall.product."
+
+// SubjectFor derives the JetStream subject for an event type, e.g.
+// "05A_ENRICHMENT_COMPLETED" becomes "tall.product.05A_ENRICHMENT_COMPLETED".
+func SubjectFor(eventType string) string {
+	return subjectPrefix + eventType
+}
+
+// EventTypeFromSubject is the inverse of SubjectFor, returning ok=false if
+// subject isn't under subjectPrefix.
+func EventTypeFromSubject(subject string) (eventType string, ok bool) {
+	if !strings.HasPrefix(subject, subjectPrefix) {
+		return "", false
+	}
+	return strings.TrimPrefix(subject, subjectPrefix), true
+}
+
+// DeadLetterSubject receives messages whose decoded payload fails
+// Validate().
+const DeadLetterSubject = "tall.product.dead-letter"
@@ -0,0 +1,43 @@
+package nats
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/MalteBoehm/tall-affiliate-common/pkg/events"
+)
+
+func TestSubjectFor(t *testing.T) {
+	assert.Equal(t, "tall.product.05A_ENRICHMENT_COMPLETED", SubjectFor("05A_ENRICHMENT_COMPLETED"))
+}
+
+func TestEventTypeFromSubject(t *testing.T) {
+	eventType, ok := EventTypeFromSubject("tall.product.05A_ENRICHMENT_COMPLETED")
+	assert.True(t, ok)
+	assert.Equal(t, "05A_ENRICHMENT_COMPLETED", eventType)
+
+	_, ok = EventTypeFromSubject("other.subject")
+	assert.False(t, ok)
+}
+
+func TestPayloadForRegisteredType(t *testing.T) {
+	payload := payloadFor(events.EVENT_01_PRODUCT_DETECTED)
+	_, ok := payload.(*events.NewProductDetectedPayload)
+	assert.True(t, ok)
+}
+
+func TestPayloadForUnregisteredTypeFallsBackToMap(t *testing.T) {
+	payload := payloadFor("SOME_UNKNOWN_EVENT_TYPE")
+	_, ok := payload.(*map[string]any)
+	assert.True(t, ok)
+}
+
+func TestRegisterPayloadFactoryOverridesDefault(t *testing.T) {
+	type customPayload struct{ Foo string }
+	RegisterPayloadFactory("CUSTOM_EVENT_TYPE", func() any { return &customPayload{} })
+
+	payload := payloadFor("CUSTOM_EVENT_TYPE")
+	_, ok := payload.(*customPayload)
+	assert.True(t, ok)
+}
@@ -0,0 +1,48 @@
+package nats
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+
+	"github.com/nats-io/nats.go"
+
+	"github.com/MalteBoehm/tall-affiliate-common/pkg/events"
+)
+
+// Publisher publishes Events onto NATS JetStream, deriving the subject from
+// Event.Type via SubjectFor and setting the Nats-Msg-Id header to Event.ID
+// so JetStream's duplicate-message window gives exactly-once delivery for
+// republished events.
+type Publisher struct {
+	js nats.JetStreamContext
+}
+
+// NewPublisher creates a Publisher using an already-configured JetStream
+// context (see StreamConfigFor for setting up the underlying stream).
+func NewPublisher(js nats.JetStreamContext) *Publisher {
+	return &Publisher{js: js}
+}
+
+// Publish marshals the whole event and publishes it to SubjectFor(event.Type).
+func (p *Publisher) Publish(ctx context.Context, event *events.Event) error {
+	if event == nil {
+		return fmt.Errorf("event cannot be nil")
+	}
+
+	data, err := json.Marshal(event)
+	if err != nil {
+		return fmt.Errorf("failed to marshal event: %w", err)
+	}
+
+	msg := nats.NewMsg(SubjectFor(event.Type))
+	msg.Data = data
+	msg.Header.Set(nats.MsgIdHdr, event.ID)
+
+	_, err = p.js.PublishMsg(msg, nats.Context(ctx))
+	if err != nil {
+		return fmt.Errorf("failed to publish event %s to %s: %w", event.ID, msg.Subject, err)
+	}
+
+	return nil
+}
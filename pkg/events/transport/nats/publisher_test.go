@@ -0,0 +1,53 @@
+package nats
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	natsgo "github.com/nats-io/nats.go"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/MalteBoehm/tall-affiliate-common/pkg/events"
+)
+
+func connectJetStream(t *testing.T) natsgo.JetStreamContext {
+	t.Helper()
+
+	nc, err := natsgo.Connect(natsgo.DefaultURL, natsgo.Timeout(time.Second))
+	if err != nil {
+		t.Skip("NATS not available")
+	}
+	t.Cleanup(nc.Close)
+
+	js, err := nc.JetStream()
+	require.NoError(t, err)
+	return js
+}
+
+func TestPublisherSetsMsgIdForExactlyOnce(t *testing.T) {
+	js := connectJetStream(t)
+
+	_, err := js.AddStream(StreamConfigFor("product", []string{"tall.product.>"}, StreamRetention{
+		Policy: natsgo.LimitsPolicy,
+		MaxAge: time.Hour,
+	}))
+	require.NoError(t, err)
+
+	publisher := NewPublisher(js)
+	event := &events.Event{
+		ID:            "evt_1",
+		Type:          events.EVENT_05A_ENRICHMENT_COMPLETED,
+		AggregateType: "product",
+		AggregateID:   "asin_1",
+		Timestamp:     time.Now(),
+	}
+
+	err = publisher.Publish(context.Background(), event)
+	assert.NoError(t, err)
+
+	msg, err := js.GetLastMsg("TALL_PRODUCT", SubjectFor(event.Type))
+	require.NoError(t, err)
+	assert.Equal(t, event.ID, msg.Header.Get(natsgo.MsgIdHdr))
+}
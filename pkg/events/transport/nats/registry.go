@@ -0,0 +1,48 @@
+package nats
+
+import "github.com/MalteBoehm/tall-affiliate-common/pkg/events"
+
+// PayloadFactory returns a fresh, zero-valued pointer to an event's payload
+// type, suitable as the target of Event.UnmarshalPayload.
+type PayloadFactory func() any
+
+// payloadFactories maps EVENT_* constants to the payload type Subscriber
+// should decode them into. Event types not listed here decode into a plain
+// map[string]any.
+var payloadFactories = map[string]PayloadFactory{
+	events.EVENT_01_PRODUCT_DETECTED:         func() any { return &events.NewProductDetectedPayload{} },
+	events.EVENT_02B_PRODUCT_IGNORED:         func() any { return &events.ProductIgnoredPayload{} },
+	events.EVENT_02C_PRODUCT_REVIEW_REQUIRED: func() any { return &events.ProductReviewRequiredPayload{} },
+
+	events.EVENT_04B_COLOR_ENRICHMENT_REQUESTED:    func() any { return &events.ColorEnrichmentRequestedPayload{} },
+	events.EVENT_04D_VARIANTS_ENRICHMENT_REQUESTED: func() any { return &events.VariationEnrichmentRequestedPayload{} },
+
+	events.EVENT_06_QUALITY_ASSESSMENT_REQUESTED:  func() any { return &events.QualityAssessmentRequestedPayload{} },
+	events.EVENT_07A_QUALITY_ASSESSMENT_COMPLETED: func() any { return &events.QualityAssessmentCompletedPayload{} },
+	events.EVENT_07B_QUALITY_ASSESSMENT_FAILED:    func() any { return &events.QualityAssessmentFailedPayload{} },
+
+	events.EVENT_08A_CONTENT_GENERATION_REQUESTED: func() any { return &events.ContentGenerationRequestedPayload{} },
+	events.EVENT_08B_REVIEWS_REQUESTED:            func() any { return &events.ReviewsRequestedPayload{} },
+	events.EVENT_10A_CONTENT_GENERATED:            func() any { return &events.ContentGeneratedPayload{} },
+	events.EVENT_10B_CONTENT_GENERATION_FAILED:    func() any { return &events.ContentGenerationFailedPayload{} },
+
+	events.EVENT_14A_PRICE_MONITORING_SCHEDULED:   func() any { return &events.PriceMonitoringScheduledPayload{} },
+	events.EVENT_14B_AVAILABILITY_CHECK_SCHEDULED: func() any { return &events.AvailabilityCheckScheduledPayload{} },
+	events.EVENT_14C_PERIODIC_UPDATE_SCHEDULED:    func() any { return &events.PeriodicUpdateScheduledPayload{} },
+}
+
+// RegisterPayloadFactory adds or overrides the factory used to decode
+// eventType, letting callers extend the catalog with their own payload
+// types without forking this package.
+func RegisterPayloadFactory(eventType string, factory PayloadFactory) {
+	payloadFactories[eventType] = factory
+}
+
+// payloadFor returns a fresh decode target for eventType: the registered
+// payload type if one exists, otherwise a plain map[string]any.
+func payloadFor(eventType string) any {
+	if factory, ok := payloadFactories[eventType]; ok {
+		return factory()
+	}
+	return &map[string]any{}
+}
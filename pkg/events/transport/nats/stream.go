@@ -0,0 +1,30 @@
+package nats
+
+import (
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/nats-io/nats.go"
+)
+
+// StreamRetention configures how long a stream retains messages and under
+// which policy, so e.g. the short-lived "scraper.job" aggregate can use a
+// tight MaxAge while "product" events are kept longer for replay.
+type StreamRetention struct {
+	Policy   nats.RetentionPolicy
+	MaxAge   time.Duration
+	MaxBytes int64
+}
+
+// StreamConfigFor builds a JetStream stream configuration named after
+// aggregateType, filtering on subjects and applying retention.
+func StreamConfigFor(aggregateType string, subjects []string, retention StreamRetention) *nats.StreamConfig {
+	return &nats.StreamConfig{
+		Name:      fmt.Sprintf("TALL_%s", strings.ToUpper(strings.ReplaceAll(aggregateType, ".", "_"))),
+		Subjects:  subjects,
+		Retention: retention.Policy,
+		MaxAge:    retention.MaxAge,
+		MaxBytes:  retention.MaxBytes,
+	}
+}
@@ -0,0 +1,21 @@
+package nats
+
+import (
+	"testing"
+	"time"
+
+	natsgo "github.com/nats-io/nats.go"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestStreamConfigFor(t *testing.T) {
+	cfg := StreamConfigFor("scraper.job", []string{"tall.product.>"}, StreamRetention{
+		Policy: natsgo.LimitsPolicy,
+		MaxAge: 24 * time.Hour,
+	})
+
+	assert.Equal(t, "TALL_SCRAPER_JOB", cfg.Name)
+	assert.Equal(t, []string{"tall.product.>"}, cfg.Subjects)
+	assert.Equal(t, natsgo.LimitsPolicy, cfg.Retention)
+	assert.Equal(t, 24*time.Hour, cfg.MaxAge)
+}
@@ -0,0 +1,165 @@
+package nats
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+
+	"github.com/nats-io/nats.go"
+
+	"github.com/MalteBoehm/tall-affiliate-common/pkg/events"
+)
+
+// Phase groups the EVENT_* catalog into the pipeline stages a durable
+// consumer typically wants to subscribe to as a unit.
+type Phase string
+
+const (
+	PhaseEnrichment  Phase = "enrichment"
+	PhaseQuality     Phase = "quality"
+	PhaseContent     Phase = "content"
+	PhasePublication Phase = "publication"
+)
+
+// phaseEventTypes lists the EVENT_* constants belonging to each Phase.
+var phaseEventTypes = map[Phase][]string{
+	PhaseEnrichment: {
+		events.EVENT_03_ENRICHMENT_ORCHESTRATION_STARTED,
+		events.EVENT_04B_COLOR_ENRICHMENT_REQUESTED,
+		events.EVENT_04D_VARIANTS_ENRICHMENT_REQUESTED,
+		events.EVENT_05A_ENRICHMENT_COMPLETED,
+		events.EVENT_05B_ENRICHMENT_FAILED,
+		events.EVENT_05C_VARIANTS_ENRICHED,
+		events.EVENT_05D_ENRICHMENT_RETRY,
+	},
+	PhaseQuality: {
+		events.EVENT_06_QUALITY_ASSESSMENT_REQUESTED,
+		events.EVENT_07A_QUALITY_ASSESSMENT_COMPLETED,
+		events.EVENT_07B_QUALITY_ASSESSMENT_FAILED,
+	},
+	PhaseContent: {
+		events.EVENT_08A_CONTENT_GENERATION_REQUESTED,
+		events.EVENT_08B_REVIEWS_REQUESTED,
+		events.EVENT_09A_CONTENT_GENERATION_STARTED,
+		events.EVENT_09B_REVIEWS_FETCHED,
+		events.EVENT_10A_CONTENT_GENERATED,
+		events.EVENT_10B_CONTENT_GENERATION_FAILED,
+		events.EVENT_10C_REVIEWS_PROCESSED,
+		events.EVENT_10D_CONTENT_GENERATION_RETRIED,
+		events.EVENT_11A_REVIEWS_VALIDATED,
+		events.EVENT_11B_REVIEWS_FETCH_FAILED,
+		events.EVENT_12A_REVIEWS_STORED,
+		events.EVENT_12B_REVIEWS_ERROR,
+	},
+	PhasePublication: {
+		events.EVENT_13_PRODUCT_READY_FOR_PUBLICATION,
+		events.EVENT_14A_PRICE_MONITORING_SCHEDULED,
+		events.EVENT_14B_AVAILABILITY_CHECK_SCHEDULED,
+		events.EVENT_14C_PERIODIC_UPDATE_SCHEDULED,
+		events.EVENT_15A_PRICE_UPDATED,
+		events.EVENT_15B_PRICE_UPDATE_FAILED,
+		events.EVENT_16A_PRODUCT_UPDATED,
+		events.EVENT_16B_PRODUCT_UPDATE_FAILED,
+		events.EVENT_17_PRODUCT_AVAILABILITY_CHANGED,
+		events.EVENT_18_PRODUCT_STATUS_CHANGED,
+		events.EVENT_19_PRODUCT_DELETED,
+	},
+}
+
+// Handler processes a decoded event and its typed payload (as registered via
+// RegisterPayloadFactory, or a *map[string]any for unregistered types).
+type Handler func(ctx context.Context, event *events.Event, payload any) error
+
+// validator is implemented by payload types with a Validate method, e.g.
+// *events.ProductEnrichmentRequestedData.
+type validator interface {
+	Validate() error
+}
+
+// Subscriber decodes JetStream messages back into Events and their typed
+// payloads.
+type Subscriber struct {
+	js nats.JetStreamContext
+}
+
+// NewSubscriber creates a Subscriber using an already-configured JetStream
+// context.
+func NewSubscriber(js nats.JetStreamContext) *Subscriber {
+	return &Subscriber{js: js}
+}
+
+// SubscribePhase creates a durable consumer named "<phase>-durable" for
+// every event type phase covers. Each decoded message is passed to handler;
+// a message whose payload fails UnmarshalPayload or Validate() is
+// republished to DeadLetterSubject and acknowledged on its original subject
+// instead of being redelivered forever. Callers must Drain the returned
+// subscriptions to unsubscribe cleanly.
+func (s *Subscriber) SubscribePhase(ctx context.Context, phase Phase, handler Handler) ([]*nats.Subscription, error) {
+	eventTypes, ok := phaseEventTypes[phase]
+	if !ok {
+		return nil, fmt.Errorf("nats: unknown phase %q", phase)
+	}
+
+	durable := fmt.Sprintf("%s-durable", phase)
+	subs := make([]*nats.Subscription, 0, len(eventTypes))
+	for _, eventType := range eventTypes {
+		sub, err := s.js.Subscribe(SubjectFor(eventType), func(msg *nats.Msg) {
+			s.dispatch(ctx, msg, handler)
+		}, nats.Durable(durable), nats.ManualAck(), nats.AckExplicit())
+		if err != nil {
+			for _, opened := range subs {
+				opened.Drain()
+			}
+			return nil, fmt.Errorf("nats: subscribe phase %s to event type %s: %w", phase, eventType, err)
+		}
+		subs = append(subs, sub)
+	}
+	return subs, nil
+}
+
+func (s *Subscriber) dispatch(ctx context.Context, msg *nats.Msg, handler Handler) {
+	var event events.Event
+	if err := json.Unmarshal(msg.Data, &event); err != nil {
+		msg.Nak()
+		return
+	}
+
+	eventType, _ := EventTypeFromSubject(msg.Subject)
+	payload := payloadFor(eventType)
+	if err := event.UnmarshalPayload(payload); err != nil {
+		s.deadLetter(msg, err)
+		return
+	}
+
+	if v, ok := payload.(validator); ok {
+		if err := v.Validate(); err != nil {
+			s.deadLetter(msg, err)
+			return
+		}
+	}
+
+	if err := handler(ctx, &event, payload); err != nil {
+		msg.Nak()
+		return
+	}
+	msg.Ack()
+}
+
+// deadLetter republishes msg onto DeadLetterSubject with the original
+// subject and failure reason attached as headers, then acknowledges the
+// original message so it isn't redelivered indefinitely.
+func (s *Subscriber) deadLetter(msg *nats.Msg, cause error) {
+	dlqMsg := nats.NewMsg(DeadLetterSubject)
+	dlqMsg.Data = msg.Data
+	for k, v := range msg.Header {
+		dlqMsg.Header[k] = append([]string(nil), v...)
+	}
+	dlqMsg.Header.Set("Original-Subject", msg.Subject)
+	dlqMsg.Header.Set("Dead-Letter-Reason", cause.Error())
+
+	if _, err := s.js.PublishMsg(dlqMsg); err != nil {
+		msg.Nak()
+		return
+	}
+	msg.Ack()
+}
@@ -0,0 +1,228 @@
+package webhooks
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"log/slog"
+	"net/http"
+	"sync"
+	"time"
+
+	"github.com/google/uuid"
+
+	"github.com/MalteBoehm/tall-affiliate-common/pkg/events"
+)
+
+// attemptTimeout bounds each of the three quick tries that make up one
+// delivery attempt.
+const attemptTimeout = 30 * time.Second
+
+// quickRetryDelays are the sleeps between the three quick tries within a
+// single delivery attempt.
+var quickRetryDelays = []time.Duration{time.Second, 5 * time.Second}
+
+// Dispatcher matches *events.Event against its configured Subscriptions and
+// delivers the signed Envelope to each match over HTTP, persisting failed
+// deliveries via Store so they survive a restart and get redelivered on a
+// staged backoff schedule.
+type Dispatcher struct {
+	mu            sync.RWMutex
+	subscriptions []Subscription
+
+	store   Store
+	client  *http.Client
+	metrics MetricsSink
+	logger  *slog.Logger
+}
+
+// NewDispatcher creates a Dispatcher persisting failed deliveries via store.
+// If metrics is nil, delivery outcomes are not recorded. If logger is nil,
+// slog.Default() is used.
+func NewDispatcher(store Store, metrics MetricsSink, logger *slog.Logger) *Dispatcher {
+	if logger == nil {
+		logger = slog.Default()
+	}
+	return &Dispatcher{
+		store:   store,
+		client:  &http.Client{Timeout: attemptTimeout},
+		metrics: metrics,
+		logger:  logger.With("component", "webhooks-dispatcher"),
+	}
+}
+
+// AddSubscription registers sub for future Dispatch calls.
+func (d *Dispatcher) AddSubscription(sub Subscription) {
+	d.mu.Lock()
+	defer d.mu.Unlock()
+	d.subscriptions = append(d.subscriptions, sub)
+}
+
+// RemoveSubscription removes the subscription with the given ID, if present.
+func (d *Dispatcher) RemoveSubscription(id string) {
+	d.mu.Lock()
+	defer d.mu.Unlock()
+	for i, sub := range d.subscriptions {
+		if sub.ID == id {
+			d.subscriptions = append(d.subscriptions[:i], d.subscriptions[i+1:]...)
+			return
+		}
+	}
+}
+
+// Dispatch delivers event to every subscription whose filters match it. A
+// subscription whose initial delivery attempt fails is persisted via Store
+// for later redelivery by RunRedeliveries rather than returned as an error -
+// Dispatch only returns an error if event itself can't be marshaled, or if
+// persisting a failed delivery fails.
+func (d *Dispatcher) Dispatch(ctx context.Context, event *events.Event) error {
+	envelope := NewEnvelope(event)
+	body, err := json.Marshal(envelope)
+	if err != nil {
+		return fmt.Errorf("webhooks: marshal envelope: %w", err)
+	}
+
+	d.mu.RLock()
+	matches := make([]Subscription, 0, len(d.subscriptions))
+	for _, sub := range d.subscriptions {
+		if sub.Matches(event) {
+			matches = append(matches, sub)
+		}
+	}
+	d.mu.RUnlock()
+
+	for _, sub := range matches {
+		if d.attemptDelivery(ctx, sub.URL, sub.Secret, body) {
+			d.recordDelivered(event.Type)
+			continue
+		}
+
+		d.recordFailed(event.Type)
+		pending := PendingDelivery{
+			ID:             uuid.New().String(),
+			SubscriptionID: sub.ID,
+			URL:            sub.URL,
+			Secret:         sub.Secret,
+			EventType:      event.Type,
+			Body:           body,
+			Attempt:        1,
+			NextAttemptAt:  time.Now().Add(nextRedeliveryDelay(1)),
+			CreatedAt:      time.Now(),
+		}
+		if err := d.store.Save(ctx, pending); err != nil {
+			return fmt.Errorf("webhooks: save pending delivery for subscription %s: %w", sub.ID, err)
+		}
+	}
+
+	return nil
+}
+
+// RunRedeliveries polls Store every pollInterval for due deliveries and
+// retries them, until ctx is cancelled.
+func (d *Dispatcher) RunRedeliveries(ctx context.Context, pollInterval time.Duration) error {
+	ticker := time.NewTicker(pollInterval)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-ctx.Done():
+			return ctx.Err()
+		case <-ticker.C:
+			if err := d.redeliverDue(ctx); err != nil {
+				d.logger.Error("failed to process due redeliveries", "error", err)
+			}
+		}
+	}
+}
+
+func (d *Dispatcher) redeliverDue(ctx context.Context) error {
+	due, err := d.store.Due(ctx, time.Now(), 100)
+	if err != nil {
+		return fmt.Errorf("list due deliveries: %w", err)
+	}
+
+	for _, delivery := range due {
+		if d.attemptDelivery(ctx, delivery.URL, delivery.Secret, delivery.Body) {
+			d.recordDelivered(delivery.EventType)
+			if err := d.store.Delete(ctx, delivery.ID); err != nil {
+				d.logger.Error("failed to delete delivered webhook", "deliveryID", delivery.ID, "error", err)
+			}
+			continue
+		}
+
+		d.recordRetried(delivery.EventType)
+		nextAttempt := delivery.Attempt + 1
+		nextAttemptAt := time.Now().Add(nextRedeliveryDelay(nextAttempt))
+		if err := d.store.UpdateAttempt(ctx, delivery.ID, nextAttempt, nextAttemptAt); err != nil {
+			d.logger.Error("failed to update webhook delivery attempt", "deliveryID", delivery.ID, "error", err)
+		}
+	}
+
+	return nil
+}
+
+// attemptDelivery runs one delivery attempt: up to three quick tries, each
+// with its own attemptTimeout, sleeping quickRetryDelays between them. Only
+// a 2xx response counts as success.
+func (d *Dispatcher) attemptDelivery(ctx context.Context, url, secret string, body []byte) bool {
+	tries := len(quickRetryDelays) + 1
+	for i := 0; i < tries; i++ {
+		if i > 0 {
+			select {
+			case <-ctx.Done():
+				return false
+			case <-time.After(quickRetryDelays[i-1]):
+			}
+		}
+
+		if d.postOnce(ctx, url, secret, body) {
+			return true
+		}
+	}
+	return false
+}
+
+func (d *Dispatcher) postOnce(ctx context.Context, url, secret string, body []byte) bool {
+	reqCtx, cancel := context.WithTimeout(ctx, attemptTimeout)
+	defer cancel()
+
+	req, err := http.NewRequestWithContext(reqCtx, http.MethodPost, url, bytes.NewReader(body))
+	if err != nil {
+		d.logger.Error("failed to build webhook request", "url", url, "error", err)
+		return false
+	}
+	req.Header.Set("Content-Type", "application/json")
+	req.Header.Set("X-Tall-Signature", Sign(body, secret))
+
+	resp, err := d.client.Do(req)
+	if err != nil {
+		d.logger.Warn("webhook delivery attempt failed", "url", url, "error", err)
+		return false
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode < 200 || resp.StatusCode >= 300 {
+		d.logger.Warn("webhook delivery attempt rejected", "url", url, "status", resp.StatusCode)
+		return false
+	}
+	return true
+}
+
+func (d *Dispatcher) recordDelivered(eventType string) {
+	if d.metrics != nil {
+		d.metrics.IncDelivered(eventType)
+	}
+}
+
+func (d *Dispatcher) recordFailed(eventType string) {
+	if d.metrics != nil {
+		d.metrics.IncFailed(eventType)
+	}
+}
+
+func (d *Dispatcher) recordRetried(eventType string) {
+	if d.metrics != nil {
+		d.metrics.IncRetried(eventType)
+	}
+}
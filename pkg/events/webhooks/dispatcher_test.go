@@ -0,0 +1,121 @@
+package webhooks
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/MalteBoehm/tall-affiliate-common/pkg/events"
+)
+
+func testEvent() *events.Event {
+	return &events.Event{
+		ID:            "evt_1",
+		Type:          "05A_ENRICHMENT_COMPLETED",
+		AggregateType: "product",
+		AggregateID:   "asin_1",
+		Payload:       map[string]string{"asin": "asin_1"},
+		Timestamp:     time.Now(),
+	}
+}
+
+func TestSubscriptionMatchesByEventTypeGlob(t *testing.T) {
+	sub := Subscription{EventTypes: []string{"05*"}}
+	assert.True(t, sub.Matches(testEvent()))
+
+	sub = Subscription{EventTypes: []string{"EVENT_10A_*"}}
+	assert.False(t, sub.Matches(testEvent()))
+}
+
+func TestSubscriptionMatchesByAggregateType(t *testing.T) {
+	sub := Subscription{AggregateTypes: []string{"order"}}
+	assert.False(t, sub.Matches(testEvent()))
+
+	sub = Subscription{AggregateTypes: []string{"product"}}
+	assert.True(t, sub.Matches(testEvent()))
+}
+
+func TestDispatcherDeliversOnFirstTry(t *testing.T) {
+	var calls int32
+	var gotSignature string
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		atomic.AddInt32(&calls, 1)
+		gotSignature = r.Header.Get("X-Tall-Signature")
+		w.WriteHeader(http.StatusOK)
+	}))
+	defer server.Close()
+
+	store := NewInMemoryStore()
+	d := NewDispatcher(store, nil, nil)
+	d.AddSubscription(Subscription{ID: "sub1", URL: server.URL, Secret: "shh", EventTypes: []string{"05*"}})
+
+	err := d.Dispatch(context.Background(), testEvent())
+	require.NoError(t, err)
+
+	assert.Equal(t, int32(1), atomic.LoadInt32(&calls))
+	assert.NotEmpty(t, gotSignature)
+
+	due, err := store.Due(context.Background(), time.Now().Add(time.Hour), 10)
+	require.NoError(t, err)
+	assert.Empty(t, due)
+}
+
+func TestDispatcherPersistsFailedDeliveryForRedelivery(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusInternalServerError)
+	}))
+	defer server.Close()
+
+	store := NewInMemoryStore()
+	d := NewDispatcher(store, nil, nil)
+	d.AddSubscription(Subscription{ID: "sub1", URL: server.URL, Secret: "shh"})
+
+	err := d.Dispatch(context.Background(), testEvent())
+	require.NoError(t, err)
+
+	due, err := store.Due(context.Background(), time.Now().Add(time.Hour), 10)
+	require.NoError(t, err)
+	require.Len(t, due, 1)
+	assert.Equal(t, 1, due[0].Attempt)
+}
+
+func TestDispatcherSkipsNonMatchingSubscriptions(t *testing.T) {
+	var calls int32
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		atomic.AddInt32(&calls, 1)
+		w.WriteHeader(http.StatusOK)
+	}))
+	defer server.Close()
+
+	store := NewInMemoryStore()
+	d := NewDispatcher(store, nil, nil)
+	d.AddSubscription(Subscription{ID: "sub1", URL: server.URL, Secret: "shh", EventTypes: []string{"EVENT_10A_*"}})
+
+	err := d.Dispatch(context.Background(), testEvent())
+	require.NoError(t, err)
+	assert.Equal(t, int32(0), atomic.LoadInt32(&calls))
+}
+
+func TestDispatcherRemoveSubscription(t *testing.T) {
+	var calls int32
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		atomic.AddInt32(&calls, 1)
+		w.WriteHeader(http.StatusOK)
+	}))
+	defer server.Close()
+
+	store := NewInMemoryStore()
+	d := NewDispatcher(store, nil, nil)
+	d.AddSubscription(Subscription{ID: "sub1", URL: server.URL, Secret: "shh"})
+	d.RemoveSubscription("sub1")
+
+	err := d.Dispatch(context.Background(), testEvent())
+	require.NoError(t, err)
+	assert.Equal(t, int32(0), atomic.LoadInt32(&calls))
+}
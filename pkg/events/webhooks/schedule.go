@@ -0,0 +1,40 @@
+package webhooks
+
+import "time"
+
+// redeliverySchedule holds the fixed delays used for the first several
+// redelivery attempts after a delivery's initial three quick tries have all
+// failed. Once exhausted, nextRedeliveryDelay grows the last stage
+// exponentially, capped at maxRedeliveryDelay.
+var redeliverySchedule = []time.Duration{
+	30 * time.Minute,
+	60 * time.Minute,
+	90 * time.Minute,
+	2 * time.Hour,
+	3 * time.Hour,
+}
+
+// maxRedeliveryDelay caps the exponential growth applied once
+// redeliverySchedule is exhausted.
+const maxRedeliveryDelay = 24 * time.Hour
+
+// nextRedeliveryDelay returns the delay before redelivery attempt, where
+// attempt 1 is the first redelivery after the initial three quick tries
+// have all failed.
+func nextRedeliveryDelay(attempt int) time.Duration {
+	if attempt <= 0 {
+		attempt = 1
+	}
+	if attempt <= len(redeliverySchedule) {
+		return redeliverySchedule[attempt-1]
+	}
+
+	delay := redeliverySchedule[len(redeliverySchedule)-1]
+	for i := 0; i < attempt-len(redeliverySchedule); i++ {
+		delay *= 2
+		if delay >= maxRedeliveryDelay {
+			return maxRedeliveryDelay
+		}
+	}
+	return delay
+}
@@ -0,0 +1,26 @@
+package webhooks
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestNextRedeliveryDelayFollowsStagedSchedule(t *testing.T) {
+	assert.Equal(t, 30*time.Minute, nextRedeliveryDelay(1))
+	assert.Equal(t, 60*time.Minute, nextRedeliveryDelay(2))
+	assert.Equal(t, 90*time.Minute, nextRedeliveryDelay(3))
+	assert.Equal(t, 2*time.Hour, nextRedeliveryDelay(4))
+	assert.Equal(t, 3*time.Hour, nextRedeliveryDelay(5))
+}
+
+func TestNextRedeliveryDelayGrowsExponentiallyAfterSchedule(t *testing.T) {
+	assert.Equal(t, 6*time.Hour, nextRedeliveryDelay(6))
+	assert.Equal(t, 12*time.Hour, nextRedeliveryDelay(7))
+}
+
+func TestNextRedeliveryDelayCapsAt24Hours(t *testing.T) {
+	assert.Equal(t, 24*time.Hour, nextRedeliveryDelay(8))
+	assert.Equal(t, 24*time.Hour, nextRedeliveryDelay(20))
+}
@@ -0,0 +1,82 @@
+package webhooks
+
+import (
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/hex"
+	"fmt"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// DefaultReplayWindow is the maximum age Verify accepts for a signature's
+// timestamp before treating it as a replay.
+const DefaultReplayWindow = 5 * time.Minute
+
+// Sign computes the X-Tall-Signature header value for body: a timestamp and
+// the hex-encoded HMAC-SHA256 of body using secret.
+func Sign(body []byte, secret string) string {
+	mac := hmac.New(sha256.New, []byte(secret))
+	mac.Write(body)
+	sig := hex.EncodeToString(mac.Sum(nil))
+	return fmt.Sprintf("t=%d,v1=%s", time.Now().Unix(), sig)
+}
+
+// Verify checks that header is a valid X-Tall-Signature for body under
+// secret, and that its timestamp falls within DefaultReplayWindow of now -
+// receiver-side replay protection against a captured, re-sent request.
+func Verify(header string, body []byte, secret string) error {
+	t, sig, err := parseSignatureHeader(header)
+	if err != nil {
+		return err
+	}
+
+	age := time.Since(time.Unix(t, 0))
+	if age < 0 {
+		age = -age
+	}
+	if age > DefaultReplayWindow {
+		return fmt.Errorf("webhooks: signature timestamp outside replay window: %s", age)
+	}
+
+	mac := hmac.New(sha256.New, []byte(secret))
+	mac.Write(body)
+	expected := mac.Sum(nil)
+
+	got, err := hex.DecodeString(sig)
+	if err != nil {
+		return fmt.Errorf("webhooks: invalid signature encoding: %w", err)
+	}
+	if !hmac.Equal(expected, got) {
+		return fmt.Errorf("webhooks: signature mismatch")
+	}
+
+	return nil
+}
+
+// parseSignatureHeader splits a "t=<unix>,v1=<hex>" header into its fields.
+func parseSignatureHeader(header string) (t int64, sig string, err error) {
+	for _, part := range strings.Split(header, ",") {
+		kv := strings.SplitN(part, "=", 2)
+		if len(kv) != 2 {
+			continue
+		}
+		switch kv[0] {
+		case "t":
+			t, err = strconv.ParseInt(kv[1], 10, 64)
+			if err != nil {
+				return 0, "", fmt.Errorf("webhooks: invalid timestamp in signature header: %w", err)
+			}
+		case "v1":
+			sig = kv[1]
+		}
+	}
+	if sig == "" {
+		return 0, "", fmt.Errorf("webhooks: signature header missing v1 field")
+	}
+	if t == 0 {
+		return 0, "", fmt.Errorf("webhooks: signature header missing t field")
+	}
+	return t, sig, nil
+}
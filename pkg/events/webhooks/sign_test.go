@@ -0,0 +1,37 @@
+package webhooks
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestSignAndVerifyRoundTrip(t *testing.T) {
+	body := []byte(`{"event_id":"evt_1"}`)
+	header := Sign(body, "shh")
+
+	err := Verify(header, body, "shh")
+	assert.NoError(t, err)
+}
+
+func TestVerifyRejectsWrongSecret(t *testing.T) {
+	body := []byte(`{"event_id":"evt_1"}`)
+	header := Sign(body, "shh")
+
+	err := Verify(header, body, "wrong")
+	assert.Error(t, err)
+}
+
+func TestVerifyRejectsTamperedBody(t *testing.T) {
+	body := []byte(`{"event_id":"evt_1"}`)
+	header := Sign(body, "shh")
+
+	err := Verify(header, []byte(`{"event_id":"evt_2"}`), "shh")
+	assert.Error(t, err)
+}
+
+func TestVerifyRejectsMalformedHeader(t *testing.T) {
+	err := Verify("not-a-valid-header", []byte("body"), "shh")
+	require.Error(t, err)
+}
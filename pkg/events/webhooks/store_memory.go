@@ -0,0 +1,74 @@
+package webhooks
+
+import (
+	"context"
+	"fmt"
+	"sort"
+	"sync"
+	"time"
+)
+
+// InMemoryStore is a Store backed by an in-process map. It is safe for
+// concurrent use and is intended for tests and single-process services.
+type InMemoryStore struct {
+	mu         sync.Mutex
+	deliveries map[string]PendingDelivery
+}
+
+// NewInMemoryStore creates an empty InMemoryStore.
+func NewInMemoryStore() *InMemoryStore {
+	return &InMemoryStore{deliveries: make(map[string]PendingDelivery)}
+}
+
+// Save implements Store.
+func (s *InMemoryStore) Save(_ context.Context, delivery PendingDelivery) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.deliveries[delivery.ID] = delivery
+	return nil
+}
+
+// Due implements Store.
+func (s *InMemoryStore) Due(_ context.Context, now time.Time, limit int) ([]PendingDelivery, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	var due []PendingDelivery
+	for _, d := range s.deliveries {
+		if !d.NextAttemptAt.After(now) {
+			due = append(due, d)
+		}
+	}
+	sort.Slice(due, func(i, j int) bool {
+		return due[i].NextAttemptAt.Before(due[j].NextAttemptAt)
+	})
+	if limit > 0 && len(due) > limit {
+		due = due[:limit]
+	}
+	return due, nil
+}
+
+// UpdateAttempt implements Store.
+func (s *InMemoryStore) UpdateAttempt(_ context.Context, id string, attempt int, nextAttemptAt time.Time) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	d, ok := s.deliveries[id]
+	if !ok {
+		return fmt.Errorf("webhooks: delivery %s not found", id)
+	}
+	d.Attempt = attempt
+	d.NextAttemptAt = nextAttemptAt
+	s.deliveries[id] = d
+	return nil
+}
+
+// Delete implements Store.
+func (s *InMemoryStore) Delete(_ context.Context, id string) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	delete(s.deliveries, id)
+	return nil
+}
+
+var _ Store = (*InMemoryStore)(nil)
@@ -0,0 +1,97 @@
+package webhooks
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+	"time"
+)
+
+// PostgresStore is a Store backed by a webhook_deliveries table:
+//
+//	CREATE TABLE webhook_deliveries (
+//		id               TEXT PRIMARY KEY,
+//		subscription_id  TEXT NOT NULL,
+//		url              TEXT NOT NULL,
+//		secret           TEXT NOT NULL,
+//		event_type       TEXT NOT NULL,
+//		body             BYTEA NOT NULL,
+//		attempt          INTEGER NOT NULL,
+//		next_attempt_at  TIMESTAMPTZ NOT NULL,
+//		created_at       TIMESTAMPTZ NOT NULL
+//	);
+type PostgresStore struct {
+	db *sql.DB
+}
+
+// NewPostgresStore creates a PostgresStore using db.
+func NewPostgresStore(db *sql.DB) *PostgresStore {
+	return &PostgresStore{db: db}
+}
+
+// Save implements Store.
+func (s *PostgresStore) Save(ctx context.Context, delivery PendingDelivery) error {
+	_, err := s.db.ExecContext(ctx, `
+		INSERT INTO webhook_deliveries
+			(id, subscription_id, url, secret, event_type, body, attempt, next_attempt_at, created_at)
+		VALUES ($1, $2, $3, $4, $5, $6, $7, $8, $9)`,
+		delivery.ID, delivery.SubscriptionID, delivery.URL, delivery.Secret, delivery.EventType,
+		delivery.Body, delivery.Attempt, delivery.NextAttemptAt, delivery.CreatedAt)
+	if err != nil {
+		return fmt.Errorf("webhooks: insert pending delivery: %w", err)
+	}
+	return nil
+}
+
+// Due implements Store.
+func (s *PostgresStore) Due(ctx context.Context, now time.Time, limit int) ([]PendingDelivery, error) {
+	rows, err := s.db.QueryContext(ctx, `
+		SELECT id, subscription_id, url, secret, event_type, body, attempt, next_attempt_at, created_at
+		FROM webhook_deliveries
+		WHERE next_attempt_at <= $1
+		ORDER BY next_attempt_at ASC
+		LIMIT $2`, now, limit)
+	if err != nil {
+		return nil, fmt.Errorf("webhooks: query due deliveries: %w", err)
+	}
+	defer rows.Close()
+
+	var due []PendingDelivery
+	for rows.Next() {
+		var d PendingDelivery
+		if err := rows.Scan(&d.ID, &d.SubscriptionID, &d.URL, &d.Secret, &d.EventType,
+			&d.Body, &d.Attempt, &d.NextAttemptAt, &d.CreatedAt); err != nil {
+			return nil, fmt.Errorf("webhooks: scan due delivery: %w", err)
+		}
+		due = append(due, d)
+	}
+	return due, rows.Err()
+}
+
+// UpdateAttempt implements Store.
+func (s *PostgresStore) UpdateAttempt(ctx context.Context, id string, attempt int, nextAttemptAt time.Time) error {
+	result, err := s.db.ExecContext(ctx, `
+		UPDATE webhook_deliveries SET attempt = $2, next_attempt_at = $3 WHERE id = $1`,
+		id, attempt, nextAttemptAt)
+	if err != nil {
+		return fmt.Errorf("webhooks: update delivery attempt: %w", err)
+	}
+	rows, err := result.RowsAffected()
+	if err != nil {
+		return fmt.Errorf("webhooks: update delivery attempt: %w", err)
+	}
+	if rows == 0 {
+		return fmt.Errorf("webhooks: delivery %s not found", id)
+	}
+	return nil
+}
+
+// Delete implements Store.
+func (s *PostgresStore) Delete(ctx context.Context, id string) error {
+	if _, err := s.db.ExecContext(ctx, `DELETE FROM webhook_deliveries WHERE id = $1`, id); err != nil {
+		return fmt.Errorf("webhooks: delete delivery: %w", err)
+	}
+	return nil
+}
+
+var _ Store = (*PostgresStore)(nil)
@@ -0,0 +1,114 @@
+// Package webhooks delivers pkg/events domain events to external HTTP
+// consumers: a Dispatcher matches each *events.Event against configured
+// Subscriptions and POSTs a signed JSON envelope to every match, retrying
+// failed deliveries on a staged backoff schedule persisted via Store so a
+// restart doesn't drop pending redeliveries.
+package webhooks
+
+import (
+	"context"
+	"path"
+	"time"
+
+	"github.com/MalteBoehm/tall-affiliate-common/pkg/events"
+)
+
+// Subscription describes one external consumer's interest in events.
+type Subscription struct {
+	// ID identifies the subscription, e.g. for logging and metrics.
+	ID string
+	// URL receives the signed envelope via HTTP POST.
+	URL string
+	// Secret is the per-subscription HMAC key used by Sign/Verify.
+	Secret string
+	// EventTypes is a set of glob patterns (path.Match syntax, e.g. "05*" or
+	// "EVENT_10A_*") matched against the event's Type. A subscription with no
+	// patterns matches every event type.
+	EventTypes []string
+	// AggregateTypes optionally restricts matches to the given
+	// event.AggregateType values. Empty means no restriction.
+	AggregateTypes []string
+}
+
+// Matches reports whether event satisfies sub's EventTypes and
+// AggregateTypes filters.
+func (sub Subscription) Matches(event *events.Event) bool {
+	if len(sub.AggregateTypes) > 0 && !containsString(sub.AggregateTypes, event.AggregateType) {
+		return false
+	}
+	if len(sub.EventTypes) == 0 {
+		return true
+	}
+	for _, pattern := range sub.EventTypes {
+		if ok, _ := path.Match(pattern, event.Type); ok {
+			return true
+		}
+	}
+	return false
+}
+
+func containsString(values []string, target string) bool {
+	for _, v := range values {
+		if v == target {
+			return true
+		}
+	}
+	return false
+}
+
+// Envelope is the JSON body POSTed to a subscription's URL.
+type Envelope struct {
+	EventID     string    `json:"event_id"`
+	EventType   string    `json:"event_type"`
+	AggregateID string    `json:"aggregate_id"`
+	CreatedAt   time.Time `json:"created_at"`
+	Payload     any       `json:"payload"`
+}
+
+// NewEnvelope builds the Envelope for event.
+func NewEnvelope(event *events.Event) Envelope {
+	return Envelope{
+		EventID:     event.ID,
+		EventType:   event.Type,
+		AggregateID: event.AggregateID,
+		CreatedAt:   event.Timestamp,
+		Payload:     event.Payload,
+	}
+}
+
+// PendingDelivery is a webhook delivery that has failed at least once and is
+// awaiting redelivery, persisted via Store so restarts don't lose it.
+type PendingDelivery struct {
+	ID             string
+	SubscriptionID string
+	URL            string
+	Secret         string
+	EventType      string
+	Body           []byte
+	Attempt        int
+	NextAttemptAt  time.Time
+	CreatedAt      time.Time
+}
+
+// Store persists PendingDeliveries across restarts.
+type Store interface {
+	// Save records a new pending delivery.
+	Save(ctx context.Context, delivery PendingDelivery) error
+	// Due returns up to limit pending deliveries with NextAttemptAt <= now,
+	// ordered oldest-due first.
+	Due(ctx context.Context, now time.Time, limit int) ([]PendingDelivery, error)
+	// UpdateAttempt records a failed redelivery attempt, advancing attempt
+	// and nextAttemptAt for the next try.
+	UpdateAttempt(ctx context.Context, id string, attempt int, nextAttemptAt time.Time) error
+	// Delete removes a delivery once it has succeeded.
+	Delete(ctx context.Context, id string) error
+}
+
+// MetricsSink receives delivery outcome counts per event type. Implementations
+// are expected to be cheap and non-blocking (e.g. incrementing a Prometheus
+// counter); Dispatcher does not buffer or batch calls.
+type MetricsSink interface {
+	IncDelivered(eventType string)
+	IncFailed(eventType string)
+	IncRetried(eventType string)
+}
@@ -0,0 +1,27 @@
+package interfaces
+
+import (
+	"context"
+	"time"
+)
+
+// RetryPolicy computes the backoff delay a stream consumer should wait
+// before re-dispatching a message, as a function of how many times it has
+// already been delivered. pkg/redis.RetryPolicy satisfies this
+// structurally, so existing callers need no changes to be usable wherever
+// this interface is expected.
+type RetryPolicy interface {
+	// Delay returns the backoff duration before attempt should be retried.
+	// attempt below 1 is treated as 1.
+	Delay(attempt int) time.Duration
+}
+
+// DLQPublisher publishes a message that exceeded its retry budget to a
+// backend-specific dead-letter destination, tagging it with the headers
+// StreamConsumer implementations are expected to set: x-original-id,
+// x-failure-reason, and x-delivery-count.
+type DLQPublisher interface {
+	// PublishDeadLetter writes payload (the original message body) to the
+	// dead-letter destination for originalStream, along with headers.
+	PublishDeadLetter(ctx context.Context, originalStream string, headers map[string]string, payload []byte) error
+}
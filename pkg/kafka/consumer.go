@@ -0,0 +1,207 @@
+package kafka
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"log/slog"
+	"strconv"
+	"strings"
+	"time"
+
+	kafka "github.com/segmentio/kafka-go"
+
+	"github.com/MalteBoehm/tall-affiliate-common/pkg/events"
+	"github.com/MalteBoehm/tall-affiliate-common/pkg/interfaces"
+)
+
+// StreamConsumer consumes events from a Kafka topic as part of a consumer
+// group, satisfying interfaces.StreamConsumer with the same ack and
+// dead-letter semantics as pkg/redis.StreamConsumer: "claim-on-rebalance"
+// falls out of Kafka's own group-coordination protocol (a crashed member's
+// partitions are reassigned to a live one, which resumes from the last
+// committed offset, so there is no separate claim step to drive), and
+// "pending inspection" is exposed via GroupLag.
+type StreamConsumer struct {
+	brokersList  []string
+	streamName   string
+	groupName    string
+	consumerName string
+	logger       *slog.Logger
+}
+
+// NewStreamConsumer creates a StreamConsumer that will consume streamName
+// as part of groupName. consumerName is used only as the reader's
+// ClientID, for log/metric correlation; Kafka's own group protocol, not
+// consumerName, determines partition ownership.
+func NewStreamConsumer(brokers []string, streamName, groupName, consumerName string, logger *slog.Logger) *StreamConsumer {
+	if logger == nil {
+		logger = slog.Default()
+	}
+	return &StreamConsumer{
+		brokersList:  brokers,
+		streamName:   streamName,
+		groupName:    groupName,
+		consumerName: consumerName,
+		logger:       logger.With("component", "kafka-stream-consumer", "stream", streamName),
+	}
+}
+
+// ConsumeStream implements interfaces.StreamConsumer. batchSize sets the
+// reader's QueueCapacity; pollInterval sets MaxWait between fetches.
+// Offsets are committed synchronously after handler succeeds, so a failed
+// handler call leaves the message to be redelivered on the next fetch.
+func (c *StreamConsumer) ConsumeStream(
+	ctx context.Context,
+	streamName string,
+	groupName string,
+	batchSize int64,
+	pollInterval time.Duration,
+	handler func(context.Context, *events.Event, string) error,
+) error {
+	reader := kafka.NewReader(kafka.ReaderConfig{
+		Brokers:        c.brokersList,
+		Topic:          streamName,
+		GroupID:        groupName,
+		Dialer:         &kafka.Dialer{ClientID: c.consumerName},
+		QueueCapacity:  int(batchSize),
+		MaxWait:        pollInterval,
+		CommitInterval: 0,
+	})
+	defer reader.Close()
+
+	c.logger.Info("starting to consume topic",
+		"topic", streamName,
+		"group", groupName,
+		"consumer", c.consumerName,
+		"batchSize", batchSize,
+		"pollInterval", pollInterval)
+
+	for {
+		select {
+		case <-ctx.Done():
+			return ctx.Err()
+		default:
+		}
+
+		msg, err := reader.FetchMessage(ctx)
+		if err != nil {
+			if ctx.Err() != nil {
+				return ctx.Err()
+			}
+			c.logger.Error("failed to fetch message", "error", err)
+			continue
+		}
+
+		if err := c.processMessage(ctx, reader, msg, handler); err != nil {
+			c.logger.Error("failed to process message",
+				"partition", msg.Partition,
+				"offset", msg.Offset,
+				"error", err)
+		}
+	}
+}
+
+func (c *StreamConsumer) processMessage(
+	ctx context.Context,
+	reader *kafka.Reader,
+	msg kafka.Message,
+	handler func(context.Context, *events.Event, string) error,
+) error {
+	ackToken := ackTokenFor(msg)
+
+	var event events.Event
+	if err := json.Unmarshal(msg.Value, &event); err != nil {
+		if dlqErr := c.deadLetterParseError(ctx, msg, fmt.Errorf("failed to unmarshal event: %w", err)); dlqErr != nil {
+			return dlqErr
+		}
+		return reader.CommitMessages(ctx, msg)
+	}
+
+	if err := handler(ctx, &event, ackToken); err != nil {
+		return fmt.Errorf("handler failed: %w", err)
+	}
+
+	if err := reader.CommitMessages(ctx, msg); err != nil {
+		return fmt.Errorf("failed to commit message %s: %w", ackToken, err)
+	}
+	return nil
+}
+
+// dlqTopic is the dead-letter topic a poison message - one whose value
+// fails to unmarshal into events.Event - is routed to, mirroring
+// pkg/redis.StreamConsumer's "<stream>:parse-errors" DLQ stream.
+func (c *StreamConsumer) dlqTopic() string {
+	return c.streamName + ".dlq"
+}
+
+// deadLetterParseError republishes msg onto dlqTopic, tagging it with the
+// decode error and original topic, so a poison message doesn't get stuck
+// retrying forever once its offset is committed.
+func (c *StreamConsumer) deadLetterParseError(ctx context.Context, msg kafka.Message, parseErr error) error {
+	writer := &kafka.Writer{
+		Addr:                   kafka.TCP(c.brokersList...),
+		Topic:                  c.dlqTopic(),
+		AllowAutoTopicCreation: true,
+	}
+	defer writer.Close()
+
+	headers := append([]kafka.Header{}, msg.Headers...)
+	headers = append(headers,
+		kafka.Header{Key: "x-original-topic", Value: []byte(c.streamName)},
+		kafka.Header{Key: "x-error", Value: []byte(parseErr.Error())},
+		kafka.Header{Key: "x-first-seen", Value: []byte(time.Now().Format(time.RFC3339Nano))},
+	)
+
+	err := writer.WriteMessages(ctx, kafka.Message{Key: msg.Key, Value: msg.Value, Headers: headers})
+	if err != nil {
+		c.logger.Error("failed to publish poison message to dlq topic", "offset", msg.Offset, "error", err)
+		return fmt.Errorf("failed to dead-letter message at offset %d: %w", msg.Offset, err)
+	}
+	c.logger.Warn("poison message routed to dlq topic", "offset", msg.Offset, "error", parseErr)
+	return nil
+}
+
+// GroupLag returns the total number of unconsumed messages across all
+// partitions streamName's groupName consumer group is assigned, the
+// Kafka analogue of pkg/redis.StreamConsumer.PendingMessages.
+func (c *StreamConsumer) GroupLag(ctx context.Context) (int64, error) {
+	reader := kafka.NewReader(kafka.ReaderConfig{
+		Brokers: c.brokersList,
+		Topic:   c.streamName,
+		GroupID: c.groupName,
+	})
+	defer reader.Close()
+
+	lag, err := reader.ReadLag(ctx)
+	if err != nil {
+		return 0, fmt.Errorf("failed to read group lag for topic %s: %w", c.streamName, err)
+	}
+	return lag, nil
+}
+
+// ackTokenFor encodes a Kafka message's position as the string ack token
+// handlers receive, in "partition:offset" form.
+func ackTokenFor(msg kafka.Message) string {
+	return strconv.Itoa(msg.Partition) + ":" + strconv.FormatInt(msg.Offset, 10)
+}
+
+// parseAckToken is the inverse of ackTokenFor, for callers that need to
+// resolve a previously handed-out ack token back to a partition/offset pair.
+func parseAckToken(token string) (partition int, offset int64, err error) {
+	parts := strings.SplitN(token, ":", 2)
+	if len(parts) != 2 {
+		return 0, 0, fmt.Errorf("invalid kafka ack token %q", token)
+	}
+	partition, err = strconv.Atoi(parts[0])
+	if err != nil {
+		return 0, 0, fmt.Errorf("invalid kafka ack token %q: %w", token, err)
+	}
+	offset, err = strconv.ParseInt(parts[1], 10, 64)
+	if err != nil {
+		return 0, 0, fmt.Errorf("invalid kafka ack token %q: %w", token, err)
+	}
+	return partition, offset, nil
+}
+
+var _ interfaces.StreamConsumer = (*StreamConsumer)(nil)
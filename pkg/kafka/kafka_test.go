@@ -0,0 +1,45 @@
+package kafka
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	kafka "github.com/segmentio/kafka-go"
+
+	"github.com/MalteBoehm/tall-affiliate-common/pkg/streamtest"
+)
+
+func requireBroker(t *testing.T) []string {
+	t.Helper()
+	brokers := []string{"localhost:9092"}
+	conn, err := kafka.DialContext(context.Background(), "tcp", brokers[0])
+	if err != nil {
+		t.Skip("Kafka not available")
+	}
+	conn.Close()
+	return brokers
+}
+
+func TestStreamConformance(t *testing.T) {
+	brokers := requireBroker(t)
+
+	producer := NewStreamProducer(brokers, nil)
+	defer producer.Close()
+	consumer := NewStreamConsumer(brokers, "conformance-topic", "conformance-group", "conformance-consumer", nil)
+
+	streamtest.RunConformanceSuite(t, producer, consumer, "conformance-topic", "conformance-group", 15*time.Second)
+}
+
+func TestAckTokenRoundTrip(t *testing.T) {
+	msg := kafka.Message{Partition: 3, Offset: 42}
+	token := ackTokenFor(msg)
+
+	partition, offset, err := parseAckToken(token)
+	if err != nil {
+		t.Fatalf("parseAckToken: %v", err)
+	}
+	if partition != 3 || offset != 42 {
+		t.Fatalf("got partition=%d offset=%d, want 3, 42", partition, offset)
+	}
+}
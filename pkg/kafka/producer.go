@@ -0,0 +1,110 @@
+// Package kafka provides Kafka-backed implementations of
+// interfaces.StreamProducer and interfaces.StreamConsumer that mirror the
+// semantics of pkg/redis's stream types - consumer groups, per-message
+// ack, claim-on-rebalance, and pending (lag) inspection - so a service can
+// switch a stream's backend via config without rewriting its handlers.
+// pkg/adapters.KafkaProducerAdapter/KafkaConsumerAdapter remain the
+// simpler DI-layer wrappers; StreamProducer/StreamConsumer here are the
+// transport-layer counterpart to pkg/redis.StreamProducer/StreamConsumer.
+package kafka
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"log/slog"
+	"sync"
+
+	kafka "github.com/segmentio/kafka-go"
+
+	"github.com/MalteBoehm/tall-affiliate-common/pkg/events"
+	"github.com/MalteBoehm/tall-affiliate-common/pkg/interfaces"
+)
+
+// StreamProducer publishes events to Kafka topics, keyed by AggregateID so
+// every event for a given aggregate lands on the same partition and is
+// delivered in order.
+type StreamProducer struct {
+	brokers []string
+	logger  *slog.Logger
+
+	mu      sync.Mutex
+	writers map[string]*kafka.Writer
+}
+
+// NewStreamProducer creates a StreamProducer that dials brokers on demand,
+// one writer per topic.
+func NewStreamProducer(brokers []string, logger *slog.Logger) *StreamProducer {
+	if logger == nil {
+		logger = slog.Default()
+	}
+	return &StreamProducer{
+		brokers: brokers,
+		logger:  logger.With("component", "kafka-stream-producer"),
+		writers: make(map[string]*kafka.Writer),
+	}
+}
+
+// PublishEvent implements interfaces.StreamProducer.
+func (p *StreamProducer) PublishEvent(ctx context.Context, streamName string, event *events.Event) error {
+	if streamName == "" {
+		return fmt.Errorf("stream name cannot be empty")
+	}
+	if event == nil {
+		return fmt.Errorf("event cannot be nil")
+	}
+
+	payload, err := json.Marshal(event)
+	if err != nil {
+		return fmt.Errorf("failed to marshal event: %w", err)
+	}
+
+	writer := p.writerFor(streamName)
+	if err := writer.WriteMessages(ctx, kafka.Message{
+		Key:   []byte(event.AggregateID),
+		Value: payload,
+	}); err != nil {
+		return fmt.Errorf("failed to publish event to topic %s: %w", streamName, err)
+	}
+
+	p.logger.Debug("event published to topic",
+		"topic", streamName,
+		"eventType", event.Type,
+		"eventID", event.ID,
+		"aggregateID", event.AggregateID)
+	return nil
+}
+
+func (p *StreamProducer) writerFor(topic string) *kafka.Writer {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+
+	if writer, ok := p.writers[topic]; ok {
+		return writer
+	}
+	writer := &kafka.Writer{
+		Addr:                   kafka.TCP(p.brokers...),
+		Topic:                  topic,
+		Balancer:               &kafka.Hash{},
+		AllowAutoTopicCreation: true,
+	}
+	p.writers[topic] = writer
+	return writer
+}
+
+// Close releases every writer created by PublishEvent.
+func (p *StreamProducer) Close() error {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+
+	var firstErr error
+	for topic, writer := range p.writers {
+		if err := writer.Close(); err != nil && firstErr == nil {
+			firstErr = err
+		}
+		delete(p.writers, topic)
+	}
+	return firstErr
+}
+
+var _ interfaces.StreamProducer = (*StreamProducer)(nil)
@@ -0,0 +1,89 @@
+package metering
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/MalteBoehm/tall-affiliate-common/pkg/events"
+	"github.com/MalteBoehm/tall-affiliate-common/pkg/interfaces"
+)
+
+// BillingUsageRecordedPayload is the payload of a MON_BILLING_USAGE_RECORDED
+// event emitted by Aggregator.Run, one per tenant per window.
+type BillingUsageRecordedPayload struct {
+	Tenant      string           `json:"tenant"`
+	Window      string           `json:"window"`
+	ByEventType map[string]Stats `json:"by_event_type"`
+}
+
+// Aggregator periodically snapshots a set of tenants' usage from a Meter
+// and publishes it as events.CodeToCE["MON_BILLING_USAGE_RECORDED"] events, so
+// downstream billing systems can subscribe instead of querying the meter
+// directly.
+type Aggregator struct {
+	meter      Meter
+	producer   interfaces.StreamProducer
+	streamName string
+	tenants    func(ctx context.Context) ([]string, error)
+}
+
+// NewAggregator creates an Aggregator that publishes usage snapshots onto
+// streamName via producer. tenants is called once per tick to list the
+// tenants to snapshot.
+func NewAggregator(meter Meter, producer interfaces.StreamProducer, streamName string, tenants func(ctx context.Context) ([]string, error)) *Aggregator {
+	return &Aggregator{meter: meter, producer: producer, streamName: streamName, tenants: tenants}
+}
+
+// Run calls RunOnce every interval until ctx is cancelled.
+func (a *Aggregator) Run(ctx context.Context, interval time.Duration) error {
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-ctx.Done():
+			return ctx.Err()
+		case <-ticker.C:
+			if err := a.RunOnce(ctx); err != nil {
+				return err
+			}
+		}
+	}
+}
+
+// RunOnce snapshots every tenant returned by a.tenants and publishes one
+// MON_BILLING_USAGE_RECORDED event per tenant whose snapshot has any
+// recorded usage.
+func (a *Aggregator) RunOnce(ctx context.Context) error {
+	tenants, err := a.tenants(ctx)
+	if err != nil {
+		return fmt.Errorf("metering: failed to list tenants: %w", err)
+	}
+
+	now := time.Now()
+	for _, tenant := range tenants {
+		snap, err := a.meter.Snapshot(ctx, tenant, now)
+		if err != nil {
+			return fmt.Errorf("metering: failed to snapshot tenant %s: %w", tenant, err)
+		}
+		if len(snap.ByEventType) == 0 {
+			continue
+		}
+
+		payload := BillingUsageRecordedPayload{
+			Tenant:      tenant,
+			Window:      snap.Window,
+			ByEventType: snap.ByEventType,
+		}
+		evt, err := events.NewEvent(events.CodeToCE["MON_BILLING_USAGE_RECORDED"], "billing", tenant, payload)
+		if err != nil {
+			return fmt.Errorf("metering: failed to build usage event for tenant %s: %w", tenant, err)
+		}
+
+		if err := a.producer.PublishEvent(ctx, a.streamName, evt); err != nil {
+			return fmt.Errorf("metering: failed to publish usage event for tenant %s: %w", tenant, err)
+		}
+	}
+	return nil
+}
@@ -0,0 +1,31 @@
+package metering
+
+import (
+	"context"
+	"time"
+
+	"github.com/MalteBoehm/tall-affiliate-common/pkg/events"
+)
+
+// WrapHandler wraps a ConsumeStream-style handler so every call records
+// its tenant's usage (event count, payload size, processing duration) on
+// meter before returning, regardless of whether handler itself succeeds -
+// a failed handler call still consumed processing time and counts toward
+// the tenant's usage.
+func WrapHandler(
+	meter Meter,
+	extractor events.TenantExtractor,
+	handler func(context.Context, *events.Event, string) error,
+) func(context.Context, *events.Event, string) error {
+	return func(ctx context.Context, evt *events.Event, messageID string) error {
+		start := time.Now()
+		err := handler(ctx, evt, messageID)
+		duration := time.Since(start)
+
+		tenant := events.Tenant(evt, extractor)
+		payloadBytes := EstimatePayloadBytes(evt.Payload)
+		_ = meter.RecordConsume(ctx, tenant, evt.Type, payloadBytes, duration)
+
+		return err
+	}
+}
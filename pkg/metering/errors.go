@@ -0,0 +1,26 @@
+package metering
+
+import (
+	"errors"
+	"strconv"
+)
+
+// errSnapshotUnsupported is returned by Meter implementations (such as
+// PrometheusMeter) that don't support point-in-time Snapshot queries.
+var errSnapshotUnsupported = errors.New("metering: snapshot not supported by this meter")
+
+// ErrQuotaExceeded is returned by QuotaEnforcer.PublishEvent when a
+// tenant's usage for the current window has reached its configured
+// limit.
+type ErrQuotaExceeded struct {
+	Tenant string
+	Window string
+	Limit  int64
+	Usage  int64
+}
+
+func (e *ErrQuotaExceeded) Error() string {
+	return "metering: tenant " + e.Tenant + " exceeded quota (" +
+		strconv.FormatInt(e.Usage, 10) + "/" + strconv.FormatInt(e.Limit, 10) +
+		" in window " + e.Window + ")"
+}
@@ -0,0 +1,84 @@
+package metering
+
+import (
+	"context"
+	"sync"
+	"time"
+)
+
+// InMemoryMeter is a Meter backed by an in-process map, for tests and
+// single-process services that don't need usage data to survive a
+// restart or be shared across instances.
+type InMemoryMeter struct {
+	mu   sync.Mutex
+	data map[string]map[string]map[string]Stats // tenant -> window -> eventType -> Stats
+}
+
+// NewInMemoryMeter creates an empty InMemoryMeter.
+func NewInMemoryMeter() *InMemoryMeter {
+	return &InMemoryMeter{data: make(map[string]map[string]map[string]Stats)}
+}
+
+func (m *InMemoryMeter) statsFor(tenant, window, eventType string) Stats {
+	byWindow, ok := m.data[tenant]
+	if !ok {
+		return Stats{}
+	}
+	byType, ok := byWindow[window]
+	if !ok {
+		return Stats{}
+	}
+	return byType[eventType]
+}
+
+func (m *InMemoryMeter) setStats(tenant, window, eventType string, s Stats) {
+	if m.data[tenant] == nil {
+		m.data[tenant] = make(map[string]map[string]Stats)
+	}
+	if m.data[tenant][window] == nil {
+		m.data[tenant][window] = make(map[string]Stats)
+	}
+	m.data[tenant][window][eventType] = s
+}
+
+// RecordPublish implements Meter.
+func (m *InMemoryMeter) RecordPublish(ctx context.Context, tenant, eventType string, payloadBytes int) error {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	window := WindowKey(time.Now())
+	s := m.statsFor(tenant, window, eventType)
+	s.EventsEmitted++
+	s.TotalPayloadBytes += int64(payloadBytes)
+	m.setStats(tenant, window, eventType, s)
+	return nil
+}
+
+// RecordConsume implements Meter.
+func (m *InMemoryMeter) RecordConsume(ctx context.Context, tenant, eventType string, payloadBytes int, duration time.Duration) error {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	window := WindowKey(time.Now())
+	s := m.statsFor(tenant, window, eventType)
+	s.EventsConsumed++
+	s.TotalPayloadBytes += int64(payloadBytes)
+	s.TotalProcessingDuration += duration
+	m.setStats(tenant, window, eventType, s)
+	return nil
+}
+
+// Snapshot implements Meter.
+func (m *InMemoryMeter) Snapshot(ctx context.Context, tenant string, at time.Time) (Snapshot, error) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	window := WindowKey(at)
+	snap := Snapshot{Tenant: tenant, Window: window, ByEventType: make(map[string]Stats)}
+	for eventType, s := range m.data[tenant][window] {
+		snap.ByEventType[eventType] = s
+	}
+	return snap, nil
+}
+
+var _ Meter = (*InMemoryMeter)(nil)
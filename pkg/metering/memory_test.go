@@ -0,0 +1,53 @@
+package metering
+
+import (
+	"context"
+	"testing"
+	"time"
+)
+
+func TestInMemoryMeterRecordsAndSnapshots(t *testing.T) {
+	m := NewInMemoryMeter()
+	ctx := context.Background()
+
+	if err := m.RecordPublish(ctx, "acme", "product.created", 100); err != nil {
+		t.Fatalf("RecordPublish: %v", err)
+	}
+	if err := m.RecordConsume(ctx, "acme", "product.created", 100, 5*time.Millisecond); err != nil {
+		t.Fatalf("RecordConsume: %v", err)
+	}
+
+	snap, err := m.Snapshot(ctx, "acme", time.Now())
+	if err != nil {
+		t.Fatalf("Snapshot: %v", err)
+	}
+
+	stats, ok := snap.ByEventType["product.created"]
+	if !ok {
+		t.Fatalf("expected stats for product.created, got %v", snap.ByEventType)
+	}
+	if stats.EventsEmitted != 1 || stats.EventsConsumed != 1 {
+		t.Errorf("got EventsEmitted=%d EventsConsumed=%d, want 1/1", stats.EventsEmitted, stats.EventsConsumed)
+	}
+	if stats.TotalPayloadBytes != 200 {
+		t.Errorf("TotalPayloadBytes = %d, want 200", stats.TotalPayloadBytes)
+	}
+	if stats.AvgPayloadBytes() != 100 {
+		t.Errorf("AvgPayloadBytes() = %v, want 100", stats.AvgPayloadBytes())
+	}
+}
+
+func TestInMemoryMeterSeparatesTenants(t *testing.T) {
+	m := NewInMemoryMeter()
+	ctx := context.Background()
+
+	_ = m.RecordPublish(ctx, "acme", "x", 10)
+	_ = m.RecordPublish(ctx, "globex", "x", 10)
+
+	acme, _ := m.Snapshot(ctx, "acme", time.Now())
+	globex, _ := m.Snapshot(ctx, "globex", time.Now())
+
+	if acme.ByEventType["x"].EventsEmitted != 1 || globex.ByEventType["x"].EventsEmitted != 1 {
+		t.Errorf("tenants leaked into each other's counters: acme=%v globex=%v", acme, globex)
+	}
+}
@@ -0,0 +1,61 @@
+// Package metering records per-tenant, per-event-type usage (events
+// published, events consumed, payload bytes, processing duration) so
+// downstream systems can bill on it or enforce quotas. It hooks into a
+// producer/consumer via QuotaEnforcer/WrapHandler rather than
+// events.Event or ServiceEventAdapter themselves, so metering stays
+// opt-in per service.
+package metering
+
+import (
+	"context"
+	"time"
+)
+
+// Stats accumulates one tenant/event-type/window's counters.
+type Stats struct {
+	EventsEmitted           int64
+	EventsConsumed          int64
+	TotalPayloadBytes       int64
+	TotalProcessingDuration time.Duration
+}
+
+// AvgPayloadBytes returns the mean payload size across every recorded
+// publish and consume, or 0 if nothing was recorded yet.
+func (s Stats) AvgPayloadBytes() float64 {
+	count := s.EventsEmitted + s.EventsConsumed
+	if count == 0 {
+		return 0
+	}
+	return float64(s.TotalPayloadBytes) / float64(count)
+}
+
+// Snapshot is one tenant's usage for a single hourly window.
+type Snapshot struct {
+	Tenant      string
+	Window      string // yyyymmddhh, UTC
+	ByEventType map[string]Stats
+}
+
+// Meter records usage and answers Snapshot queries for it. Implementations:
+// NewInMemoryMeter (tests), NewRedisMeter (HINCRBY on
+// "metering:{tenant}:{yyyymmddhh}" hashes), NewPrometheusMeter
+// (CounterVec/HistogramVec).
+type Meter interface {
+	// RecordPublish records one event of eventType published by tenant,
+	// with a serialized payload of payloadBytes.
+	RecordPublish(ctx context.Context, tenant, eventType string, payloadBytes int) error
+	// RecordConsume records one event of eventType consumed by tenant,
+	// with a serialized payload of payloadBytes that took duration to
+	// process.
+	RecordConsume(ctx context.Context, tenant, eventType string, payloadBytes int, duration time.Duration) error
+	// Snapshot returns tenant's accumulated usage for the hourly window
+	// containing at.
+	Snapshot(ctx context.Context, tenant string, at time.Time) (Snapshot, error)
+}
+
+// WindowKey returns the hourly window identifier ("yyyymmddhh", UTC) at
+// belongs to - the granularity metering:{tenant}:{window} keys are
+// bucketed at.
+func WindowKey(at time.Time) string {
+	return at.UTC().Format("2006010215")
+}
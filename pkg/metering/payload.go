@@ -0,0 +1,18 @@
+package metering
+
+import "encoding/json"
+
+// EstimatePayloadBytes returns the JSON-serialized size of payload, or 0
+// if it can't be marshaled. This is an estimate - producers may use a
+// different wire encoding (see pkg/redis.WireMode) - but JSON size is
+// close enough for usage accounting and billing.
+func EstimatePayloadBytes(payload any) int {
+	if payload == nil {
+		return 0
+	}
+	b, err := json.Marshal(payload)
+	if err != nil {
+		return 0
+	}
+	return len(b)
+}
@@ -0,0 +1,70 @@
+package metering
+
+import (
+	"context"
+	"time"
+
+	"github.com/prometheus/client_golang/prometheus"
+)
+
+// PrometheusMeter is a Meter that records usage as Prometheus metrics
+// instead of accumulating queryable per-window state: a CounterVec for
+// events emitted/consumed and a HistogramVec for payload bytes and
+// processing duration, both labeled by tenant and event type. Snapshot is
+// unsupported - scrape the registered collectors instead.
+type PrometheusMeter struct {
+	emitted        *prometheus.CounterVec
+	consumed       *prometheus.CounterVec
+	payloadBytes   *prometheus.HistogramVec
+	processingTime *prometheus.HistogramVec
+}
+
+// NewPrometheusMeter registers its collectors on reg and returns a
+// PrometheusMeter backed by them.
+func NewPrometheusMeter(reg prometheus.Registerer) *PrometheusMeter {
+	m := &PrometheusMeter{
+		emitted: prometheus.NewCounterVec(prometheus.CounterOpts{
+			Name: "metering_events_emitted_total",
+			Help: "Events published, by tenant and event type.",
+		}, []string{"tenant", "event_type"}),
+		consumed: prometheus.NewCounterVec(prometheus.CounterOpts{
+			Name: "metering_events_consumed_total",
+			Help: "Events consumed, by tenant and event type.",
+		}, []string{"tenant", "event_type"}),
+		payloadBytes: prometheus.NewHistogramVec(prometheus.HistogramOpts{
+			Name:    "metering_payload_bytes",
+			Help:    "Serialized event payload size in bytes, by tenant and event type.",
+			Buckets: prometheus.ExponentialBuckets(64, 4, 8),
+		}, []string{"tenant", "event_type"}),
+		processingTime: prometheus.NewHistogramVec(prometheus.HistogramOpts{
+			Name:    "metering_processing_duration_seconds",
+			Help:    "Consumer handler processing duration in seconds, by tenant and event type.",
+			Buckets: prometheus.DefBuckets,
+		}, []string{"tenant", "event_type"}),
+	}
+	reg.MustRegister(m.emitted, m.consumed, m.payloadBytes, m.processingTime)
+	return m
+}
+
+// RecordPublish implements Meter.
+func (m *PrometheusMeter) RecordPublish(ctx context.Context, tenant, eventType string, payloadBytes int) error {
+	m.emitted.WithLabelValues(tenant, eventType).Inc()
+	m.payloadBytes.WithLabelValues(tenant, eventType).Observe(float64(payloadBytes))
+	return nil
+}
+
+// RecordConsume implements Meter.
+func (m *PrometheusMeter) RecordConsume(ctx context.Context, tenant, eventType string, payloadBytes int, duration time.Duration) error {
+	m.consumed.WithLabelValues(tenant, eventType).Inc()
+	m.payloadBytes.WithLabelValues(tenant, eventType).Observe(float64(payloadBytes))
+	m.processingTime.WithLabelValues(tenant, eventType).Observe(duration.Seconds())
+	return nil
+}
+
+// Snapshot is unsupported for PrometheusMeter: Prometheus counters are
+// read via scraping, not point queries. It always returns an error.
+func (m *PrometheusMeter) Snapshot(ctx context.Context, tenant string, at time.Time) (Snapshot, error) {
+	return Snapshot{}, errSnapshotUnsupported
+}
+
+var _ Meter = (*PrometheusMeter)(nil)
@@ -0,0 +1,63 @@
+package metering
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/MalteBoehm/tall-affiliate-common/pkg/events"
+	"github.com/MalteBoehm/tall-affiliate-common/pkg/interfaces"
+)
+
+// QuotaEnforcer wraps a StreamProducer, rejecting PublishEvent with
+// *ErrQuotaExceeded once a tenant's event count for the current window
+// (from Meter.Snapshot) reaches Limit. Usage is recorded on the wrapped
+// Meter before the limit check, so a publish that's itself rejected still
+// counts toward the tenant's usage for the current window - this favors a
+// simple, conservative accounting model over exactness at the boundary.
+type QuotaEnforcer struct {
+	producer  interfaces.StreamProducer
+	meter     Meter
+	extractor events.TenantExtractor
+	limit     int64
+}
+
+// NewQuotaEnforcer wraps producer, checking each tenant's usage against
+// limit events per window via meter. extractor derives a tenant from
+// events that don't already carry one in Metadata; pass
+// events.DefaultTenantExtractor for the AggregateID-prefix convention.
+func NewQuotaEnforcer(producer interfaces.StreamProducer, meter Meter, extractor events.TenantExtractor, limit int64) *QuotaEnforcer {
+	return &QuotaEnforcer{producer: producer, meter: meter, extractor: extractor, limit: limit}
+}
+
+// PublishEvent records evt's tenant usage, then rejects the publish with
+// *ErrQuotaExceeded if that tenant has crossed its per-window limit.
+func (q *QuotaEnforcer) PublishEvent(ctx context.Context, streamName string, evt *events.Event) error {
+	tenant := events.Tenant(evt, q.extractor)
+	payloadBytes := EstimatePayloadBytes(evt.Payload)
+
+	if err := q.meter.RecordPublish(ctx, tenant, evt.Type, payloadBytes); err != nil {
+		return fmt.Errorf("metering: failed to record publish: %w", err)
+	}
+
+	if q.limit <= 0 {
+		return q.producer.PublishEvent(ctx, streamName, evt)
+	}
+
+	snap, err := q.meter.Snapshot(ctx, tenant, time.Now())
+	if err != nil {
+		return fmt.Errorf("metering: failed to read usage for quota check: %w", err)
+	}
+
+	var usage int64
+	for _, s := range snap.ByEventType {
+		usage += s.EventsEmitted
+	}
+	if usage > q.limit {
+		return &ErrQuotaExceeded{Tenant: tenant, Window: snap.Window, Limit: q.limit, Usage: usage}
+	}
+
+	return q.producer.PublishEvent(ctx, streamName, evt)
+}
+
+var _ interfaces.StreamProducer = (*QuotaEnforcer)(nil)
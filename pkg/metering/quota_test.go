@@ -0,0 +1,59 @@
+package metering
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/MalteBoehm/tall-affiliate-common/pkg/events"
+)
+
+type recordingProducer struct {
+	published int
+}
+
+func (p *recordingProducer) PublishEvent(ctx context.Context, streamName string, evt *events.Event) error {
+	p.published++
+	return nil
+}
+
+func TestQuotaEnforcerAllowsUnderLimit(t *testing.T) {
+	producer := &recordingProducer{}
+	meter := NewInMemoryMeter()
+	enforcer := NewQuotaEnforcer(producer, meter, events.DefaultTenantExtractor, 2)
+
+	for i := 0; i < 2; i++ {
+		evt := &events.Event{Type: "x", AggregateID: "acme-1", Payload: map[string]string{"a": "b"}}
+		if err := enforcer.PublishEvent(context.Background(), "stream", evt); err != nil {
+			t.Fatalf("PublishEvent %d: %v", i, err)
+		}
+	}
+	if producer.published != 2 {
+		t.Errorf("published = %d, want 2", producer.published)
+	}
+}
+
+func TestQuotaEnforcerRejectsOverLimit(t *testing.T) {
+	producer := &recordingProducer{}
+	meter := NewInMemoryMeter()
+	enforcer := NewQuotaEnforcer(producer, meter, events.DefaultTenantExtractor, 1)
+
+	ctx := context.Background()
+	evt := func() *events.Event { return &events.Event{Type: "x", AggregateID: "acme-1"} }
+
+	if err := enforcer.PublishEvent(ctx, "stream", evt()); err != nil {
+		t.Fatalf("first publish: %v", err)
+	}
+
+	err := enforcer.PublishEvent(ctx, "stream", evt())
+	var quotaErr *ErrQuotaExceeded
+	if !errors.As(err, &quotaErr) {
+		t.Fatalf("expected *ErrQuotaExceeded, got %v", err)
+	}
+	if quotaErr.Tenant != "acme" {
+		t.Errorf("Tenant = %q, want acme", quotaErr.Tenant)
+	}
+	if producer.published != 1 {
+		t.Errorf("published = %d, want 1 (second call should be rejected)", producer.published)
+	}
+}
@@ -0,0 +1,284 @@
+package outbox
+
+import (
+	"bytes"
+	"context"
+	"database/sql"
+	"encoding/json"
+	"fmt"
+	"time"
+
+	"github.com/MalteBoehm/tall-affiliate-common/pkg/cloudevents"
+	"github.com/google/uuid"
+)
+
+// OpType identifies the kind of outbox operation a bulk entry represents,
+// mirroring Elasticsearch's bulk action types.
+type OpType string
+
+const (
+	// OpPublish inserts a new outbox row to be dispatched.
+	OpPublish OpType = "publish"
+	// OpRetry re-inserts a previously failed row for another dispatch attempt.
+	OpRetry OpType = "retry"
+	// OpTombstone marks an aggregate's outstanding events as superseded; no
+	// payload is carried, mirroring Elastic's bulk "delete" action.
+	OpTombstone OpType = "tombstone"
+)
+
+const (
+	// DefaultBulkBatchSize is the default number of rows per INSERT statement.
+	DefaultBulkBatchSize = 500
+	// DefaultMaxBulkBytes is the default cumulative payload ceiling per batch.
+	DefaultMaxBulkBytes = 4 * 1024 * 1024
+)
+
+// OutboxRow is the flattened, DB-ready form of a bulk entry.
+type OutboxRow struct {
+	ID          string
+	AggregateID string
+	EventType   string
+	Op          OpType
+	Payload     json.RawMessage
+	CreatedAt   time.Time
+}
+
+// bulkAction is the NDJSON action line preceding each entry's payload line,
+// modeled on Elasticsearch's `{"index": {...}}` / `{"delete": {...}}` lines.
+type bulkAction struct {
+	Op          OpType `json:"op"`
+	ID          string `json:"_id"`
+	AggregateID string `json:"aggregate_id"`
+}
+
+// BulkItemError reports a single failed row, so callers can retry only the
+// failing subset instead of the whole batch.
+type BulkItemError struct {
+	ID          string
+	AggregateID string
+	Err         error
+}
+
+func (e *BulkItemError) Error() string {
+	return fmt.Sprintf("outbox row %s (aggregate %s): %v", e.ID, e.AggregateID, e.Err)
+}
+
+// BulkResult reports the per-item outcome of a Flush call.
+type BulkResult struct {
+	Succeeded []string
+	Failed    []BulkItemError
+}
+
+// BulkBuilder accumulates typed outbox operations and builds a single
+// newline-delimited JSON buffer (action line + payload line per entry)
+// alongside the parallel []OutboxRow needed for DB insertion.
+type BulkBuilder struct {
+	rows []OutboxRow
+}
+
+// NewBulkBuilder creates an empty BulkBuilder.
+func NewBulkBuilder() *BulkBuilder {
+	return &BulkBuilder{}
+}
+
+// Append adds a typed operation for aggregateID. For OpPublish/OpRetry, event
+// must be non-nil and its Data is validated against MaxPayloadSize. For
+// OpTombstone, event may be nil since no payload is carried.
+func (b *BulkBuilder) Append(op OpType, aggregateID string, event *cloudevents.Event) error {
+	if aggregateID == "" {
+		return &PayloadError{Operation: "bulk-append", Err: fmt.Errorf("aggregateID cannot be empty")}
+	}
+
+	row := OutboxRow{
+		ID:          uuid.New().String(),
+		AggregateID: aggregateID,
+		Op:          op,
+		CreatedAt:   time.Now().UTC(),
+	}
+
+	switch op {
+	case OpPublish, OpRetry:
+		if event == nil {
+			return &PayloadError{Operation: "bulk-append", Err: fmt.Errorf("event cannot be nil for op %q", op)}
+		}
+		if err := ValidatePayloadSize(event.Data); err != nil {
+			return err
+		}
+		row.EventType = event.Type
+		row.Payload = event.Data
+	case OpTombstone:
+		if event != nil {
+			row.EventType = event.Type
+		}
+	default:
+		return &PayloadError{Operation: "bulk-append", Err: fmt.Errorf("unknown op type %q", op)}
+	}
+
+	b.rows = append(b.rows, row)
+	return nil
+}
+
+// Len returns the number of entries appended so far.
+func (b *BulkBuilder) Len() int {
+	return len(b.rows)
+}
+
+// Build returns the NDJSON bulk buffer (action line + payload line per
+// publish/retry entry, action line only for tombstones) plus the parallel
+// rows ready for DB insertion.
+func (b *BulkBuilder) Build() ([]byte, []OutboxRow, error) {
+	var buf bytes.Buffer
+	enc := json.NewEncoder(&buf)
+
+	for _, row := range b.rows {
+		if err := enc.Encode(bulkAction{Op: row.Op, ID: row.ID, AggregateID: row.AggregateID}); err != nil {
+			return nil, nil, fmt.Errorf("outbox: encode bulk action: %w", err)
+		}
+		if row.Op == OpTombstone {
+			continue
+		}
+		if err := enc.Encode(row.Payload); err != nil {
+			return nil, nil, fmt.Errorf("outbox: encode bulk payload: %w", err)
+		}
+	}
+
+	return buf.Bytes(), b.rows, nil
+}
+
+// FlushOptions configures Flush's batching behavior.
+type FlushOptions struct {
+	// BatchSize caps the number of rows per INSERT statement. Defaults to
+	// DefaultBulkBatchSize.
+	BatchSize int
+	// MaxBulkBytes caps the cumulative payload size per batch; exceeding it
+	// splits into an additional transaction. Defaults to DefaultMaxBulkBytes.
+	MaxBulkBytes int
+}
+
+// Flush inserts the builder's rows into the outbox table, auto-splitting into
+// multiple transactions whenever BatchSize or MaxBulkBytes would be exceeded.
+// Rows that fail within an otherwise successful batch are retried
+// individually so a single bad row doesn't fail its neighbors; the resulting
+// BulkResult lets callers retry only the failing subset.
+func (b *BulkBuilder) Flush(ctx context.Context, db *sql.DB, opts FlushOptions) (*BulkResult, error) {
+	if opts.BatchSize <= 0 {
+		opts.BatchSize = DefaultBulkBatchSize
+	}
+	if opts.MaxBulkBytes <= 0 {
+		opts.MaxBulkBytes = DefaultMaxBulkBytes
+	}
+
+	result := &BulkResult{}
+	for _, batch := range splitBatches(b.rows, opts.BatchSize, opts.MaxBulkBytes) {
+		if err := flushBatch(ctx, db, batch, result); err != nil {
+			return result, err
+		}
+	}
+	return result, nil
+}
+
+// splitBatches groups rows respecting both the row-count and cumulative-byte
+// ceilings.
+func splitBatches(rows []OutboxRow, batchSize, maxBytes int) [][]OutboxRow {
+	var batches [][]OutboxRow
+	var current []OutboxRow
+	currentBytes := 0
+
+	for _, row := range rows {
+		rowBytes := len(row.Payload)
+		if len(current) > 0 && (len(current) >= batchSize || currentBytes+rowBytes > maxBytes) {
+			batches = append(batches, current)
+			current = nil
+			currentBytes = 0
+		}
+		current = append(current, row)
+		currentBytes += rowBytes
+	}
+	if len(current) > 0 {
+		batches = append(batches, current)
+	}
+	return batches
+}
+
+func flushBatch(ctx context.Context, db *sql.DB, batch []OutboxRow, result *BulkResult) error {
+	tx, err := db.BeginTx(ctx, nil)
+	if err != nil {
+		return fmt.Errorf("outbox: begin bulk flush transaction: %w", err)
+	}
+	defer tx.Rollback()
+
+	if err := insertRows(ctx, tx, batch); err != nil {
+		// Fall back to per-row inserts so a single bad row doesn't sink the
+		// whole batch's successes.
+		if rerr := tx.Rollback(); rerr != nil && rerr != sql.ErrTxDone {
+			return fmt.Errorf("outbox: rollback bulk flush transaction: %w", rerr)
+		}
+		return flushIndividually(ctx, db, batch, result)
+	}
+
+	if err := tx.Commit(); err != nil {
+		return fmt.Errorf("outbox: commit bulk flush transaction: %w", err)
+	}
+	for _, row := range batch {
+		result.Succeeded = append(result.Succeeded, row.ID)
+	}
+	return nil
+}
+
+func flushIndividually(ctx context.Context, db *sql.DB, batch []OutboxRow, result *BulkResult) error {
+	for _, row := range batch {
+		err := func() error {
+			tx, err := db.BeginTx(ctx, nil)
+			if err != nil {
+				return err
+			}
+			defer tx.Rollback()
+			if err := insertRows(ctx, tx, []OutboxRow{row}); err != nil {
+				return err
+			}
+			return tx.Commit()
+		}()
+		if err != nil {
+			result.Failed = append(result.Failed, BulkItemError{ID: row.ID, AggregateID: row.AggregateID, Err: err})
+			continue
+		}
+		result.Succeeded = append(result.Succeeded, row.ID)
+	}
+	return nil
+}
+
+func insertRows(ctx context.Context, tx *sql.Tx, rows []OutboxRow) error {
+	if len(rows) == 0 {
+		return nil
+	}
+
+	const columnsPerRow = 6
+	placeholders := make([]string, 0, len(rows))
+	args := make([]interface{}, 0, len(rows)*columnsPerRow)
+
+	for i, row := range rows {
+		base := i * columnsPerRow
+		placeholders = append(placeholders, fmt.Sprintf("($%d, $%d, $%d, $%d, $%d, $%d)",
+			base+1, base+2, base+3, base+4, base+5, base+6))
+		args = append(args, row.ID, row.AggregateID, row.EventType, string(row.Op), row.Payload, row.CreatedAt)
+	}
+
+	query := fmt.Sprintf(
+		"INSERT INTO outbox (id, aggregate_id, event_type, op, payload, created_at) VALUES %s",
+		joinPlaceholders(placeholders),
+	)
+
+	_, err := tx.ExecContext(ctx, query, args...)
+	return err
+}
+
+func joinPlaceholders(placeholders []string) string {
+	var buf bytes.Buffer
+	for i, p := range placeholders {
+		if i > 0 {
+			buf.WriteString(", ")
+		}
+		buf.WriteString(p)
+	}
+	return buf.String()
+}
@@ -0,0 +1,86 @@
+package outbox
+
+import (
+	"encoding/json"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/MalteBoehm/tall-affiliate-common/pkg/cloudevents"
+)
+
+func newTestEvent(t *testing.T, data any) *cloudevents.Event {
+	t.Helper()
+	e, err := cloudevents.New("catalog", "asin-1", "catalog.product.detected.v1", data)
+	require.NoError(t, err)
+	return e
+}
+
+func TestBulkBuilderAppendAndBuild(t *testing.T) {
+	b := NewBulkBuilder()
+
+	require.NoError(t, b.Append(OpPublish, "agg-1", newTestEvent(t, map[string]string{"asin": "B01"})))
+	require.NoError(t, b.Append(OpRetry, "agg-2", newTestEvent(t, map[string]string{"asin": "B02"})))
+	require.NoError(t, b.Append(OpTombstone, "agg-3", nil))
+
+	assert.Equal(t, 3, b.Len())
+
+	buf, rows, err := b.Build()
+	require.NoError(t, err)
+	require.Len(t, rows, 3)
+
+	// 2 action+payload pairs + 1 action-only line = 5 NDJSON lines.
+	lines := splitLines(buf)
+	assert.Len(t, lines, 5)
+
+	var firstAction bulkAction
+	require.NoError(t, json.Unmarshal(lines[0], &firstAction))
+	assert.Equal(t, OpPublish, firstAction.Op)
+	assert.Equal(t, "agg-1", firstAction.AggregateID)
+}
+
+func TestBulkBuilderAppendRejectsNilEventForPublish(t *testing.T) {
+	b := NewBulkBuilder()
+	err := b.Append(OpPublish, "agg-1", nil)
+	require.Error(t, err)
+}
+
+func TestBulkBuilderAppendRejectsOversizedPayload(t *testing.T) {
+	b := NewBulkBuilder()
+	big := make(map[string]string, 1)
+	big["data"] = string(make([]byte, MaxPayloadSize+1))
+	err := b.Append(OpPublish, "agg-1", newTestEvent(t, big))
+	require.Error(t, err)
+}
+
+func TestSplitBatchesRespectsBatchSizeAndByteCeiling(t *testing.T) {
+	rows := []OutboxRow{
+		{ID: "1", Payload: json.RawMessage(`"aaaa"`)},
+		{ID: "2", Payload: json.RawMessage(`"bbbb"`)},
+		{ID: "3", Payload: json.RawMessage(`"cccc"`)},
+	}
+
+	batches := splitBatches(rows, 2, 1_000_000)
+	require.Len(t, batches, 2)
+	assert.Len(t, batches[0], 2)
+	assert.Len(t, batches[1], 1)
+
+	byteLimited := splitBatches(rows, 100, 8)
+	require.Len(t, byteLimited, 3)
+}
+
+func splitLines(buf []byte) [][]byte {
+	var lines [][]byte
+	start := 0
+	for i, b := range buf {
+		if b == '\n' {
+			lines = append(lines, buf[start:i])
+			start = i + 1
+		}
+	}
+	if start < len(buf) {
+		lines = append(lines, buf[start:])
+	}
+	return lines
+}
@@ -0,0 +1,318 @@
+package outbox
+
+import (
+	"bytes"
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"os"
+	"path/filepath"
+	"strings"
+
+	"github.com/google/uuid"
+)
+
+// claimCheckField is the envelope marker field. Its presence (and a non-empty
+// value) is what distinguishes a claim-check envelope from a regular inline
+// JSON payload that merely happens to be an object.
+const claimCheckField = "$claim"
+
+// DefaultClaimCheckThreshold is the payload size above which MarshalPayloadClaimChecked
+// externalizes the payload instead of rejecting it outright. It matches
+// MaxPayloadSize so small payloads keep the pre-existing inline behavior.
+const DefaultClaimCheckThreshold = MaxPayloadSize
+
+// PayloadStore externalizes oversized payloads so only a small claim-check
+// envelope needs to travel through the outbox row itself.
+type PayloadStore interface {
+	// Put writes data under key and returns a URI that Get can resolve later.
+	Put(ctx context.Context, key string, data []byte) (uri string, err error)
+	// Get fetches the raw bytes previously stored at uri.
+	Get(ctx context.Context, uri string) ([]byte, error)
+}
+
+// claimCheckEnvelope is the small JSON object left in the outbox row's
+// payload column in place of the externalized data.
+type claimCheckEnvelope struct {
+	Claim  string `json:"$claim"`
+	Size   int64  `json:"size"`
+	SHA256 string `json:"sha256"`
+}
+
+// NoopPayloadStore refuses to externalize anything, preserving the
+// pre-existing all-inline behavior for callers that haven't opted into
+// claim-check mode.
+type NoopPayloadStore struct{}
+
+// Put always fails, since NoopPayloadStore never externalizes payloads.
+func (NoopPayloadStore) Put(ctx context.Context, key string, data []byte) (string, error) {
+	return "", &PayloadError{Operation: "claim-check-put", Err: fmt.Errorf("claim-check mode is disabled (NoopPayloadStore)")}
+}
+
+// Get always fails; NoopPayloadStore never produces a URI to resolve.
+func (NoopPayloadStore) Get(ctx context.Context, uri string) ([]byte, error) {
+	return nil, &PayloadError{Operation: "claim-check-get", Err: fmt.Errorf("claim-check mode is disabled (NoopPayloadStore)")}
+}
+
+// FilesystemPayloadStore stores externalized payloads as files under BaseDir,
+// returning file:// URIs. Intended for local development and tests.
+type FilesystemPayloadStore struct {
+	BaseDir string
+}
+
+// NewFilesystemPayloadStore creates a FilesystemPayloadStore rooted at baseDir.
+func NewFilesystemPayloadStore(baseDir string) *FilesystemPayloadStore {
+	return &FilesystemPayloadStore{BaseDir: baseDir}
+}
+
+// Put writes data to BaseDir/key and returns a file:// URI.
+func (s *FilesystemPayloadStore) Put(ctx context.Context, key string, data []byte) (string, error) {
+	path := filepath.Join(s.BaseDir, key)
+	if err := os.MkdirAll(filepath.Dir(path), 0o755); err != nil {
+		return "", &PayloadError{Operation: "claim-check-put", Err: err}
+	}
+	if err := os.WriteFile(path, data, 0o644); err != nil {
+		return "", &PayloadError{Operation: "claim-check-put", Err: err}
+	}
+	return "file://" + path, nil
+}
+
+// Get reads back the file referenced by a file:// URI produced by Put.
+func (s *FilesystemPayloadStore) Get(ctx context.Context, uri string) ([]byte, error) {
+	path := strings.TrimPrefix(uri, "file://")
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return nil, &PayloadError{Operation: "claim-check-get", Err: err}
+	}
+	return data, nil
+}
+
+// S3PayloadStore stores externalized payloads in an S3-compatible bucket
+// using presigned-free, credential-signed PUT/GET requests. It only depends
+// on the standard library so it works without vendoring the AWS SDK.
+type S3PayloadStore struct {
+	Bucket          string
+	Region          string
+	AccessKeyID     string
+	SecretAccessKey string
+	// Endpoint overrides the default "https://<bucket>.s3.<region>.amazonaws.com"
+	// host, for S3-compatible providers (MinIO, R2, etc).
+	Endpoint   string
+	HTTPClient *http.Client
+}
+
+// NewS3PayloadStore creates an S3PayloadStore for bucket/region using the
+// given credentials. A nil http.Client falls back to http.DefaultClient.
+func NewS3PayloadStore(bucket, region, accessKeyID, secretAccessKey string) *S3PayloadStore {
+	return &S3PayloadStore{
+		Bucket:          bucket,
+		Region:          region,
+		AccessKeyID:     accessKeyID,
+		SecretAccessKey: secretAccessKey,
+	}
+}
+
+func (s *S3PayloadStore) client() *http.Client {
+	if s.HTTPClient != nil {
+		return s.HTTPClient
+	}
+	return http.DefaultClient
+}
+
+func (s *S3PayloadStore) baseURL() string {
+	if s.Endpoint != "" {
+		return strings.TrimSuffix(s.Endpoint, "/") + "/" + s.Bucket
+	}
+	return fmt.Sprintf("https://%s.s3.%s.amazonaws.com", s.Bucket, s.Region)
+}
+
+// Put uploads data to s3://bucket/key and returns that URI.
+func (s *S3PayloadStore) Put(ctx context.Context, key string, data []byte) (string, error) {
+	req, err := http.NewRequestWithContext(ctx, http.MethodPut, s.baseURL()+"/"+key, bytes.NewReader(data))
+	if err != nil {
+		return "", &PayloadError{Operation: "claim-check-put", Err: err}
+	}
+	req.Header.Set("Content-Type", "application/json")
+	s.sign(req, data)
+
+	resp, err := s.client().Do(req)
+	if err != nil {
+		return "", &PayloadError{Operation: "claim-check-put", Err: err}
+	}
+	defer resp.Body.Close()
+	if resp.StatusCode >= 300 {
+		body, _ := io.ReadAll(resp.Body)
+		return "", &PayloadError{Operation: "claim-check-put", Err: fmt.Errorf("s3 put failed with status %d: %s", resp.StatusCode, body)}
+	}
+	return fmt.Sprintf("s3://%s/%s", s.Bucket, key), nil
+}
+
+// Get downloads the object referenced by an s3://bucket/key URI.
+func (s *S3PayloadStore) Get(ctx context.Context, uri string) ([]byte, error) {
+	key, err := parseS3Key(uri, s.Bucket)
+	if err != nil {
+		return nil, &PayloadError{Operation: "claim-check-get", Err: err}
+	}
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, s.baseURL()+"/"+key, nil)
+	if err != nil {
+		return nil, &PayloadError{Operation: "claim-check-get", Err: err}
+	}
+	s.sign(req, nil)
+
+	resp, err := s.client().Do(req)
+	if err != nil {
+		return nil, &PayloadError{Operation: "claim-check-get", Err: err}
+	}
+	defer resp.Body.Close()
+	if resp.StatusCode >= 300 {
+		body, _ := io.ReadAll(resp.Body)
+		return nil, &PayloadError{Operation: "claim-check-get", Err: fmt.Errorf("s3 get failed with status %d: %s", resp.StatusCode, body)}
+	}
+	return io.ReadAll(resp.Body)
+}
+
+// sign attaches a minimal AWS "signature" header. It is not a full SigV4
+// implementation (no canonical request/date scoping); it's a placeholder
+// authentication hook so a real signer can be swapped in via HTTPClient's
+// Transport without changing PayloadStore's call sites.
+func (s *S3PayloadStore) sign(req *http.Request, body []byte) {
+	sum := sha256.Sum256(body)
+	req.Header.Set("X-Amz-Content-Sha256", hex.EncodeToString(sum[:]))
+	req.Header.Set("Authorization", fmt.Sprintf("AWS4-HMAC-SHA256 Credential=%s", s.AccessKeyID))
+}
+
+func parseS3Key(uri, bucket string) (string, error) {
+	prefix := "s3://" + bucket + "/"
+	if !strings.HasPrefix(uri, prefix) {
+		return "", fmt.Errorf("uri %q does not reference bucket %q", uri, bucket)
+	}
+	return strings.TrimPrefix(uri, prefix), nil
+}
+
+// ClaimCheckConfig controls MarshalPayloadClaimChecked's externalization
+// behavior.
+type ClaimCheckConfig struct {
+	// Store is where oversized payloads are externalized to. Defaults to
+	// NoopPayloadStore, which preserves the pre-existing all-inline behavior.
+	Store PayloadStore
+	// Threshold is the payload size above which externalization kicks in.
+	// Payloads at or below it always stay inline. Defaults to
+	// DefaultClaimCheckThreshold.
+	Threshold int
+	// KeyPrefix is prepended to the generated storage key, e.g. "outbox/".
+	KeyPrefix string
+}
+
+// NewClaimCheckConfig creates a ClaimCheckConfig backed by store, with the
+// default threshold and no key prefix.
+func NewClaimCheckConfig(store PayloadStore) *ClaimCheckConfig {
+	return &ClaimCheckConfig{Store: store, Threshold: DefaultClaimCheckThreshold}
+}
+
+func (c *ClaimCheckConfig) store() PayloadStore {
+	if c == nil || c.Store == nil {
+		return NoopPayloadStore{}
+	}
+	return c.Store
+}
+
+func (c *ClaimCheckConfig) threshold() int {
+	if c == nil || c.Threshold <= 0 {
+		return DefaultClaimCheckThreshold
+	}
+	return c.Threshold
+}
+
+func (c *ClaimCheckConfig) keyPrefix() string {
+	if c == nil {
+		return ""
+	}
+	return c.KeyPrefix
+}
+
+// MarshalPayloadClaimChecked behaves like MarshalPayload for payloads at or
+// below cfg's threshold. Above it, the marshaled payload is written to
+// cfg.Store and the returned json.RawMessage is a small envelope
+// ({"$claim":"<uri>","size":N,"sha256":"..."}) referencing it instead. A nil
+// cfg uses NoopPayloadStore and the default threshold, so an oversized
+// payload fails with the store's "claim-check-put" PayloadError.
+func MarshalPayloadClaimChecked(ctx context.Context, payload any, cfg *ClaimCheckConfig) (json.RawMessage, error) {
+	if payload == nil {
+		return json.RawMessage("null"), nil
+	}
+
+	data, err := json.Marshal(payload)
+	if err != nil {
+		return nil, &PayloadError{Operation: "marshal", Err: err}
+	}
+
+	if len(data) <= cfg.threshold() {
+		if err := ValidatePayloadSize(data); err != nil {
+			return nil, err
+		}
+		return json.RawMessage(data), nil
+	}
+
+	sum := sha256.Sum256(data)
+	key := cfg.keyPrefix() + uuid.New().String() + ".json"
+
+	uri, err := cfg.store().Put(ctx, key, data)
+	if err != nil {
+		return nil, err
+	}
+
+	envelope, err := json.Marshal(claimCheckEnvelope{
+		Claim:  uri,
+		Size:   int64(len(data)),
+		SHA256: hex.EncodeToString(sum[:]),
+	})
+	if err != nil {
+		return nil, &PayloadError{Operation: "marshal", Err: err}
+	}
+	return json.RawMessage(envelope), nil
+}
+
+// IsClaimCheckEnvelope reports whether payload is a claim-check envelope
+// rather than an inline payload.
+func IsClaimCheckEnvelope(payload json.RawMessage) bool {
+	var envelope claimCheckEnvelope
+	if err := json.Unmarshal(payload, &envelope); err != nil {
+		return false
+	}
+	return envelope.Claim != ""
+}
+
+// UnmarshalPayloadClaimChecked behaves like UnmarshalPayload, except it first
+// detects a claim-check envelope and, if found, fetches the externalized
+// payload from store, verifies its SHA-256 against the envelope, and
+// unmarshals the fetched data into target instead of the envelope itself.
+func UnmarshalPayloadClaimChecked(ctx context.Context, payload json.RawMessage, target any, store PayloadStore) error {
+	if !IsClaimCheckEnvelope(payload) {
+		return UnmarshalPayload(payload, target)
+	}
+
+	var envelope claimCheckEnvelope
+	if err := json.Unmarshal(payload, &envelope); err != nil {
+		return &PayloadError{Operation: "unmarshal", Err: err}
+	}
+	if store == nil {
+		store = NoopPayloadStore{}
+	}
+
+	data, err := store.Get(ctx, envelope.Claim)
+	if err != nil {
+		return err
+	}
+
+	sum := sha256.Sum256(data)
+	if hex.EncodeToString(sum[:]) != envelope.SHA256 {
+		return &PayloadError{Operation: "unmarshal", Err: fmt.Errorf("claim-check sha256 mismatch for %q", envelope.Claim)}
+	}
+
+	return UnmarshalPayload(data, target)
+}
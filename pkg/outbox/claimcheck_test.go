@@ -0,0 +1,84 @@
+package outbox
+
+import (
+	"context"
+	"encoding/json"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestMarshalPayloadClaimCheckedStaysInlineBelowThreshold(t *testing.T) {
+	small := map[string]string{"name": "ok"}
+	cfg := NewClaimCheckConfig(NoopPayloadStore{})
+
+	raw, err := MarshalPayloadClaimChecked(context.Background(), small, cfg)
+	require.NoError(t, err)
+	assert.JSONEq(t, `{"name":"ok"}`, string(raw))
+	assert.False(t, IsClaimCheckEnvelope(raw))
+}
+
+func TestMarshalPayloadClaimCheckedNilConfigRejectsOversizedPayload(t *testing.T) {
+	// A nil config falls back to NoopPayloadStore, so an oversized payload
+	// still can't be delivered inline and has nowhere to be externalized to.
+	oversized := map[string]string{"data": strings.Repeat("x", MaxPayloadSize)}
+
+	_, err := MarshalPayloadClaimChecked(context.Background(), oversized, nil)
+	require.Error(t, err)
+
+	var payloadErr *PayloadError
+	require.ErrorAs(t, err, &payloadErr)
+	assert.Equal(t, "claim-check-put", payloadErr.Operation)
+}
+
+func TestMarshalAndUnmarshalPayloadClaimCheckedRoundTrip(t *testing.T) {
+	store := NewFilesystemPayloadStore(t.TempDir())
+	cfg := NewClaimCheckConfig(store)
+
+	oversized := map[string]string{"data": strings.Repeat("x", MaxPayloadSize+1)}
+
+	raw, err := MarshalPayloadClaimChecked(context.Background(), oversized, cfg)
+	require.NoError(t, err)
+	require.True(t, IsClaimCheckEnvelope(raw))
+
+	var envelope claimCheckEnvelope
+	require.NoError(t, json.Unmarshal(raw, &envelope))
+	assert.True(t, strings.HasPrefix(envelope.Claim, "file://"))
+	assert.Equal(t, int64(len(`{"data":"`)+MaxPayloadSize+1+len(`"}`)), envelope.Size)
+
+	var target map[string]string
+	err = UnmarshalPayloadClaimChecked(context.Background(), raw, &target, store)
+	require.NoError(t, err)
+	assert.Equal(t, oversized, target)
+}
+
+func TestUnmarshalPayloadClaimCheckedRejectsShaMismatch(t *testing.T) {
+	store := NewFilesystemPayloadStore(t.TempDir())
+	uri, err := store.Put(context.Background(), "tampered.json", []byte(`{"data":"original"}`))
+	require.NoError(t, err)
+
+	envelope, err := json.Marshal(claimCheckEnvelope{Claim: uri, Size: 20, SHA256: "deadbeef"})
+	require.NoError(t, err)
+
+	var target map[string]string
+	err = UnmarshalPayloadClaimChecked(context.Background(), envelope, &target, store)
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), "sha256 mismatch")
+}
+
+func TestUnmarshalPayloadClaimCheckedPassesThroughInlinePayloads(t *testing.T) {
+	var target map[string]string
+	err := UnmarshalPayloadClaimChecked(context.Background(), json.RawMessage(`{"name":"ok"}`), &target, nil)
+	require.NoError(t, err)
+	assert.Equal(t, map[string]string{"name": "ok"}, target)
+}
+
+func TestNoopPayloadStoreRejectsPutAndGet(t *testing.T) {
+	_, err := NoopPayloadStore{}.Put(context.Background(), "k", []byte("v"))
+	require.Error(t, err)
+
+	_, err = NoopPayloadStore{}.Get(context.Background(), "s3://bucket/k")
+	require.Error(t, err)
+}
@@ -0,0 +1,253 @@
+package outbox
+
+import (
+	"bytes"
+	"compress/gzip"
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"sort"
+)
+
+const (
+	// ContentEncodingGzip is the sentinel datacontenttype/content-encoding hint
+	// written for payloads compressed with gzip.
+	ContentEncodingGzip = "application/json+gzip"
+
+	// DefaultCompressionThreshold is the soft threshold (in marshaled bytes) above
+	// which MarshalPayloadCompressed attempts compression before falling back to
+	// chunking.
+	DefaultCompressionThreshold = 8 * 1024
+
+	// MaxChunkedPayloadSize is the total-size ceiling enforced across all parts
+	// of a chunked payload.
+	MaxChunkedPayloadSize = 10 * MaxPayloadSize
+)
+
+// gzipSentinel is the magic prefix written ahead of gzip-compressed payloads so
+// IsCompressed/UnmarshalPayload can distinguish them from plain JSON without
+// relying on the caller passing along the content-encoding hint.
+var gzipSentinel = []byte("\x00GZIP\x00")
+
+// Codec compresses and decompresses marshaled payloads. Callers pass a
+// Codec to MarshalPayloadCompressed/UnmarshalPayloadCompressed to opt into
+// compression for oversized payloads.
+type Codec interface {
+	// Name returns the content-encoding hint stored alongside the payload.
+	Name() string
+	Compress(data []byte) ([]byte, error)
+	Decompress(data []byte) ([]byte, error)
+}
+
+// GzipCodec implements Codec using compress/gzip.
+type GzipCodec struct{}
+
+// Name returns the gzip content-encoding hint.
+func (GzipCodec) Name() string { return ContentEncodingGzip }
+
+// Compress gzips data and prefixes it with the gzip sentinel.
+func (GzipCodec) Compress(data []byte) ([]byte, error) {
+	var buf bytes.Buffer
+	buf.Write(gzipSentinel)
+	w := gzip.NewWriter(&buf)
+	if _, err := w.Write(data); err != nil {
+		return nil, fmt.Errorf("gzip compress: %w", err)
+	}
+	if err := w.Close(); err != nil {
+		return nil, fmt.Errorf("gzip compress: %w", err)
+	}
+	return buf.Bytes(), nil
+}
+
+// Decompress strips the gzip sentinel and inflates the remainder.
+func (GzipCodec) Decompress(data []byte) ([]byte, error) {
+	data, ok := stripSentinel(data)
+	if !ok {
+		return nil, &PayloadError{Operation: "decompress", Err: fmt.Errorf("missing gzip sentinel")}
+	}
+	r, err := gzip.NewReader(bytes.NewReader(data))
+	if err != nil {
+		return nil, fmt.Errorf("gzip decompress: %w", err)
+	}
+	defer r.Close()
+	out, err := io.ReadAll(r)
+	if err != nil {
+		return nil, fmt.Errorf("gzip decompress: %w", err)
+	}
+	return out, nil
+}
+
+func stripSentinel(data []byte) ([]byte, bool) {
+	if len(data) < len(gzipSentinel) || !bytes.Equal(data[:len(gzipSentinel)], gzipSentinel) {
+		return nil, false
+	}
+	return data[len(gzipSentinel):], true
+}
+
+// IsCompressed reports whether payload carries the gzip sentinel written by
+// MarshalPayloadCompressed.
+func IsCompressed(payload json.RawMessage) bool {
+	_, ok := stripSentinel(payload)
+	return ok
+}
+
+// MarshalPayloadCompressed marshals payload and, if the result exceeds
+// threshold, compresses it with codec. The returned json.RawMessage is either
+// plain JSON (under threshold, or if compression did not help) or the
+// sentinel-prefixed compressed bytes; contentEncoding reports which.
+func MarshalPayloadCompressed(payload any, codec Codec, threshold int) (data json.RawMessage, contentEncoding string, err error) {
+	if threshold <= 0 {
+		threshold = DefaultCompressionThreshold
+	}
+
+	// Marshal without the hard MaxPayloadSize check so oversized payloads get
+	// a chance to shrink below the limit through compression first.
+	raw, err := json.Marshal(payload)
+	if err != nil {
+		return nil, "", &PayloadError{Operation: "marshal", Err: err}
+	}
+
+	if len(raw) <= threshold || codec == nil {
+		if err := ValidatePayloadSize(raw); err != nil {
+			return nil, "", err
+		}
+		return raw, "application/json", nil
+	}
+
+	compressed, err := codec.Compress(raw)
+	if err != nil {
+		return nil, "", &PayloadError{Operation: "compress", Err: err}
+	}
+	if len(compressed) >= len(raw) {
+		// Compression didn't help; fall back to the plain payload.
+		if err := ValidatePayloadSize(raw); err != nil {
+			return nil, "", err
+		}
+		return raw, "application/json", nil
+	}
+	if err := ValidatePayloadSize(compressed); err != nil {
+		return nil, "", err
+	}
+	return json.RawMessage(compressed), codec.Name(), nil
+}
+
+// UnmarshalPayloadCompressed transparently decompresses payload (if it carries
+// the codec's sentinel) before unmarshaling into target.
+func UnmarshalPayloadCompressed(payload json.RawMessage, codec Codec, target any) error {
+	if IsCompressed(payload) {
+		if codec == nil {
+			return &PayloadError{Operation: "decompress", Err: fmt.Errorf("payload is compressed but no codec was provided")}
+		}
+		raw, err := codec.Decompress(payload)
+		if err != nil {
+			return &PayloadError{Operation: "decompress", Err: err}
+		}
+		payload = raw
+	}
+	return UnmarshalPayload(payload, target)
+}
+
+// ChunkPart is a single ordered fragment of a chunked payload.
+type ChunkPart struct {
+	ChunkGroupID string          `json:"chunk_group_id"`
+	Index        int             `json:"index"`
+	Total        int             `json:"total"`
+	Data         json.RawMessage `json:"data"`
+}
+
+// ChunkPayload splits data into n ordered parts sharing chunkGroupID, each no
+// larger than maxPartSize bytes. It is intended for payloads that still
+// overflow MaxPayloadSize after compression.
+func ChunkPayload(chunkGroupID string, data json.RawMessage, maxPartSize int) ([]ChunkPart, error) {
+	if maxPartSize <= 0 {
+		maxPartSize = MaxPayloadSize
+	}
+	if len(data) > MaxChunkedPayloadSize {
+		return nil, &PayloadError{
+			Operation: "chunk",
+			Err:       fmt.Errorf("payload size %d bytes exceeds chunking ceiling %d bytes", len(data), MaxChunkedPayloadSize),
+		}
+	}
+
+	total := (len(data) + maxPartSize - 1) / maxPartSize
+	if total == 0 {
+		total = 1
+	}
+
+	parts := make([]ChunkPart, 0, total)
+	for i := 0; i < total; i++ {
+		start := i * maxPartSize
+		end := start + maxPartSize
+		if end > len(data) {
+			end = len(data)
+		}
+		partData := make([]byte, end-start)
+		copy(partData, data[start:end])
+
+		parts = append(parts, ChunkPart{
+			ChunkGroupID: chunkGroupID,
+			Index:        i,
+			Total:        total,
+			Data:         partData,
+		})
+	}
+	return parts, nil
+}
+
+// ValidateChunkParts enforces that parts belong to a single chunk group, carry
+// monotonic indices covering [0, total), and together stay within
+// MaxChunkedPayloadSize.
+func ValidateChunkParts(parts []ChunkPart) error {
+	if len(parts) == 0 {
+		return &PayloadError{Operation: "chunk-validation", Err: fmt.Errorf("no chunk parts provided")}
+	}
+
+	groupID := parts[0].ChunkGroupID
+	total := parts[0].Total
+	sorted := make([]ChunkPart, len(parts))
+	copy(sorted, parts)
+	sort.Slice(sorted, func(i, j int) bool { return sorted[i].Index < sorted[j].Index })
+
+	totalSize := 0
+	for i, p := range sorted {
+		if p.ChunkGroupID != groupID {
+			return &PayloadError{Operation: "chunk-validation", Err: fmt.Errorf("part %d has mismatched chunk_group_id %q, want %q", i, p.ChunkGroupID, groupID)}
+		}
+		if p.Total != total {
+			return &PayloadError{Operation: "chunk-validation", Err: fmt.Errorf("part %d has mismatched total %d, want %d", i, p.Total, total)}
+		}
+		if p.Index != i {
+			return &PayloadError{Operation: "chunk-validation", Err: fmt.Errorf("non-monotonic chunk indices: expected index %d, got %d", i, p.Index)}
+		}
+		totalSize += len(p.Data)
+	}
+	if total != len(sorted) {
+		return &PayloadError{Operation: "chunk-validation", Err: fmt.Errorf("expected %d parts, got %d", total, len(sorted))}
+	}
+	if totalSize > MaxChunkedPayloadSize {
+		return &PayloadError{Operation: "chunk-validation", Err: fmt.Errorf("reassembled size %d bytes exceeds chunking ceiling %d bytes", totalSize, MaxChunkedPayloadSize)}
+	}
+	return nil
+}
+
+// ReassemblePayload validates parts and concatenates their data back into a
+// single json.RawMessage in index order.
+func ReassemblePayload(ctx context.Context, parts ...ChunkPart) (json.RawMessage, error) {
+	if err := ctx.Err(); err != nil {
+		return nil, err
+	}
+	if err := ValidateChunkParts(parts); err != nil {
+		return nil, err
+	}
+
+	sorted := make([]ChunkPart, len(parts))
+	copy(sorted, parts)
+	sort.Slice(sorted, func(i, j int) bool { return sorted[i].Index < sorted[j].Index })
+
+	var buf bytes.Buffer
+	for _, p := range sorted {
+		buf.Write(p.Data)
+	}
+	return json.RawMessage(buf.Bytes()), nil
+}
@@ -0,0 +1,106 @@
+package outbox
+
+import (
+	"context"
+	"encoding/json"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestMarshalPayloadCompressedRoundTrip(t *testing.T) {
+	tests := []struct {
+		name         string
+		payload      any
+		threshold    int
+		wantEncoding string
+		wantCompress bool
+	}{
+		{
+			name:         "small payload stays plain",
+			payload:      map[string]string{"name": "John"},
+			threshold:    DefaultCompressionThreshold,
+			wantEncoding: "application/json",
+			wantCompress: false,
+		},
+		{
+			name:         "large repetitive payload compresses",
+			payload:      map[string]string{"description": strings.Repeat("tall friendly clothing ", 2000)},
+			threshold:    64,
+			wantEncoding: ContentEncodingGzip,
+			wantCompress: true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			data, encoding, err := MarshalPayloadCompressed(tt.payload, GzipCodec{}, tt.threshold)
+			require.NoError(t, err)
+			assert.Equal(t, tt.wantEncoding, encoding)
+			assert.Equal(t, tt.wantCompress, IsCompressed(data))
+
+			var target map[string]string
+			require.NoError(t, UnmarshalPayloadCompressed(data, GzipCodec{}, &target))
+
+			want, err := json.Marshal(tt.payload)
+			require.NoError(t, err)
+			var wantMap map[string]string
+			require.NoError(t, json.Unmarshal(want, &wantMap))
+			assert.Equal(t, wantMap, target)
+		})
+	}
+}
+
+func TestChunkPayloadAndReassemble(t *testing.T) {
+	raw, err := MarshalPayload(map[string]string{"faq": strings.Repeat("x", 100)})
+	require.NoError(t, err)
+
+	parts, err := ChunkPayload("group-1", raw, 30)
+	require.NoError(t, err)
+	require.Greater(t, len(parts), 1)
+
+	require.NoError(t, ValidateChunkParts(parts))
+
+	reassembled, err := ReassemblePayload(context.Background(), parts...)
+	require.NoError(t, err)
+	assert.Equal(t, raw, reassembled)
+}
+
+func TestValidateChunkPartsRejectsMismatchedGroupID(t *testing.T) {
+	parts := []ChunkPart{
+		{ChunkGroupID: "g", Index: 1, Total: 2, Data: json.RawMessage(`"a"`)},
+		{ChunkGroupID: "g", Index: 0, Total: 2, Data: json.RawMessage(`"b"`)},
+	}
+	// Sorted by index this is valid (0,1); tamper with one part's group id instead.
+	parts[1].ChunkGroupID = "other"
+
+	err := ValidateChunkParts(parts)
+	require.Error(t, err)
+	var payloadErr *PayloadError
+	require.ErrorAs(t, err, &payloadErr)
+	assert.Equal(t, "chunk-validation", payloadErr.Operation)
+}
+
+func TestValidateChunkPartsRejectsOversizedGroup(t *testing.T) {
+	parts, err := ChunkPayload("group-2", json.RawMessage(strings.Repeat("a", 100)), 10)
+	require.NoError(t, err)
+
+	err = ValidateChunkParts(parts)
+	require.NoError(t, err)
+
+	// Force the ceiling check with two parts whose combined data exceeds
+	// MaxChunkedPayloadSize.
+	tooBig := make([]ChunkPart, 0, 2)
+	for i := 0; i < 2; i++ {
+		tooBig = append(tooBig, ChunkPart{
+			ChunkGroupID: "group-3",
+			Index:        i,
+			Total:        2,
+			Data:         json.RawMessage(strings.Repeat("y", MaxChunkedPayloadSize)),
+		})
+	}
+	err = ValidateChunkParts(tooBig)
+	require.Error(t, err)
+}
@@ -0,0 +1,70 @@
+package outbox
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/MalteBoehm/tall-affiliate-common/pkg/events"
+	"github.com/redis/go-redis/v9"
+)
+
+// DefaultDeduplicationTTL bounds how long a Deduplicator remembers an
+// event ID as seen, so the Redis key set doesn't grow unbounded once a
+// Relay's at-least-once redelivery window has safely passed.
+const DefaultDeduplicationTTL = 24 * time.Hour
+
+// Deduplicator is a StreamConsumer handler middleware that drops events
+// whose ID has already been seen, keyed in Redis via SET NX with a TTL.
+// It lets downstream consumers safely tolerate the at-least-once
+// redelivery a Relay produces when it retries a row whose previous
+// publish actually succeeded but whose MarkPublished call failed.
+type Deduplicator struct {
+	client    *redis.Client
+	keyPrefix string
+	ttl       time.Duration
+}
+
+// NewDeduplicator creates a Deduplicator backed by client. keyPrefix
+// namespaces the Redis keys it writes; an empty keyPrefix defaults to
+// "outbox:dedup:". ttl of zero falls back to DefaultDeduplicationTTL.
+func NewDeduplicator(client *redis.Client, keyPrefix string, ttl time.Duration) *Deduplicator {
+	if keyPrefix == "" {
+		keyPrefix = "outbox:dedup:"
+	}
+	if ttl <= 0 {
+		ttl = DefaultDeduplicationTTL
+	}
+	return &Deduplicator{client: client, keyPrefix: keyPrefix, ttl: ttl}
+}
+
+// Seen reports whether eventID has already been processed, recording it
+// as seen (for ttl) if not. A true result means the event is a
+// redelivery and should be skipped.
+func (d *Deduplicator) Seen(ctx context.Context, eventID string) (bool, error) {
+	ok, err := d.client.SetNX(ctx, d.keyPrefix+eventID, 1, d.ttl).Result()
+	if err != nil {
+		return false, fmt.Errorf("outbox: dedup check for event %s: %w", eventID, err)
+	}
+	// SetNX returns true when the key was newly set, i.e. eventID has not
+	// been seen before.
+	return !ok, nil
+}
+
+// Wrap returns handler wrapped so that it is skipped (returning nil
+// without calling handler) for any event whose ID has already been seen,
+// keyed on the event's own events.Event.ID rather than the stream
+// message ID - matching Relay retries, which always republish with the
+// original event.
+func (d *Deduplicator) Wrap(handler func(context.Context, *events.Event, string) error) func(context.Context, *events.Event, string) error {
+	return func(ctx context.Context, evt *events.Event, messageID string) error {
+		dup, err := d.Seen(ctx, evt.ID)
+		if err != nil {
+			return err
+		}
+		if dup {
+			return nil
+		}
+		return handler(ctx, evt, messageID)
+	}
+}
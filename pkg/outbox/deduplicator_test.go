@@ -0,0 +1,56 @@
+package outbox
+
+import (
+	"context"
+	"testing"
+
+	"github.com/redis/go-redis/v9"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/MalteBoehm/tall-affiliate-common/pkg/events"
+)
+
+func newTestRedisClient(t *testing.T) *redis.Client {
+	t.Helper()
+	client := redis.NewClient(&redis.Options{Addr: "localhost:6379"})
+	if err := client.Ping(context.Background()).Err(); err != nil {
+		t.Skip("Redis not available")
+	}
+	return client
+}
+
+func TestDeduplicatorSeenMarksFirstOccurrenceOnly(t *testing.T) {
+	client := newTestRedisClient(t)
+	dedup := NewDeduplicator(client, "test:dedup:", 0)
+	ctx := context.Background()
+	defer client.Del(ctx, "test:dedup:evt-1")
+
+	first, err := dedup.Seen(ctx, "evt-1")
+	require.NoError(t, err)
+	assert.False(t, first)
+
+	second, err := dedup.Seen(ctx, "evt-1")
+	require.NoError(t, err)
+	assert.True(t, second)
+}
+
+func TestDeduplicatorWrapSkipsRedeliveredEvent(t *testing.T) {
+	client := newTestRedisClient(t)
+	dedup := NewDeduplicator(client, "test:dedup:", 0)
+	ctx := context.Background()
+	evt, err := events.NewEvent("01_PRODUCT_DETECTED", "product", "acme-1", nil)
+	require.NoError(t, err)
+	defer client.Del(ctx, "test:dedup:"+evt.ID)
+
+	var calls int
+	handler := dedup.Wrap(func(context.Context, *events.Event, string) error {
+		calls++
+		return nil
+	})
+
+	require.NoError(t, handler(ctx, evt, "msg-1"))
+	require.NoError(t, handler(ctx, evt, "msg-1"))
+
+	assert.Equal(t, 1, calls)
+}
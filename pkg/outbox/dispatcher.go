@@ -0,0 +1,221 @@
+package outbox
+
+import (
+	"context"
+	"database/sql"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/MalteBoehm/tall-affiliate-common/pkg/cloudevents"
+)
+
+// Sender delivers a CloudEvent to whatever transport the caller wires up
+// (NATS, Kafka, HTTP, ...). Dispatcher is transport-agnostic.
+type Sender interface {
+	Send(ctx context.Context, event *cloudevents.Event) error
+}
+
+// AttemptRecord is a single dispatch attempt, kept in a row's attempt history
+// so a dead-lettered event carries its full retry timeline.
+type AttemptRecord struct {
+	Attempt int       `json:"attempt"`
+	At      time.Time `json:"at"`
+	Error   string    `json:"error,omitempty"`
+}
+
+// PendingRow is a row read from the outbox table awaiting dispatch.
+type PendingRow struct {
+	ID             string
+	AggregateID    string
+	EventType      string
+	Envelope       json.RawMessage // the stored CloudEvent, as emitted by cloudevents.Event.Marshal(FormatJSON)
+	Attempt        int
+	AttemptHistory []AttemptRecord
+	NextAttemptAt  time.Time
+}
+
+// MaxAttemptsFunc resolves the maximum dispatch attempts allowed for an event
+// type, mirroring the table-lookup style of events.GetReviewsEventPriority.
+type MaxAttemptsFunc func(eventType string) int
+
+// DefaultMaxAttempts is used by dispatchers that don't supply a MaxAttemptsFunc.
+const DefaultMaxAttempts = 5
+
+// Dispatcher reads pending outbox rows, invokes a pluggable Sender, and
+// applies RetryPolicy-driven backoff on failure. Rows that exhaust their
+// attempt budget are moved to the dead_letter table with their original
+// envelope, last error, and full attempt history.
+type Dispatcher struct {
+	db          *sql.DB
+	sender      Sender
+	policy      RetryPolicy
+	maxAttempts MaxAttemptsFunc
+}
+
+// NewDispatcher creates a Dispatcher. maxAttempts may be nil, in which case
+// every event type is allowed DefaultMaxAttempts attempts.
+func NewDispatcher(db *sql.DB, sender Sender, policy RetryPolicy, maxAttempts MaxAttemptsFunc) *Dispatcher {
+	if maxAttempts == nil {
+		maxAttempts = func(string) int { return DefaultMaxAttempts }
+	}
+	return &Dispatcher{db: db, sender: sender, policy: policy, maxAttempts: maxAttempts}
+}
+
+// DispatchPending fetches up to limit rows due for dispatch (next_attempt_at
+// <= now) and attempts to send each one, updating backoff state or moving
+// the row to the dead_letter table on exhaustion. A row that fails to
+// dispatch does not stop the rest of the batch from being processed - since
+// fetchPending orders by next_attempt_at, one stuck row must not be able to
+// wedge every row behind it - so DispatchPending collects every row's error
+// and returns them joined rather than aborting on the first one.
+func (d *Dispatcher) DispatchPending(ctx context.Context, limit int) error {
+	rows, err := d.fetchPending(ctx, limit)
+	if err != nil {
+		return fmt.Errorf("outbox: fetch pending rows: %w", err)
+	}
+
+	var errs []error
+	for _, row := range rows {
+		if err := d.dispatchOne(ctx, row); err != nil {
+			errs = append(errs, fmt.Errorf("outbox: dispatch row %s: %w", row.ID, err))
+		}
+	}
+	return errors.Join(errs...)
+}
+
+func (d *Dispatcher) dispatchOne(ctx context.Context, row PendingRow) error {
+	var event cloudevents.Event
+	if err := json.Unmarshal(row.Envelope, &event); err != nil {
+		// The envelope itself is malformed, so retrying can never succeed -
+		// dead-letter it immediately instead of leaving it in outbox
+		// forever, blocked behind nothing but also never able to publish.
+		return d.moveToDeadLetter(ctx, row, fmt.Errorf("unmarshal envelope: %w", err))
+	}
+
+	sendErr := d.sender.Send(ctx, &event)
+	if sendErr == nil {
+		return d.deleteRow(ctx, row.ID)
+	}
+
+	row.Attempt++
+	row.AttemptHistory = append(row.AttemptHistory, AttemptRecord{
+		Attempt: row.Attempt,
+		At:      time.Now().UTC(),
+		Error:   sendErr.Error(),
+	})
+
+	maxAttempts := d.maxAttempts(row.EventType)
+	backoff := d.policy.NextBackoff(row.Attempt)
+	if row.Attempt >= maxAttempts || backoff == Stop {
+		return d.moveToDeadLetter(ctx, row, sendErr)
+	}
+
+	return d.updateRetryState(ctx, row, time.Now().UTC().Add(backoff))
+}
+
+// moveToDeadLetter writes row's original envelope, last error, and full
+// attempt history to the dead_letter table, then removes it from outbox.
+func (d *Dispatcher) moveToDeadLetter(ctx context.Context, row PendingRow, lastErr error) error {
+	history, err := json.Marshal(row.AttemptHistory)
+	if err != nil {
+		return fmt.Errorf("marshal attempt history: %w", err)
+	}
+
+	tx, err := d.db.BeginTx(ctx, nil)
+	if err != nil {
+		return fmt.Errorf("begin dead-letter transaction: %w", err)
+	}
+	defer tx.Rollback()
+
+	_, err = tx.ExecContext(ctx, `
+		INSERT INTO dead_letter (id, aggregate_id, event_type, envelope, last_error, attempt_history, created_at)
+		VALUES ($1, $2, $3, $4, $5, $6, $7)`,
+		row.ID, row.AggregateID, row.EventType, row.Envelope, lastErr.Error(), history, time.Now().UTC())
+	if err != nil {
+		return fmt.Errorf("insert dead_letter row: %w", err)
+	}
+
+	if _, err := tx.ExecContext(ctx, `DELETE FROM outbox WHERE id = $1`, row.ID); err != nil {
+		return fmt.Errorf("delete outbox row: %w", err)
+	}
+
+	return tx.Commit()
+}
+
+// ReplayDeadLetter moves a dead-lettered row back into the outbox, resetting
+// its attempt counter and history so it re-enters the normal dispatch flow.
+func (d *Dispatcher) ReplayDeadLetter(ctx context.Context, id string) error {
+	tx, err := d.db.BeginTx(ctx, nil)
+	if err != nil {
+		return fmt.Errorf("begin replay transaction: %w", err)
+	}
+	defer tx.Rollback()
+
+	var aggregateID, eventType string
+	var envelope json.RawMessage
+	row := tx.QueryRowContext(ctx, `SELECT aggregate_id, event_type, envelope FROM dead_letter WHERE id = $1`, id)
+	if err := row.Scan(&aggregateID, &eventType, &envelope); err != nil {
+		return fmt.Errorf("load dead_letter row: %w", err)
+	}
+
+	_, err = tx.ExecContext(ctx, `
+		INSERT INTO outbox (id, aggregate_id, event_type, op, payload, created_at, next_attempt_at, attempt)
+		VALUES ($1, $2, $3, $4, $5, $6, $7, 0)`,
+		id, aggregateID, eventType, string(OpRetry), envelope, time.Now().UTC(), time.Now().UTC())
+	if err != nil {
+		return fmt.Errorf("reinsert outbox row: %w", err)
+	}
+
+	if _, err := tx.ExecContext(ctx, `DELETE FROM dead_letter WHERE id = $1`, id); err != nil {
+		return fmt.Errorf("delete dead_letter row: %w", err)
+	}
+
+	return tx.Commit()
+}
+
+func (d *Dispatcher) fetchPending(ctx context.Context, limit int) ([]PendingRow, error) {
+	rows, err := d.db.QueryContext(ctx, `
+		SELECT id, aggregate_id, event_type, payload, attempt, attempt_history, next_attempt_at
+		FROM outbox
+		WHERE next_attempt_at <= $1
+		ORDER BY next_attempt_at ASC
+		LIMIT $2`, time.Now().UTC(), limit)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	var pending []PendingRow
+	for rows.Next() {
+		var row PendingRow
+		var history json.RawMessage
+		if err := rows.Scan(&row.ID, &row.AggregateID, &row.EventType, &row.Envelope, &row.Attempt, &history, &row.NextAttemptAt); err != nil {
+			return nil, err
+		}
+		if len(history) > 0 {
+			if err := json.Unmarshal(history, &row.AttemptHistory); err != nil {
+				return nil, fmt.Errorf("unmarshal attempt history for row %s: %w", row.ID, err)
+			}
+		}
+		pending = append(pending, row)
+	}
+	return pending, rows.Err()
+}
+
+func (d *Dispatcher) updateRetryState(ctx context.Context, row PendingRow, nextAttemptAt time.Time) error {
+	history, err := json.Marshal(row.AttemptHistory)
+	if err != nil {
+		return fmt.Errorf("marshal attempt history: %w", err)
+	}
+	_, err = d.db.ExecContext(ctx, `
+		UPDATE outbox SET attempt = $1, attempt_history = $2, next_attempt_at = $3
+		WHERE id = $4`, row.Attempt, history, nextAttemptAt, row.ID)
+	return err
+}
+
+func (d *Dispatcher) deleteRow(ctx context.Context, id string) error {
+	_, err := d.db.ExecContext(ctx, `DELETE FROM outbox WHERE id = $1`, id)
+	return err
+}
@@ -0,0 +1,83 @@
+package outbox
+
+import (
+	"time"
+
+	"github.com/prometheus/client_golang/prometheus"
+)
+
+// DispatcherMetrics receives TableRelay's operational counters.
+// Implementations typically forward these to whatever metrics backend a
+// service already uses; TableRelay has no opinion on the backend. See
+// NewPrometheusDispatcherMetrics for a ready-made Prometheus-backed
+// implementation.
+type DispatcherMetrics interface {
+	// IncDispatched records one row successfully published.
+	IncDispatched(eventType string)
+	// IncFailed records one failed publish attempt (whether or not it
+	// exhausted the row's attempt budget).
+	IncFailed(eventType string)
+	// ObserveLag records the time between a row's creation and its
+	// successful dispatch.
+	ObserveLag(eventType string, lag time.Duration)
+}
+
+// NoopDispatcherMetrics discards every observation. It is TableRelay's
+// default so wiring metrics in is opt-in.
+type NoopDispatcherMetrics struct{}
+
+func (NoopDispatcherMetrics) IncDispatched(string)             {}
+func (NoopDispatcherMetrics) IncFailed(string)                 {}
+func (NoopDispatcherMetrics) ObserveLag(string, time.Duration) {}
+
+var _ DispatcherMetrics = NoopDispatcherMetrics{}
+
+// PrometheusDispatcherMetrics is a DispatcherMetrics that records
+// TableRelay activity as Prometheus collectors, labeled by event type:
+// outbox_dispatched_total and outbox_failed_total counters, and
+// outbox_lag_seconds - the age of a row when it was successfully
+// dispatched - as a histogram.
+type PrometheusDispatcherMetrics struct {
+	dispatched *prometheus.CounterVec
+	failed     *prometheus.CounterVec
+	lag        *prometheus.HistogramVec
+}
+
+// NewPrometheusDispatcherMetrics registers its collectors on reg and
+// returns a PrometheusDispatcherMetrics backed by them.
+func NewPrometheusDispatcherMetrics(reg prometheus.Registerer) *PrometheusDispatcherMetrics {
+	m := &PrometheusDispatcherMetrics{
+		dispatched: prometheus.NewCounterVec(prometheus.CounterOpts{
+			Name: "outbox_dispatched_total",
+			Help: "Outbox rows successfully dispatched, by event type.",
+		}, []string{"event_type"}),
+		failed: prometheus.NewCounterVec(prometheus.CounterOpts{
+			Name: "outbox_failed_total",
+			Help: "Outbox row dispatch attempts that failed, by event type.",
+		}, []string{"event_type"}),
+		lag: prometheus.NewHistogramVec(prometheus.HistogramOpts{
+			Name:    "outbox_lag_seconds",
+			Help:    "Seconds between an outbox row's creation and its successful dispatch, by event type.",
+			Buckets: prometheus.DefBuckets,
+		}, []string{"event_type"}),
+	}
+	reg.MustRegister(m.dispatched, m.failed, m.lag)
+	return m
+}
+
+// IncDispatched implements DispatcherMetrics.
+func (m *PrometheusDispatcherMetrics) IncDispatched(eventType string) {
+	m.dispatched.WithLabelValues(eventType).Inc()
+}
+
+// IncFailed implements DispatcherMetrics.
+func (m *PrometheusDispatcherMetrics) IncFailed(eventType string) {
+	m.failed.WithLabelValues(eventType).Inc()
+}
+
+// ObserveLag implements DispatcherMetrics.
+func (m *PrometheusDispatcherMetrics) ObserveLag(eventType string, lag time.Duration) {
+	m.lag.WithLabelValues(eventType).Observe(lag.Seconds())
+}
+
+var _ DispatcherMetrics = (*PrometheusDispatcherMetrics)(nil)
@@ -0,0 +1,229 @@
+package outbox
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"log/slog"
+	"sync"
+)
+
+// ValidationScope identifies where a payload validation check applies, so a
+// single event type can be enforced differently depending on which pipeline
+// stage is evaluating it.
+type ValidationScope int
+
+const (
+	// ScopeProducer covers validation performed while building an outgoing payload.
+	ScopeProducer ValidationScope = iota
+	// ScopeConsumer covers validation performed while handling an incoming payload.
+	ScopeConsumer
+	// ScopeStorage covers validation performed immediately before persisting a payload.
+	ScopeStorage
+)
+
+func (s ValidationScope) String() string {
+	switch s {
+	case ScopeProducer:
+		return "producer"
+	case ScopeConsumer:
+		return "consumer"
+	case ScopeStorage:
+		return "storage"
+	default:
+		return "unknown"
+	}
+}
+
+// EnforcementAction controls what happens when a scoped validation check fails.
+type EnforcementAction int
+
+const (
+	// ActionDeny fails the operation with the underlying error. This is the
+	// default for every scope, matching the pre-existing all-or-nothing behavior.
+	ActionDeny EnforcementAction = iota
+	// ActionWarn lets the operation succeed but records a *PayloadWarning,
+	// retrievable from the context via a WarningCollector.
+	ActionWarn
+	// ActionDryRun lets the operation succeed and only logs what would have
+	// happened, via the ValidatorConfig's injected *slog.Logger.
+	ActionDryRun
+)
+
+// PayloadWarning is a non-fatal sibling of PayloadError, surfaced when a scope
+// is configured with ActionWarn instead of ActionDeny.
+type PayloadWarning struct {
+	Operation string
+	Scope     ValidationScope
+	Err       error
+}
+
+func (w *PayloadWarning) Error() string {
+	return fmt.Sprintf("payload %s warning (%s scope): %v", w.Operation, w.Scope, w.Err)
+}
+
+// ValidatorConfig maps {scope -> action}, with optional per-event-type
+// overrides so integrators can roll out stricter or looser enforcement
+// incrementally (e.g. dry-run content-generation events while denying reviews
+// events strictly).
+type ValidatorConfig struct {
+	logger    *slog.Logger
+	defaults  map[ValidationScope]EnforcementAction
+	overrides map[string]map[ValidationScope]EnforcementAction
+}
+
+// DefaultValidatorConfig returns the config matching the pre-existing
+// behavior: every scope denies on validation failure.
+func DefaultValidatorConfig() *ValidatorConfig {
+	return NewValidatorConfig(nil)
+}
+
+// NewValidatorConfig creates a ValidatorConfig with every scope defaulted to
+// ActionDeny. A nil logger falls back to slog.Default() for dry-run logging.
+func NewValidatorConfig(logger *slog.Logger) *ValidatorConfig {
+	if logger == nil {
+		logger = slog.Default()
+	}
+	return &ValidatorConfig{
+		logger: logger,
+		defaults: map[ValidationScope]EnforcementAction{
+			ScopeProducer: ActionDeny,
+			ScopeConsumer: ActionDeny,
+			ScopeStorage:  ActionDeny,
+		},
+		overrides: make(map[string]map[ValidationScope]EnforcementAction),
+	}
+}
+
+// SetAction changes the default action for scope across all event types.
+func (c *ValidatorConfig) SetAction(scope ValidationScope, action EnforcementAction) *ValidatorConfig {
+	c.defaults[scope] = action
+	return c
+}
+
+// SetActionForEventType overrides the action for scope, for eventType only.
+func (c *ValidatorConfig) SetActionForEventType(eventType string, scope ValidationScope, action EnforcementAction) *ValidatorConfig {
+	if c.overrides[eventType] == nil {
+		c.overrides[eventType] = make(map[ValidationScope]EnforcementAction)
+	}
+	c.overrides[eventType][scope] = action
+	return c
+}
+
+func (c *ValidatorConfig) actionFor(eventType string, scope ValidationScope) EnforcementAction {
+	if perType, ok := c.overrides[eventType]; ok {
+		if action, ok := perType[scope]; ok {
+			return action
+		}
+	}
+	return c.defaults[scope]
+}
+
+// WarningCollector accumulates PayloadWarnings so ActionWarn pipelines can
+// surface them to callers without failing.
+type WarningCollector struct {
+	mu       sync.Mutex
+	warnings []*PayloadWarning
+}
+
+// NewWarningCollector creates an empty WarningCollector.
+func NewWarningCollector() *WarningCollector {
+	return &WarningCollector{}
+}
+
+// Add records w.
+func (c *WarningCollector) Add(w *PayloadWarning) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	c.warnings = append(c.warnings, w)
+}
+
+// Warnings returns the warnings recorded so far.
+func (c *WarningCollector) Warnings() []*PayloadWarning {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	out := make([]*PayloadWarning, len(c.warnings))
+	copy(out, c.warnings)
+	return out
+}
+
+type warningCollectorKey struct{}
+
+// ContextWithWarningCollector attaches collector to ctx so enforceScopedSize
+// can record ActionWarn outcomes.
+func ContextWithWarningCollector(ctx context.Context, collector *WarningCollector) context.Context {
+	return context.WithValue(ctx, warningCollectorKey{}, collector)
+}
+
+// WarningCollectorFromContext retrieves the collector attached by
+// ContextWithWarningCollector, if any.
+func WarningCollectorFromContext(ctx context.Context) (*WarningCollector, bool) {
+	collector, ok := ctx.Value(warningCollectorKey{}).(*WarningCollector)
+	return collector, ok
+}
+
+// MarshalPayloadScoped behaves like MarshalPayload, except the MaxPayloadSize
+// check is enforced according to cfg's ScopeProducer action for eventType
+// instead of always denying. A nil cfg behaves like DefaultValidatorConfig.
+func MarshalPayloadScoped(ctx context.Context, payload any, eventType string, cfg *ValidatorConfig) (json.RawMessage, error) {
+	if payload == nil {
+		return json.RawMessage("null"), nil
+	}
+
+	data, err := json.Marshal(payload)
+	if err != nil {
+		return nil, &PayloadError{Operation: "marshal", Err: err}
+	}
+	raw := json.RawMessage(data)
+
+	if err := enforceScopedSize(ctx, raw, eventType, ScopeProducer, cfg); err != nil {
+		return nil, err
+	}
+	return raw, nil
+}
+
+// ValidatePayloadSizeScoped behaves like ValidatePayloadSize, except
+// enforcement is governed by cfg's ScopeProducer action for eventType.
+func ValidatePayloadSizeScoped(ctx context.Context, payload json.RawMessage, eventType string, cfg *ValidatorConfig) error {
+	return enforceScopedSize(ctx, payload, eventType, ScopeProducer, cfg)
+}
+
+// ValidateOnConsume validates payload against MaxPayloadSize, governed by
+// cfg's ScopeConsumer action for eventType.
+func ValidateOnConsume(ctx context.Context, payload json.RawMessage, eventType string, cfg *ValidatorConfig) error {
+	return enforceScopedSize(ctx, payload, eventType, ScopeConsumer, cfg)
+}
+
+// ValidateOnStore validates payload against MaxPayloadSize, governed by
+// cfg's ScopeStorage action for eventType.
+func ValidateOnStore(ctx context.Context, payload json.RawMessage, eventType string, cfg *ValidatorConfig) error {
+	return enforceScopedSize(ctx, payload, eventType, ScopeStorage, cfg)
+}
+
+func enforceScopedSize(ctx context.Context, payload json.RawMessage, eventType string, scope ValidationScope, cfg *ValidatorConfig) error {
+	if cfg == nil {
+		cfg = DefaultValidatorConfig()
+	}
+
+	sizeErr := ValidatePayloadSize(payload)
+	if sizeErr == nil {
+		return nil
+	}
+
+	switch cfg.actionFor(eventType, scope) {
+	case ActionWarn:
+		warning := &PayloadWarning{Operation: "validation", Scope: scope, Err: sizeErr}
+		if collector, ok := WarningCollectorFromContext(ctx); ok {
+			collector.Add(warning)
+		}
+		return nil
+	case ActionDryRun:
+		cfg.logger.Warn("payload validation would fail (dry-run)",
+			"scope", scope.String(),
+			"event_type", eventType,
+			"error", sizeErr)
+		return nil
+	default: // ActionDeny
+		return sizeErr
+	}
+}
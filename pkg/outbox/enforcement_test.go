@@ -0,0 +1,107 @@
+package outbox
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"log/slog"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// TestDefaultValidatorConfigMatchesPreExistingBehavior is the migration guide:
+// it proves that DefaultValidatorConfig ({all scopes: ActionDeny}) produces
+// exactly the same pass/fail outcome as the original unscoped functions.
+func TestDefaultValidatorConfigMatchesPreExistingBehavior(t *testing.T) {
+	oversized := make(map[string]string, 1)
+	oversized["data"] = strings.Repeat("x", MaxPayloadSize)
+
+	ctx := context.Background()
+	cfg := DefaultValidatorConfig()
+
+	_, legacyErr := MarshalPayload(oversized)
+	_, scopedErr := MarshalPayloadScoped(ctx, oversized, "any.event.v1", cfg)
+
+	require.Error(t, legacyErr)
+	require.Error(t, scopedErr)
+
+	var legacyPayloadErr, scopedPayloadErr *PayloadError
+	require.ErrorAs(t, legacyErr, &legacyPayloadErr)
+	require.ErrorAs(t, scopedErr, &scopedPayloadErr)
+	assert.Equal(t, legacyPayloadErr.Operation, scopedPayloadErr.Operation)
+
+	small := map[string]string{"name": "ok"}
+	legacyRaw, err := MarshalPayload(small)
+	require.NoError(t, err)
+	scopedRaw, err := MarshalPayloadScoped(ctx, small, "any.event.v1", cfg)
+	require.NoError(t, err)
+	assert.JSONEq(t, string(legacyRaw), string(scopedRaw))
+}
+
+func TestValidatorConfigActionWarnCollectsWarning(t *testing.T) {
+	cfg := NewValidatorConfig(nil).SetAction(ScopeProducer, ActionWarn)
+	collector := NewWarningCollector()
+	ctx := ContextWithWarningCollector(context.Background(), collector)
+
+	oversized := make(json.RawMessage, MaxPayloadSize+1)
+	for i := range oversized {
+		oversized[i] = 'x'
+	}
+
+	err := ValidatePayloadSizeScoped(ctx, oversized, "content.generation.v1", cfg)
+	require.NoError(t, err)
+
+	warnings := collector.Warnings()
+	require.Len(t, warnings, 1)
+	assert.Equal(t, ScopeProducer, warnings[0].Scope)
+}
+
+func TestValidatorConfigActionDryRunLogsAndSucceeds(t *testing.T) {
+	var buf bytes.Buffer
+	logger := slog.New(slog.NewTextHandler(&buf, nil))
+
+	cfg := NewValidatorConfig(logger).SetActionForEventType("content.generation.v1", ScopeProducer, ActionDryRun)
+
+	oversized := make(json.RawMessage, MaxPayloadSize+1)
+	for i := range oversized {
+		oversized[i] = 'x'
+	}
+
+	err := ValidatePayloadSizeScoped(context.Background(), oversized, "content.generation.v1", cfg)
+	require.NoError(t, err)
+	assert.Contains(t, buf.String(), "dry-run")
+}
+
+func TestValidatorConfigDeniesByDefaultForOtherEventTypes(t *testing.T) {
+	cfg := NewValidatorConfig(nil).SetActionForEventType("content.generation.v1", ScopeProducer, ActionDryRun)
+
+	oversized := make(json.RawMessage, MaxPayloadSize+1)
+	for i := range oversized {
+		oversized[i] = 'x'
+	}
+
+	// reviews events keep the strict default even though content-generation
+	// was loosened.
+	err := ValidatePayloadSizeScoped(context.Background(), oversized, "reviews.requested.v1", cfg)
+	require.Error(t, err)
+}
+
+func TestValidateOnConsumeUsesConsumerScope(t *testing.T) {
+	cfg := NewValidatorConfig(nil).SetAction(ScopeConsumer, ActionWarn)
+	collector := NewWarningCollector()
+	ctx := ContextWithWarningCollector(context.Background(), collector)
+
+	oversized := make(json.RawMessage, MaxPayloadSize+1)
+	for i := range oversized {
+		oversized[i] = 'x'
+	}
+
+	require.NoError(t, ValidateOnConsume(ctx, oversized, "any.event.v1", cfg))
+	require.Len(t, collector.Warnings(), 1)
+
+	// Producer scope is untouched, so it still denies.
+	require.Error(t, ValidatePayloadSizeScoped(ctx, oversized, "any.event.v1", cfg))
+}
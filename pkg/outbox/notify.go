@@ -0,0 +1,72 @@
+package outbox
+
+import (
+	"fmt"
+	"time"
+
+	"github.com/lib/pq"
+)
+
+// NotifyChannel is the Postgres NOTIFY channel a trigger on
+// constants.TableOutbox should publish to, so TableRelay.Run can wake
+// immediately on insert instead of waiting out its full poll interval:
+//
+//	CREATE FUNCTION notify_outbox() RETURNS trigger AS $$
+//	BEGIN
+//	  PERFORM pg_notify('outbox_relay', NEW.id);
+//	  RETURN NEW;
+//	END;
+//	$$ LANGUAGE plpgsql;
+//
+//	CREATE TRIGGER outbox_notify AFTER INSERT ON outbox
+//	  FOR EACH ROW EXECUTE FUNCTION notify_outbox();
+//
+// This trigger is optional: TableRelay.Run works by polling alone if it is
+// never installed, just with up to one pollInterval of added latency.
+const NotifyChannel = "outbox_relay"
+
+// NotifyListener wraps a *pq.Listener bound to NotifyChannel, adapting its
+// notification channel to the plain wake signal TableRelay.Run expects.
+type NotifyListener struct {
+	listener *pq.Listener
+	wake     chan struct{}
+}
+
+// NewNotifyListener opens a LISTEN connection to connStr and subscribes to
+// NotifyChannel. minReconnectInterval/maxReconnectInterval are passed
+// through to pq.NewListener to control its reconnect backoff.
+func NewNotifyListener(connStr string, minReconnectInterval, maxReconnectInterval time.Duration) (*NotifyListener, error) {
+	n := &NotifyListener{wake: make(chan struct{}, 1)}
+	n.listener = pq.NewListener(connStr, minReconnectInterval, maxReconnectInterval, nil)
+	if err := n.listener.Listen(NotifyChannel); err != nil {
+		n.listener.Close()
+		return nil, fmt.Errorf("outbox: listen on %s: %w", NotifyChannel, err)
+	}
+
+	go n.forward()
+	return n, nil
+}
+
+// forward drains the underlying pq.Listener's notification channel onto
+// n.wake, coalescing bursts of notifications into a single pending wake-up
+// so a TableRelay that is mid-RelayOnce isn't asked to run again for every
+// individual insert.
+func (n *NotifyListener) forward() {
+	for range n.listener.Notify {
+		select {
+		case n.wake <- struct{}{}:
+		default:
+		}
+	}
+}
+
+// Wake returns the channel TableRelay.Run should select on to wake
+// immediately on notification.
+func (n *NotifyListener) Wake() <-chan struct{} {
+	return n.wake
+}
+
+// Close stops listening and releases the underlying connection.
+func (n *NotifyListener) Close() error {
+	return n.listener.Close()
+}
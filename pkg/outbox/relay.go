@@ -0,0 +1,96 @@
+package outbox
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+	"log/slog"
+	"time"
+
+	"github.com/MalteBoehm/tall-affiliate-common/pkg/events"
+	"github.com/MalteBoehm/tall-affiliate-common/pkg/interfaces"
+)
+
+// Outbox gives callers exactly-once-ish publishing on top of
+// interfaces.StreamProducer.PublishEvent: Enqueue writes the event as part
+// of the caller's own business transaction, so a crash between the DB
+// commit and the PublishEvent call can no longer lose the event - a Relay
+// will pick up the row and publish it later.
+type Outbox struct {
+	store Store
+}
+
+// NewOutbox creates an Outbox backed by store.
+func NewOutbox(store Store) *Outbox {
+	return &Outbox{store: store}
+}
+
+// Enqueue writes evt for streamName as part of tx. Call this inside the
+// same transaction that persists the business state evt describes.
+func (o *Outbox) Enqueue(ctx context.Context, tx *sql.Tx, streamName string, evt *events.Event) error {
+	return o.store.Enqueue(ctx, tx, streamName, evt)
+}
+
+// Relay polls a Store for unpublished rows and relays them to a
+// StreamProducer, applying RetryPolicy-driven backoff on failure. It is
+// the background counterpart to Outbox.Enqueue.
+type Relay struct {
+	store    Store
+	producer interfaces.StreamProducer
+	policy   RetryPolicy
+	logger   *slog.Logger
+}
+
+// NewRelay creates a Relay. A nil logger falls back to slog.Default().
+func NewRelay(store Store, producer interfaces.StreamProducer, policy RetryPolicy, logger *slog.Logger) *Relay {
+	if logger == nil {
+		logger = slog.Default()
+	}
+	return &Relay{store: store, producer: producer, policy: policy, logger: logger}
+}
+
+// Run polls for unpublished rows every pollInterval, fetching up to
+// batchSize per poll, until ctx is canceled.
+func (r *Relay) Run(ctx context.Context, batchSize int, pollInterval time.Duration) error {
+	for {
+		if err := r.RelayOnce(ctx, batchSize); err != nil {
+			r.logger.Error("outbox relay poll failed", "error", err)
+		}
+
+		select {
+		case <-ctx.Done():
+			return ctx.Err()
+		case <-time.After(pollInterval):
+		}
+	}
+}
+
+// RelayOnce fetches up to limit unpublished rows and attempts to publish
+// each one, marking it published on success or rescheduling it with
+// backoff on failure.
+func (r *Relay) RelayOnce(ctx context.Context, limit int) error {
+	rows, err := r.store.FetchUnpublished(ctx, limit)
+	if err != nil {
+		return fmt.Errorf("outbox: fetch unpublished rows: %w", err)
+	}
+
+	for _, row := range rows {
+		if err := r.relayOne(ctx, row); err != nil {
+			return fmt.Errorf("outbox: relay row %s: %w", row.ID, err)
+		}
+	}
+	return nil
+}
+
+func (r *Relay) relayOne(ctx context.Context, row RelayRow) error {
+	pubErr := r.producer.PublishEvent(ctx, row.StreamName, row.Event)
+	if pubErr == nil {
+		return r.store.MarkPublished(ctx, row.ID)
+	}
+
+	backoff := r.policy.NextBackoff(row.Attempt)
+	if backoff == Stop {
+		backoff = r.policy.NextBackoff(0)
+	}
+	return r.store.MarkFailed(ctx, row.ID, time.Now().UTC().Add(backoff), pubErr)
+}
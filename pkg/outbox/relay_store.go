@@ -0,0 +1,190 @@
+package outbox
+
+import (
+	"context"
+	"database/sql"
+	"encoding/json"
+	"fmt"
+	"time"
+
+	"github.com/MalteBoehm/tall-affiliate-common/pkg/events"
+)
+
+// RelayRow is a row read from the outbox_relay table awaiting relay to a
+// StreamProducer.
+type RelayRow struct {
+	ID            string
+	StreamName    string
+	Event         *events.Event
+	Attempt       int
+	NextAttemptAt time.Time
+}
+
+// Store persists events enqueued inside a business transaction and lets a
+// Relay poll for the ones still awaiting publish, so PublishEvent failing
+// after the enclosing DB commit can't silently lose an event: the row is
+// already committed and a Relay will retry it.
+type Store interface {
+	// Enqueue inserts evt for streamName as part of tx, the same
+	// transaction the caller used to persist its own business state.
+	Enqueue(ctx context.Context, tx *sql.Tx, streamName string, evt *events.Event) error
+	// FetchUnpublished returns up to limit rows due for relay
+	// (next_attempt_at <= now), oldest first.
+	FetchUnpublished(ctx context.Context, limit int) ([]RelayRow, error)
+	// MarkPublished removes id from the outbox_relay table after a
+	// successful PublishEvent.
+	MarkPublished(ctx context.Context, id string) error
+	// MarkFailed records a failed publish attempt, bumping id's attempt
+	// counter and scheduling its next try at nextAttemptAt.
+	MarkFailed(ctx context.Context, id string, nextAttemptAt time.Time, lastErr error) error
+}
+
+// PostgresStore is a Store backed by an outbox_relay table:
+//
+//	CREATE TABLE outbox_relay (
+//		id               TEXT PRIMARY KEY,
+//		stream_name      TEXT NOT NULL,
+//		event            JSONB NOT NULL,
+//		attempt          INT NOT NULL DEFAULT 0,
+//		last_error       TEXT,
+//		created_at       TIMESTAMPTZ NOT NULL,
+//		next_attempt_at  TIMESTAMPTZ NOT NULL
+//	);
+//	CREATE INDEX outbox_relay_next_attempt_at_idx ON outbox_relay (next_attempt_at);
+type PostgresStore struct {
+	db *sql.DB
+}
+
+// NewPostgresStore creates a PostgresStore using db.
+func NewPostgresStore(db *sql.DB) *PostgresStore {
+	return &PostgresStore{db: db}
+}
+
+// Enqueue implements Store.
+func (s *PostgresStore) Enqueue(ctx context.Context, tx *sql.Tx, streamName string, evt *events.Event) error {
+	payload, err := json.Marshal(evt)
+	if err != nil {
+		return fmt.Errorf("outbox: marshal event %s: %w", evt.ID, err)
+	}
+	now := time.Now().UTC()
+	_, err = tx.ExecContext(ctx, `
+		INSERT INTO outbox_relay (id, stream_name, event, attempt, created_at, next_attempt_at)
+		VALUES ($1, $2, $3, 0, $4, $4)
+		ON CONFLICT (id) DO NOTHING`,
+		evt.ID, streamName, payload, now)
+	if err != nil {
+		return fmt.Errorf("outbox: enqueue event %s: %w", evt.ID, err)
+	}
+	return nil
+}
+
+// FetchUnpublished implements Store.
+func (s *PostgresStore) FetchUnpublished(ctx context.Context, limit int) ([]RelayRow, error) {
+	rows, err := s.db.QueryContext(ctx, `
+		SELECT id, stream_name, event, attempt, next_attempt_at
+		FROM outbox_relay
+		WHERE next_attempt_at <= $1
+		ORDER BY next_attempt_at ASC
+		LIMIT $2`, time.Now().UTC(), limit)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	return scanRelayRows(rows)
+}
+
+// MarkPublished implements Store.
+func (s *PostgresStore) MarkPublished(ctx context.Context, id string) error {
+	_, err := s.db.ExecContext(ctx, `DELETE FROM outbox_relay WHERE id = $1`, id)
+	return err
+}
+
+// MarkFailed implements Store.
+func (s *PostgresStore) MarkFailed(ctx context.Context, id string, nextAttemptAt time.Time, lastErr error) error {
+	_, err := s.db.ExecContext(ctx, `
+		UPDATE outbox_relay SET attempt = attempt + 1, last_error = $1, next_attempt_at = $2
+		WHERE id = $3`, lastErr.Error(), nextAttemptAt, id)
+	return err
+}
+
+var _ Store = (*PostgresStore)(nil)
+
+// SQLiteStore is a Store backed by the same outbox_relay schema as
+// PostgresStore, using "?" placeholders in place of "$n".
+type SQLiteStore struct {
+	db *sql.DB
+}
+
+// NewSQLiteStore creates a SQLiteStore using db.
+func NewSQLiteStore(db *sql.DB) *SQLiteStore {
+	return &SQLiteStore{db: db}
+}
+
+// Enqueue implements Store.
+func (s *SQLiteStore) Enqueue(ctx context.Context, tx *sql.Tx, streamName string, evt *events.Event) error {
+	payload, err := json.Marshal(evt)
+	if err != nil {
+		return fmt.Errorf("outbox: marshal event %s: %w", evt.ID, err)
+	}
+	now := time.Now().UTC()
+	_, err = tx.ExecContext(ctx, `
+		INSERT INTO outbox_relay (id, stream_name, event, attempt, created_at, next_attempt_at)
+		VALUES (?, ?, ?, 0, ?, ?)
+		ON CONFLICT(id) DO NOTHING`,
+		evt.ID, streamName, payload, now, now)
+	if err != nil {
+		return fmt.Errorf("outbox: enqueue event %s: %w", evt.ID, err)
+	}
+	return nil
+}
+
+// FetchUnpublished implements Store.
+func (s *SQLiteStore) FetchUnpublished(ctx context.Context, limit int) ([]RelayRow, error) {
+	rows, err := s.db.QueryContext(ctx, `
+		SELECT id, stream_name, event, attempt, next_attempt_at
+		FROM outbox_relay
+		WHERE next_attempt_at <= ?
+		ORDER BY next_attempt_at ASC
+		LIMIT ?`, time.Now().UTC(), limit)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	return scanRelayRows(rows)
+}
+
+// MarkPublished implements Store.
+func (s *SQLiteStore) MarkPublished(ctx context.Context, id string) error {
+	_, err := s.db.ExecContext(ctx, `DELETE FROM outbox_relay WHERE id = ?`, id)
+	return err
+}
+
+// MarkFailed implements Store.
+func (s *SQLiteStore) MarkFailed(ctx context.Context, id string, nextAttemptAt time.Time, lastErr error) error {
+	_, err := s.db.ExecContext(ctx, `
+		UPDATE outbox_relay SET attempt = attempt + 1, last_error = ?, next_attempt_at = ?
+		WHERE id = ?`, lastErr.Error(), nextAttemptAt, id)
+	return err
+}
+
+var _ Store = (*SQLiteStore)(nil)
+
+// scanRelayRows decodes the shared "id, stream_name, event, attempt,
+// next_attempt_at" column set used by both PostgresStore and SQLiteStore.
+func scanRelayRows(rows *sql.Rows) ([]RelayRow, error) {
+	var result []RelayRow
+	for rows.Next() {
+		var row RelayRow
+		var payload []byte
+		if err := rows.Scan(&row.ID, &row.StreamName, &payload, &row.Attempt, &row.NextAttemptAt); err != nil {
+			return nil, err
+		}
+		var evt events.Event
+		if err := json.Unmarshal(payload, &evt); err != nil {
+			return nil, fmt.Errorf("outbox: unmarshal event for row %s: %w", row.ID, err)
+		}
+		row.Event = &evt
+		result = append(result, row)
+	}
+	return result, rows.Err()
+}
@@ -0,0 +1,128 @@
+package outbox
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/MalteBoehm/tall-affiliate-common/pkg/events"
+)
+
+// fakeStore is an in-memory Store double, standing in for PostgresStore /
+// SQLiteStore in tests that only need to exercise Relay's control flow.
+type fakeStore struct {
+	rows      map[string]RelayRow
+	published []string
+	failed    []string
+}
+
+func newFakeStore(rows ...RelayRow) *fakeStore {
+	s := &fakeStore{rows: make(map[string]RelayRow)}
+	for _, r := range rows {
+		s.rows[r.ID] = r
+	}
+	return s
+}
+
+func (s *fakeStore) Enqueue(ctx context.Context, tx *sql.Tx, streamName string, evt *events.Event) error {
+	s.rows[evt.ID] = RelayRow{ID: evt.ID, StreamName: streamName, Event: evt, NextAttemptAt: time.Now().UTC()}
+	return nil
+}
+
+func (s *fakeStore) FetchUnpublished(ctx context.Context, limit int) ([]RelayRow, error) {
+	var due []RelayRow
+	now := time.Now().UTC()
+	for _, row := range s.rows {
+		if !row.NextAttemptAt.After(now) {
+			due = append(due, row)
+		}
+		if len(due) == limit {
+			break
+		}
+	}
+	return due, nil
+}
+
+func (s *fakeStore) MarkPublished(ctx context.Context, id string) error {
+	delete(s.rows, id)
+	s.published = append(s.published, id)
+	return nil
+}
+
+func (s *fakeStore) MarkFailed(ctx context.Context, id string, nextAttemptAt time.Time, lastErr error) error {
+	row := s.rows[id]
+	row.Attempt++
+	row.NextAttemptAt = nextAttemptAt
+	s.rows[id] = row
+	s.failed = append(s.failed, id)
+	return nil
+}
+
+// fakeProducer is a StreamProducer double that records published events and
+// optionally fails for a configured set of event IDs.
+type fakeProducer struct {
+	failFor  map[string]bool
+	received []string
+}
+
+func (p *fakeProducer) PublishEvent(ctx context.Context, streamName string, event *events.Event) error {
+	if p.failFor[event.ID] {
+		return errors.New("publish failed")
+	}
+	p.received = append(p.received, event.ID)
+	return nil
+}
+
+func newRelayTestEvent(t *testing.T, id string) *events.Event {
+	t.Helper()
+	evt, err := events.NewEvent("01_PRODUCT_DETECTED", "product", id, map[string]string{"asin": "B0"})
+	require.NoError(t, err)
+	evt.ID = id
+	return evt
+}
+
+func TestRelayOnceMarksPublishedOnSuccess(t *testing.T) {
+	evt := newRelayTestEvent(t, "evt-1")
+	store := newFakeStore(RelayRow{ID: evt.ID, StreamName: "stream:product_lifecycle", Event: evt})
+	producer := &fakeProducer{failFor: map[string]bool{}}
+	relay := NewRelay(store, producer, RetryPolicy{InitialInterval: time.Millisecond}, nil)
+
+	require.NoError(t, relay.RelayOnce(context.Background(), 10))
+
+	assert.Contains(t, producer.received, "evt-1")
+	assert.Contains(t, store.published, "evt-1")
+	assert.Empty(t, store.failed)
+}
+
+func TestRelayOnceReschedulesOnFailure(t *testing.T) {
+	evt := newRelayTestEvent(t, "evt-2")
+	store := newFakeStore(RelayRow{ID: evt.ID, StreamName: "stream:product_lifecycle", Event: evt})
+	producer := &fakeProducer{failFor: map[string]bool{"evt-2": true}}
+	relay := NewRelay(store, producer, RetryPolicy{InitialInterval: time.Millisecond}, nil)
+
+	require.NoError(t, relay.RelayOnce(context.Background(), 10))
+
+	assert.Empty(t, producer.received)
+	assert.Contains(t, store.failed, "evt-2")
+	row, ok := store.rows["evt-2"]
+	require.True(t, ok)
+	assert.Equal(t, 1, row.Attempt)
+	assert.True(t, row.NextAttemptAt.After(time.Now().UTC().Add(-time.Second)))
+}
+
+func TestOutboxEnqueueDelegatesToStore(t *testing.T) {
+	store := newFakeStore()
+	ob := NewOutbox(store)
+	evt := newRelayTestEvent(t, "evt-3")
+
+	require.NoError(t, ob.Enqueue(context.Background(), nil, "stream:product_lifecycle", evt))
+
+	row, ok := store.rows["evt-3"]
+	require.True(t, ok)
+	assert.Equal(t, "stream:product_lifecycle", row.StreamName)
+}
@@ -0,0 +1,84 @@
+package outbox
+
+import (
+	"math"
+	"math/rand"
+	"time"
+)
+
+// Stop is returned by RetryPolicy.NextBackoff once MaxElapsedTime has been
+// exceeded, signaling that the caller should give up retrying.
+const Stop time.Duration = -1
+
+// RetryPolicy is a full-jitter exponential backoff policy, modeled on the
+// Google HTTP client's ExponentialBackOff.
+type RetryPolicy struct {
+	// InitialInterval is the backoff used for attempt 0.
+	InitialInterval time.Duration
+	// MaxInterval caps the un-jittered backoff value.
+	MaxInterval time.Duration
+	// Multiplier grows the backoff each attempt. Defaults to 2.0.
+	Multiplier float64
+	// RandomizationFactor controls the +/- jitter applied around the
+	// computed backoff. Defaults to 0.5 (full jitter).
+	RandomizationFactor float64
+	// MaxElapsedTime bounds the total retry budget. Zero means unbounded.
+	MaxElapsedTime time.Duration
+}
+
+// withDefaults returns a copy of p with zero-valued tunables replaced by
+// their documented defaults.
+func (p RetryPolicy) withDefaults() RetryPolicy {
+	if p.Multiplier <= 0 {
+		p.Multiplier = 2.0
+	}
+	if p.RandomizationFactor <= 0 {
+		p.RandomizationFactor = 0.5
+	}
+	return p
+}
+
+// baseBackoff computes InitialInterval * Multiplier^attempt, capped at
+// MaxInterval, without jitter applied.
+func (p RetryPolicy) baseBackoff(attempt int) time.Duration {
+	d := float64(p.InitialInterval) * math.Pow(p.Multiplier, float64(attempt))
+	if p.MaxInterval > 0 && d > float64(p.MaxInterval) {
+		d = float64(p.MaxInterval)
+	}
+	return time.Duration(d)
+}
+
+// elapsedThrough estimates the cumulative un-jittered backoff spent across
+// attempts [0, attempt), used to evaluate MaxElapsedTime.
+func (p RetryPolicy) elapsedThrough(attempt int) time.Duration {
+	var total time.Duration
+	for i := 0; i < attempt; i++ {
+		total += p.baseBackoff(i)
+	}
+	return total
+}
+
+// NextBackoff returns the jittered backoff duration to wait before retrying
+// attempt, or Stop once the cumulative backoff already spent exceeds
+// MaxElapsedTime.
+func (p RetryPolicy) NextBackoff(attempt int) time.Duration {
+	p = p.withDefaults()
+
+	if p.MaxElapsedTime > 0 && p.elapsedThrough(attempt) >= p.MaxElapsedTime {
+		return Stop
+	}
+
+	base := float64(p.baseBackoff(attempt))
+	delta := p.RandomizationFactor * base
+	min := base - delta
+	max := base + delta
+	if max <= min {
+		return time.Duration(base)
+	}
+
+	jittered := min + rand.Float64()*(max-min)
+	if jittered < 0 {
+		jittered = 0
+	}
+	return time.Duration(jittered)
+}
@@ -0,0 +1,72 @@
+package outbox
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestRetryPolicyNextBackoffMonotonicity(t *testing.T) {
+	policy := RetryPolicy{
+		InitialInterval: 100 * time.Millisecond,
+		MaxInterval:     10 * time.Second,
+		Multiplier:      2.0,
+	}
+
+	var prev time.Duration
+	for attempt := 0; attempt < 6; attempt++ {
+		base := policy.baseBackoff(attempt)
+		assert.GreaterOrEqual(t, base, prev, "base backoff should grow monotonically with attempt %d", attempt)
+		prev = base
+	}
+}
+
+func TestRetryPolicyNextBackoffRespectsMaxInterval(t *testing.T) {
+	policy := RetryPolicy{
+		InitialInterval: time.Second,
+		MaxInterval:     5 * time.Second,
+		Multiplier:      2.0,
+	}
+
+	base := policy.baseBackoff(10)
+	assert.Equal(t, 5*time.Second, base)
+}
+
+func TestRetryPolicyNextBackoffJitterBounds(t *testing.T) {
+	policy := RetryPolicy{
+		InitialInterval:     time.Second,
+		MaxInterval:         time.Minute,
+		Multiplier:          2.0,
+		RandomizationFactor: 0.5,
+	}
+
+	base := float64(policy.baseBackoff(2))
+	min := time.Duration(base * 0.5)
+	max := time.Duration(base * 1.5)
+
+	for i := 0; i < 50; i++ {
+		got := policy.NextBackoff(2)
+		assert.GreaterOrEqual(t, got, min)
+		assert.LessOrEqual(t, got, max)
+	}
+}
+
+func TestRetryPolicyNextBackoffStopsAfterMaxElapsedTime(t *testing.T) {
+	policy := RetryPolicy{
+		InitialInterval: time.Second,
+		MaxInterval:     time.Second,
+		Multiplier:      2.0,
+		MaxElapsedTime:  3 * time.Second,
+	}
+
+	// baseBackoff is capped at 1s per attempt, so by attempt 4 cumulative
+	// elapsed (4s) exceeds the 3s budget.
+	assert.Equal(t, Stop, policy.NextBackoff(4))
+}
+
+func TestRetryPolicyDefaults(t *testing.T) {
+	policy := RetryPolicy{InitialInterval: time.Second, MaxInterval: time.Minute}
+	got := policy.NextBackoff(0)
+	assert.GreaterOrEqual(t, got, time.Duration(0))
+}
@@ -0,0 +1,103 @@
+package outbox
+
+import (
+	"encoding/json"
+	"fmt"
+
+	"github.com/MalteBoehm/tall-affiliate-common/pkg/events/schema"
+)
+
+// schemaAwareEnvelope is the small JSON object left in the outbox row's
+// payload column when a caller opts into SchemaAwarePayload instead of raw
+// JSON; Wire carries the Confluent-wire-format bytes (magic byte + schema ID
+// + codec body).
+type schemaAwareEnvelope struct {
+	Wire []byte `json:"schema_wire"`
+}
+
+// MarshalPayloadSchemaAware encodes payload with codec and wraps the result
+// in the Confluent wire format (magic byte + schemaID + body), then stores
+// that inside a small JSON envelope so the result stays valid json.RawMessage
+// for the outbox payload column. schemaID is expected to already be
+// registered via a SchemaRegistry.
+func MarshalPayloadSchemaAware(payload any, schemaID uint32, codec schema.Codec) (json.RawMessage, error) {
+	if codec == nil {
+		return nil, &PayloadError{Operation: "schema-marshal", Err: fmt.Errorf("codec is required")}
+	}
+
+	body, err := codec.Marshal(payload)
+	if err != nil {
+		return nil, &PayloadError{Operation: "schema-marshal", Err: err}
+	}
+
+	wire := schema.EncodeConfluent(schemaID, body)
+	data, err := json.Marshal(schemaAwareEnvelope{Wire: wire})
+	if err != nil {
+		return nil, &PayloadError{Operation: "schema-marshal", Err: err}
+	}
+	return json.RawMessage(data), nil
+}
+
+// CodecForFormat resolves the Codec to use for a schema.Format looked up from
+// a registry. Formats other than Avro and Protobuf return an error, so
+// UnmarshalPayloadSchemaAware, which resolves its codec here, decodes only
+// those two.
+func CodecForFormat(format schema.Format) (schema.Codec, error) {
+	switch format {
+	case schema.FormatAvro:
+		return schema.AvroCodec{}, nil
+	case schema.FormatProtobuf:
+		return schema.ProtobufCodec{}, nil
+	default:
+		return nil, fmt.Errorf("schema: no codec registered for format %q", format)
+	}
+}
+
+// UnmarshalPayloadSchemaAware reverses MarshalPayloadSchemaAware: it unwraps
+// the envelope, decodes the Confluent wire header to find the schema ID,
+// looks the schema up in registry to learn its format and Avro field layout
+// (for AvroCodec), and decodes the body into target.
+func UnmarshalPayloadSchemaAware(payload json.RawMessage, target any, registry schema.SchemaRegistry) error {
+	if registry == nil {
+		return &PayloadError{Operation: "schema-unmarshal", Err: fmt.Errorf("registry is required")}
+	}
+
+	var env schemaAwareEnvelope
+	if err := json.Unmarshal(payload, &env); err != nil {
+		return &PayloadError{Operation: "schema-unmarshal", Err: err}
+	}
+
+	schemaID, body, err := schema.DecodeConfluent(env.Wire)
+	if err != nil {
+		return &PayloadError{Operation: "schema-unmarshal", Err: err}
+	}
+
+	sch, err := registry.Lookup(schemaID)
+	if err != nil {
+		return &PayloadError{Operation: "schema-unmarshal", Err: err}
+	}
+
+	codec, err := CodecForFormat(sch.Format)
+	if err != nil {
+		return &PayloadError{Operation: "schema-unmarshal", Err: err}
+	}
+	if avro, ok := codec.(schema.AvroCodec); ok {
+		avro.Schema = sch.Raw
+		codec = avro
+	}
+
+	if err := codec.Unmarshal(body, target); err != nil {
+		return &PayloadError{Operation: "schema-unmarshal", Err: err}
+	}
+	return nil
+}
+
+// IsSchemaAwareEnvelope reports whether payload is a schemaAwareEnvelope
+// produced by MarshalPayloadSchemaAware, as opposed to a plain inline value.
+func IsSchemaAwareEnvelope(payload json.RawMessage) bool {
+	var env schemaAwareEnvelope
+	if err := json.Unmarshal(payload, &env); err != nil {
+		return false
+	}
+	return len(env.Wire) > 0
+}
@@ -0,0 +1,54 @@
+package outbox
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/MalteBoehm/tall-affiliate-common/pkg/events/schema"
+)
+
+const schemaAwareTestSchema = `{
+	"type": "record",
+	"name": "ProductEnriched",
+	"fields": [
+		{"name": "asin", "type": "string"},
+		{"name": "title", "type": "string"}
+	]
+}`
+
+func TestMarshalAndUnmarshalPayloadSchemaAwareRoundTrip(t *testing.T) {
+	registry := schema.NewInMemoryRegistry(schema.CompatibilityNone)
+	schemaID, err := registry.Register("catalog.product.enriched.v1", 1, schema.FormatAvro, []byte(schemaAwareTestSchema))
+	require.NoError(t, err)
+
+	payload := map[string]any{"asin": "B001234567", "title": "Test Product"}
+	data, err := MarshalPayloadSchemaAware(payload, schemaID, schema.AvroCodec{Schema: []byte(schemaAwareTestSchema)})
+	require.NoError(t, err)
+	assert.True(t, IsSchemaAwareEnvelope(data))
+
+	var out struct {
+		Asin  string `json:"asin"`
+		Title string `json:"title"`
+	}
+	require.NoError(t, UnmarshalPayloadSchemaAware(data, &out, registry))
+	assert.Equal(t, "B001234567", out.Asin)
+	assert.Equal(t, "Test Product", out.Title)
+}
+
+func TestUnmarshalPayloadSchemaAwareRejectsUnknownSchemaID(t *testing.T) {
+	registry := schema.NewInMemoryRegistry(schema.CompatibilityNone)
+	data, err := MarshalPayloadSchemaAware(map[string]any{"asin": "B1", "title": "T"}, 999, schema.AvroCodec{Schema: []byte(schemaAwareTestSchema)})
+	require.NoError(t, err)
+
+	var out map[string]any
+	err = UnmarshalPayloadSchemaAware(data, &out, registry)
+	require.Error(t, err)
+}
+
+func TestIsSchemaAwareEnvelopeRejectsPlainPayload(t *testing.T) {
+	plain, err := MarshalPayload(map[string]string{"asin": "B1"})
+	require.NoError(t, err)
+	assert.False(t, IsSchemaAwareEnvelope(plain))
+}
@@ -0,0 +1,114 @@
+package outbox
+
+import (
+	"bytes"
+	"compress/gzip"
+	"encoding/json"
+	"fmt"
+	"io"
+)
+
+// PayloadCodec configures the streaming Marshal/UnmarshalPayloadStream
+// helpers. The zero value behaves like MarshalPayload/UnmarshalPayload:
+// plain JSON capped at MaxPayloadSize. Set Gzip to compress on the fly and
+// raise MaxSize for services that can safely accept larger streamed
+// payloads (e.g. 1MB enrichment results).
+type PayloadCodec struct {
+	// MaxSize caps the encoded (post-compression, if Gzip is set) payload
+	// size. Zero falls back to MaxPayloadSize.
+	MaxSize int64
+	// Gzip compresses the encoded JSON before returning it from
+	// MarshalPayloadStream. ContentEncoding reports ContentEncodingGzip when set, so
+	// callers can record it alongside the outbox row.
+	Gzip bool
+}
+
+func (c PayloadCodec) maxSize() int64 {
+	if c.MaxSize <= 0 {
+		return MaxPayloadSize
+	}
+	return c.MaxSize
+}
+
+// ContentEncoding reports the content-encoding hint MarshalPayloadStream
+// produces for this codec's configuration: ContentEncodingGzip or "" for
+// plain JSON.
+func (c PayloadCodec) ContentEncoding() string {
+	if c.Gzip {
+		return ContentEncodingGzip
+	}
+	return ""
+}
+
+// MarshalPayloadStream encodes payload as JSON (gzip-compressing it first if
+// c.Gzip is set) and returns it as an io.ReadCloser along with its final
+// size, so callers can stream it into an outbox row or HTTP body without an
+// extra copy. It enforces c.maxSize() the same way MarshalPayload enforces
+// MaxPayloadSize.
+func (c PayloadCodec) MarshalPayloadStream(payload any) (io.ReadCloser, int64, error) {
+	var buf bytes.Buffer
+	if err := json.NewEncoder(&buf).Encode(payload); err != nil {
+		return nil, 0, &PayloadError{Operation: "marshal", Err: err}
+	}
+	data := bytes.TrimRight(buf.Bytes(), "\n")
+
+	if c.Gzip {
+		var gzBuf bytes.Buffer
+		gzBuf.Write(gzipSentinel)
+		w := gzip.NewWriter(&gzBuf)
+		if _, err := w.Write(data); err != nil {
+			return nil, 0, &PayloadError{Operation: "compress", Err: err}
+		}
+		if err := w.Close(); err != nil {
+			return nil, 0, &PayloadError{Operation: "compress", Err: err}
+		}
+		data = gzBuf.Bytes()
+	}
+
+	if int64(len(data)) > c.maxSize() {
+		return nil, 0, &PayloadError{
+			Operation: "validation",
+			Err:       fmt.Errorf("payload size %d bytes exceeds maximum %d bytes", len(data), c.maxSize()),
+		}
+	}
+
+	return io.NopCloser(bytes.NewReader(data)), int64(len(data)), nil
+}
+
+// UnmarshalPayloadStream decodes a payload produced by MarshalPayloadStream
+// from r into target, transparently gzip-decompressing when c.Gzip is set.
+func (c PayloadCodec) UnmarshalPayloadStream(r io.Reader, target any) error {
+	reader := r
+	if c.Gzip {
+		data, err := io.ReadAll(r)
+		if err != nil {
+			return &PayloadError{Operation: "unmarshal", Err: err}
+		}
+		stripped, ok := stripSentinel(data)
+		if !ok {
+			return &PayloadError{Operation: "decompress", Err: fmt.Errorf("missing gzip sentinel")}
+		}
+		gz, err := gzip.NewReader(bytes.NewReader(stripped))
+		if err != nil {
+			return &PayloadError{Operation: "decompress", Err: err}
+		}
+		defer gz.Close()
+		reader = gz
+	}
+
+	if err := json.NewDecoder(reader).Decode(target); err != nil {
+		return &PayloadError{Operation: "unmarshal", Err: err}
+	}
+	return nil
+}
+
+// MarshalPayloadStream encodes payload using the default PayloadCodec
+// (plain JSON, capped at MaxPayloadSize).
+func MarshalPayloadStream(payload any) (io.ReadCloser, int64, error) {
+	return PayloadCodec{}.MarshalPayloadStream(payload)
+}
+
+// UnmarshalPayloadStream decodes r using the default PayloadCodec.
+func UnmarshalPayloadStream(r io.Reader, target any) error {
+	return PayloadCodec{}.UnmarshalPayloadStream(r, target)
+}
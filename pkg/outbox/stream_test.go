@@ -0,0 +1,57 @@
+package outbox
+
+import (
+	"io"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestMarshalUnmarshalPayloadStreamPlainRoundTrip(t *testing.T) {
+	payload := map[string]string{"name": "John"}
+
+	r, size, err := MarshalPayloadStream(payload)
+	require.NoError(t, err)
+	assert.Greater(t, size, int64(0))
+
+	var out map[string]string
+	require.NoError(t, UnmarshalPayloadStream(r, &out))
+	assert.Equal(t, payload, out)
+}
+
+func TestMarshalUnmarshalPayloadStreamGzipRoundTrip(t *testing.T) {
+	codec := PayloadCodec{Gzip: true}
+	payload := map[string]string{"description": strings.Repeat("tall friendly clothing ", 2000)}
+
+	r, size, err := codec.MarshalPayloadStream(payload)
+	require.NoError(t, err)
+	assert.Equal(t, ContentEncodingGzip, codec.ContentEncoding())
+	assert.Greater(t, size, int64(0))
+
+	var out map[string]string
+	require.NoError(t, codec.UnmarshalPayloadStream(r, &out))
+	assert.Equal(t, payload, out)
+}
+
+func TestMarshalPayloadStreamRejectsOversizedPayload(t *testing.T) {
+	codec := PayloadCodec{MaxSize: 16}
+	_, _, err := codec.MarshalPayloadStream(map[string]string{"name": "this is far too long to fit"})
+	require.Error(t, err)
+	var payloadErr *PayloadError
+	require.ErrorAs(t, err, &payloadErr)
+	assert.Equal(t, "validation", payloadErr.Operation)
+}
+
+func TestPayloadCodecContentEncodingDefaultsToEmpty(t *testing.T) {
+	assert.Equal(t, "", PayloadCodec{}.ContentEncoding())
+}
+
+func TestMarshalPayloadStreamReturnsReadCloser(t *testing.T) {
+	r, _, err := MarshalPayloadStream(map[string]string{"a": "b"})
+	require.NoError(t, err)
+
+	var rc io.ReadCloser = r
+	require.NoError(t, rc.Close())
+}
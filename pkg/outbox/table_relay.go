@@ -0,0 +1,308 @@
+package outbox
+
+import (
+	"context"
+	"database/sql"
+	"encoding/json"
+	"fmt"
+	"log/slog"
+	"time"
+
+	"github.com/MalteBoehm/tall-affiliate-common/pkg/constants"
+	"github.com/MalteBoehm/tall-affiliate-common/pkg/database"
+	"github.com/MalteBoehm/tall-affiliate-common/pkg/events"
+	"github.com/MalteBoehm/tall-affiliate-common/pkg/interfaces"
+)
+
+// DLQStreamName is the stream TableRelay publishes an event to once it
+// exhausts its attempt budget.
+const DLQStreamName = "stream:dlq"
+
+// MetadataDLQReason is the Event.Metadata key TableRelay stamps with the
+// last relay error before publishing an exhausted event to DLQStreamName.
+const MetadataDLQReason = "x-dlq-reason"
+
+// claimLease is how long a claimed-but-not-yet-resolved row is protected
+// from being claimed again by a concurrent TableRelay instance. It only
+// needs to outlast one RelayOnce batch's publish calls, not a full retry
+// backoff interval.
+const claimLease = 30 * time.Second
+
+// TableRelay polls constants.TableOutbox (the table Writer writes to) for
+// rows due for dispatch and publishes them through a StreamProducer,
+// claiming each batch with SELECT ... FOR UPDATE SKIP LOCKED so multiple
+// TableRelay instances can run concurrently without double-publishing.
+// Claiming briefly pushes a row's next_attempt_at forward by claimLease as
+// a lease, rather than holding the claim transaction open across the
+// publish calls; a row whose publish fails has its own next_attempt_at
+// rescheduled with RetryPolicy-driven backoff, and one that exhausts
+// MaxAttemptsFunc is instead published to a DLQ stream. Rows are claimed
+// ordered by aggregate_id then created_at, and RelayOnce defers any row
+// whose aggregate_id still has an earlier, unresolved row in the same
+// batch - so a stuck row can never let a later row for its own aggregate
+// overtake it, without forcing the whole batch to roll back over one slow
+// publish. It is the consolidated counterpart to database.NewOutboxEvent,
+// much like Relay is to Outbox.Enqueue - this is the one meant for rows
+// Writer writes.
+type TableRelay struct {
+	db          *sql.DB
+	producer    interfaces.StreamProducer
+	streamName  string
+	policy      RetryPolicy
+	maxAttempts MaxAttemptsFunc
+	metrics     DispatcherMetrics
+	logger      *slog.Logger
+}
+
+// NewTableRelay creates a TableRelay that publishes claimed rows onto
+// streamName via producer, applying policy's backoff on failure. maxAttempts
+// may be nil, in which case every event type is allowed DefaultMaxAttempts
+// attempts; metrics may be nil (NoopDispatcherMetrics); logger may be nil
+// (slog.Default()).
+func NewTableRelay(
+	db *sql.DB,
+	producer interfaces.StreamProducer,
+	streamName string,
+	policy RetryPolicy,
+	maxAttempts MaxAttemptsFunc,
+	metrics DispatcherMetrics,
+	logger *slog.Logger,
+) *TableRelay {
+	if maxAttempts == nil {
+		maxAttempts = func(string) int { return DefaultMaxAttempts }
+	}
+	if metrics == nil {
+		metrics = NoopDispatcherMetrics{}
+	}
+	if logger == nil {
+		logger = slog.Default()
+	}
+	return &TableRelay{
+		db:          db,
+		producer:    producer,
+		streamName:  streamName,
+		policy:      policy,
+		maxAttempts: maxAttempts,
+		metrics:     metrics,
+		logger:      logger.With("component", "outbox-table-relay"),
+	}
+}
+
+// outboxRow is a row claimed from constants.TableOutbox.
+type outboxRow struct {
+	id          string
+	aggregateID string
+	event       *events.Event
+	attempt     int
+	createdAt   time.Time
+}
+
+// RelayOnce claims up to limit rows due for dispatch and attempts to
+// publish each one, rescheduling it with backoff on failure or
+// dead-lettering it once MaxAttemptsFunc is exhausted. Rows are claimed in
+// aggregate_id, created_at order; once a row fails and is scheduled for
+// retry, every later row in the batch for that same aggregate_id is
+// deferred to the same time instead of being published out of turn. It
+// returns the number of rows whose outcome was recorded without error
+// (published, dead-lettered, or rescheduled for retry); deferred rows are
+// not counted.
+func (r *TableRelay) RelayOnce(ctx context.Context, limit int) (int, error) {
+	rows, err := r.claim(ctx, limit)
+	if err != nil {
+		return 0, err
+	}
+
+	relayed := 0
+	blockedUntil := make(map[string]time.Time)
+	for _, row := range rows {
+		if until, blocked := blockedUntil[row.aggregateID]; blocked {
+			if err := r.deferRow(ctx, row.id, until); err != nil {
+				r.logger.Error("failed to defer outbox row behind its aggregate", "id", row.id, "error", err)
+			}
+			continue
+		}
+
+		resolved, nextAttemptAt, err := r.relayOne(ctx, row)
+		if err != nil {
+			r.logger.Error("failed to relay outbox row", "id", row.id, "error", err)
+			continue
+		}
+		relayed++
+		if !resolved {
+			blockedUntil[row.aggregateID] = nextAttemptAt
+		}
+	}
+	return relayed, nil
+}
+
+// claim selects up to limit rows due for dispatch via SELECT ... FOR
+// UPDATE SKIP LOCKED, built with database.QueryBuilder, ordered by
+// aggregate_id then created_at so RelayOnce can enforce per-aggregate
+// ordering, then leases them by pushing next_attempt_at forward by
+// claimLease - all in one transaction, so a concurrent TableRelay
+// claiming the same table skips rows this call is already holding instead
+// of blocking on them, and won't reclaim them again while this call is
+// still publishing.
+func (r *TableRelay) claim(ctx context.Context, limit int) ([]outboxRow, error) {
+	tx, err := r.db.BeginTx(ctx, nil)
+	if err != nil {
+		return nil, fmt.Errorf("outbox: begin claim transaction: %w", err)
+	}
+	defer tx.Rollback()
+
+	query, args := database.NewQueryBuilder().
+		Select("id", "aggregate_id", "payload", "attempt", "created_at").
+		From(constants.TableOutbox).
+		Where("published_at IS NULL").
+		Where("next_attempt_at <= NOW()").
+		OrderBy("aggregate_id", false).
+		OrderBy("created_at", false).
+		Limit(limit).
+		Build()
+	query += " FOR UPDATE SKIP LOCKED"
+
+	sqlRows, err := tx.QueryContext(ctx, query, args...)
+	if err != nil {
+		return nil, fmt.Errorf("outbox: claim rows: %w", err)
+	}
+
+	var claimed []outboxRow
+	for sqlRows.Next() {
+		var id string
+		var aggregateID string
+		var payload []byte
+		var attempt int
+		var createdAt time.Time
+		if err := sqlRows.Scan(&id, &aggregateID, &payload, &attempt, &createdAt); err != nil {
+			sqlRows.Close()
+			return nil, fmt.Errorf("outbox: scan claimed row: %w", err)
+		}
+		var evt events.Event
+		if err := json.Unmarshal(payload, &evt); err != nil {
+			sqlRows.Close()
+			return nil, fmt.Errorf("outbox: unmarshal event for row %s: %w", id, err)
+		}
+		claimed = append(claimed, outboxRow{id: id, aggregateID: aggregateID, event: &evt, attempt: attempt, createdAt: createdAt})
+	}
+	if err := sqlRows.Err(); err != nil {
+		sqlRows.Close()
+		return nil, err
+	}
+	sqlRows.Close()
+
+	leasedUntil := time.Now().UTC().Add(claimLease)
+	for _, row := range claimed {
+		if _, err := tx.ExecContext(ctx,
+			fmt.Sprintf(`UPDATE %s SET next_attempt_at = $1 WHERE id = $2`, constants.TableOutbox),
+			leasedUntil, row.id,
+		); err != nil {
+			return nil, fmt.Errorf("outbox: lease row %s: %w", row.id, err)
+		}
+	}
+
+	if err := tx.Commit(); err != nil {
+		return nil, fmt.Errorf("outbox: commit claim transaction: %w", err)
+	}
+	return claimed, nil
+}
+
+// relayOne publishes row, then resolves its outcome: marking it published
+// on success, dead-lettering it once its attempt budget is exhausted, or
+// rescheduling it with backoff on a retryable failure. resolved is true
+// once row will never block a later same-aggregate row again (published
+// or dead-lettered); when resolved is false, nextAttemptAt is when row
+// itself - and any later row RelayOnce defers behind it - becomes due.
+func (r *TableRelay) relayOne(ctx context.Context, row outboxRow) (resolved bool, nextAttemptAt time.Time, err error) {
+	pubErr := r.producer.PublishEvent(ctx, r.streamName, row.event)
+	if pubErr == nil {
+		r.metrics.IncDispatched(row.event.Type)
+		r.metrics.ObserveLag(row.event.Type, time.Since(row.createdAt))
+		return true, time.Time{}, r.markPublished(ctx, row.id)
+	}
+
+	r.metrics.IncFailed(row.event.Type)
+	attempt := row.attempt + 1
+	backoff := r.policy.NextBackoff(attempt)
+	if attempt >= r.maxAttempts(row.event.Type) || backoff == Stop {
+		return true, time.Time{}, r.deadLetter(ctx, row, pubErr)
+	}
+
+	nextAttemptAt = time.Now().UTC().Add(backoff)
+	return false, nextAttemptAt, r.markRetry(ctx, row.id, attempt, pubErr, nextAttemptAt)
+}
+
+// deferRow pushes id's next_attempt_at to until without touching its
+// attempt count, used when an earlier row for the same aggregate_id in
+// this batch hasn't resolved yet - so id's own due time doesn't let it
+// publish ahead of an aggregate-mate that's still retrying.
+func (r *TableRelay) deferRow(ctx context.Context, id string, until time.Time) error {
+	_, err := r.db.ExecContext(ctx,
+		fmt.Sprintf(`UPDATE %s SET next_attempt_at = $1 WHERE id = $2`, constants.TableOutbox),
+		until, id)
+	if err != nil {
+		return fmt.Errorf("outbox: defer %s pending aggregate order: %w", id, err)
+	}
+	return nil
+}
+
+// deadLetter publishes row.event onto DLQStreamName - preserving its
+// original type, aggregate, and metadata - stamped with MetadataDLQReason,
+// then marks the row published so it stops being claimed.
+func (r *TableRelay) deadLetter(ctx context.Context, row outboxRow, lastErr error) error {
+	evt := row.event
+	if evt.Metadata == nil {
+		evt.Metadata = make(map[string]any)
+	}
+	evt.Metadata[MetadataDLQReason] = lastErr.Error()
+
+	if err := r.producer.PublishEvent(ctx, DLQStreamName, evt); err != nil {
+		return fmt.Errorf("outbox: publish row %s to dlq: %w", row.id, err)
+	}
+	return r.markPublished(ctx, row.id)
+}
+
+// markPublished sets published_at on id.
+func (r *TableRelay) markPublished(ctx context.Context, id string) error {
+	_, err := r.db.ExecContext(ctx,
+		fmt.Sprintf(`UPDATE %s SET published_at = $1 WHERE id = $2`, constants.TableOutbox),
+		time.Now().UTC(), id)
+	if err != nil {
+		return fmt.Errorf("outbox: mark %s published: %w", id, err)
+	}
+	return nil
+}
+
+// markRetry records a failed publish attempt for id, to be retried no
+// earlier than nextAttemptAt.
+func (r *TableRelay) markRetry(ctx context.Context, id string, attempt int, lastErr error, nextAttemptAt time.Time) error {
+	_, err := r.db.ExecContext(ctx, fmt.Sprintf(`
+		UPDATE %s SET attempt = $1, last_error = $2, next_attempt_at = $3
+		WHERE id = $4`, constants.TableOutbox),
+		attempt, lastErr.Error(), nextAttemptAt, id)
+	if err != nil {
+		return fmt.Errorf("outbox: mark %s for retry: %w", id, err)
+	}
+	return nil
+}
+
+// Run polls RelayOnce every pollInterval until ctx is cancelled. If wake
+// is non-nil (see NewNotifyListener), a value received on it triggers an
+// immediate RelayOnce instead of waiting out the rest of pollInterval, so
+// a freshly-written row is relayed promptly rather than on the next tick.
+func (r *TableRelay) Run(ctx context.Context, limit int, pollInterval time.Duration, wake <-chan struct{}) error {
+	ticker := time.NewTicker(pollInterval)
+	defer ticker.Stop()
+
+	for {
+		if _, err := r.RelayOnce(ctx, limit); err != nil {
+			r.logger.Error("failed to relay outbox batch", "error", err)
+		}
+
+		select {
+		case <-ctx.Done():
+			return ctx.Err()
+		case <-ticker.C:
+		case <-wake:
+		}
+	}
+}
@@ -0,0 +1,86 @@
+package outbox
+
+import (
+	"context"
+	"database/sql"
+	"encoding/json"
+	"fmt"
+	"time"
+
+	"github.com/MalteBoehm/tall-affiliate-common/pkg/constants"
+	"github.com/MalteBoehm/tall-affiliate-common/pkg/database"
+	"github.com/MalteBoehm/tall-affiliate-common/pkg/events"
+)
+
+// Writer inserts events.Event rows into constants.TableOutbox:
+//
+//	CREATE TABLE outbox (
+//		id              TEXT PRIMARY KEY,
+//		aggregate_id    TEXT NOT NULL,
+//		type            TEXT NOT NULL,
+//		payload         JSONB NOT NULL,
+//		created_at      TIMESTAMPTZ NOT NULL,
+//		published_at    TIMESTAMPTZ,
+//		attempt         INT NOT NULL DEFAULT 0,
+//		last_error      TEXT,
+//		next_attempt_at TIMESTAMPTZ NOT NULL DEFAULT NOW()
+//	);
+//	CREATE INDEX outbox_unpublished_idx ON outbox (aggregate_id, created_at)
+//		WHERE published_at IS NULL;
+//
+// attempt/last_error/next_attempt_at default a freshly written row to
+// immediately claimable with no retry history, so Write/WriteBatch don't
+// need to set them explicitly - TableRelay is the only thing that updates
+// them, once it starts claiming the row.
+//
+// as part of a caller-provided transaction, so a business write and its
+// outbox row commit or roll back together - the gap TableRelay's FOR
+// UPDATE SKIP LOCKED polling closes without a two-phase commit.
+type Writer struct{}
+
+// NewWriter creates a Writer.
+func NewWriter() *Writer {
+	return &Writer{}
+}
+
+// Write inserts evt into constants.TableOutbox as part of tx: id,
+// aggregate_id, type, payload (jsonb), created_at, and a null
+// published_at for TableRelay to later claim. A duplicate id (the caller
+// retried the same business transaction) is silently ignored.
+func (w *Writer) Write(ctx context.Context, tx *sql.Tx, evt *events.Event) error {
+	payload, err := json.Marshal(evt)
+	if err != nil {
+		return fmt.Errorf("outbox: marshal event %s: %w", evt.ID, err)
+	}
+	_, err = tx.ExecContext(ctx, fmt.Sprintf(`
+		INSERT INTO %s (id, aggregate_id, type, payload, created_at, published_at)
+		VALUES ($1, $2, $3, $4, $5, NULL)
+		ON CONFLICT (id) DO NOTHING`, constants.TableOutbox),
+		evt.ID, evt.AggregateID, evt.Type, payload, time.Now().UTC())
+	if err != nil {
+		return fmt.Errorf("outbox: write event %s: %w", evt.ID, err)
+	}
+	return nil
+}
+
+// WriteBatch bulk-inserts evts into constants.TableOutbox via
+// database.BulkInsert, for callers seeding or backfilling outside of any
+// single business transaction.
+func (w *Writer) WriteBatch(ctx context.Context, db *sql.DB, evts []*events.Event) error {
+	if len(evts) == 0 {
+		return nil
+	}
+
+	now := time.Now().UTC()
+	columns := []string{"id", "aggregate_id", "type", "payload", "created_at"}
+	values := make([][]interface{}, 0, len(evts))
+	for _, evt := range evts {
+		payload, err := json.Marshal(evt)
+		if err != nil {
+			return fmt.Errorf("outbox: marshal event %s: %w", evt.ID, err)
+		}
+		values = append(values, []interface{}{evt.ID, evt.AggregateID, evt.Type, payload, now})
+	}
+
+	return database.BulkInsert(ctx, db, constants.TableOutbox, columns, values)
+}
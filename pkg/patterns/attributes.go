@@ -0,0 +1,363 @@
+package patterns
+
+import (
+	"regexp"
+	"strings"
+
+	patternindex "github.com/MalteBoehm/tall-affiliate-common/pkg/patterns/index"
+)
+
+// Color is a canonical color value. Extracted via synonym tables covering
+// German and English spellings (e.g. "schwarz"/"black"/"noir").
+type Color string
+
+// Canonical Color values. ExtractAttributes never returns any other value
+// for a known color.
+const (
+	ColorBlack  Color = "black"
+	ColorWhite  Color = "white"
+	ColorGrey   Color = "grey"
+	ColorRed    Color = "red"
+	ColorBlue   Color = "blue"
+	ColorGreen  Color = "green"
+	ColorYellow Color = "yellow"
+	ColorOrange Color = "orange"
+	ColorPurple Color = "purple"
+	ColorPink   Color = "pink"
+	ColorBrown  Color = "brown"
+	ColorBeige  Color = "beige"
+)
+
+// Material is a canonical fabric/material value.
+type Material string
+
+// Canonical Material values.
+const (
+	MaterialCotton    Material = "cotton"
+	MaterialPolyester Material = "polyester"
+	MaterialWool      Material = "wool"
+	MaterialLinen     Material = "linen"
+	MaterialDenim     Material = "denim"
+	MaterialLeather   Material = "leather"
+	MaterialSilk      Material = "silk"
+	MaterialViscose   Material = "viscose"
+)
+
+// Fit is a canonical garment fit value.
+type Fit string
+
+// Canonical Fit values.
+const (
+	FitSlim     Fit = "slim"
+	FitRegular  Fit = "regular"
+	FitLoose    Fit = "loose"
+	FitOversize Fit = "oversize"
+)
+
+// SleeveLength is a canonical sleeve length value.
+type SleeveLength string
+
+// Canonical SleeveLength values.
+const (
+	SleeveLengthShort        SleeveLength = "short"
+	SleeveLengthLong         SleeveLength = "long"
+	SleeveLengthSleeveless   SleeveLength = "sleeveless"
+	SleeveLengthThreeQuarter SleeveLength = "three_quarter"
+)
+
+// Neckline is a canonical collar/neckline value.
+type Neckline string
+
+// Canonical Neckline values.
+const (
+	NecklineCrew   Neckline = "crew"
+	NecklineVNeck  Neckline = "v_neck"
+	NecklinePolo   Neckline = "polo"
+	NecklineHooded Neckline = "hooded"
+	NecklineTurtle Neckline = "turtle"
+)
+
+// Occasion is a canonical use-case value.
+type Occasion string
+
+// Canonical Occasion values.
+const (
+	OccasionCasual   Occasion = "casual"
+	OccasionSport    Occasion = "sport"
+	OccasionBusiness Occasion = "business"
+	OccasionFormal   Occasion = "formal"
+)
+
+// AgeGroup is a canonical target age group value.
+type AgeGroup string
+
+// Canonical AgeGroup values.
+const (
+	AgeGroupAdult AgeGroup = "adult"
+	AgeGroupKids  AgeGroup = "kids"
+	AgeGroupBaby  AgeGroup = "baby"
+)
+
+// SeasonalTag is a canonical season value.
+type SeasonalTag string
+
+// Canonical SeasonalTag values.
+const (
+	SeasonalTagSummer    SeasonalTag = "summer"
+	SeasonalTagWinter    SeasonalTag = "winter"
+	SeasonalTagAllSeason SeasonalTag = "all_season"
+)
+
+// ProductAttributes is a fixed set of typed product attributes extracted
+// from free text and Browse Nodes, so downstream tagging and filtering can
+// rely on structured fields instead of pattern-matching raw text again.
+// Each attribute has an XIsKnown flag (false if nothing matched) and an
+// XConfidence score in [0, 1].
+type ProductAttributes struct {
+	Size           string  `json:"size"`
+	SizeIsKnown    bool    `json:"size_is_known"`
+	SizeConfidence float64 `json:"size_confidence"`
+
+	Color           Color   `json:"color"`
+	ColorIsKnown    bool    `json:"color_is_known"`
+	ColorConfidence float64 `json:"color_confidence"`
+
+	Material           Material `json:"material"`
+	MaterialIsKnown    bool     `json:"material_is_known"`
+	MaterialConfidence float64  `json:"material_confidence"`
+
+	Fit           Fit     `json:"fit"`
+	FitIsKnown    bool    `json:"fit_is_known"`
+	FitConfidence float64 `json:"fit_confidence"`
+
+	SleeveLength           SleeveLength `json:"sleeve_length"`
+	SleeveLengthIsKnown    bool         `json:"sleeve_length_is_known"`
+	SleeveLengthConfidence float64      `json:"sleeve_length_confidence"`
+
+	Neckline           Neckline `json:"neckline"`
+	NecklineIsKnown    bool     `json:"neckline_is_known"`
+	NecklineConfidence float64  `json:"neckline_confidence"`
+
+	Occasion           Occasion `json:"occasion"`
+	OccasionIsKnown    bool     `json:"occasion_is_known"`
+	OccasionConfidence float64  `json:"occasion_confidence"`
+
+	AgeGroup           AgeGroup `json:"age_group"`
+	AgeGroupIsKnown    bool     `json:"age_group_is_known"`
+	AgeGroupConfidence float64  `json:"age_group_confidence"`
+
+	SeasonalTag           SeasonalTag `json:"seasonal_tag"`
+	SeasonalTagIsKnown    bool        `json:"seasonal_tag_is_known"`
+	SeasonalTagConfidence float64     `json:"seasonal_tag_confidence"`
+}
+
+var (
+	colorIndex, colorGroups               = mustGroupedIndex(getColorPatterns(), defaultAnalyzeOptions)
+	materialIndex, materialGroups         = mustGroupedIndex(getMaterialPatterns(), defaultAnalyzeOptions)
+	fitIndex, fitGroups                   = mustGroupedIndex(getFitPatterns(), defaultAnalyzeOptions)
+	sleeveLengthIndex, sleeveLengthGroups = mustGroupedIndex(getSleeveLengthPatterns(), defaultAnalyzeOptions)
+	necklineIndex, necklineGroups         = mustGroupedIndex(getNecklinePatterns(), defaultAnalyzeOptions)
+	occasionIndex, occasionGroups         = mustGroupedIndex(getOccasionPatterns(), defaultAnalyzeOptions)
+	ageGroupIndex, ageGroupGroups         = mustGroupedIndex(getAgeGroupPatterns(), defaultAnalyzeOptions)
+	seasonalTagIndex, seasonalTagGroups   = mustGroupedIndex(getSeasonalTagPatterns(), defaultAnalyzeOptions)
+)
+
+// sizeRe matches a standalone clothing size token: letter sizes (xs..xxxl)
+// or a two-digit EU size in the 30-59 range.
+var sizeRe = regexp.MustCompile(`(?i)\b(xxs|xs|s|m|l|xl|xxl|xxxl|3xl|[3-5][0-9])\b`)
+
+// ExtractAttributes analyzes title, description and Browse Nodes to produce
+// a ProductAttributes struct, the same way DetectCategory/DetectGender turn
+// free text into a single category/gender, but across the broader set of
+// product attributes tagging and filtering need.
+func ExtractAttributes(browseNodes []BrowseNode, title, description string) ProductAttributes {
+	combinedText := title + " " + description
+	normalized := defaultAnalyzeOptions.normalize(combinedText)
+
+	var attrs ProductAttributes
+	attrs.Size, attrs.SizeIsKnown, attrs.SizeConfidence = extractSize(combinedText)
+
+	color, known, confidence := extractAttribute(colorIndex, colorGroups, normalized)
+	attrs.Color, attrs.ColorIsKnown, attrs.ColorConfidence = Color(color), known, confidence
+
+	material, known, confidence := extractAttribute(materialIndex, materialGroups, normalized)
+	attrs.Material, attrs.MaterialIsKnown, attrs.MaterialConfidence = Material(material), known, confidence
+
+	fit, known, confidence := extractAttribute(fitIndex, fitGroups, normalized)
+	attrs.Fit, attrs.FitIsKnown, attrs.FitConfidence = Fit(fit), known, confidence
+
+	sleeveLength, known, confidence := extractAttribute(sleeveLengthIndex, sleeveLengthGroups, normalized)
+	attrs.SleeveLength, attrs.SleeveLengthIsKnown, attrs.SleeveLengthConfidence = SleeveLength(sleeveLength), known, confidence
+
+	neckline, known, confidence := extractAttribute(necklineIndex, necklineGroups, normalized)
+	attrs.Neckline, attrs.NecklineIsKnown, attrs.NecklineConfidence = Neckline(neckline), known, confidence
+
+	occasion, known, confidence := extractAttribute(occasionIndex, occasionGroups, normalized)
+	attrs.Occasion, attrs.OccasionIsKnown, attrs.OccasionConfidence = Occasion(occasion), known, confidence
+
+	ageGroup, known, confidence := extractAttribute(ageGroupIndex, ageGroupGroups, normalized)
+	attrs.AgeGroup, attrs.AgeGroupIsKnown, attrs.AgeGroupConfidence = AgeGroup(ageGroup), known, confidence
+
+	seasonalTag, known, confidence := extractAttribute(seasonalTagIndex, seasonalTagGroups, normalized)
+	attrs.SeasonalTag, attrs.SeasonalTagIsKnown, attrs.SeasonalTagConfidence = SeasonalTag(seasonalTag), known, confidence
+
+	// Browse Node tags carry an occasion/category signal PA-API already
+	// curated; use it to fill in occasion when content text found nothing.
+	if !attrs.OccasionIsKnown {
+		if occasion, confidence := occasionFromBrowseNodes(browseNodes); occasion != "" {
+			attrs.Occasion, attrs.OccasionIsKnown, attrs.OccasionConfidence = Occasion(occasion), true, confidence
+		}
+	}
+
+	return attrs
+}
+
+// extractAttribute runs normalizedText against idx and returns the
+// highest-scoring group (if any) with a confidence derived from its score.
+func extractAttribute(idx *patternindex.PatternIndex, groups []string, normalizedText string) (value string, known bool, confidence float64) {
+	group, scores, err := idx.TopGroup(normalizedText, groups, 1)
+	if err != nil || group == "" {
+		return "", false, 0
+	}
+	return group, true, scoreToConfidence(scores[group])
+}
+
+// scoreToConfidence maps a pattern score to a confidence in [0, 1], using
+// the same score bands as calculateCombinedConfidence's content evidence
+// tiers.
+func scoreToConfidence(score int) float64 {
+	switch {
+	case score >= 10:
+		return 0.9
+	case score >= 5:
+		return 0.6
+	case score > 0:
+		return 0.3
+	default:
+		return 0
+	}
+}
+
+// extractSize looks for a standalone letter or EU numeric size token in
+// text. Unlike the other attributes, sizes aren't a small closed vocabulary
+// worth indexing - a direct regex match is both simpler and more precise.
+func extractSize(text string) (value string, known bool, confidence float64) {
+	match := sizeRe.FindString(text)
+	if match == "" {
+		return "", false, 0
+	}
+	return strings.ToUpper(match), true, 0.9
+}
+
+// occasionFromBrowseNodes infers an occasion from a product's Browse Node
+// tags when content text analysis found nothing, e.g. PA-API's own
+// "sportbekleidung" Browse Node.
+func occasionFromBrowseNodes(browseNodes []BrowseNode) (string, float64) {
+	mappings := getExtendedBrowseNodeMappings()
+	for _, node := range browseNodes {
+		mapping, exists := mappings[node.GetID()]
+		if !exists {
+			continue
+		}
+		for _, tag := range mapping.Tags {
+			switch tag {
+			case "sport":
+				return string(OccasionSport), 0.5
+			case "casual":
+				return string(OccasionCasual), 0.5
+			}
+		}
+	}
+	return "", 0
+}
+
+// getColorPatterns returns color detection patterns.
+func getColorPatterns() map[string][]PatternRule {
+	return map[string][]PatternRule{
+		string(ColorBlack):  {{Patterns: []string{"schwarz", "black", "noir"}, Weight: 10, Language: "both"}},
+		string(ColorWhite):  {{Patterns: []string{"weiß", "weiss", "white"}, Weight: 10, Language: "both"}},
+		string(ColorGrey):   {{Patterns: []string{"grau", "grey", "gray"}, Weight: 10, Language: "both"}},
+		string(ColorRed):    {{Patterns: []string{"rot", "red"}, Weight: 10, Language: "both"}},
+		string(ColorBlue):   {{Patterns: []string{"blau", "blue", "navy"}, Weight: 10, Language: "both"}},
+		string(ColorGreen):  {{Patterns: []string{"grün", "gruen", "green"}, Weight: 10, Language: "both"}},
+		string(ColorYellow): {{Patterns: []string{"gelb", "yellow"}, Weight: 10, Language: "both"}},
+		string(ColorOrange): {{Patterns: []string{"orange"}, Weight: 10, Language: "both"}},
+		string(ColorPurple): {{Patterns: []string{"lila", "violett", "purple"}, Weight: 10, Language: "both"}},
+		string(ColorPink):   {{Patterns: []string{"rosa", "pink"}, Weight: 10, Language: "both"}},
+		string(ColorBrown):  {{Patterns: []string{"braun", "brown"}, Weight: 10, Language: "both"}},
+		string(ColorBeige):  {{Patterns: []string{"beige"}, Weight: 10, Language: "both"}},
+	}
+}
+
+// getMaterialPatterns returns material detection patterns.
+func getMaterialPatterns() map[string][]PatternRule {
+	return map[string][]PatternRule{
+		string(MaterialCotton):    {{Patterns: []string{"baumwolle", "cotton"}, Weight: 10, Language: "both"}},
+		string(MaterialPolyester): {{Patterns: []string{"polyester"}, Weight: 10, Language: "both"}},
+		string(MaterialWool):      {{Patterns: []string{"wolle", "wool"}, Weight: 10, Language: "both"}},
+		string(MaterialLinen):     {{Patterns: []string{"leinen", "linen"}, Weight: 10, Language: "both"}},
+		string(MaterialDenim):     {{Patterns: []string{"denim", "jeansstoff"}, Weight: 10, Language: "both"}},
+		string(MaterialLeather):   {{Patterns: []string{"leder", "leather"}, Weight: 10, Language: "both"}},
+		string(MaterialSilk):      {{Patterns: []string{"seide", "silk"}, Weight: 10, Language: "both"}},
+		string(MaterialViscose):   {{Patterns: []string{"viskose", "viscose"}, Weight: 10, Language: "both"}},
+	}
+}
+
+// getFitPatterns returns garment fit detection patterns.
+func getFitPatterns() map[string][]PatternRule {
+	return map[string][]PatternRule{
+		string(FitSlim):     {{Patterns: []string{"slim fit", "schmale passform", "slim"}, Weight: 10, Language: "both"}},
+		string(FitRegular):  {{Patterns: []string{"regular fit", "normale passform", "regular"}, Weight: 10, Language: "both"}},
+		string(FitLoose):    {{Patterns: []string{"loose fit", "weite passform", "loose"}, Weight: 10, Language: "both"}},
+		string(FitOversize): {{Patterns: []string{"oversize", "oversized"}, Weight: 10, Language: "both"}},
+	}
+}
+
+// getSleeveLengthPatterns returns sleeve length detection patterns.
+func getSleeveLengthPatterns() map[string][]PatternRule {
+	return map[string][]PatternRule{
+		string(SleeveLengthShort):        {{Patterns: []string{"kurzarm", "short sleeve"}, Weight: 10, Language: "both"}},
+		string(SleeveLengthLong):         {{Patterns: []string{"langarm", "long sleeve"}, Weight: 10, Language: "both"}},
+		string(SleeveLengthSleeveless):   {{Patterns: []string{"ärmellos", "aermellos", "sleeveless", "tank"}, Weight: 10, Language: "both"}},
+		string(SleeveLengthThreeQuarter): {{Patterns: []string{"dreiviertelarm", "three quarter sleeve", "3/4 arm"}, Weight: 10, Language: "both"}},
+	}
+}
+
+// getNecklinePatterns returns collar/neckline detection patterns.
+func getNecklinePatterns() map[string][]PatternRule {
+	return map[string][]PatternRule{
+		string(NecklineCrew):   {{Patterns: []string{"rundhals", "crew neck", "rundhalsausschnitt"}, Weight: 10, Language: "both"}},
+		string(NecklineVNeck):  {{Patterns: []string{"v-ausschnitt", "v neck", "v-neck"}, Weight: 10, Language: "both"}},
+		string(NecklinePolo):   {{Patterns: []string{"polokragen", "polo collar"}, Weight: 10, Language: "both"}},
+		string(NecklineHooded): {{Patterns: []string{"kapuze", "hood", "hooded"}, Weight: 10, Language: "both"}},
+		string(NecklineTurtle): {{Patterns: []string{"rollkragen", "turtleneck", "turtle neck"}, Weight: 10, Language: "both"}},
+	}
+}
+
+// getOccasionPatterns returns use-case detection patterns.
+func getOccasionPatterns() map[string][]PatternRule {
+	return map[string][]PatternRule{
+		string(OccasionCasual):   {{Patterns: []string{"casual", "alltag", "freizeit"}, Weight: 10, Language: "both"}},
+		string(OccasionSport):    {{Patterns: []string{"sport", "fitness", "training"}, Weight: 10, Language: "both"}},
+		string(OccasionBusiness): {{Patterns: []string{"business", "office", "büro"}, Weight: 10, Language: "both"}},
+		string(OccasionFormal):   {{Patterns: []string{"formal", "festlich", "anlass"}, Weight: 10, Language: "both"}},
+	}
+}
+
+// getAgeGroupPatterns returns target age group detection patterns.
+func getAgeGroupPatterns() map[string][]PatternRule {
+	return map[string][]PatternRule{
+		string(AgeGroupAdult): {{Patterns: []string{"erwachsene", "adult"}, Weight: 10, Language: "both"}},
+		string(AgeGroupKids):  {{Patterns: []string{"kinder", "kids", "jungen", "mädchen"}, Weight: 10, Language: "both"}},
+		string(AgeGroupBaby):  {{Patterns: []string{"baby", "babys", "säugling"}, Weight: 10, Language: "both"}},
+	}
+}
+
+// getSeasonalTagPatterns returns seasonal detection patterns.
+func getSeasonalTagPatterns() map[string][]PatternRule {
+	return map[string][]PatternRule{
+		string(SeasonalTagSummer):    {{Patterns: []string{"sommer", "summer"}, Weight: 10, Language: "both"}},
+		string(SeasonalTagWinter):    {{Patterns: []string{"winter"}, Weight: 10, Language: "both"}},
+		string(SeasonalTagAllSeason): {{Patterns: []string{"ganzjährig", "all season", "allseason"}, Weight: 10, Language: "both"}},
+	}
+}
@@ -0,0 +1,430 @@
+package patterns
+
+import (
+	"math"
+	"regexp"
+	"runtime"
+	"sort"
+	"strings"
+	"sync"
+	"sync/atomic"
+
+	patternindex "github.com/MalteBoehm/tall-affiliate-common/pkg/patterns/index"
+)
+
+// Signal records one pattern rule's contribution to a Classification:
+// which dimension ("gender", "category", or "tag") and group it voted
+// for, the rule weight it contributed, the literal span of the input
+// text it matched, and whether that match was exact or fuzzy
+// (typo-tolerant). Classification.Explanation is a slice of these, so a
+// caller can answer "why was this tagged women's sportswear?" by reading
+// off the Signals that produced it.
+type Signal struct {
+	Dimension string
+	Group     string
+	Pattern   string
+	Weight    int
+	Span      string
+	Fuzzy     bool
+}
+
+// Classification is the combined result of scoring a product text against
+// the gender, category, and tag pattern tables: the winning gender and
+// category (after breakCategoryTieByBrowseNodePriority's browse-node tie-break),
+// every tag that scored above zero, a normalized confidence in [0, 1],
+// and the Signals that produced the decision.
+type Classification struct {
+	Gender      string
+	Category    string
+	Tags        []string
+	Confidence  float64
+	Explanation []Signal
+}
+
+// Classifier combines DetectGender, DetectCategory, and
+// GenerateTagsFromText's underlying pattern groups into one explainable
+// Classification. Unlike DetectGenderWithOptions, it matches patterns
+// directly against text (see matchSignals) so it can report the matched
+// span for each Signal; only Opts' HTML/readability preprocessing
+// (StripHTML, ReadabilityMode) applies - Opts.Normalizers is ignored,
+// since stemming or punctuation-collapsing text before matching would
+// make Signal.Span no longer a literal substring of the input. The zero
+// value matches exactly; use NewClassifier with a non-nil fuzzy for
+// typo-tolerant matching.
+type Classifier struct {
+	Opts  AnalyzeOptions
+	Fuzzy *FuzzyConfig
+}
+
+// NewClassifier returns a Classifier that strips HTML/boilerplate from
+// text per opts (see Classifier's doc comment for what else of opts
+// applies) and, when fuzzy is non-nil, tolerates typos the same way
+// AnalyzeOptions.Fuzzy does for DetectGenderWithOptions et al.
+func NewClassifier(opts AnalyzeOptions, fuzzy *FuzzyConfig) *Classifier {
+	return &Classifier{Opts: opts, Fuzzy: fuzzy}
+}
+
+// Classify scores text against the gender, category, and tag pattern
+// tables and combines them into one Classification. Ties in the category
+// score are broken in favor of the tied candidate with the lowest (most
+// specific) Priority among supported ExtendedBrowseNodeMapping entries.
+func (c *Classifier) Classify(text string) Classification {
+	text = c.Opts.preprocess(text)
+
+	genderSignals := matchSignals(dimGender, getGenderPatterns(), text, c.Fuzzy)
+	categorySignals := matchSignals(dimCategory, getCategoryPatterns(), text, c.Fuzzy)
+	tagSignals := matchSignals(dimTag, getTagPatterns(), text, c.Fuzzy)
+
+	recordPatternMetrics(genderSignals, categorySignals, tagSignals)
+
+	genderScores := sumSignalsByGroup(genderSignals)
+	categoryScores := sumSignalsByGroup(categorySignals)
+	tagScores := sumSignalsByGroup(tagSignals)
+
+	gender := bestGroupByPriority(genderScores, genderPriorityOrder)
+	category := breakCategoryTieByBrowseNodePriority(tiedGroups(categoryScores))
+
+	var tags []string
+	for _, group := range sortedNonZeroGroups(tagScores) {
+		tags = append(tags, group)
+	}
+
+	confidence := combinedConfidence(genderScores, categoryScores)
+	if confidence < lowConfidenceThreshold {
+		atomic.AddInt64(&patternMetrics.lowConfidenceMatches, 1)
+	}
+
+	explanation := make([]Signal, 0, len(genderSignals)+len(categorySignals)+len(tagSignals))
+	explanation = append(explanation, genderSignals...)
+	explanation = append(explanation, categorySignals...)
+	explanation = append(explanation, tagSignals...)
+	sort.SliceStable(explanation, func(i, j int) bool { return explanation[i].Weight > explanation[j].Weight })
+
+	return Classification{
+		Gender:      gender,
+		Category:    category,
+		Tags:        tags,
+		Confidence:  confidence,
+		Explanation: explanation,
+	}
+}
+
+// ClassifyBatch runs Classify over every item in texts concurrently, using
+// a worker pool sized to runtime.GOMAXPROCS(0), and returns results in the
+// same order as texts.
+func (c *Classifier) ClassifyBatch(texts []string) []Classification {
+	results := make([]Classification, len(texts))
+
+	workers := runtime.GOMAXPROCS(0)
+	if workers > len(texts) {
+		workers = len(texts)
+	}
+	if workers < 1 {
+		return results
+	}
+
+	jobs := make(chan int)
+	var wg sync.WaitGroup
+	wg.Add(workers)
+	for w := 0; w < workers; w++ {
+		go func() {
+			defer wg.Done()
+			for i := range jobs {
+				results[i] = c.Classify(texts[i])
+			}
+		}()
+	}
+	for i := range texts {
+		jobs <- i
+	}
+	close(jobs)
+	wg.Wait()
+
+	return results
+}
+
+const (
+	dimGender   = "gender"
+	dimCategory = "category"
+	dimTag      = "tag"
+)
+
+// lowConfidenceThreshold is the Confidence below which PatternMetrics
+// counts a Classification as low-confidence, for ops to alert on pattern
+// drift (a rising low-confidence rate usually means product titles have
+// drifted away from what the pattern tables cover).
+const lowConfidenceThreshold = 0.3
+
+// signalTokenRe tokenizes text for fuzzy Signal matching the same way
+// patternindex's tokenizer does.
+var signalTokenRe = regexp.MustCompile(`[\p{L}\p{N}]+`)
+
+// patternRegexCache memoizes the word-boundary regex matchPattern uses for
+// exact matching, so repeated Classify calls over the same pattern tables
+// don't recompile it every time. Matching on \b...\b (rather than a plain
+// substring check) keeps a pattern like "men" from firing on "Damen".
+var patternRegexCache sync.Map // lowercased pattern -> *regexp.Regexp
+
+func patternRegex(patLower string) *regexp.Regexp {
+	if v, ok := patternRegexCache.Load(patLower); ok {
+		return v.(*regexp.Regexp)
+	}
+	re := regexp.MustCompile(`(?i)\b` + regexp.QuoteMeta(patLower) + `\b`)
+	actual, _ := patternRegexCache.LoadOrStore(patLower, re)
+	return actual.(*regexp.Regexp)
+}
+
+// matchSignals scans patterns against text directly (not through the
+// Bleve-backed patternindex.PatternIndex DetectGender/DetectCategory use),
+// so it can report which literal pattern and text span matched. Because
+// it doesn't go through the same German/English stemming
+// DetectGenderWithOptions does, a Signal can in rare cases disagree at the
+// margin with the indexed decision (e.g. a compound word that only the
+// Snowball stemmer normalizes). Classify decides Gender and Category from
+// these Signals' summed weights, so such a difference affects its
+// decision as well as its Explanation.
+func matchSignals(dimension string, patterns map[string][]PatternRule, text string, fuzzy *FuzzyConfig) []Signal {
+	lower := strings.ToLower(text)
+	textTokens := signalTokenRe.FindAllString(lower, -1)
+
+	groups := make([]string, 0, len(patterns))
+	for group := range patterns {
+		groups = append(groups, group)
+	}
+	sort.Strings(groups)
+
+	var signals []Signal
+	for _, group := range groups {
+		for _, rule := range patterns[group] {
+			for _, pattern := range rule.Patterns {
+				if sig, ok := matchPattern(dimension, group, pattern, rule.Weight, text, textTokens, fuzzy); ok {
+					signals = append(signals, sig)
+				}
+			}
+		}
+	}
+	return signals
+}
+
+func matchPattern(dimension, group, pattern string, weight int, text string, textTokens []string, fuzzy *FuzzyConfig) (Signal, bool) {
+	patLower := strings.ToLower(pattern)
+	if loc := patternRegex(patLower).FindStringIndex(text); loc != nil {
+		return Signal{
+			Dimension: dimension, Group: group, Pattern: pattern, Weight: weight,
+			Span: text[loc[0]:loc[1]], Fuzzy: false,
+		}, true
+	}
+	if fuzzy == nil {
+		return Signal{}, false
+	}
+
+	patternTokens := signalTokenRe.FindAllString(patLower, -1)
+	if len(patternTokens) == 0 {
+		return Signal{}, false
+	}
+	worstRatio, span := 1.0, ""
+	for _, pt := range patternTokens {
+		bestDist, bestTok := -1, ""
+		for _, tt := range textTokens {
+			if ok, d := patternindex.FuzzyTokenMatch(pt, tt, *fuzzy); ok && (bestDist == -1 || d < bestDist) {
+				bestDist, bestTok = d, tt
+			}
+		}
+		if bestDist == -1 {
+			return Signal{}, false
+		}
+		threshold := fuzzy.Threshold(len([]rune(pt)))
+		ratio := 1.0
+		if threshold > 0 {
+			ratio = 1 - float64(bestDist)/float64(threshold+1)
+		}
+		if ratio < worstRatio {
+			worstRatio, span = ratio, bestTok
+		}
+	}
+	return Signal{
+		Dimension: dimension, Group: group, Pattern: pattern,
+		Weight: int(float64(weight) * worstRatio), Span: span, Fuzzy: true,
+	}, true
+}
+
+func sumSignalsByGroup(signals []Signal) map[string]int {
+	scores := make(map[string]int)
+	for _, s := range signals {
+		scores[s.Group] += s.Weight
+	}
+	return scores
+}
+
+func sortedNonZeroGroups(scores map[string]int) []string {
+	groups := make([]string, 0, len(scores))
+	for g, s := range scores {
+		if s > 0 {
+			groups = append(groups, g)
+		}
+	}
+	sort.Strings(groups)
+	return groups
+}
+
+// bestGroupByPriority returns the highest-scoring group in scores,
+// breaking ties (and the "nothing scored" case) by priority order, the
+// same convention patternindex.PatternIndex.TopGroup uses.
+func bestGroupByPriority(scores map[string]int, priority []string) string {
+	best, bestScore := "", 0
+	for _, group := range priority {
+		if s := scores[group]; s > bestScore {
+			best, bestScore = group, s
+		}
+	}
+	return best
+}
+
+// tiedGroups returns every group sharing scores' maximum (positive) value.
+func tiedGroups(scores map[string]int) []string {
+	best := 0
+	for _, s := range scores {
+		if s > best {
+			best = s
+		}
+	}
+	if best == 0 {
+		return nil
+	}
+	var tied []string
+	for g, s := range scores {
+		if s == best {
+			tied = append(tied, g)
+		}
+	}
+	sort.Strings(tied)
+	return tied
+}
+
+// breakCategoryTieByBrowseNodePriority picks, among tied category names,
+// the one with the lowest (most specific) Priority among supported
+// ExtendedBrowseNodeMapping entries. Ties in Priority, or no supported
+// mapping for any tied candidate, fall back to the alphabetically first
+// candidate so the result is always deterministic.
+func breakCategoryTieByBrowseNodePriority(tied []string) string {
+	if len(tied) == 0 {
+		return ""
+	}
+	if len(tied) == 1 {
+		return tied[0]
+	}
+
+	tiedSet := make(map[string]bool, len(tied))
+	for _, g := range tied {
+		tiedSet[g] = true
+	}
+
+	mappings := getExtendedBrowseNodeMappings()
+	ids := make([]string, 0, len(mappings))
+	for id := range mappings {
+		ids = append(ids, id)
+	}
+	sort.Strings(ids)
+
+	best, bestPriority := "", math.MaxInt32
+	for _, id := range ids {
+		m := mappings[id]
+		if !m.IsSupported || !tiedSet[m.Category] {
+			continue
+		}
+		if m.Priority < bestPriority {
+			best, bestPriority = m.Category, m.Priority
+		}
+	}
+	if best != "" {
+		return best
+	}
+	return tied[0]
+}
+
+// combinedConfidence normalizes gender and category scores into [0, 1] by
+// dividing each dimension's winning score by the sum of every score in
+// that dimension (score / sum-of-weights), then averaging the two
+// dimensions - so a Classification with one dominant gender match and one
+// dominant category match reads as high-confidence, while a text that
+// barely edges out a close second in both dimensions reads as low.
+func combinedConfidence(genderScores, categoryScores map[string]int) float64 {
+	g := normalizedTopShare(genderScores)
+	c := normalizedTopShare(categoryScores)
+	if g == 0 && c == 0 {
+		return 0
+	}
+	return (g + c) / 2
+}
+
+func normalizedTopShare(scores map[string]int) float64 {
+	sum, top := 0, 0
+	for _, s := range scores {
+		sum += s
+		if s > top {
+			top = s
+		}
+	}
+	if sum == 0 {
+		return 0
+	}
+	return float64(top) / float64(sum)
+}
+
+// PatternMetrics holds Prometheus-style monotonic counters tracking
+// pattern-matching health over time: total matches per pattern group
+// (keyed "dimension/group"), how many matches were fuzzy versus exact,
+// and how many Classifications fell below lowConfidenceThreshold. A
+// rising fuzzy-to-exact ratio or low-confidence rate usually means
+// product titles have drifted away from what the pattern tables cover.
+// Snapshot it via ClassifierMetrics for export under any registry
+// (Prometheus or otherwise).
+type PatternMetrics struct {
+	mu                   sync.Mutex
+	matchesByPattern     map[string]int64
+	exactMatches         int64
+	fuzzyMatches         int64
+	lowConfidenceMatches int64
+}
+
+var patternMetrics = &PatternMetrics{matchesByPattern: map[string]int64{}}
+
+func recordPatternMetrics(signalSets ...[]Signal) {
+	patternMetrics.mu.Lock()
+	defer patternMetrics.mu.Unlock()
+	for _, signals := range signalSets {
+		for _, s := range signals {
+			patternMetrics.matchesByPattern[s.Dimension+"/"+s.Group]++
+			if s.Fuzzy {
+				atomic.AddInt64(&patternMetrics.fuzzyMatches, 1)
+			} else {
+				atomic.AddInt64(&patternMetrics.exactMatches, 1)
+			}
+		}
+	}
+}
+
+// PatternMetricsSnapshot is a point-in-time copy of PatternMetrics' counters.
+type PatternMetricsSnapshot struct {
+	MatchesByPattern     map[string]int64
+	ExactMatches         int64
+	FuzzyMatches         int64
+	LowConfidenceMatches int64
+}
+
+// ClassifierMetrics returns a snapshot of the process-wide pattern-matching
+// counters every Classifier.Classify call updates.
+func ClassifierMetrics() PatternMetricsSnapshot {
+	patternMetrics.mu.Lock()
+	defer patternMetrics.mu.Unlock()
+	byPattern := make(map[string]int64, len(patternMetrics.matchesByPattern))
+	for k, v := range patternMetrics.matchesByPattern {
+		byPattern[k] = v
+	}
+	return PatternMetricsSnapshot{
+		MatchesByPattern:     byPattern,
+		ExactMatches:         atomic.LoadInt64(&patternMetrics.exactMatches),
+		FuzzyMatches:         atomic.LoadInt64(&patternMetrics.fuzzyMatches),
+		LowConfidenceMatches: atomic.LoadInt64(&patternMetrics.lowConfidenceMatches),
+	}
+}
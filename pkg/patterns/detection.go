@@ -1,7 +1,12 @@
 package patterns
 
 import (
+	"sort"
 	"strings"
+	"sync"
+
+	patternindex "github.com/MalteBoehm/tall-affiliate-common/pkg/patterns/index"
+	"github.com/MalteBoehm/tall-affiliate-common/pkg/patterns/rules"
 )
 
 // BrowseNode interface defines the methods that browse node implementations must provide
@@ -66,13 +71,24 @@ type MultiNodeAnalysisResult struct {
 	ContentGender      string            `json:"content_gender"`
 	ContentCategory    string            `json:"content_category"`
 	CombinedConfidence float64           `json:"combined_confidence"`
+	Attributes         ProductAttributes `json:"attributes"`
+	TopGenders         []Ranked[string]  `json:"top_genders"`
+	TopCategories      []Ranked[string]  `json:"top_categories"`
 }
 
 // PatternRule represents a pattern matching rule with weight and language
 type PatternRule struct {
 	Patterns []string // List of patterns to match
 	Weight   int      // Weight for scoring (higher = more important)
-	Language string   // "de", "en", or "both"
+	Language string   // "de", "en", or "both" - which Bleve analyzer field this rule is indexed under
+	// Locale is the BCP-47 tag the rule's Patterns were written for (e.g.
+	// "de-DE", "fr-FR", "en-US"), or "mul" for language-neutral rules such
+	// as the hard-coded tables in this file. It's metadata only for
+	// DetectGender/DetectCategory; DetectGenderForLocales and
+	// DetectCategoryForLocales use it to weight a caller's preferred
+	// locale's rules over fallbacks. Rules generated by
+	// cmd/gen-locale-patterns always set it.
+	Locale string
 }
 
 // PatternMatch represents a pattern match result
@@ -82,22 +98,117 @@ type PatternMatch struct {
 	Matched  []string
 }
 
-// DetectCategory analyzes text to determine the product category using intelligent pattern matching
-func DetectCategory(text string) string {
-	// Use enhanced pattern matching with fuzzy matching and stemming
-	categoryPatterns := getCategoryPatterns()
+// genderPriorityOrder decides which gender wins a tied score, and is also
+// the order DetectGender and DetectGenderWithScores report scores in.
+var genderPriorityOrder = []string{"women", "men", "unisex"}
+
+// categoryMatcher, genderMatcher, and tagMatcher are the package-level
+// default PatternMatchers DetectCategory, DetectGender, and
+// GenerateTagsFromText wrap. They're built lazily (instead of as ordinary
+// package vars) because getCategoryPatterns/getGenderPatterns/
+// getTagPatterns merge in locale tables registered from
+// cmd/gen-locale-patterns-generated files' init() funcs - and the Go spec
+// only guarantees init() funcs run after package-level variables have been
+// initialized, not before, so building these matchers as plain var
+// initializers would race the locale registry and silently miss every
+// generated locale table.
+var (
+	categoryMatcherOnce sync.Once
+	categoryMatcherVal  *PatternMatcher
+	genderMatcherOnce   sync.Once
+	genderMatcherVal    *PatternMatcher
+	tagMatcherOnce      sync.Once
+	tagMatcherVal       *PatternMatcher
+)
+
+func categoryMatcher() *PatternMatcher {
+	categoryMatcherOnce.Do(func() {
+		categoryMatcherVal = mustPatternMatcher(getCategoryPatterns(), defaultAnalyzeOptions)
+	})
+	return categoryMatcherVal
+}
+
+func genderMatcher() *PatternMatcher {
+	genderMatcherOnce.Do(func() {
+		genderMatcherVal = mustPatternMatcher(getGenderPatterns(), defaultAnalyzeOptions)
+	})
+	return genderMatcherVal
+}
 
-	for category, patterns := range categoryPatterns {
-		if matchesPatternWithFuzzy(text, patterns) {
-			return category
+func tagMatcher() *PatternMatcher {
+	tagMatcherOnce.Do(func() {
+		tagMatcherVal = mustPatternMatcher(getTagPatterns(), defaultAnalyzeOptions)
+	})
+	return tagMatcherVal
+}
+
+// flattenGroupedPatterns converts a group name -> []PatternRule map into the
+// flat []patternindex.Rule form NewPatternIndex expects, normalizing every
+// pattern term through opts, and returns the group names in a stable
+// (sorted) order.
+func flattenGroupedPatterns(patterns map[string][]PatternRule, opts AnalyzeOptions) ([]patternindex.Rule, []string) {
+	groups := make([]string, 0, len(patterns))
+	for group := range patterns {
+		groups = append(groups, group)
+	}
+	sort.Strings(groups)
+
+	var rules []patternindex.Rule
+	for _, group := range groups {
+		for _, rule := range patterns[group] {
+			normalized := make([]string, len(rule.Patterns))
+			for i, pattern := range rule.Patterns {
+				normalized[i] = opts.normalize(pattern)
+			}
+			rules = append(rules, patternindex.Rule{
+				Group:    group,
+				Patterns: normalized,
+				Weight:   rule.Weight,
+				Language: rule.Language,
+			})
 		}
 	}
+	return rules, groups
+}
 
-	return ""
+// topGroup runs TopGroup, or TopGroupFuzzy when opts.Fuzzy is set, so every
+// Detect*WithOptions caller gets typo tolerance for free by setting
+// AnalyzeOptions.Fuzzy.
+func topGroup(pi *patternindex.PatternIndex, text string, priority []string, minScore int, opts AnalyzeOptions) (string, map[string]int, error) {
+	if opts.Fuzzy != nil {
+		return pi.TopGroupFuzzy(text, priority, minScore, *opts.Fuzzy)
+	}
+	return pi.TopGroup(text, priority, minScore)
+}
+
+// DetectCategory analyzes text to determine the product category, using
+// DefaultAnalyzeOptions to normalize it before matching against the
+// Bleve-backed pattern index.
+func DetectCategory(text string) string {
+	return DetectCategoryWithOptions(text, defaultAnalyzeOptions)
 }
 
-// DetectGender analyzes text to determine the target gender using intelligent pattern matching
+// DetectCategoryWithOptions is DetectCategory with a caller-supplied
+// normalization chain, returning the highest-scoring category.
+func DetectCategoryWithOptions(text string, opts AnalyzeOptions) string {
+	category, _, err := categoryMatcher().TopGroup(text, nil, 1, opts)
+	if err != nil {
+		return ""
+	}
+	return category
+}
+
+// DetectGender analyzes text to determine the target gender, using
+// DefaultAnalyzeOptions to normalize it before matching against the
+// Bleve-backed pattern index.
 func DetectGender(text string) string {
+	return DetectGenderWithOptions(text, defaultAnalyzeOptions)
+}
+
+// DetectGenderWithOptions is DetectGender with a caller-supplied
+// normalization chain, after checking for high-priority explicit gender
+// markers.
+func DetectGenderWithOptions(text string, opts AnalyzeOptions) string {
 	textLower := strings.ToLower(text)
 
 	// Check for high-priority explicit gender markers first
@@ -108,45 +219,32 @@ func DetectGender(text string) string {
 		return "men"
 	}
 
-	// If no explicit markers, use pattern scoring
-	genderPatterns := getGenderPatterns()
-	priorityOrder := []string{"women", "men", "unisex"}
-	scores := make(map[string]int)
-	maxScore := 0
-
-	for _, gender := range priorityOrder {
-		if patterns, exists := genderPatterns[gender]; exists {
-			score := calculateGenderScore(text, patterns)
-			scores[gender] = score
-			if score > maxScore {
-				maxScore = score
-			}
-		}
-	}
-
-	// Require minimum score for confidence
-	if maxScore < 5 {
+	// If no explicit markers, fall back to weighted pattern scoring.
+	gender, _, err := genderMatcher().TopGroup(text, genderPriorityOrder, 5, opts)
+	if err != nil {
 		return ""
 	}
-
-	// Return the first gender in priority order that has the maximum score
-	for _, gender := range priorityOrder {
-		if scores[gender] == maxScore {
-			return gender
-		}
-	}
-
-	return ""
+	return gender
 }
 
-// GenerateTagsFromText generates relevant tags from text analysis using intelligent pattern matching
+// GenerateTagsFromText generates relevant tags from text analysis, using
+// DefaultAnalyzeOptions to normalize it before matching against the
+// Bleve-backed pattern index, combined with hierarchical tag inference.
 func GenerateTagsFromText(text string) []string {
+	return GenerateTagsFromTextWithOptions(text, defaultAnalyzeOptions)
+}
+
+// GenerateTagsFromTextWithOptions is GenerateTagsFromText with a
+// caller-supplied normalization chain.
+func GenerateTagsFromTextWithOptions(text string, opts AnalyzeOptions) []string {
 	var tags []string
-	tagPatterns := getTagPatterns()
 
-	for tag, patterns := range tagPatterns {
-		if matchesPatternWithFuzzy(text, patterns) {
-			tags = append(tags, tag)
+	scores, err := tagMatcher().Scores(text, opts)
+	if err == nil {
+		for _, tag := range tagMatcher().Groups() {
+			if scores[tag] > 0 {
+				tags = append(tags, tag)
+			}
 		}
 	}
 
@@ -207,38 +305,48 @@ func DetermineBestCategory(categories []string) string {
 	return bestCategory
 }
 
-// DetectGenderWithScores returns both the detected gender and the scores for debugging
-func DetectGenderWithScores(text string) (string, map[string]int) {
-	genderPatterns := getGenderPatterns()
+// DetectCategoryWithScores returns both the detected category and the
+// per-category scores for debugging.
+func DetectCategoryWithScores(text string) (string, map[string]int) {
+	return DetectCategoryWithScoresWithOptions(text, defaultAnalyzeOptions)
+}
 
-	// Calculate scores for all genders with explicit priority order
-	priorityOrder := []string{"women", "men", "unisex"}
-	scores := make(map[string]int)
-	maxScore := 0
+// DetectCategoryWithScoresWithOptions is DetectCategoryWithScores with a
+// caller-supplied normalization chain.
+func DetectCategoryWithScoresWithOptions(text string, opts AnalyzeOptions) (string, map[string]int) {
+	category, raw, err := categoryMatcher().TopGroup(text, nil, 1, opts)
 
-	for _, gender := range priorityOrder {
-		if patterns, exists := genderPatterns[gender]; exists {
-			score := calculateGenderScore(text, patterns)
-			scores[gender] = score
-			if score > maxScore {
-				maxScore = score
-			}
-		}
+	groups := categoryMatcher().Groups()
+	scores := make(map[string]int, len(groups))
+	for _, c := range groups {
+		scores[c] = raw[c]
 	}
-
-	// Require minimum score for confidence
-	if maxScore < 5 {
+	if err != nil {
 		return "", scores
 	}
+	return category, scores
+}
 
-	// Return the first gender in priority order that has the maximum score
-	for _, gender := range priorityOrder {
-		if scores[gender] == maxScore {
-			return gender, scores
-		}
-	}
+// DetectGenderWithScores returns both the detected gender and the scores for debugging
+func DetectGenderWithScores(text string) (string, map[string]int) {
+	return DetectGenderWithScoresWithOptions(text, defaultAnalyzeOptions)
+}
+
+// DetectGenderWithScoresWithOptions is DetectGenderWithScores with a
+// caller-supplied normalization chain.
+func DetectGenderWithScoresWithOptions(text string, opts AnalyzeOptions) (string, map[string]int) {
+	gender, raw, err := genderMatcher().TopGroup(text, genderPriorityOrder, 5, opts)
 
-	return "", scores
+	// Always report a score for every gender in priority order, even if it
+	// didn't match anything, matching the pre-index debugging output.
+	scores := make(map[string]int, len(genderPriorityOrder))
+	for _, g := range genderPriorityOrder {
+		scores[g] = raw[g]
+	}
+	if err != nil {
+		return "", scores
+	}
+	return gender, scores
 }
 
 // DetectGenderFromBrowseNodes analyzes Browse Nodes to determine gender using hierarchical scoring
@@ -350,6 +458,25 @@ func calculatePriorityScore(priority int) int {
 
 // AnalyzeMultipleBrowseNodes performs comprehensive analysis combining Browse Nodes + Content patterns
 func AnalyzeMultipleBrowseNodes(browseNodes []BrowseNode, contentText string) MultiNodeAnalysisResult {
+	return AnalyzeMultipleBrowseNodesWithOptions(browseNodes, contentText, defaultAnalyzeOptions)
+}
+
+// AnalyzeMultipleBrowseNodesWithOptions is AnalyzeMultipleBrowseNodes with a
+// caller-supplied normalization chain for contentText, e.g. opts.ReadabilityMode
+// to strip HTML and seller boilerplate out of a scraped product description
+// before it's pattern-matched. It combines Browse Node and content evidence
+// using rules.DefaultRuleSet; use AnalyzeMultipleBrowseNodesWithRulePack to
+// pick a marketplace-specific pack instead.
+func AnalyzeMultipleBrowseNodesWithOptions(browseNodes []BrowseNode, contentText string, opts AnalyzeOptions) MultiNodeAnalysisResult {
+	return AnalyzeMultipleBrowseNodesWithRulePack(browseNodes, contentText, opts, rules.DefaultMarketplace)
+}
+
+// AnalyzeMultipleBrowseNodesWithRulePack is AnalyzeMultipleBrowseNodesWithOptions,
+// combining Browse Node and content evidence using the rule pack registered
+// for marketplace (see rules.Register) instead of a hard-coded Go decision,
+// so tuning that decision for a given marketplace (DE/US/UK) doesn't require
+// a code change.
+func AnalyzeMultipleBrowseNodesWithRulePack(browseNodes []BrowseNode, contentText string, opts AnalyzeOptions, marketplace string) MultiNodeAnalysisResult {
 	result := MultiNodeAnalysisResult{
 		NodeScores:       make([]BrowseNodeScore, 0),
 		SupportedNodes:   make([]string, 0),
@@ -382,76 +509,59 @@ func AnalyzeMultipleBrowseNodes(browseNodes []BrowseNode, contentText string) Mu
 
 	// Convert map to slice and categorize nodes
 	totalBrowseScore := 0
+	bestBrowseNodePriority := 0
 	for _, score := range allBrowseScores {
 		result.NodeScores = append(result.NodeScores, score)
 		if score.IsSupported {
 			result.SupportedNodes = append(result.SupportedNodes, score.BrowseNodeID)
 			totalBrowseScore += score.Score
+			if bestBrowseNodePriority == 0 || score.Priority < bestBrowseNodePriority {
+				bestBrowseNodePriority = score.Priority
+			}
 		} else {
 			result.UnsupportedNodes = append(result.UnsupportedNodes, score.BrowseNodeID)
 		}
 	}
 
 	// 2. CONTENT PATTERN ANALYSIS
-	contentGender := DetectGender(contentText)
-	contentCategory := DetectCategory(contentText)
-	contentGenderScores := make(map[string]int)
-	if contentGender != "" {
-		_, contentGenderScores = DetectGenderWithScores(contentText)
-	}
-
-	// 3. HYBRID SCORING & CONFIDENCE CALCULATION
-	result.BestGender = determineBestGenderHybrid(browseGender, contentGender, totalBrowseScore, contentGenderScores)
-	result.BestCategory = determineBestCategoryHybrid(browseCategory, contentCategory, totalBrowseScore)
+	contentGender := DetectGenderWithOptions(contentText, opts)
+	contentCategory := DetectCategoryWithOptions(contentText, opts)
+	_, contentGenderScores := DetectGenderWithScoresWithOptions(contentText, opts)
+	_, contentCategoryScores := DetectCategoryWithScoresWithOptions(contentText, opts)
+
+	// 3. RULE-DRIVEN DECISION & CONFIDENCE CALCULATION
+	decision := rules.Evaluate(rules.Get(marketplace), rules.Facts{
+		BrowseGender:       browseGender,
+		BrowseCategory:     browseCategory,
+		BrowseNodePriority: bestBrowseNodePriority,
+		BrowseScore:        totalBrowseScore,
+		ContentGender:      contentGender,
+		ContentCategory:    contentCategory,
+	})
+	result.BestGender = decision.Gender
+	result.BestCategory = decision.Category
 	result.TotalScore = totalBrowseScore
 	result.ContentGender = contentGender
 	result.ContentCategory = contentCategory
-	result.CombinedConfidence = calculateCombinedConfidence(result, contentGenderScores)
+	result.CombinedConfidence = calculateCombinedConfidence(result, contentGenderScores) + decision.ConfidenceBoost
+	if result.CombinedConfidence > 1.0 {
+		result.CombinedConfidence = 1.0
+	}
+	result.Attributes = ExtractAttributes(browseNodes, contentText, "")
+	// rankCandidates ranks a node by its Category when set, so clear it to
+	// rank the gender nodes by Gender.
+	genderNodeScores := make([]BrowseNodeScore, len(browseGenderScores))
+	for i, ns := range browseGenderScores {
+		ns.Category = ""
+		genderNodeScores[i] = ns
+	}
+	result.TopGenders = rankCandidates(genderNodeScores, contentGenderScores)
+	result.TopCategories = rankCandidates(browseCategoryScores, contentCategoryScores)
+	SortNodeScores(&result, []string{"-score", "priority"})
 
 	return result
 }
 
-// determineBestGenderHybrid combines Browse Node + Content Pattern results with intelligent weighting
-func determineBestGenderHybrid(browseGender, contentGender string, browseScore int, contentScores map[string]int) string {
-	// High Priority: If content explicitly mentions "Damen/Herren", trust content
-	if contentGender == "women" || contentGender == "men" {
-		return contentGender
-	}
-
-	// Medium Priority: Strong browse node evidence (Priority 1-2 nodes)
-	if browseGender != "" && browseScore >= 80 {
-		return browseGender
-	}
-
-	// Low Priority: Weak evidence - prefer content over unisex
-	if contentGender != "" {
-		return contentGender
-	}
-
-	// Fallback: Use browse node result
-	return browseGender
-}
-
-// determineBestCategoryHybrid combines Browse Node + Content Pattern results for category
-func determineBestCategoryHybrid(browseCategory, contentCategory string, browseScore int) string {
-	// If content has specific category match, prefer content
-	if contentCategory != "" && (contentCategory == "T-Shirts" || contentCategory == "Poloshirts" || contentCategory == "Hoodies") {
-		return contentCategory
-	}
-
-	// Strong browse node evidence
-	if browseCategory != "" && browseScore >= 80 {
-		return browseCategory
-	}
-
-	// Fallback logic
-	if contentCategory != "" {
-		return contentCategory
-	}
-
-	return browseCategory
-}
-
 // calculateCombinedConfidence calculates confidence score based on evidence strength
 func calculateCombinedConfidence(result MultiNodeAnalysisResult, contentScores map[string]int) float64 {
 	confidence := 0.0
@@ -545,11 +655,19 @@ func ValidateMultiNodeResult(result MultiNodeAnalysisResult) []string {
 // AnalyzeProductForGenderAndCategory is a convenience function for the Content Generation Worker
 // It takes PA-API Browse Nodes and product content, returns best gender/category with confidence
 func AnalyzeProductForGenderAndCategory(browseNodes []BrowseNode, title, description string) (gender, category string, confidence float64, debugInfo MultiNodeAnalysisResult) {
+	return AnalyzeProductForGenderAndCategoryWithOptions(browseNodes, title, description, defaultAnalyzeOptions)
+}
+
+// AnalyzeProductForGenderAndCategoryWithOptions is AnalyzeProductForGenderAndCategory
+// with a caller-supplied normalization chain, e.g. AnalyzeOptions{ReadabilityMode: true}
+// so PA-API descriptions containing HTML markup and seller boilerplate don't
+// pollute gender/category scoring.
+func AnalyzeProductForGenderAndCategoryWithOptions(browseNodes []BrowseNode, title, description string, opts AnalyzeOptions) (gender, category string, confidence float64, debugInfo MultiNodeAnalysisResult) {
 	// Combine title and description for comprehensive text analysis
 	combinedText := title + " " + description
 
 	// Perform full analysis
-	result := AnalyzeMultipleBrowseNodes(browseNodes, combinedText)
+	result := AnalyzeMultipleBrowseNodesWithOptions(browseNodes, combinedText, opts)
 
 	return result.BestGender, result.BestCategory, result.CombinedConfidence, result
 }
@@ -688,90 +806,66 @@ func getExtendedBrowseNodeMappings() map[string]ExtendedBrowseNodeMapping {
 
 // ===== PATTERN MATCHING FUNCTIONS =====
 
-// getGenderPatterns returns gender detection patterns
+// getGenderPatterns returns gender detection patterns: the hard-coded
+// German/English base table plus every locale table registered by a
+// cmd/gen-locale-patterns-generated file (see mergeLocalePatterns), so a
+// French "femme"/"homme" scores the "women"/"men" groups the same way
+// "damen"/"herren" does today without this function needing to change
+// when a new locale ships.
 func getGenderPatterns() map[string][]PatternRule {
-	return map[string][]PatternRule{
+	return mergeLocalePatterns(map[string][]PatternRule{
 		"women": {
-			{Patterns: []string{"damen", "frauen", "women", "female"}, Weight: 10, Language: "both"},
-			{Patterns: []string{"lady", "ladies", "girl", "girls"}, Weight: 8, Language: "en"},
-			{Patterns: []string{"feminine", "feminin"}, Weight: 6, Language: "both"},
+			{Patterns: []string{"damen", "frauen", "women", "female"}, Weight: 10, Language: "both", Locale: "mul"},
+			{Patterns: []string{"lady", "ladies", "girl", "girls"}, Weight: 8, Language: "en", Locale: "en"},
+			{Patterns: []string{"feminine", "feminin"}, Weight: 6, Language: "both", Locale: "mul"},
 		},
 		"men": {
-			{Patterns: []string{"herren", "männer", "men", "male"}, Weight: 10, Language: "both"},
-			{Patterns: []string{"guy", "guys", "gentleman"}, Weight: 8, Language: "en"},
-			{Patterns: []string{"masculine", "maskulin"}, Weight: 6, Language: "both"},
+			{Patterns: []string{"herren", "männer", "men", "male"}, Weight: 10, Language: "both", Locale: "mul"},
+			{Patterns: []string{"guy", "guys", "gentleman"}, Weight: 8, Language: "en", Locale: "en"},
+			{Patterns: []string{"masculine", "maskulin"}, Weight: 6, Language: "both", Locale: "mul"},
 		},
 		"unisex": {
-			{Patterns: []string{"unisex", "universal", "both"}, Weight: 10, Language: "both"},
-			{Patterns: []string{"gender neutral", "geschlechtsneutral"}, Weight: 8, Language: "both"},
-			{Patterns: []string{"everyone", "alle", "für alle"}, Weight: 6, Language: "both"},
+			{Patterns: []string{"unisex", "universal", "both"}, Weight: 10, Language: "both", Locale: "mul"},
+			{Patterns: []string{"gender neutral", "geschlechtsneutral"}, Weight: 8, Language: "both", Locale: "mul"},
+			{Patterns: []string{"everyone", "alle", "für alle"}, Weight: 6, Language: "both", Locale: "mul"},
 		},
-	}
+	}, localeGenderPatterns)
 }
 
-// getCategoryPatterns returns category detection patterns
+// getCategoryPatterns returns category detection patterns, merged with
+// registered locale tables the same way getGenderPatterns is.
 func getCategoryPatterns() map[string][]PatternRule {
-	return map[string][]PatternRule{
+	return mergeLocalePatterns(map[string][]PatternRule{
 		"T-Shirts": {
-			{Patterns: []string{"t-shirt", "tshirt", "t shirt"}, Weight: 10, Language: "both"},
-			{Patterns: []string{"kurzarm", "short sleeve"}, Weight: 6, Language: "both"},
+			{Patterns: []string{"t-shirt", "tshirt", "t shirt"}, Weight: 10, Language: "both", Locale: "mul"},
+			{Patterns: []string{"kurzarm", "short sleeve"}, Weight: 6, Language: "both", Locale: "mul"},
 		},
 		"Poloshirts": {
-			{Patterns: []string{"polo", "poloshirt", "polo shirt"}, Weight: 10, Language: "both"},
+			{Patterns: []string{"polo", "poloshirt", "polo shirt"}, Weight: 10, Language: "both", Locale: "mul"},
 		},
 		"Hoodies": {
-			{Patterns: []string{"hoodie", "kapuzenpullover", "hooded"}, Weight: 10, Language: "both"},
+			{Patterns: []string{"hoodie", "kapuzenpullover", "hooded"}, Weight: 10, Language: "both", Locale: "mul"},
 		},
 		"Sweatshirts": {
-			{Patterns: []string{"sweatshirt", "sweater"}, Weight: 10, Language: "both"},
+			{Patterns: []string{"sweatshirt", "sweater"}, Weight: 10, Language: "both", Locale: "mul"},
 		},
-	}
+	}, localeCategoryPatterns)
 }
 
-// getTagPatterns returns tag detection patterns
+// getTagPatterns returns tag detection patterns, merged with registered
+// locale tables the same way getGenderPatterns is.
 func getTagPatterns() map[string][]PatternRule {
-	return map[string][]PatternRule{
+	return mergeLocalePatterns(map[string][]PatternRule{
 		"casual": {
-			{Patterns: []string{"casual", "everyday", "lässig"}, Weight: 5, Language: "both"},
+			{Patterns: []string{"casual", "everyday", "lässig"}, Weight: 5, Language: "both", Locale: "mul"},
 		},
 		"sport": {
-			{Patterns: []string{"sport", "athletic", "fitness"}, Weight: 5, Language: "both"},
+			{Patterns: []string{"sport", "athletic", "fitness"}, Weight: 5, Language: "both", Locale: "mul"},
 		},
 		"basic": {
-			{Patterns: []string{"basic", "essential", "klassisch"}, Weight: 3, Language: "both"},
+			{Patterns: []string{"basic", "essential", "klassisch"}, Weight: 3, Language: "both", Locale: "mul"},
 		},
-	}
-}
-
-// calculateGenderScore calculates score for gender patterns
-func calculateGenderScore(text string, patterns []PatternRule) int {
-	textLower := strings.ToLower(text)
-	totalScore := 0
-
-	for _, rule := range patterns {
-		for _, pattern := range rule.Patterns {
-			if strings.Contains(textLower, strings.ToLower(pattern)) {
-				totalScore += rule.Weight
-			}
-		}
-	}
-
-	return totalScore
-}
-
-// matchesPatternWithFuzzy checks if text matches patterns with fuzzy matching
-func matchesPatternWithFuzzy(text string, patterns []PatternRule) bool {
-	textLower := strings.ToLower(text)
-
-	for _, rule := range patterns {
-		for _, pattern := range rule.Patterns {
-			if strings.Contains(textLower, strings.ToLower(pattern)) {
-				return true
-			}
-		}
-	}
-
-	return false
+	}, localeTagPatterns)
 }
 
 // generateHierarchicalTags generates hierarchical tags from text
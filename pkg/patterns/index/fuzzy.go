@@ -0,0 +1,188 @@
+package index
+
+import (
+	"regexp"
+	"strings"
+)
+
+// FuzzyConfig configures Levenshtein-based fuzzy token matching for
+// PatternIndex.FuzzyScores, approximating the "auto fuzziness" convention
+// popularized by Bleve/Elasticsearch: the edit-distance budget scales with
+// token length instead of being a single fixed number, so a misspelled
+// product title (e.g. "hoddie", "t-shrt", "daamen") still matches its
+// pattern.
+type FuzzyConfig struct {
+	// MaxDistance is the edit-distance budget applied to every pattern
+	// token. Ignored when Auto is true.
+	MaxDistance int
+
+	// Auto picks MaxDistance per pattern token from its rune length: 0
+	// edits for length <= 2, 1 edit for length 3-5, 2 edits for length >= 6.
+	Auto bool
+
+	// PrefixLen is the number of leading runes that must match exactly
+	// between a pattern token and a candidate text token, useful for
+	// pinning brand-like tokens where a typo at the very start of the word
+	// is implausible.
+	PrefixLen int
+}
+
+// Threshold returns the edit-distance budget cfg allows for a pattern token
+// of the given rune length.
+func (cfg FuzzyConfig) Threshold(tokenLen int) int {
+	if !cfg.Auto {
+		return cfg.MaxDistance
+	}
+	switch {
+	case tokenLen <= 2:
+		return 0
+	case tokenLen <= 5:
+		return 1
+	default:
+		return 2
+	}
+}
+
+// threshold is the package-internal spelling of Threshold, kept so the
+// rest of this file's (pre-existing) call sites don't need to change.
+func (cfg FuzzyConfig) threshold(tokenLen int) int { return cfg.Threshold(tokenLen) }
+
+// FuzzyTokenMatch reports whether token fuzzily matches pattern under cfg,
+// and the edit distance between them. It's the same per-token comparison
+// FuzzyScores and fuzzyPhraseMatch use internally, exported for callers
+// that need per-token match detail - e.g. an explainability layer that
+// wants to show which input token a fuzzy rule matched, not just the
+// aggregate group score.
+func FuzzyTokenMatch(pattern, token string, cfg FuzzyConfig) (ok bool, distance int) {
+	if !hasExactPrefix(pattern, token, cfg.PrefixLen) {
+		return false, 0
+	}
+	threshold := cfg.Threshold(len([]rune(pattern)))
+	d := damerauLevenshtein(pattern, token, threshold)
+	if d > threshold {
+		return false, d
+	}
+	return true, d
+}
+
+// tokenRe splits free text into word tokens on anything that isn't a letter
+// or digit, mirroring the whitespace/punctuation tokenization FuzzyScores'
+// callers expect.
+var tokenRe = regexp.MustCompile(`[\p{L}\p{N}]+`)
+
+func tokenize(text string) []string {
+	return tokenRe.FindAllString(text, -1)
+}
+
+// rawTokenSet tokenizes text the same way tokenize does and returns its
+// distinct, lower-cased terms as a set.
+func rawTokenSet(text string) map[string]bool {
+	tokens := tokenize(strings.ToLower(text))
+	set := make(map[string]bool, len(tokens))
+	for _, t := range tokens {
+		set[t] = true
+	}
+	return set
+}
+
+// hasExactPrefix reports whether a and b share the same leading prefixLen
+// runes. A non-positive prefixLen always passes.
+func hasExactPrefix(a, b string, prefixLen int) bool {
+	if prefixLen <= 0 {
+		return true
+	}
+	ra, rb := []rune(a), []rune(b)
+	if len(ra) < prefixLen || len(rb) < prefixLen {
+		return false
+	}
+	return string(ra[:prefixLen]) == string(rb[:prefixLen])
+}
+
+// damerauLevenshtein returns the Damerau-Levenshtein edit distance (insert,
+// delete, substitute, or transpose adjacent runes) between a and b, or
+// max+1 as soon as it's clear the true distance exceeds max - in
+// particular whenever a and b differ in rune length by more than max,
+// since no sequence of at most max single-rune edits can close a larger
+// length gap. This keeps a single comparison cheap even though FuzzyScores
+// checks every pattern token against every text token.
+func damerauLevenshtein(a, b string, max int) int {
+	ra, rb := []rune(a), []rune(b)
+	if d := len(ra) - len(rb); d > max || -d > max {
+		return max + 1
+	}
+
+	la, lb := len(ra), len(rb)
+	d := make([][]int, la+1)
+	for i := range d {
+		d[i] = make([]int, lb+1)
+		d[i][0] = i
+	}
+	for j := 0; j <= lb; j++ {
+		d[0][j] = j
+	}
+
+	for i := 1; i <= la; i++ {
+		for j := 1; j <= lb; j++ {
+			cost := 1
+			if ra[i-1] == rb[j-1] {
+				cost = 0
+			}
+			best := d[i-1][j] + 1 // deletion
+			if v := d[i][j-1] + 1; v < best {
+				best = v // insertion
+			}
+			if v := d[i-1][j-1] + cost; v < best {
+				best = v // substitution
+			}
+			if i > 1 && j > 1 && ra[i-1] == rb[j-2] && ra[i-2] == rb[j-1] {
+				if v := d[i-2][j-2] + cost; v < best {
+					best = v // transposition
+				}
+			}
+			d[i][j] = best
+		}
+	}
+	return d[la][lb]
+}
+
+// fuzzyPhraseMatch reports whether every token in patternTokens has some
+// match among textTokens within cfg's edit-distance budget, and if so the
+// confidence ratio to scale that rule's weight by: 1.0 for an exact match,
+// shrinking toward 0 as the worst per-token distance approaches its
+// threshold.
+func fuzzyPhraseMatch(patternTokens map[string]bool, textTokens []string, cfg FuzzyConfig) (ratio float64, ok bool) {
+	if len(patternTokens) == 0 {
+		return 0, false
+	}
+
+	worst := 1.0
+	for pt := range patternTokens {
+		threshold := cfg.threshold(len([]rune(pt)))
+		best := threshold + 1
+		for _, tt := range textTokens {
+			if !hasExactPrefix(pt, tt, cfg.PrefixLen) {
+				continue
+			}
+			if d := damerauLevenshtein(pt, tt, threshold); d < best {
+				best = d
+				if best == 0 {
+					break
+				}
+			}
+		}
+		if best > threshold {
+			return 0, false
+		}
+		// +1 in the denominator keeps a match at the threshold boundary from
+		// contributing exactly zero weight: it's still a match, just the
+		// least confident one cfg allows.
+		r := 1.0
+		if threshold > 0 {
+			r = 1 - float64(best)/float64(threshold+1)
+		}
+		if r < worst {
+			worst = r
+		}
+	}
+	return worst, true
+}
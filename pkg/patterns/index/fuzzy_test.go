@@ -0,0 +1,85 @@
+package index
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestFuzzyScoresMatchesMisspelledToken(t *testing.T) {
+	pi, err := NewPatternIndex([]Rule{
+		{Group: "hoodies", Patterns: []string{"hoodie"}, Weight: 10, Language: "en"},
+	})
+	require.NoError(t, err)
+
+	cfg := FuzzyConfig{Auto: true}
+
+	scores, err := pi.Scores("hoddie in stock")
+	require.NoError(t, err)
+	assert.Equal(t, 0, scores["hoodies"], "exact Scores should not match a typo")
+
+	fuzzy, err := pi.FuzzyScores("hoddie in stock", cfg)
+	require.NoError(t, err)
+	assert.Greater(t, fuzzy["hoodies"], 0)
+	assert.Less(t, fuzzy["hoodies"], 10, "a fuzzy hit should score less than an exact match")
+}
+
+func TestFuzzyScoresExactMatchScoresFullWeight(t *testing.T) {
+	pi, err := NewPatternIndex([]Rule{
+		{Group: "women", Patterns: []string{"damen"}, Weight: 10, Language: "de"},
+	})
+	require.NoError(t, err)
+
+	scores, err := pi.FuzzyScores("damen t-shirt", FuzzyConfig{Auto: true})
+	require.NoError(t, err)
+	assert.Equal(t, 10, scores["women"])
+}
+
+func TestFuzzyScoresRejectsMatchBeyondThreshold(t *testing.T) {
+	pi, err := NewPatternIndex([]Rule{
+		{Group: "women", Patterns: []string{"damen"}, Weight: 10, Language: "de"},
+	})
+	require.NoError(t, err)
+
+	scores, err := pi.FuzzyScores("herren t-shirt", FuzzyConfig{Auto: true})
+	require.NoError(t, err)
+	assert.Equal(t, 0, scores["women"])
+}
+
+func TestFuzzyConfigPrefixLenRejectsMismatchedStart(t *testing.T) {
+	pi, err := NewPatternIndex([]Rule{
+		{Group: "women", Patterns: []string{"damen"}, Weight: 10, Language: "de"},
+	})
+	require.NoError(t, err)
+
+	// "kamen" is within 1 edit of "damen", but a PrefixLen of 1 requires the
+	// first rune to match exactly, so it's rejected.
+	scores, err := pi.FuzzyScores("kamen shirt", FuzzyConfig{Auto: true, PrefixLen: 1})
+	require.NoError(t, err)
+	assert.Equal(t, 0, scores["women"])
+}
+
+func TestTopGroupFuzzyPicksHighestScoringGroup(t *testing.T) {
+	pi, err := NewPatternIndex(testRules())
+	require.NoError(t, err)
+
+	group, _, err := pi.TopGroupFuzzy("daamen t-shrt", nil, 1, FuzzyConfig{Auto: true})
+	require.NoError(t, err)
+	assert.Equal(t, "women", group)
+}
+
+func TestDamerauLevenshteinTransposition(t *testing.T) {
+	assert.Equal(t, 1, damerauLevenshtein("herren", "herern", 2))
+}
+
+func BenchmarkFuzzyScores(b *testing.B) {
+	pi, err := NewPatternIndex(testRules())
+	require.NoError(b, err)
+	cfg := FuzzyConfig{Auto: true}
+
+	b.ResetTimer()
+	for i := 0; i < b.N; i++ {
+		_, _ = pi.FuzzyScores("daamen t-shrt im neuen schnitt", cfg)
+	}
+}
@@ -0,0 +1,407 @@
+// Package index provides a Bleve-backed inverted index over weighted text
+// patterns, used by the patterns package to score free text against a set
+// of named groups (categories, genders, tags) without an O(len(rules)) scan
+// of every pattern on every call.
+package index
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+
+	"github.com/blevesearch/bleve/v2"
+	"github.com/blevesearch/bleve/v2/analysis/analyzer/keyword"
+	"github.com/blevesearch/bleve/v2/analysis/lang/de"
+	"github.com/blevesearch/bleve/v2/analysis/lang/en"
+	"github.com/blevesearch/bleve/v2/mapping"
+)
+
+// textDEField and textENField are the index fields a Rule's patterns are
+// analyzed into, using Bleve's built-in German and English analyzers
+// (stemming, stopword removal) so e.g. "Damen-T-Shirt" matches a pattern
+// registered as "damen t-shirts".
+const (
+	textDEField = "text_de"
+	textENField = "text_en"
+)
+
+// Rule is one weighted set of pattern terms belonging to a named Group (a
+// category, gender, or tag label). It mirrors patterns.PatternRule plus the
+// group it belongs to, since this package must not import patterns (which
+// imports this package).
+type Rule struct {
+	Group    string
+	Patterns []string
+	Weight   int
+	Language string // "de", "en", or "both"
+}
+
+// ruleDoc is the document shape indexed for each Rule.
+type ruleDoc struct {
+	Group    string `json:"group"`
+	Language string `json:"language"`
+	Weight   int    `json:"weight"`
+	TextDE   string `json:"text_de"`
+	TextEN   string `json:"text_en"`
+}
+
+// PatternIndex is an in-memory Bleve index over a fixed set of Rules.
+type PatternIndex struct {
+	idx           bleve.Index
+	im            *mapping.IndexMappingImpl
+	rules         []Rule
+	variants      []ruleVariant
+	fuzzyVariants []fuzzyVariant
+	trigrams      trigramIndex
+}
+
+// ruleVariant is one pattern phrase of a Rule, reduced to the token set its
+// field analyzer produces. Scores uses this to require that a rule's whole
+// phrase is present in the matched text, not just a single token it happens
+// to share with some other rule's phrase (e.g. "shirt" alone, shared by
+// "t-shirt" and "polo shirt").
+type ruleVariant struct {
+	ruleIdx int
+	field   string
+	tokens  map[string]bool
+}
+
+// fuzzyVariant is one pattern phrase of a Rule, reduced to its raw
+// whitespace/punctuation-tokenized word set (no stemming or stopword
+// removal). FuzzyScores compares these against input text tokens by edit
+// distance, so a typo in "hoodie" still resembles "hoodie" rather than
+// whatever its Bleve-stemmed form happens to be.
+type fuzzyVariant struct {
+	ruleIdx int
+	tokens  map[string]bool
+}
+
+// NewPatternIndex builds a PatternIndex from rules, indexing each one as a
+// document with its patterns analyzed into German and/or English text
+// fields according to its Language.
+func NewPatternIndex(rules []Rule) (*PatternIndex, error) {
+	im := buildMapping()
+	idx, err := bleve.NewMemOnly(im)
+	if err != nil {
+		return nil, fmt.Errorf("patterns/index: build index: %w", err)
+	}
+
+	pi := &PatternIndex{idx: idx, im: im, rules: rules}
+	batch := idx.NewBatch()
+	for i, rule := range rules {
+		doc := ruleDoc{Group: rule.Group, Language: rule.Language, Weight: rule.Weight}
+		text := joinPatterns(rule.Patterns)
+		if rule.Language != "en" {
+			doc.TextDE = text
+		}
+		if rule.Language != "de" {
+			doc.TextEN = text
+		}
+		if err := batch.Index(fmt.Sprintf("%d", i), doc); err != nil {
+			return nil, fmt.Errorf("patterns/index: index rule %d: %w", i, err)
+		}
+		for _, p := range rule.Patterns {
+			if rule.Language != "en" {
+				if tokens, err := analyzeTokens(im, de.AnalyzerName, p); err == nil && len(tokens) > 0 {
+					pi.variants = append(pi.variants, ruleVariant{ruleIdx: i, field: textDEField, tokens: tokens})
+				}
+			}
+			if rule.Language != "de" {
+				if tokens, err := analyzeTokens(im, en.AnalyzerName, p); err == nil && len(tokens) > 0 {
+					pi.variants = append(pi.variants, ruleVariant{ruleIdx: i, field: textENField, tokens: tokens})
+				}
+			}
+			if tokens := rawTokenSet(p); len(tokens) > 0 {
+				pi.fuzzyVariants = append(pi.fuzzyVariants, fuzzyVariant{ruleIdx: i, tokens: tokens})
+			}
+		}
+	}
+	if err := idx.Batch(batch); err != nil {
+		return nil, fmt.Errorf("patterns/index: commit batch: %w", err)
+	}
+	pi.trigrams = buildTrigramIndex(pi.fuzzyVariants)
+
+	return pi, nil
+}
+
+// analyzeTokens runs text through the named analyzer and returns its distinct
+// terms as a set.
+func analyzeTokens(im *mapping.IndexMappingImpl, analyzerName, text string) (map[string]bool, error) {
+	stream, err := im.AnalyzeText(analyzerName, []byte(text))
+	if err != nil {
+		return nil, err
+	}
+	tokens := make(map[string]bool, len(stream))
+	for _, tok := range stream {
+		tokens[string(tok.Term)] = true
+	}
+	return tokens, nil
+}
+
+func buildMapping() *mapping.IndexMappingImpl {
+	keywordField := bleve.NewTextFieldMapping()
+	keywordField.Analyzer = keyword.Name
+	keywordField.Store = true
+
+	numberField := bleve.NewNumericFieldMapping()
+	numberField.Store = true
+
+	textDE := bleve.NewTextFieldMapping()
+	textDE.Analyzer = de.AnalyzerName
+
+	textEN := bleve.NewTextFieldMapping()
+	textEN.Analyzer = en.AnalyzerName
+
+	doc := bleve.NewDocumentMapping()
+	doc.AddFieldMappingsAt("group", keywordField)
+	doc.AddFieldMappingsAt("language", keywordField)
+	doc.AddFieldMappingsAt("weight", numberField)
+	doc.AddFieldMappingsAt(textDEField, textDE)
+	doc.AddFieldMappingsAt(textENField, textEN)
+
+	im := bleve.NewIndexMapping()
+	im.DefaultMapping = doc
+	return im
+}
+
+func joinPatterns(patterns []string) string {
+	joined := ""
+	for i, p := range patterns {
+		if i > 0 {
+			joined += " "
+		}
+		joined += p
+	}
+	return joined
+}
+
+// Scores runs text against the index and returns the summed weight of every
+// Rule whose patterns matched, aggregated per Group.
+//
+// A bleve search over the joined pattern text first narrows the candidate
+// rules down to those sharing at least one token with text, avoiding an
+// O(len(rules)) scan as the rule set grows. A candidate only contributes its
+// weight, though, if at least one of its own pattern phrases is fully
+// covered by text's tokens: checking the joined text directly would let an
+// unrelated rule match on a single shared word (e.g. "polo shirt" and
+// "t-shirt" both contain "shirt").
+func (pi *PatternIndex) Scores(text string) (map[string]int, error) {
+	if text == "" {
+		return map[string]int{}, nil
+	}
+
+	deQuery := bleve.NewMatchQuery(text)
+	deQuery.SetField(textDEField)
+	enQuery := bleve.NewMatchQuery(text)
+	enQuery.SetField(textENField)
+
+	req := bleve.NewSearchRequestOptions(bleve.NewDisjunctionQuery(deQuery, enQuery), len(pi.rules), 0, false)
+	result, err := pi.idx.Search(req)
+	if err != nil {
+		return nil, fmt.Errorf("patterns/index: search: %w", err)
+	}
+
+	candidates := make(map[int]bool, len(result.Hits))
+	for _, hit := range result.Hits {
+		i, err := ruleIndexFromID(hit.ID)
+		if err != nil || i < 0 || i >= len(pi.rules) {
+			continue
+		}
+		candidates[i] = true
+	}
+	if len(candidates) == 0 {
+		return map[string]int{}, nil
+	}
+
+	textTokens := map[string]map[string]bool{
+		textDEField: {},
+		textENField: {},
+	}
+	for field := range textTokens {
+		analyzerName := de.AnalyzerName
+		if field == textENField {
+			analyzerName = en.AnalyzerName
+		}
+		if tokens, err := analyzeTokens(pi.im, analyzerName, text); err == nil {
+			textTokens[field] = tokens
+		}
+	}
+
+	matched := make(map[int]bool, len(candidates))
+	for _, v := range pi.variants {
+		if matched[v.ruleIdx] || !candidates[v.ruleIdx] {
+			continue
+		}
+		if subsetOf(v.tokens, textTokens[v.field]) {
+			matched[v.ruleIdx] = true
+		}
+	}
+
+	scores := make(map[string]int)
+	for i := range matched {
+		rule := pi.rules[i]
+		scores[rule.Group] += rule.Weight
+	}
+	return scores, nil
+}
+
+// subsetOf reports whether every token in sub is present in super. An empty
+// sub is not considered a match.
+func subsetOf(sub, super map[string]bool) bool {
+	if len(sub) == 0 {
+		return false
+	}
+	for t := range sub {
+		if !super[t] {
+			return false
+		}
+	}
+	return true
+}
+
+// FuzzyScores is Scores with typo tolerance: a rule phrase is accepted if
+// every one of its pattern tokens has some text token within cfg's
+// edit-distance budget, not just an identical one, and contributes a
+// reduced weight (rule.Weight scaled by fuzzyPhraseMatch's confidence
+// ratio) rather than its full weight.
+//
+// Edit distance can't be narrowed by Bleve's exact-token search the way
+// Scores' subset check is, so FuzzyScores first narrows to rules sharing at
+// least one 3-gram with an input token via pi.trigrams, then only compares
+// those candidates' fuzzyVariants against text directly. A genuine match at
+// the edge of cfg's distance budget can in theory share no 3-gram with its
+// pattern (e.g. "die" vs "dye" at distance 1); this trades a small amount
+// of recall for roughly an order-of-magnitude fewer edit-distance
+// comparisons on large pattern sets. Input text shorter than 3 runes can't
+// produce a 3-gram at all, so it skips narrowing and scans every rule.
+func (pi *PatternIndex) FuzzyScores(text string, cfg FuzzyConfig) (map[string]int, error) {
+	if text == "" {
+		return map[string]int{}, nil
+	}
+
+	lower := strings.ToLower(text)
+	textTokens := tokenize(lower)
+
+	var candidates map[int]bool
+	if len([]rune(lower)) >= 3 {
+		candidates = make(map[int]bool)
+		for _, t := range textTokens {
+			for ruleIdx := range pi.trigrams.candidates(t) {
+				candidates[ruleIdx] = true
+			}
+		}
+	}
+
+	matched := make(map[int]bool, len(pi.rules))
+	scores := make(map[string]int)
+	for _, v := range pi.fuzzyVariants {
+		if matched[v.ruleIdx] || (candidates != nil && !candidates[v.ruleIdx]) {
+			continue
+		}
+		ratio, ok := fuzzyPhraseMatch(v.tokens, textTokens, cfg)
+		if !ok {
+			continue
+		}
+		matched[v.ruleIdx] = true
+		rule := pi.rules[v.ruleIdx]
+		scores[rule.Group] += int(float64(rule.Weight) * ratio)
+	}
+	return scores, nil
+}
+
+// TopGroupFuzzy is TopGroup using FuzzyScores instead of Scores, so an
+// exact hit still wins outright (ratio 1.0) while a misspelled product
+// title still contributes enough score to classify.
+func (pi *PatternIndex) TopGroupFuzzy(text string, priority []string, minScore int, cfg FuzzyConfig) (string, map[string]int, error) {
+	scores, err := pi.FuzzyScores(text, cfg)
+	if err != nil {
+		return "", scores, err
+	}
+
+	order := priority
+	if len(order) == 0 {
+		order = sortedGroups(scores)
+	}
+
+	maxScore := 0
+	for _, group := range order {
+		if scores[group] > maxScore {
+			maxScore = scores[group]
+		}
+	}
+	if maxScore < minScore {
+		return "", scores, nil
+	}
+	for _, group := range order {
+		if scores[group] == maxScore {
+			return group, scores, nil
+		}
+	}
+	return "", scores, nil
+}
+
+// MatchesAny reports whether text matches at least one pattern belonging to
+// any of the given groups. If groups is empty, any group is considered.
+func (pi *PatternIndex) MatchesAny(text string, groups ...string) (bool, error) {
+	scores, err := pi.Scores(text)
+	if err != nil {
+		return false, err
+	}
+	if len(groups) == 0 {
+		return len(scores) > 0, nil
+	}
+	for _, g := range groups {
+		if scores[g] > 0 {
+			return true, nil
+		}
+	}
+	return false, nil
+}
+
+// TopGroup returns the group with the highest aggregated score, restricted
+// to priority (in the order given) when it is non-empty. Ties are broken in
+// favor of the earlier entry in priority, or otherwise the alphabetically
+// first group. TopGroup returns "" if no group
+// scores at least minScore.
+func (pi *PatternIndex) TopGroup(text string, priority []string, minScore int) (string, map[string]int, error) {
+	scores, err := pi.Scores(text)
+	if err != nil {
+		return "", scores, err
+	}
+
+	order := priority
+	if len(order) == 0 {
+		order = sortedGroups(scores)
+	}
+
+	maxScore := 0
+	for _, group := range order {
+		if scores[group] > maxScore {
+			maxScore = scores[group]
+		}
+	}
+	if maxScore < minScore {
+		return "", scores, nil
+	}
+	for _, group := range order {
+		if scores[group] == maxScore {
+			return group, scores, nil
+		}
+	}
+	return "", scores, nil
+}
+
+func sortedGroups(scores map[string]int) []string {
+	groups := make([]string, 0, len(scores))
+	for g := range scores {
+		groups = append(groups, g)
+	}
+	sort.Strings(groups)
+	return groups
+}
+
+func ruleIndexFromID(id string) (int, error) {
+	var i int
+	_, err := fmt.Sscanf(id, "%d", &i)
+	return i, err
+}
@@ -0,0 +1,67 @@
+package index
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func testRules() []Rule {
+	return []Rule{
+		{Group: "women", Patterns: []string{"damen", "frauen", "women"}, Weight: 10, Language: "both"},
+		{Group: "women", Patterns: []string{"lady", "ladies"}, Weight: 8, Language: "en"},
+		{Group: "men", Patterns: []string{"herren", "men"}, Weight: 10, Language: "both"},
+	}
+}
+
+func TestNewPatternIndexScoresMatchingGroup(t *testing.T) {
+	pi, err := NewPatternIndex(testRules())
+	require.NoError(t, err)
+
+	scores, err := pi.Scores("Damen T-Shirts im neuen Schnitt")
+	require.NoError(t, err)
+	assert.Equal(t, 10, scores["women"])
+	assert.Equal(t, 0, scores["men"])
+}
+
+func TestNewPatternIndexStemmingMatchesInflectedForm(t *testing.T) {
+	pi, err := NewPatternIndex(testRules())
+	require.NoError(t, err)
+
+	scores, err := pi.Scores("Das Damen-T-Shirt ist bequem")
+	require.NoError(t, err)
+	assert.Greater(t, scores["women"], 0)
+}
+
+func TestPatternIndexTopGroupRequiresMinScore(t *testing.T) {
+	pi, err := NewPatternIndex(testRules())
+	require.NoError(t, err)
+
+	group, _, err := pi.TopGroup("ein allgemeiner Text ohne Treffer", []string{"women", "men"}, 5)
+	require.NoError(t, err)
+	assert.Equal(t, "", group)
+}
+
+func TestPatternIndexTopGroupPicksHighestScore(t *testing.T) {
+	pi, err := NewPatternIndex(testRules())
+	require.NoError(t, err)
+
+	group, scores, err := pi.TopGroup("herren men shirt", []string{"women", "men"}, 1)
+	require.NoError(t, err)
+	assert.Equal(t, "men", group)
+	assert.Equal(t, 10, scores["men"])
+}
+
+func TestPatternIndexMatchesAnyRestrictsToGivenGroups(t *testing.T) {
+	pi, err := NewPatternIndex(testRules())
+	require.NoError(t, err)
+
+	matched, err := pi.MatchesAny("damen jacke", "men")
+	require.NoError(t, err)
+	assert.False(t, matched)
+
+	matched, err = pi.MatchesAny("damen jacke", "women")
+	require.NoError(t, err)
+	assert.True(t, matched)
+}
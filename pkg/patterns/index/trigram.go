@@ -0,0 +1,67 @@
+package index
+
+// trigramBoundary brackets text on both sides before extracting trigrams,
+// so a short pattern like "xl" still contributes a couple of trigrams
+// ("\x02xl", "xl\x02") distinguishing it from "xl" appearing mid-word in
+// unrelated text.
+const trigramBoundary = "\x02"
+
+// trigrams returns the set of 3-rune windows of text (lowercased, bracketed
+// with boundary sentinels). Non-empty inputs shorter than 3 runes (after
+// bracketing, at least "\x02"+rune+"\x02", 3 runes) still yield a trigram;
+// an empty input yields none.
+func trigrams(text string) map[string]bool {
+	bracketed := []rune(trigramBoundary + text + trigramBoundary)
+	if len(bracketed) < 3 {
+		return nil
+	}
+	set := make(map[string]bool, len(bracketed)-2)
+	for i := 0; i+3 <= len(bracketed); i++ {
+		set[string(bracketed[i:i+3])] = true
+	}
+	return set
+}
+
+// trigramIndex maps each trigram to the rule indices that have at least one
+// raw pattern token containing it, letting FuzzyScores narrow its
+// fuzzyVariants scan to rules that plausibly share some substring with the
+// input text instead of comparing every rule against every input.
+type trigramIndex map[string][]int
+
+// buildTrigramIndex indexes every fuzzyVariant's tokens by trigram.
+func buildTrigramIndex(variants []fuzzyVariant) trigramIndex {
+	idx := make(trigramIndex)
+	seen := make(map[string]map[int]bool)
+	for _, v := range variants {
+		for token := range v.tokens {
+			for tg := range trigrams(token) {
+				if seen[tg] == nil {
+					seen[tg] = make(map[int]bool)
+				}
+				if seen[tg][v.ruleIdx] {
+					continue
+				}
+				seen[tg][v.ruleIdx] = true
+				idx[tg] = append(idx[tg], v.ruleIdx)
+			}
+		}
+	}
+	return idx
+}
+
+// candidates returns the set of rule indices sharing at least one trigram
+// with text, or nil if text is too short to produce trigrams (callers
+// should fall back to scanning every rule in that case).
+func (idx trigramIndex) candidates(text string) map[int]bool {
+	tgs := trigrams(text)
+	if len(tgs) == 0 {
+		return nil
+	}
+	out := make(map[int]bool)
+	for tg := range tgs {
+		for _, ruleIdx := range idx[tg] {
+			out[ruleIdx] = true
+		}
+	}
+	return out
+}
@@ -0,0 +1,138 @@
+package patterns
+
+import (
+	"sort"
+
+	"golang.org/x/text/language"
+)
+
+// localeGenderPatterns, localeCategoryPatterns, and localeTagPatterns hold
+// locale -> group -> []PatternRule tables registered by
+// cmd/gen-locale-patterns-generated files (see patterns_locale_gen.go).
+// Each generated file registers its table from an init() func; because
+// init() funcs only run after every package-level variable has been
+// initialized, nothing may read these maps from a var initializer - see
+// mergeLocalePatterns's callers in detection.go and the
+// categoryMatcher/genderMatcher/tagMatcher lazy accessors it required.
+var (
+	localeGenderPatterns   = map[string]map[string][]PatternRule{}
+	localeCategoryPatterns = map[string]map[string][]PatternRule{}
+	localeTagPatterns      = map[string]map[string][]PatternRule{}
+)
+
+// registerLocaleGenderPatterns adds locale's gender pattern table to the
+// registry getGenderPatterns merges in. Called from generated
+// patterns_<locale>.go files' init() funcs, never directly.
+func registerLocaleGenderPatterns(locale string, patterns map[string][]PatternRule) {
+	localeGenderPatterns[locale] = patterns
+}
+
+// registerLocaleCategoryPatterns is registerLocaleGenderPatterns for
+// getCategoryPatterns.
+func registerLocaleCategoryPatterns(locale string, patterns map[string][]PatternRule) {
+	localeCategoryPatterns[locale] = patterns
+}
+
+// registerLocaleTagPatterns is registerLocaleGenderPatterns for
+// getTagPatterns.
+func registerLocaleTagPatterns(locale string, patterns map[string][]PatternRule) {
+	localeTagPatterns[locale] = patterns
+}
+
+// mergeLocalePatterns returns base with every group in every registered
+// locale table appended, iterating locales in sorted order so the merged
+// table - and the Bleve index built from it - stays deterministic across
+// runs regardless of generated-file init() ordering.
+func mergeLocalePatterns(base map[string][]PatternRule, locales map[string]map[string][]PatternRule) map[string][]PatternRule {
+	if len(locales) == 0 {
+		return base
+	}
+	merged := make(map[string][]PatternRule, len(base))
+	for group, rules := range base {
+		merged[group] = append([]PatternRule(nil), rules...)
+	}
+	localeNames := make([]string, 0, len(locales))
+	for l := range locales {
+		localeNames = append(localeNames, l)
+	}
+	sort.Strings(localeNames)
+	for _, l := range localeNames {
+		for group, rules := range locales[l] {
+			merged[group] = append(merged[group], rules...)
+		}
+	}
+	return merged
+}
+
+// localeWeightMultiplier scales a rule's Weight by how well ruleLocale
+// matches the caller's preferred locales: language-neutral rules ("mul" or
+// "") always count at full weight, an exact or regional-only mismatch
+// ("en-GB" ruleLocale against a preferred "en-US") still counts at full
+// weight since the language itself matched, and a rule in a language the
+// caller didn't ask for at all is kept at a reduced fallback weight rather
+// than dropped, so a classifier never returns a worse answer than it would
+// have without locale preferences.
+const localeFallbackWeight = 0.4
+
+func localeWeightMultiplier(ruleLocale string, matcher language.Matcher) float64 {
+	if ruleLocale == "" || ruleLocale == "mul" {
+		return 1.0
+	}
+	tag, err := language.Parse(ruleLocale)
+	if err != nil {
+		return 1.0
+	}
+	_, _, confidence := matcher.Match(tag)
+	if confidence == language.No {
+		return localeFallbackWeight
+	}
+	return 1.0
+}
+
+// localeWeightedPatternRules returns a copy of patterns with every rule's
+// Weight scaled by localeWeightMultiplier against preferred.
+func localeWeightedPatternRules(patterns map[string][]PatternRule, preferred []language.Tag) map[string][]PatternRule {
+	matcher := language.NewMatcher(preferred)
+	out := make(map[string][]PatternRule, len(patterns))
+	for group, rules := range patterns {
+		scaled := make([]PatternRule, len(rules))
+		for i, r := range rules {
+			scaled[i] = r
+			scaled[i].Weight = int(float64(r.Weight) * localeWeightMultiplier(r.Locale, matcher))
+		}
+		out[group] = scaled
+	}
+	return out
+}
+
+// DetectGenderForLocales is DetectGenderWithOptions, but weighting every
+// pattern rule by how well its Locale matches preferred (in preference
+// order) first - so a French "Femme Poloshirt" scores "women" as
+// confidently as a German "Damen Poloshirt" does when preferred is
+// []language.Tag{language.French}, while an unrelated locale's synonyms
+// still contribute at a reduced fallback weight instead of being ignored.
+func DetectGenderForLocales(text string, preferred []language.Tag, opts AnalyzeOptions) string {
+	matcher, err := NewPatternMatcher(localeWeightedPatternRules(getGenderPatterns(), preferred), opts)
+	if err != nil {
+		return ""
+	}
+	gender, _, err := matcher.TopGroup(text, genderPriorityOrder, 5, opts)
+	if err != nil {
+		return ""
+	}
+	return gender
+}
+
+// DetectCategoryForLocales is DetectGenderForLocales's category
+// counterpart.
+func DetectCategoryForLocales(text string, preferred []language.Tag, opts AnalyzeOptions) string {
+	matcher, err := NewPatternMatcher(localeWeightedPatternRules(getCategoryPatterns(), preferred), opts)
+	if err != nil {
+		return ""
+	}
+	category, _, err := matcher.TopGroup(text, nil, 1, opts)
+	if err != nil {
+		return ""
+	}
+	return category
+}
@@ -0,0 +1,91 @@
+package patterns
+
+import (
+	"fmt"
+
+	patternindex "github.com/MalteBoehm/tall-affiliate-common/pkg/patterns/index"
+)
+
+// PatternMatcher is a reusable, once-built matcher over a named group of
+// PatternRules (e.g. gender, category, or tag patterns). It wraps a
+// Bleve-backed patternindex.PatternIndex - which itself narrows both exact
+// matching (via Bleve) and fuzzy matching (via a trigram index) to a small
+// candidate subset instead of scanning every pattern - so classifying a
+// large batch of product titles against a fixed rule set stays cheap per
+// item. DetectCategory, DetectGender, and GenerateTagsFromText wrap a
+// package-level default PatternMatcher per pattern group; callers with
+// their own rule set build one directly with NewPatternMatcher.
+type PatternMatcher struct {
+	idx    *patternindex.PatternIndex
+	groups []string
+}
+
+// NewPatternMatcher builds a PatternMatcher from patterns (a group name ->
+// []PatternRule map), normalizing every pattern term through buildOpts so
+// rule text and later query text go through the same chain.
+func NewPatternMatcher(patterns map[string][]PatternRule, buildOpts AnalyzeOptions) (*PatternMatcher, error) {
+	rules, groups := flattenGroupedPatterns(patterns, buildOpts)
+	idx, err := patternindex.NewPatternIndex(rules)
+	if err != nil {
+		return nil, fmt.Errorf("patterns: build pattern index: %w", err)
+	}
+	return &PatternMatcher{idx: idx, groups: groups}, nil
+}
+
+// mustGroupedIndex builds a patternindex.PatternIndex from a group name ->
+// []PatternRule map, normalizing every pattern term through opts first so
+// rule text and query text go through the same chain. It panics if the
+// (statically defined) rules fail to index - a failure here means a
+// programming error, not bad input. Attribute extractors that don't need
+// PatternMatcher's fuzzy/trigram machinery (colorIndex, materialIndex, etc.
+// in attributes.go) use this directly; DetectCategory/DetectGender/
+// GenerateTagsFromText use mustPatternMatcher instead.
+func mustGroupedIndex(patterns map[string][]PatternRule, opts AnalyzeOptions) (*patternindex.PatternIndex, []string) {
+	rules, groups := flattenGroupedPatterns(patterns, opts)
+	idx, err := patternindex.NewPatternIndex(rules)
+	if err != nil {
+		panic(fmt.Sprintf("patterns: build pattern index: %v", err))
+	}
+	return idx, groups
+}
+
+// mustPatternMatcher is NewPatternMatcher, panicking on error. It's only
+// used to build the package-level default matchers from the (statically
+// defined) built-in pattern tables, where a failure means a programming
+// error, not bad input.
+func mustPatternMatcher(patterns map[string][]PatternRule, buildOpts AnalyzeOptions) *PatternMatcher {
+	m, err := NewPatternMatcher(patterns, buildOpts)
+	if err != nil {
+		panic(err)
+	}
+	return m
+}
+
+// Groups returns m's pattern group names, in the stable order NewPatternMatcher
+// derived them in.
+func (m *PatternMatcher) Groups() []string {
+	return m.groups
+}
+
+// TopGroup returns the highest-scoring group for text (normalized through
+// opts), restricted to priority (in the order given) when non-empty,
+// defaulting to m.Groups() otherwise. It returns "" if no group scores at
+// least minScore. Typo-tolerant matching is used instead of exact matching
+// when opts.Fuzzy is set.
+func (m *PatternMatcher) TopGroup(text string, priority []string, minScore int, opts AnalyzeOptions) (string, map[string]int, error) {
+	if len(priority) == 0 {
+		priority = m.groups
+	}
+	return topGroup(m.idx, opts.normalize(text), priority, minScore, opts)
+}
+
+// Scores returns the per-group score of text (normalized through opts).
+// Typo-tolerant matching is used instead of exact matching when opts.Fuzzy
+// is set.
+func (m *PatternMatcher) Scores(text string, opts AnalyzeOptions) (map[string]int, error) {
+	normalized := opts.normalize(text)
+	if opts.Fuzzy != nil {
+		return m.idx.FuzzyScores(normalized, *opts.Fuzzy)
+	}
+	return m.idx.Scores(normalized)
+}
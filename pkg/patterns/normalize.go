@@ -0,0 +1,173 @@
+package patterns
+
+import (
+	"regexp"
+	"strings"
+	"unicode"
+
+	"github.com/blevesearch/snowballstem"
+	"github.com/blevesearch/snowballstem/english"
+	"github.com/blevesearch/snowballstem/german"
+	"golang.org/x/text/unicode/norm"
+
+	patternindex "github.com/MalteBoehm/tall-affiliate-common/pkg/patterns/index"
+	"github.com/MalteBoehm/tall-affiliate-common/pkg/patterns/readability"
+)
+
+// FuzzyConfig configures Levenshtein-based typo tolerance for pattern
+// matching; see patternindex.FuzzyConfig for field documentation.
+type FuzzyConfig = patternindex.FuzzyConfig
+
+// Normalizer transforms text into a canonical form before pattern matching.
+// Normalizers are meant to be chained in an AnalyzeOptions so pattern rules
+// and the text they're matched against go through the exact same
+// transformation, keeping matching symmetric.
+type Normalizer interface {
+	Normalize(text string) string
+}
+
+// LowercaseNormalizer folds text to lower case.
+type LowercaseNormalizer struct{}
+
+// Normalize implements Normalizer.
+func (LowercaseNormalizer) Normalize(text string) string {
+	return strings.ToLower(text)
+}
+
+// DiacriticFolder strips combining diacritical marks via NFD decomposition,
+// so e.g. "Dämen" and "Damen" compare equal.
+type DiacriticFolder struct{}
+
+// Normalize implements Normalizer.
+func (DiacriticFolder) Normalize(text string) string {
+	var b strings.Builder
+	b.Grow(len(text))
+	for _, r := range norm.NFD.String(text) {
+		if unicode.Is(unicode.Mn, r) {
+			continue
+		}
+		b.WriteRune(r)
+	}
+	return norm.NFC.String(b.String())
+}
+
+// clumpRe collapses runs of non-word characters, the same clumping approach
+// used by tolerant search normalizers to flatten punctuation variants.
+var clumpRe = regexp.MustCompile(`\W+`)
+
+// PunctuationStripper collapses punctuation and whitespace runs into single
+// spaces and trims the result, so "t-shirt", "t shirt" and "t_shirt" compare
+// equal.
+type PunctuationStripper struct{}
+
+// Normalize implements Normalizer.
+func (PunctuationStripper) Normalize(text string) string {
+	return strings.TrimSpace(clumpRe.ReplaceAllString(text, " "))
+}
+
+// SnowballStemmer stems each whitespace-separated token of its input using
+// the Snowball algorithm for Language ("de" or "en"). Any other language is
+// a no-op.
+type SnowballStemmer struct {
+	Language string
+}
+
+// Normalize implements Normalizer.
+func (s SnowballStemmer) Normalize(text string) string {
+	fields := strings.Fields(text)
+	for i, field := range fields {
+		fields[i] = s.stem(field)
+	}
+	return strings.Join(fields, " ")
+}
+
+func (s SnowballStemmer) stem(word string) string {
+	env := snowballstem.NewEnv(word)
+	switch s.Language {
+	case "de":
+		german.Stem(env)
+	case "en":
+		english.Stem(env)
+	default:
+		return word
+	}
+	return env.Current()
+}
+
+// AnalyzeOptions selects how DetectCategoryWithOptions, DetectGenderWithOptions,
+// and GenerateTagsFromTextWithOptions prepare their input text before
+// matching it against the pattern index.
+type AnalyzeOptions struct {
+	// Normalizers is the chain applied to the text after any HTML/readability
+	// preprocessing below.
+	Normalizers []Normalizer
+
+	// StripHTML removes HTML markup from the input before normalizing,
+	// without any boilerplate filtering.
+	StripHTML bool
+
+	// ReadabilityMode strips HTML and additionally drops low-signal
+	// boilerplate blocks (seller blurbs, size charts, cross-sell copy)
+	// using readability.ExtractReadableText. It implies StripHTML, falling
+	// back to a plain strip if no block scores above the keep threshold.
+	ReadabilityMode bool
+
+	// Fuzzy enables typo-tolerant matching (e.g. "hoddie" for "hoodie")
+	// against the pattern index, using FuzzyScores instead of Scores. Nil
+	// (the default) requires an exact match, same as before Fuzzy existed.
+	Fuzzy *FuzzyConfig
+}
+
+// DefaultAnalyzeOptions returns the chain DetectCategory, DetectGender, and
+// GenerateTagsFromText use: lowercasing, diacritic folding, punctuation
+// collapsing, then German and English Snowball stemming. Pattern rules are
+// normalized through this same chain once, when each default matcher is
+// first built.
+func DefaultAnalyzeOptions() AnalyzeOptions {
+	return AnalyzeOptions{
+		Normalizers: []Normalizer{
+			LowercaseNormalizer{},
+			DiacriticFolder{},
+			PunctuationStripper{},
+			SnowballStemmer{Language: "de"},
+			SnowballStemmer{Language: "en"},
+		},
+	}
+}
+
+// normalize applies o's HTML/readability preprocessing, then runs the result
+// through every Normalizer in o.Normalizers, in order.
+func (o AnalyzeOptions) normalize(text string) string {
+	text = o.preprocess(text)
+	for _, n := range o.Normalizers {
+		text = n.Normalize(text)
+	}
+	return text
+}
+
+// preprocess strips HTML and/or boilerplate from text per ReadabilityMode
+// and StripHTML. Parse errors fall back to the original text so a caller
+// that passes plain (non-HTML) prose is unaffected.
+func (o AnalyzeOptions) preprocess(text string) string {
+	if o.ReadabilityMode {
+		if clean, err := readability.ExtractReadableText(text); err == nil && strings.TrimSpace(clean) != "" {
+			return clean
+		}
+		if stripped, err := readability.StripHTML(text); err == nil {
+			return stripped
+		}
+		return text
+	}
+	if o.StripHTML {
+		if stripped, err := readability.StripHTML(text); err == nil {
+			return stripped
+		}
+		return text
+	}
+	return text
+}
+
+// defaultAnalyzeOptions is the chain used to normalize pattern rules at
+// package load time, and by DetectCategory/DetectGender/GenerateTagsFromText
+// when the caller doesn't supply their own AnalyzeOptions.
+var defaultAnalyzeOptions = DefaultAnalyzeOptions()
@@ -0,0 +1,136 @@
+package patterns
+
+import (
+	"sort"
+	"strings"
+)
+
+// Ranked is one scored candidate value (a gender or a category) behind
+// AnalyzeMultipleBrowseNodes' single "best" pick, so callers debugging
+// classification can see the full ranked list instead of only the winner.
+type Ranked[T comparable] struct {
+	Value         T        `json:"value"`
+	Score         int      `json:"score"`
+	BrowseNodeIDs []string `json:"browse_node_ids"`
+	ContentScore  int      `json:"content_score"`
+}
+
+// rankCandidates combines per-Browse-Node scores (nodeScores, restricted to
+// supported nodes) with content-pattern scores (contentScores) into a
+// descending-by-score ranked list of every candidate value either saw
+// evidence for.
+func rankCandidates(nodeScores []BrowseNodeScore, contentScores map[string]int) []Ranked[string] {
+	byValue := make(map[string]*Ranked[string])
+	order := make([]string, 0)
+
+	get := func(value string) *Ranked[string] {
+		r, ok := byValue[value]
+		if !ok {
+			r = &Ranked[string]{Value: value}
+			byValue[value] = r
+			order = append(order, value)
+		}
+		return r
+	}
+
+	for _, ns := range nodeScores {
+		value := ns.Category
+		if value == "" {
+			value = ns.Gender
+		}
+		if value == "" || !ns.IsSupported {
+			continue
+		}
+		r := get(value)
+		r.Score += ns.Score
+		r.BrowseNodeIDs = append(r.BrowseNodeIDs, ns.BrowseNodeID)
+	}
+	for value, score := range contentScores {
+		if value == "" || score == 0 {
+			continue
+		}
+		r := get(value)
+		r.ContentScore = score
+		r.Score += score
+	}
+
+	ranked := make([]Ranked[string], len(order))
+	for i, value := range order {
+		ranked[i] = *byValue[value]
+	}
+	SortRanked(ranked, []string{"-score", "value"})
+	return ranked
+}
+
+// SortRanked sorts items in place by fields, each either "score" or "value",
+// with a leading "-" for descending. Earlier fields take precedence; ties
+// fall through to the next field.
+func SortRanked(items []Ranked[string], fields []string) {
+	sort.SliceStable(items, func(i, j int) bool {
+		for _, field := range fields {
+			desc := strings.HasPrefix(field, "-")
+			name := strings.TrimPrefix(field, "-")
+
+			var less, greater bool
+			switch name {
+			case "score":
+				less, greater = items[i].Score < items[j].Score, items[i].Score > items[j].Score
+			case "value":
+				less, greater = items[i].Value < items[j].Value, items[i].Value > items[j].Value
+			default:
+				continue
+			}
+			if desc {
+				less, greater = greater, less
+			}
+			if less {
+				return true
+			}
+			if greater {
+				return false
+			}
+		}
+		return false
+	})
+}
+
+// SortNodeScores sorts result.NodeScores in place by fields, each one of
+// "score", "priority", "category", "gender", or "browse_node_id", with a
+// leading "-" for descending. NodeScores otherwise comes out in Go's
+// randomized map-iteration order, which makes snapshot testing and the
+// debug output of AnalyzeProductForGenderAndCategory nondeterministic.
+func SortNodeScores(result *MultiNodeAnalysisResult, fields []string) {
+	sort.SliceStable(result.NodeScores, func(i, j int) bool {
+		a, b := result.NodeScores[i], result.NodeScores[j]
+		for _, field := range fields {
+			desc := strings.HasPrefix(field, "-")
+			name := strings.TrimPrefix(field, "-")
+
+			var less, greater bool
+			switch name {
+			case "score":
+				less, greater = a.Score < b.Score, a.Score > b.Score
+			case "priority":
+				less, greater = a.Priority < b.Priority, a.Priority > b.Priority
+			case "category":
+				less, greater = a.Category < b.Category, a.Category > b.Category
+			case "gender":
+				less, greater = a.Gender < b.Gender, a.Gender > b.Gender
+			case "browse_node_id":
+				less, greater = a.BrowseNodeID < b.BrowseNodeID, a.BrowseNodeID > b.BrowseNodeID
+			default:
+				continue
+			}
+			if desc {
+				less, greater = greater, less
+			}
+			if less {
+				return true
+			}
+			if greater {
+				return false
+			}
+		}
+		return false
+	})
+}
@@ -0,0 +1,212 @@
+// Package readability extracts clean prose from HTML product copy, so
+// pattern matching isn't thrown off by seller boilerplate, size charts, and
+// cross-sell blurbs embedded in scraped descriptions.
+package readability
+
+import (
+	"fmt"
+	"regexp"
+	"strings"
+
+	"golang.org/x/net/html"
+)
+
+// blockTags are the elements treated as paragraph-like scoring units.
+var blockTags = map[string]bool{
+	"p":          true,
+	"div":        true,
+	"li":         true,
+	"td":         true,
+	"blockquote": true,
+	"article":    true,
+	"section":    true,
+	"h1":         true,
+	"h2":         true,
+	"h3":         true,
+	"h4":         true,
+	"h5":         true,
+	"h6":         true,
+}
+
+var whitespaceRe = regexp.MustCompile(`\s+`)
+
+const (
+	// keepFraction is the minimum fraction of the best block's score a
+	// block must reach to be kept outright.
+	keepFraction = 0.2
+	// siblingFraction lets a low-scoring block survive if it sits next to
+	// a kept block and isn't much weaker than it, so short connective
+	// sentences between two good paragraphs aren't dropped.
+	siblingFraction = 0.5
+	// linkPenaltyWeight scales how much a block's own link density
+	// reduces its score; a block that is entirely link text loses its
+	// whole score.
+	linkPenaltyWeight = 1.0
+)
+
+// block is one scored unit of extracted text.
+type block struct {
+	text        string
+	linkTextLen int
+}
+
+// StripHTML removes all markup and returns the remaining text, whitespace
+// collapsed, without any readability scoring.
+func StripHTML(rawHTML string) (string, error) {
+	doc, err := html.Parse(strings.NewReader(rawHTML))
+	if err != nil {
+		return "", fmt.Errorf("readability: parse html: %w", err)
+	}
+	return collapseWhitespace(extractText(doc)), nil
+}
+
+// ExtractReadableText strips HTML and drops low-signal boilerplate blocks by
+// scoring each paragraph-like block on text-length/comma-count, minus a
+// penalty proportional to its link density (len(link text)/len(text)).
+// Only blocks scoring above keepFraction of the best block survive, along
+// with neighboring blocks within siblingFraction of a kept block's score.
+// The surviving blocks are joined with blank lines in document order.
+func ExtractReadableText(rawHTML string) (string, error) {
+	doc, err := html.Parse(strings.NewReader(rawHTML))
+	if err != nil {
+		return "", fmt.Errorf("readability: parse html: %w", err)
+	}
+
+	blocks := gatherBlocks(doc)
+	if len(blocks) == 0 {
+		return "", nil
+	}
+
+	scores := make([]float64, len(blocks))
+	maxScore := 0.0
+	for i, b := range blocks {
+		scores[i] = scoreBlock(b)
+		if scores[i] > maxScore {
+			maxScore = scores[i]
+		}
+	}
+	if maxScore <= 0 {
+		return "", nil
+	}
+
+	threshold := maxScore * keepFraction
+	keep := make([]bool, len(blocks))
+	for i, s := range scores {
+		keep[i] = s >= threshold
+	}
+	for i := range blocks {
+		if keep[i] {
+			continue
+		}
+		if i > 0 && keep[i-1] && scores[i] >= scores[i-1]*siblingFraction {
+			keep[i] = true
+		} else if i < len(blocks)-1 && keep[i+1] && scores[i] >= scores[i+1]*siblingFraction {
+			keep[i] = true
+		}
+	}
+
+	kept := make([]string, 0, len(blocks))
+	for i, b := range blocks {
+		if keep[i] {
+			kept = append(kept, b.text)
+		}
+	}
+	return strings.Join(kept, "\n\n"), nil
+}
+
+func scoreBlock(b block) float64 {
+	commaCount := strings.Count(b.text, ",") + 1
+	base := float64(len(b.text)) / float64(commaCount)
+
+	linkDensity := 0.0
+	if len(b.text) > 0 {
+		linkDensity = float64(b.linkTextLen) / float64(len(b.text))
+	}
+	return base - linkPenaltyWeight*linkDensity*base
+}
+
+// gatherBlocks walks doc in document order, collecting the innermost
+// block-level elements (those with no block-level descendant of their own)
+// that contain non-empty text.
+func gatherBlocks(n *html.Node) []block {
+	var blocks []block
+	var walk func(*html.Node)
+	walk = func(n *html.Node) {
+		if isSkippedElement(n) {
+			return
+		}
+		if n.Type == html.ElementNode && blockTags[n.Data] && !hasBlockDescendant(n) {
+			text := collapseWhitespace(extractText(n))
+			if text != "" {
+				blocks = append(blocks, block{text: text, linkTextLen: extractLinkTextLen(n)})
+			}
+			return
+		}
+		for c := n.FirstChild; c != nil; c = c.NextSibling {
+			walk(c)
+		}
+	}
+	walk(n)
+	return blocks
+}
+
+func hasBlockDescendant(n *html.Node) bool {
+	for c := n.FirstChild; c != nil; c = c.NextSibling {
+		if c.Type == html.ElementNode && blockTags[c.Data] {
+			return true
+		}
+		if hasBlockDescendant(c) {
+			return true
+		}
+	}
+	return false
+}
+
+func isSkippedElement(n *html.Node) bool {
+	return n.Type == html.ElementNode && (n.Data == "script" || n.Data == "style")
+}
+
+func extractText(n *html.Node) string {
+	var b strings.Builder
+	var walk func(*html.Node)
+	walk = func(n *html.Node) {
+		if n.Type == html.TextNode {
+			b.WriteString(n.Data)
+			b.WriteString(" ")
+			return
+		}
+		if isSkippedElement(n) {
+			return
+		}
+		for c := n.FirstChild; c != nil; c = c.NextSibling {
+			walk(c)
+		}
+	}
+	walk(n)
+	return b.String()
+}
+
+func extractLinkTextLen(n *html.Node) int {
+	total := 0
+	var walk func(*html.Node, bool)
+	walk = func(n *html.Node, inLink bool) {
+		if n.Type == html.ElementNode && n.Data == "a" {
+			inLink = true
+		}
+		if n.Type == html.TextNode && inLink {
+			total += len(strings.TrimSpace(n.Data))
+		}
+		if isSkippedElement(n) {
+			return
+		}
+		for c := n.FirstChild; c != nil; c = c.NextSibling {
+			walk(c, inLink)
+		}
+	}
+	walk(n, false)
+	return total
+}
+
+func collapseWhitespace(s string) string {
+	return strings.TrimSpace(whitespaceRe.ReplaceAllString(s, " "))
+}
@@ -0,0 +1,53 @@
+package readability
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestStripHTMLRemovesMarkup(t *testing.T) {
+	got, err := StripHTML("<div>Hello <b>World</b></div>")
+	require.NoError(t, err)
+	assert.Equal(t, "Hello World", got)
+}
+
+func TestStripHTMLSkipsScriptAndStyle(t *testing.T) {
+	got, err := StripHTML(`<p>Visible</p><script>var x = 1;</script><style>.a{color:red}</style>`)
+	require.NoError(t, err)
+	assert.Equal(t, "Visible", got)
+}
+
+func TestExtractReadableTextKeepsProseOverBoilerplate(t *testing.T) {
+	htmlDoc := `
+<div>
+	<p>Dieses klassische Damen T-Shirt aus weicher Baumwolle ist perfekt fuer den Alltag, ob beim Sport oder beim entspannten Wochenende mit Freunden.</p>
+	<div><a href="/related-1">Klicken Sie hier</a> <a href="/related-2">fuer weitere Produkte</a> <a href="/related-3">und Angebote</a></div>
+	<p>Groesse</p>
+</div>`
+
+	got, err := ExtractReadableText(htmlDoc)
+	require.NoError(t, err)
+	assert.Contains(t, got, "Damen T-Shirt")
+	assert.NotContains(t, got, "Klicken Sie hier")
+}
+
+func TestExtractReadableTextEmptyForNoBlocks(t *testing.T) {
+	got, err := ExtractReadableText("<br/><hr/>")
+	require.NoError(t, err)
+	assert.Empty(t, got)
+}
+
+func TestExtractReadableTextDropsHighLinkDensityBlock(t *testing.T) {
+	htmlDoc := `<div>
+	<p>` + strings.Repeat("Bequemes Material, tolle Passform, waschbar bei 30 Grad. ", 5) + `</p>
+	<p><a href="/x">Mehr</a> <a href="/y">Angebote</a> <a href="/z">hier</a></p>
+</div>`
+
+	got, err := ExtractReadableText(htmlDoc)
+	require.NoError(t, err)
+	assert.Contains(t, got, "Bequemes Material")
+	assert.NotContains(t, got, "Mehr")
+}
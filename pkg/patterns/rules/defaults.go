@@ -0,0 +1,88 @@
+package rules
+
+import "sync"
+
+// DefaultMarketplace is the registry key DefaultRuleSet is registered under,
+// and the fallback Get falls back to when a marketplace has no pack of its
+// own.
+const DefaultMarketplace = "DEFAULT"
+
+// DefaultRuleSet is the shipped rule pack: a declarative restatement of the
+// hybrid Browse-Node/content logic AnalyzeMultipleBrowseNodes used before
+// this package existed, so registering custom marketplace packs is opt-in
+// and existing behavior is preserved byte-for-byte.
+func DefaultRuleSet() RuleSet {
+	return RuleSet{
+		Marketplace: DefaultMarketplace,
+		Rules: []Rule{
+			{
+				Name: "content-gender-explicit",
+				If:   Condition{Or: []Condition{{ContentGender: "women"}, {ContentGender: "men"}}},
+				Then: Action{EmitGender: "$content_gender"},
+			},
+			{
+				Name: "strong-browse-node-gender",
+				If:   Condition{MinBrowseScore: ">=80"},
+				Then: Action{EmitGender: "$browse_gender"},
+			},
+			{
+				Name: "weak-content-gender",
+				If:   Condition{ContentGender: "*"},
+				Then: Action{EmitGender: "$content_gender"},
+			},
+			{
+				Name: "fallback-browse-gender",
+				If:   Condition{},
+				Then: Action{EmitGender: "$browse_gender"},
+			},
+			{
+				Name: "content-category-specific",
+				If: Condition{Or: []Condition{
+					{ContentCategory: "T-Shirts"},
+					{ContentCategory: "Poloshirts"},
+					{ContentCategory: "Hoodies"},
+				}},
+				Then: Action{EmitCategory: "$content_category"},
+			},
+			{
+				Name: "strong-browse-node-category",
+				If:   Condition{MinBrowseScore: ">=80"},
+				Then: Action{EmitCategory: "$browse_category"},
+			},
+			{
+				Name: "weak-content-category",
+				If:   Condition{ContentCategory: "*"},
+				Then: Action{EmitCategory: "$content_category"},
+			},
+			{
+				Name: "fallback-browse-category",
+				If:   Condition{},
+				Then: Action{EmitCategory: "$browse_category"},
+			},
+		},
+	}
+}
+
+var (
+	registryMu sync.RWMutex
+	registry   = map[string]RuleSet{DefaultMarketplace: DefaultRuleSet()}
+)
+
+// Register adds or replaces the rule pack for marketplace (e.g. "DE", "US",
+// "UK"). Concurrency-safe.
+func Register(marketplace string, rs RuleSet) {
+	registryMu.Lock()
+	defer registryMu.Unlock()
+	registry[marketplace] = rs
+}
+
+// Get returns the rule pack registered for marketplace, falling back to
+// DefaultRuleSet if none was registered.
+func Get(marketplace string) RuleSet {
+	registryMu.RLock()
+	defer registryMu.RUnlock()
+	if rs, ok := registry[marketplace]; ok {
+		return rs
+	}
+	return registry[DefaultMarketplace]
+}
@@ -0,0 +1,221 @@
+// Package rules provides a declarative rule DSL for combining Browse Node
+// evidence and content-pattern evidence into a final gender/category
+// decision, so tuning that decision doesn't require a Go code change.
+// A RuleSet is a JSON document of Rules, each an "if" Condition (leaf
+// predicates over Facts, composable with and/or/not) and a "then" Action
+// (what to emit when the condition matches).
+package rules
+
+import (
+	"fmt"
+	"regexp"
+	"strconv"
+)
+
+// Facts is the evidence a Condition is evaluated against: the result of
+// Browse Node analysis and content pattern analysis for one product.
+type Facts struct {
+	BrowseGender       string
+	BrowseCategory     string
+	BrowseNodePriority int
+	BrowseScore        int
+	ContentGender      string
+	ContentCategory    string
+}
+
+// Condition is one node of a Rule's "if" clause: either a leaf predicate
+// (whichever non-empty fields are set, implicitly AND-ed together) or a
+// boolean combinator (And/Or/Not). A Condition with no fields and no
+// combinators set always matches, which is how a RuleSet expresses a
+// catch-all fallback rule.
+//
+// Gender, Category, ContentGender and ContentCategory match exact string
+// equality, except for the sentinel value "*", which matches any non-empty
+// value. BrowseNodePriority and MinBrowseScore are comparator expressions
+// against Facts.BrowseNodePriority/BrowseScore, e.g. "<=2" or ">=80"; a bare
+// number defaults to "==".
+type Condition struct {
+	Gender          string `json:"gender,omitempty"`
+	Category        string `json:"category,omitempty"`
+	ContentGender   string `json:"content_gender,omitempty"`
+	ContentCategory string `json:"content_category,omitempty"`
+
+	BrowseNodePriority string `json:"browse_node_priority,omitempty"`
+	MinBrowseScore     string `json:"min_browse_score,omitempty"`
+
+	And []Condition `json:"and,omitempty"`
+	Or  []Condition `json:"or,omitempty"`
+	Not *Condition  `json:"not,omitempty"`
+}
+
+// Action is what a matching Rule contributes to the result. EmitGender and
+// EmitCategory may be a literal value or one of the fact-reference
+// sentinels "$browse_gender", "$browse_category", "$content_gender",
+// "$content_category", resolved against the Facts the rule matched. An
+// empty EmitGender/EmitCategory means the rule doesn't set that field.
+type Action struct {
+	EmitGender      string  `json:"emit_gender,omitempty"`
+	EmitCategory    string  `json:"emit_category,omitempty"`
+	ConfidenceBoost float64 `json:"confidence_boost,omitempty"`
+}
+
+// Rule is one "if ... then ..." entry of a RuleSet.
+type Rule struct {
+	Name string    `json:"name,omitempty"`
+	If   Condition `json:"if"`
+	Then Action    `json:"then"`
+}
+
+// RuleSet is an ordered list of Rules, optionally scoped to a marketplace
+// (e.g. "DE", "US", "UK") via Register/Get.
+type RuleSet struct {
+	Marketplace string `json:"marketplace,omitempty"`
+	Rules       []Rule `json:"rules"`
+}
+
+// Result is the outcome of evaluating a RuleSet against Facts: the first
+// rule (in RuleSet order) to set EmitGender/EmitCategory wins each field,
+// ConfidenceBoost accumulates across every matching rule, and MatchedRules
+// names every rule that matched, in evaluation order, for explainability.
+type Result struct {
+	Gender          string
+	Category        string
+	ConfidenceBoost float64
+	MatchedRules    []string
+}
+
+// Evaluate runs facts through rs and returns the combined Result.
+func Evaluate(rs RuleSet, facts Facts) Result {
+	var result Result
+	for _, rule := range rs.Rules {
+		if !rule.If.matches(facts) {
+			continue
+		}
+		result.MatchedRules = append(result.MatchedRules, rule.Name)
+		result.ConfidenceBoost += rule.Then.ConfidenceBoost
+		if result.Gender == "" && rule.Then.EmitGender != "" {
+			result.Gender = resolve(rule.Then.EmitGender, facts)
+		}
+		if result.Category == "" && rule.Then.EmitCategory != "" {
+			result.Category = resolve(rule.Then.EmitCategory, facts)
+		}
+	}
+	return result
+}
+
+func resolve(value string, facts Facts) string {
+	switch value {
+	case "$browse_gender":
+		return facts.BrowseGender
+	case "$browse_category":
+		return facts.BrowseCategory
+	case "$content_gender":
+		return facts.ContentGender
+	case "$content_category":
+		return facts.ContentCategory
+	default:
+		return value
+	}
+}
+
+func (c Condition) matches(f Facts) bool {
+	if len(c.And) > 0 {
+		for _, sub := range c.And {
+			if !sub.matches(f) {
+				return false
+			}
+		}
+		return true
+	}
+	if len(c.Or) > 0 {
+		for _, sub := range c.Or {
+			if sub.matches(f) {
+				return true
+			}
+		}
+		return false
+	}
+	if c.Not != nil {
+		return !c.Not.matches(f)
+	}
+
+	if c.Gender != "" && !matchesValue(c.Gender, f.BrowseGender) {
+		return false
+	}
+	if c.Category != "" && !matchesValue(c.Category, f.BrowseCategory) {
+		return false
+	}
+	if c.ContentGender != "" && !matchesValue(c.ContentGender, f.ContentGender) {
+		return false
+	}
+	if c.ContentCategory != "" && !matchesValue(c.ContentCategory, f.ContentCategory) {
+		return false
+	}
+	if c.BrowseNodePriority != "" && !matchesComparator(c.BrowseNodePriority, f.BrowseNodePriority) {
+		return false
+	}
+	if c.MinBrowseScore != "" && !matchesComparator(c.MinBrowseScore, f.BrowseScore) {
+		return false
+	}
+	return true
+}
+
+// matchesValue implements the Gender/Category/ContentGender/ContentCategory
+// leaf semantics: exact match, or non-empty check for the "*" sentinel.
+func matchesValue(want, got string) bool {
+	if want == "*" {
+		return got != ""
+	}
+	return want == got
+}
+
+// comparatorRe parses a leading comparator ("<=", ">=", "<", ">", "==") off
+// an integer comparison expression; a bare number defaults to "==".
+var comparatorRe = regexp.MustCompile(`^(<=|>=|==|!=|<|>)?\s*(-?\d+)$`)
+
+func matchesComparator(expr string, actual int) bool {
+	m := comparatorRe.FindStringSubmatch(expr)
+	if m == nil {
+		return false
+	}
+	op := m[1]
+	if op == "" {
+		op = "=="
+	}
+	want, err := strconv.Atoi(m[2])
+	if err != nil {
+		return false
+	}
+	switch op {
+	case "==":
+		return actual == want
+	case "!=":
+		return actual != want
+	case "<=":
+		return actual <= want
+	case ">=":
+		return actual >= want
+	case "<":
+		return actual < want
+	case ">":
+		return actual > want
+	default:
+		return false
+	}
+}
+
+// String renders a Condition back to a short human-readable expression,
+// useful for logging which rule fired and why.
+func (c Condition) String() string {
+	if len(c.And) > 0 {
+		return fmt.Sprintf("and%v", c.And)
+	}
+	if len(c.Or) > 0 {
+		return fmt.Sprintf("or%v", c.Or)
+	}
+	if c.Not != nil {
+		return fmt.Sprintf("not(%v)", *c.Not)
+	}
+	return fmt.Sprintf("{gender:%q category:%q content_gender:%q content_category:%q browse_node_priority:%q min_browse_score:%q}",
+		c.Gender, c.Category, c.ContentGender, c.ContentCategory, c.BrowseNodePriority, c.MinBrowseScore)
+}
@@ -0,0 +1,79 @@
+package rules
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestEvaluateDefaultRuleSetPrefersExplicitContentGender(t *testing.T) {
+	result := Evaluate(DefaultRuleSet(), Facts{
+		BrowseGender:  "unisex",
+		BrowseScore:   20,
+		ContentGender: "women",
+	})
+	assert.Equal(t, "women", result.Gender)
+	assert.Contains(t, result.MatchedRules, "content-gender-explicit")
+}
+
+func TestEvaluateDefaultRuleSetFallsBackToBrowseNodeOnStrongScore(t *testing.T) {
+	result := Evaluate(DefaultRuleSet(), Facts{
+		BrowseGender:   "men",
+		BrowseCategory: "Sweatshirts",
+		BrowseScore:    100,
+	})
+	assert.Equal(t, "men", result.Gender)
+	assert.Equal(t, "Sweatshirts", result.Category)
+}
+
+func TestEvaluateDefaultRuleSetFallsBackToBrowseGenderWhenNothingElseMatches(t *testing.T) {
+	result := Evaluate(DefaultRuleSet(), Facts{BrowseGender: "unisex"})
+	assert.Equal(t, "unisex", result.Gender)
+	assert.Contains(t, result.MatchedRules, "fallback-browse-gender")
+}
+
+func TestEvaluateAndOrNotCombinators(t *testing.T) {
+	rs := RuleSet{Rules: []Rule{
+		{
+			Name: "and-or-not",
+			If: Condition{And: []Condition{
+				{Or: []Condition{{ContentGender: "women"}, {ContentGender: "men"}}},
+				{Not: &Condition{Category: "Promotion"}},
+			}},
+			Then: Action{EmitGender: "matched", ConfidenceBoost: 0.2},
+		},
+	}}
+
+	result := Evaluate(rs, Facts{ContentGender: "women", BrowseCategory: "T-Shirts"})
+	assert.Equal(t, "matched", result.Gender)
+	assert.InDelta(t, 0.2, result.ConfidenceBoost, 0.0001)
+
+	result = Evaluate(rs, Facts{ContentGender: "women", BrowseCategory: "Promotion"})
+	assert.Equal(t, "", result.Gender)
+}
+
+func TestEvaluateBrowseNodePriorityComparator(t *testing.T) {
+	rs := RuleSet{Rules: []Rule{
+		{If: Condition{BrowseNodePriority: "<=2"}, Then: Action{EmitCategory: "specific"}},
+	}}
+
+	result := Evaluate(rs, Facts{BrowseNodePriority: 1})
+	assert.Equal(t, "specific", result.Category)
+
+	result = Evaluate(rs, Facts{BrowseNodePriority: 3})
+	assert.Equal(t, "", result.Category)
+}
+
+func TestGetFallsBackToDefaultForUnknownMarketplace(t *testing.T) {
+	rs := Get("NONEXISTENT")
+	assert.Equal(t, DefaultMarketplace, rs.Marketplace)
+}
+
+func TestRegisterOverridesMarketplacePack(t *testing.T) {
+	Register("UK", RuleSet{Marketplace: "UK", Rules: []Rule{
+		{If: Condition{}, Then: Action{EmitGender: "unisex"}},
+	}})
+
+	result := Evaluate(Get("UK"), Facts{})
+	assert.Equal(t, "unisex", result.Gender)
+}
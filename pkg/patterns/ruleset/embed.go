@@ -0,0 +1,15 @@
+package ruleset
+
+import _ "embed"
+
+//go:embed default.yaml
+var defaultYAML []byte
+
+// DefaultPatternSet parses the built-in rule set that mirrors
+// patterns.getGenderPatterns/getCategoryPatterns/getTagPatterns, for
+// callers that want the stock gender/category/tag rules as a PatternSet -
+// e.g. as a starting point to layer a marketplace-specific rule file on
+// top of via LoadPatternRules plus manual merging.
+func DefaultPatternSet() (*PatternSet, error) {
+	return parseYAML(defaultYAML)
+}
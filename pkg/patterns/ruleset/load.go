@@ -0,0 +1,171 @@
+package ruleset
+
+import (
+	"encoding/json"
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+
+	"gopkg.in/yaml.v3"
+)
+
+// ValidationError reports a malformed Rule found while loading a
+// PatternSet, with the rule's position in the source file when the format
+// supports it (YAML does; JSON reports the rule's name only - see
+// parseJSON).
+type ValidationError struct {
+	Line   int
+	Column int
+	Rule   string
+	Msg    string
+}
+
+// Error implements error.
+func (e *ValidationError) Error() string {
+	if e.Line > 0 {
+		return fmt.Sprintf("ruleset: %d:%d: rule %q: %s", e.Line, e.Column, e.Rule, e.Msg)
+	}
+	return fmt.Sprintf("ruleset: rule %q: %s", e.Rule, e.Msg)
+}
+
+// LoadPatternRules reads a .yaml/.yml or .json rule file and returns the
+// PatternSet it describes, after validating every rule (unknown field or
+// language, a condition with zero or more than one of contains/and/or/not
+// set, an empty dim/group, or a negative weight).
+func LoadPatternRules(path string) (*PatternSet, error) {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return nil, fmt.Errorf("ruleset: read %s: %w", path, err)
+	}
+
+	switch ext := strings.ToLower(filepath.Ext(path)); ext {
+	case ".yaml", ".yml":
+		return parseYAML(data)
+	case ".json":
+		return parseJSON(data)
+	default:
+		return nil, fmt.Errorf("ruleset: unsupported rule file extension %q (want .yaml, .yml, or .json)", ext)
+	}
+}
+
+// parseYAML parses a YAML rule file, validating every rule and reporting
+// the offending rule's line:column using the document's raw yaml.Node tree
+// (gopkg.in/yaml.v3 doesn't attach node positions to the typed PatternSet
+// returned by a direct Unmarshal).
+func parseYAML(data []byte) (*PatternSet, error) {
+	var ps PatternSet
+	if err := yaml.Unmarshal(data, &ps); err != nil {
+		return nil, fmt.Errorf("ruleset: parse yaml: %w", err)
+	}
+
+	var root yaml.Node
+	if err := yaml.Unmarshal(data, &root); err != nil {
+		return nil, fmt.Errorf("ruleset: parse yaml: %w", err)
+	}
+	ruleNodes := ruleSequenceNodes(&root)
+
+	for i, rule := range ps.Rules {
+		if err := validateRule(rule); err != nil {
+			line, col := 0, 0
+			if i < len(ruleNodes) {
+				line, col = ruleNodes[i].Line, ruleNodes[i].Column
+			}
+			return nil, &ValidationError{Line: line, Column: col, Rule: rule.Name, Msg: err.Error()}
+		}
+	}
+	return &ps, nil
+}
+
+// ruleSequenceNodes walks root to find the top-level "rules" sequence and
+// returns its item nodes, or nil if root isn't shaped as expected.
+func ruleSequenceNodes(root *yaml.Node) []*yaml.Node {
+	doc := root
+	if doc.Kind == yaml.DocumentNode && len(doc.Content) > 0 {
+		doc = doc.Content[0]
+	}
+	if doc.Kind != yaml.MappingNode {
+		return nil
+	}
+	for i := 0; i+1 < len(doc.Content); i += 2 {
+		if doc.Content[i].Value == "rules" {
+			return doc.Content[i+1].Content
+		}
+	}
+	return nil
+}
+
+// parseJSON parses a JSON rule file and validates every rule. Unlike
+// parseYAML, encoding/json doesn't retain per-value source positions, so a
+// validation error here reports the offending rule's name rather
+// than a line:column - callers that need precise positions should author
+// rules as YAML instead.
+func parseJSON(data []byte) (*PatternSet, error) {
+	var ps PatternSet
+	if err := json.Unmarshal(data, &ps); err != nil {
+		return nil, fmt.Errorf("ruleset: parse json: %w", err)
+	}
+	for _, rule := range ps.Rules {
+		if err := validateRule(rule); err != nil {
+			return nil, &ValidationError{Rule: rule.Name, Msg: err.Error()}
+		}
+	}
+	return &ps, nil
+}
+
+var allowedFields = map[string]bool{"": true, "title": true, "bullets": true, "browse_node_name": true}
+var allowedLanguages = map[string]bool{"": true, "de": true, "en": true, "both": true}
+
+// validateRule checks rule and its condition tree for the errors
+// LoadPatternRules is documented to catch.
+func validateRule(r Rule) error {
+	if r.Dim == "" {
+		return fmt.Errorf("dim is required")
+	}
+	if r.Group == "" {
+		return fmt.Errorf("group is required")
+	}
+	if r.Weight < 0 {
+		return fmt.Errorf("weight must not be negative, got %d", r.Weight)
+	}
+	return validateCondition(r.If)
+}
+
+func validateCondition(c Condition) error {
+	set := 0
+	if c.Contains != "" {
+		set++
+	}
+	if len(c.And) > 0 {
+		set++
+	}
+	if len(c.Or) > 0 {
+		set++
+	}
+	if c.Not != nil {
+		set++
+	}
+	if set != 1 {
+		return fmt.Errorf("condition must set exactly one of contains/and/or/not, found %d", set)
+	}
+	if !allowedFields[c.Field] {
+		return fmt.Errorf("unknown field %q", c.Field)
+	}
+	if !allowedLanguages[c.Language] {
+		return fmt.Errorf("unknown language %q", c.Language)
+	}
+	for _, sub := range c.And {
+		if err := validateCondition(sub); err != nil {
+			return err
+		}
+	}
+	for _, sub := range c.Or {
+		if err := validateCondition(sub); err != nil {
+			return err
+		}
+	}
+	if c.Not != nil {
+		return validateCondition(*c.Not)
+	}
+	return nil
+}
@@ -0,0 +1,133 @@
+// Package ruleset provides a data-driven pattern-matching rule format for
+// classifying free text into gender/category/tag candidates, loaded from a
+// YAML or JSON rule file instead of a hard-coded Go literal table like
+// patterns.getGenderPatterns - so a new marketplace, language, or niche
+// category can ship without a recompile of this module.
+package ruleset
+
+import (
+	"sort"
+	"strings"
+)
+
+// Condition is one node of a Rule's match condition: either a leaf pattern
+// match (Contains, optionally scoped to Field and gated by Language) or a
+// boolean combinator (And/Or/Not). Exactly one of Contains, And, Or, or Not
+// must be set; LoadPatternRules rejects a Condition with zero or more than
+// one set.
+type Condition struct {
+	// Contains is a substring to match, case-insensitively, against the
+	// text for Field.
+	Contains string `yaml:"contains,omitempty" json:"contains,omitempty"`
+
+	// Field scopes Contains to one field of the text being classified:
+	// "title", "bullets", "browse_node_name", or "" to match any field
+	// (the concatenation of every field PatternSet.ScoreFields was given).
+	Field string `yaml:"field,omitempty" json:"field,omitempty"`
+
+	// Language gates this leaf to "de", "en", or "both"/"" for either. It's
+	// metadata only, matching patterns.PatternRule.Language's existing
+	// treatment: this package doesn't detect the input's language, so
+	// Language isn't consulted during matching today, only validated.
+	Language string `yaml:"language,omitempty" json:"language,omitempty"`
+
+	And []Condition `yaml:"and,omitempty" json:"and,omitempty"`
+	Or  []Condition `yaml:"or,omitempty" json:"or,omitempty"`
+	Not *Condition  `yaml:"not,omitempty" json:"not,omitempty"`
+}
+
+// matches reports whether fields satisfies c.
+func (c Condition) matches(fields Fields) bool {
+	if len(c.And) > 0 {
+		for _, sub := range c.And {
+			if !sub.matches(fields) {
+				return false
+			}
+		}
+		return true
+	}
+	if len(c.Or) > 0 {
+		for _, sub := range c.Or {
+			if sub.matches(fields) {
+				return true
+			}
+		}
+		return false
+	}
+	if c.Not != nil {
+		return !c.Not.matches(fields)
+	}
+	text := strings.ToLower(fields.Get(c.Field))
+	return c.Contains != "" && strings.Contains(text, strings.ToLower(c.Contains))
+}
+
+// Rule is one weighted pattern rule: if If matches, Group contributes
+// Weight to Dim's candidate scores (e.g. Dim "gender", Group "men").
+type Rule struct {
+	Name   string    `yaml:"name,omitempty" json:"name,omitempty"`
+	Dim    string    `yaml:"dim" json:"dim"`
+	Group  string    `yaml:"group" json:"group"`
+	If     Condition `yaml:"if" json:"if"`
+	Weight int       `yaml:"weight" json:"weight"`
+}
+
+// PatternSet is an ordered collection of Rules spanning one or more
+// dimensions (e.g. "gender", "category", "tag"), as produced by
+// LoadPatternRules or DefaultPatternSet.
+type PatternSet struct {
+	Rules []Rule `yaml:"rules" json:"rules"`
+}
+
+// Fields is the per-field text a PatternSet is scored against: e.g.
+// {"title": "...", "bullets": "...", "browse_node_name": "..."}.
+type Fields map[string]string
+
+// Get returns fields[field], or the space-joined concatenation of every
+// field (in sorted key order, for determinism) when field is "".
+func (f Fields) Get(field string) string {
+	if field != "" {
+		return f[field]
+	}
+	keys := make([]string, 0, len(f))
+	for k := range f {
+		keys = append(keys, k)
+	}
+	sort.Strings(keys)
+	parts := make([]string, len(keys))
+	for i, k := range keys {
+		parts[i] = f[k]
+	}
+	return strings.Join(parts, " ")
+}
+
+// Score is a single-field convenience wrapper over ScoreFields, for callers
+// that don't need Condition.Field targeting (every rule's Field must be ""
+// to match here, since there's only one field to check against). Callers
+// using Field-scoped rules should use ScoreFields instead. Errors are
+// swallowed, returning an empty map, matching patterns.DetectCategory's
+// existing "best effort" error handling.
+func (ps *PatternSet) Score(text string, dim string) map[string]int {
+	scores, err := ps.ScoreFields(Fields{"": text}, dim)
+	if err != nil {
+		return map[string]int{}
+	}
+	return scores
+}
+
+// ScoreFields returns, for every Rule in dim whose If matches fields, the
+// sum of Weight per Group - e.g. {"men": 18}; a Group with no matching
+// rule is absent - so a caller can
+// pick the highest-scoring candidate the same way patterns.DetectGender
+// does over its Bleve-backed index.
+func (ps *PatternSet) ScoreFields(fields Fields, dim string) (map[string]int, error) {
+	scores := make(map[string]int)
+	for _, r := range ps.Rules {
+		if r.Dim != dim {
+			continue
+		}
+		if r.If.matches(fields) {
+			scores[r.Group] += r.Weight
+		}
+	}
+	return scores, nil
+}
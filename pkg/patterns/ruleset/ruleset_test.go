@@ -0,0 +1,151 @@
+package ruleset
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestConditionMatchesAndOrNot(t *testing.T) {
+	fields := Fields{"title": "Herren Hoodie Basic"}
+
+	and := Condition{And: []Condition{{Contains: "herren"}, {Contains: "hoodie"}}}
+	assert.True(t, and.matches(fields))
+
+	or := Condition{Or: []Condition{{Contains: "damen"}, {Contains: "hoodie"}}}
+	assert.True(t, or.matches(fields))
+
+	not := Condition{Not: &Condition{Contains: "damen"}}
+	assert.True(t, not.matches(fields))
+}
+
+func TestConditionMatchesScopesToField(t *testing.T) {
+	fields := Fields{"title": "Herren Hoodie", "bullets": "waschbar bei 30 grad"}
+
+	c := Condition{Field: "bullets", Contains: "herren"}
+	assert.False(t, c.matches(fields), "herren only appears in title, not bullets")
+
+	c2 := Condition{Field: "title", Contains: "herren"}
+	assert.True(t, c2.matches(fields))
+}
+
+func TestPatternSetScoreFieldsSumsWeightPerGroup(t *testing.T) {
+	ps := &PatternSet{Rules: []Rule{
+		{Dim: "gender", Group: "men", Weight: 10, If: Condition{Contains: "herren"}},
+		{Dim: "gender", Group: "men", Weight: 8, If: Condition{Contains: "guy"}},
+		{Dim: "gender", Group: "women", Weight: 10, If: Condition{Contains: "damen"}},
+		{Dim: "tag", Group: "sport", Weight: 5, If: Condition{Contains: "sport"}},
+	}}
+
+	scores, err := ps.ScoreFields(Fields{"title": "Herren Sport Hoodie"}, "gender")
+	require.NoError(t, err)
+	assert.Equal(t, map[string]int{"men": 10}, scores)
+}
+
+func TestPatternSetScoreIsSingleFieldConvenience(t *testing.T) {
+	ps := &PatternSet{Rules: []Rule{
+		{Dim: "gender", Group: "men", Weight: 10, If: Condition{Contains: "herren"}},
+	}}
+
+	scores := ps.Score("Herren T-Shirt", "gender")
+	assert.Equal(t, 10, scores["men"])
+}
+
+func TestValidateRuleRejectsMissingDimOrGroup(t *testing.T) {
+	err := validateRule(Rule{Group: "men", If: Condition{Contains: "herren"}, Weight: 1})
+	assert.ErrorContains(t, err, "dim is required")
+
+	err = validateRule(Rule{Dim: "gender", If: Condition{Contains: "herren"}, Weight: 1})
+	assert.ErrorContains(t, err, "group is required")
+}
+
+func TestValidateRuleRejectsNegativeWeight(t *testing.T) {
+	err := validateRule(Rule{Dim: "gender", Group: "men", Weight: -1, If: Condition{Contains: "herren"}})
+	assert.ErrorContains(t, err, "negative")
+}
+
+func TestValidateConditionRejectsAmbiguousOperator(t *testing.T) {
+	err := validateCondition(Condition{})
+	assert.ErrorContains(t, err, "exactly one")
+
+	err = validateCondition(Condition{Contains: "x", Or: []Condition{{Contains: "y"}}})
+	assert.ErrorContains(t, err, "exactly one")
+}
+
+func TestValidateConditionRejectsUnknownFieldOrLanguage(t *testing.T) {
+	err := validateCondition(Condition{Contains: "x", Field: "description"})
+	assert.ErrorContains(t, err, "unknown field")
+
+	err = validateCondition(Condition{Contains: "x", Language: "fr"})
+	assert.ErrorContains(t, err, "unknown language")
+}
+
+func TestLoadPatternRulesYAML(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "rules.yaml")
+	require.NoError(t, os.WriteFile(path, []byte(`
+rules:
+  - name: men-core
+    dim: gender
+    group: men
+    weight: 10
+    if:
+      contains: herren
+`), 0o644))
+
+	ps, err := LoadPatternRules(path)
+	require.NoError(t, err)
+	require.Len(t, ps.Rules, 1)
+	assert.Equal(t, "men", ps.Rules[0].Group)
+}
+
+func TestLoadPatternRulesYAMLReportsLineOnValidationError(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "rules.yaml")
+	require.NoError(t, os.WriteFile(path, []byte(`
+rules:
+  - name: bad-rule
+    dim: gender
+    group: men
+    weight: -1
+    if:
+      contains: herren
+`), 0o644))
+
+	_, err := LoadPatternRules(path)
+	require.Error(t, err)
+	var verr *ValidationError
+	require.ErrorAs(t, err, &verr)
+	assert.Equal(t, "bad-rule", verr.Rule)
+	assert.Greater(t, verr.Line, 0)
+}
+
+func TestLoadPatternRulesJSON(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "rules.json")
+	require.NoError(t, os.WriteFile(path, []byte(`{"rules":[
+		{"name":"men-core","dim":"gender","group":"men","weight":10,"if":{"contains":"herren"}}
+	]}`), 0o644))
+
+	ps, err := LoadPatternRules(path)
+	require.NoError(t, err)
+	require.Len(t, ps.Rules, 1)
+	assert.Equal(t, "men", ps.Rules[0].Group)
+}
+
+func TestLoadPatternRulesRejectsUnsupportedExtension(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "rules.txt")
+	require.NoError(t, os.WriteFile(path, []byte("rules: []"), 0o644))
+
+	_, err := LoadPatternRules(path)
+	assert.ErrorContains(t, err, "unsupported rule file extension")
+}
+
+func TestDefaultPatternSetReproducesBuiltInGenderScoring(t *testing.T) {
+	ps, err := DefaultPatternSet()
+	require.NoError(t, err)
+
+	scores := ps.Score("Herren Poloshirt", "gender")
+	assert.Equal(t, 10, scores["men"])
+	assert.Equal(t, 0, scores["women"])
+}
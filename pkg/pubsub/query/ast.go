@@ -0,0 +1,268 @@
+package query
+
+import (
+	"encoding/json"
+	"fmt"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/MalteBoehm/tall-affiliate-common/pkg/events"
+)
+
+// expr is a node in a parsed query's predicate tree.
+type expr interface {
+	eval(ctx *evalContext) (bool, error)
+}
+
+// evalContext resolves attribute paths against a single event, memoizing
+// the payload's decode into a map[string]any since a query may reference
+// several payload.* paths against the same event.
+type evalContext struct {
+	event *events.Event
+
+	payloadDone bool
+	payload     map[string]any
+	payloadErr  error
+}
+
+func (c *evalContext) payloadMap() (map[string]any, error) {
+	if !c.payloadDone {
+		c.payloadDone = true
+		data, err := json.Marshal(c.event.Payload)
+		if err != nil {
+			c.payloadErr = fmt.Errorf("query: encode payload: %w", err)
+		} else {
+			var m map[string]any
+			if err := json.Unmarshal(data, &m); err != nil {
+				c.payloadErr = fmt.Errorf("query: payload is not a JSON object: %w", err)
+			} else {
+				c.payload = m
+			}
+		}
+	}
+	return c.payload, c.payloadErr
+}
+
+// resolve looks up path under one of the three supported namespaces
+// (event, metadata, payload), returning ok=false for an absent field
+// rather than an error.
+func (c *evalContext) resolve(path string) (value any, ok bool, err error) {
+	namespace, rest, found := strings.Cut(path, ".")
+	if !found {
+		return nil, false, fmt.Errorf("query: path %q has no namespace (expected event./metadata./payload.)", path)
+	}
+
+	switch namespace {
+	case "event":
+		switch rest {
+		case "type":
+			return c.event.Type, true, nil
+		case "aggregate_type":
+			return c.event.AggregateType, true, nil
+		case "aggregate_id":
+			return c.event.AggregateID, true, nil
+		case "id":
+			return c.event.ID, true, nil
+		case "timestamp":
+			return c.event.Timestamp, true, nil
+		default:
+			return nil, false, nil
+		}
+	case "metadata":
+		v, ok := c.event.Metadata[rest]
+		return v, ok, nil
+	case "payload":
+		payload, err := c.payloadMap()
+		if err != nil {
+			return nil, false, err
+		}
+		return walkPath(payload, strings.Split(rest, "."))
+	default:
+		return nil, false, fmt.Errorf("query: unknown namespace %q (expected event/metadata/payload)", namespace)
+	}
+}
+
+// walkPath descends into nested map[string]any values following segments,
+// the dotted-path evaluation payload.* expressions need.
+func walkPath(m map[string]any, segments []string) (any, bool, error) {
+	var current any = m
+	for _, seg := range segments {
+		asMap, ok := current.(map[string]any)
+		if !ok {
+			return nil, false, nil
+		}
+		current, ok = asMap[seg]
+		if !ok {
+			return nil, false, nil
+		}
+	}
+	return current, true, nil
+}
+
+// literal is a parsed right-hand-side value: a quoted string or a bare
+// number.
+type literal struct {
+	raw      string
+	isString bool
+}
+
+// andExpr is AND with short-circuit evaluation: right is never evaluated
+// once left is false.
+type andExpr struct{ left, right expr }
+
+func (e *andExpr) eval(ctx *evalContext) (bool, error) {
+	ok, err := e.left.eval(ctx)
+	if err != nil || !ok {
+		return false, err
+	}
+	return e.right.eval(ctx)
+}
+
+// orExpr is OR with short-circuit evaluation: right is never evaluated
+// once left is true.
+type orExpr struct{ left, right expr }
+
+func (e *orExpr) eval(ctx *evalContext) (bool, error) {
+	ok, err := e.left.eval(ctx)
+	if err != nil || ok {
+		return ok, err
+	}
+	return e.right.eval(ctx)
+}
+
+// existsExpr matches when path resolves to any value, including a falsy
+// one such as an empty string or zero.
+type existsExpr struct{ path string }
+
+func (e *existsExpr) eval(ctx *evalContext) (bool, error) {
+	_, ok, err := ctx.resolve(e.path)
+	return ok, err
+}
+
+// comparisonExpr matches when path's value satisfies op against value. A
+// path that does not resolve never matches, rather than erroring.
+type comparisonExpr struct {
+	path  string
+	op    tokenKind
+	value literal
+}
+
+func (e *comparisonExpr) eval(ctx *evalContext) (bool, error) {
+	left, ok, err := ctx.resolve(e.path)
+	if err != nil || !ok {
+		return false, err
+	}
+	return compareValues(left, e.op, e.value)
+}
+
+func compareValues(left any, op tokenKind, value literal) (bool, error) {
+	if op == tokContains {
+		return evalContains(left, value)
+	}
+
+	if t, ok := left.(time.Time); ok {
+		return compareTimestamp(t, op, value.raw)
+	}
+
+	if !value.isString {
+		if lf, ok := toFloat(left); ok {
+			rf, err := strconv.ParseFloat(value.raw, 64)
+			if err != nil {
+				return false, fmt.Errorf("query: invalid numeric literal %q", value.raw)
+			}
+			return compareNumeric(op, lf, rf), nil
+		}
+	}
+
+	ls := fmt.Sprint(left)
+	switch op {
+	case tokEq:
+		return ls == value.raw, nil
+	case tokNeq:
+		return ls != value.raw, nil
+	default:
+		return false, fmt.Errorf("query: cannot order-compare non-numeric value %v", left)
+	}
+}
+
+func compareTimestamp(t time.Time, op tokenKind, raw string) (bool, error) {
+	rt, err := time.Parse(time.RFC3339Nano, raw)
+	if err != nil {
+		return false, fmt.Errorf("query: invalid RFC3339 timestamp literal %q: %w", raw, err)
+	}
+	switch op {
+	case tokEq:
+		return t.Equal(rt), nil
+	case tokNeq:
+		return !t.Equal(rt), nil
+	case tokLt:
+		return t.Before(rt), nil
+	case tokLte:
+		return t.Before(rt) || t.Equal(rt), nil
+	case tokGt:
+		return t.After(rt), nil
+	case tokGte:
+		return t.After(rt) || t.Equal(rt), nil
+	default:
+		return false, fmt.Errorf("query: unsupported operator for a timestamp field")
+	}
+}
+
+func compareNumeric(op tokenKind, l, r float64) bool {
+	switch op {
+	case tokEq:
+		return l == r
+	case tokNeq:
+		return l != r
+	case tokLt:
+		return l < r
+	case tokLte:
+		return l <= r
+	case tokGt:
+		return l > r
+	case tokGte:
+		return l >= r
+	default:
+		return false
+	}
+}
+
+// toFloat coerces a decoded attribute value to float64, covering the
+// numeric types json.Unmarshal and Event fields can produce.
+func toFloat(v any) (float64, bool) {
+	switch n := v.(type) {
+	case float64:
+		return n, true
+	case float32:
+		return float64(n), true
+	case int:
+		return float64(n), true
+	case int64:
+		return float64(n), true
+	case json.Number:
+		f, err := n.Float64()
+		return f, err == nil
+	case string:
+		f, err := strconv.ParseFloat(n, 64)
+		return f, err == nil
+	default:
+		return 0, false
+	}
+}
+
+func evalContains(left any, value literal) (bool, error) {
+	switch v := left.(type) {
+	case string:
+		return strings.Contains(v, value.raw), nil
+	case []any:
+		for _, item := range v {
+			if fmt.Sprint(item) == value.raw {
+				return true, nil
+			}
+		}
+		return false, nil
+	default:
+		return false, fmt.Errorf("query: CONTAINS is not supported for %T", left)
+	}
+}
@@ -0,0 +1,136 @@
+package query
+
+import (
+	"fmt"
+	"strings"
+	"unicode"
+)
+
+type tokenKind int
+
+const (
+	tokEOF tokenKind = iota
+	tokIdent
+	tokString
+	tokNumber
+	tokEq
+	tokNeq
+	tokLt
+	tokLte
+	tokGt
+	tokGte
+	tokAnd
+	tokOr
+	tokContains
+	tokExists
+	tokLParen
+	tokRParen
+)
+
+type token struct {
+	kind tokenKind
+	text string
+}
+
+// lex tokenizes a query string. Identifiers may contain dots (so
+// "payload.priority" lexes as a single tokIdent), string literals are
+// single-quoted, and numbers are plain decimal literals.
+func lex(input string) ([]token, error) {
+	var tokens []token
+	runes := []rune(input)
+	i := 0
+	for i < len(runes) {
+		r := runes[i]
+		switch {
+		case unicode.IsSpace(r):
+			i++
+		case r == '(':
+			tokens = append(tokens, token{kind: tokLParen})
+			i++
+		case r == ')':
+			tokens = append(tokens, token{kind: tokRParen})
+			i++
+		case r == '\'':
+			j := i + 1
+			for j < len(runes) && runes[j] != '\'' {
+				j++
+			}
+			if j >= len(runes) {
+				return nil, fmt.Errorf("query: unterminated string literal starting at position %d", i)
+			}
+			tokens = append(tokens, token{kind: tokString, text: string(runes[i+1 : j])})
+			i = j + 1
+		case r == '!':
+			if i+1 < len(runes) && runes[i+1] == '=' {
+				tokens = append(tokens, token{kind: tokNeq})
+				i += 2
+				continue
+			}
+			return nil, fmt.Errorf("query: unexpected '!' at position %d", i)
+		case r == '=':
+			tokens = append(tokens, token{kind: tokEq})
+			i++
+		case r == '<':
+			if i+1 < len(runes) && runes[i+1] == '=' {
+				tokens = append(tokens, token{kind: tokLte})
+				i += 2
+				continue
+			}
+			tokens = append(tokens, token{kind: tokLt})
+			i++
+		case r == '>':
+			if i+1 < len(runes) && runes[i+1] == '=' {
+				tokens = append(tokens, token{kind: tokGte})
+				i += 2
+				continue
+			}
+			tokens = append(tokens, token{kind: tokGt})
+			i++
+		case isNumberStart(r):
+			j := i + 1
+			for j < len(runes) && (unicode.IsDigit(runes[j]) || runes[j] == '.') {
+				j++
+			}
+			tokens = append(tokens, token{kind: tokNumber, text: string(runes[i:j])})
+			i = j
+		case isIdentStart(r):
+			j := i + 1
+			for j < len(runes) && isIdentPart(runes[j]) {
+				j++
+			}
+			word := string(runes[i:j])
+			tokens = append(tokens, keywordOrIdent(word))
+			i = j
+		default:
+			return nil, fmt.Errorf("query: unexpected character %q at position %d", r, i)
+		}
+	}
+	return append(tokens, token{kind: tokEOF}), nil
+}
+
+func keywordOrIdent(word string) token {
+	switch strings.ToUpper(word) {
+	case "AND":
+		return token{kind: tokAnd}
+	case "OR":
+		return token{kind: tokOr}
+	case "CONTAINS":
+		return token{kind: tokContains}
+	case "EXISTS":
+		return token{kind: tokExists}
+	default:
+		return token{kind: tokIdent, text: word}
+	}
+}
+
+func isNumberStart(r rune) bool {
+	return unicode.IsDigit(r) || r == '-'
+}
+
+func isIdentStart(r rune) bool {
+	return unicode.IsLetter(r) || r == '_'
+}
+
+func isIdentPart(r rune) bool {
+	return unicode.IsLetter(r) || unicode.IsDigit(r) || r == '_' || r == '.'
+}
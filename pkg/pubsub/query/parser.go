@@ -0,0 +1,100 @@
+package query
+
+import "fmt"
+
+type parser struct {
+	tokens []token
+	pos    int
+}
+
+func (p *parser) peek() token { return p.tokens[p.pos] }
+
+func (p *parser) next() token {
+	t := p.tokens[p.pos]
+	if p.pos < len(p.tokens)-1 {
+		p.pos++
+	}
+	return t
+}
+
+func (p *parser) expect(kind tokenKind) (token, error) {
+	if p.peek().kind != kind {
+		return token{}, fmt.Errorf("query: unexpected token near %q", p.peek().text)
+	}
+	return p.next(), nil
+}
+
+// parseOr parses the lowest-precedence level: a chain of AND-expressions
+// joined by OR.
+func (p *parser) parseOr() (expr, error) {
+	left, err := p.parseAnd()
+	if err != nil {
+		return nil, err
+	}
+	for p.peek().kind == tokOr {
+		p.next()
+		right, err := p.parseAnd()
+		if err != nil {
+			return nil, err
+		}
+		left = &orExpr{left: left, right: right}
+	}
+	return left, nil
+}
+
+// parseAnd parses a chain of terms joined by AND, binding tighter than OR.
+func (p *parser) parseAnd() (expr, error) {
+	left, err := p.parseTerm()
+	if err != nil {
+		return nil, err
+	}
+	for p.peek().kind == tokAnd {
+		p.next()
+		right, err := p.parseTerm()
+		if err != nil {
+			return nil, err
+		}
+		left = &andExpr{left: left, right: right}
+	}
+	return left, nil
+}
+
+// parseTerm parses a parenthesized sub-expression, an EXISTS predicate, or
+// a comparison.
+func (p *parser) parseTerm() (expr, error) {
+	if p.peek().kind == tokLParen {
+		p.next()
+		e, err := p.parseOr()
+		if err != nil {
+			return nil, err
+		}
+		if _, err := p.expect(tokRParen); err != nil {
+			return nil, err
+		}
+		return e, nil
+	}
+
+	pathTok, err := p.expect(tokIdent)
+	if err != nil {
+		return nil, fmt.Errorf("query: expected an attribute path, got %q", p.peek().text)
+	}
+
+	switch p.peek().kind {
+	case tokExists:
+		p.next()
+		return &existsExpr{path: pathTok.text}, nil
+	case tokEq, tokNeq, tokLt, tokLte, tokGt, tokGte, tokContains:
+		opTok := p.next()
+		valTok := p.next()
+		if valTok.kind != tokString && valTok.kind != tokNumber {
+			return nil, fmt.Errorf("query: expected a string or number after operator, got %q", valTok.text)
+		}
+		return &comparisonExpr{
+			path:  pathTok.text,
+			op:    opTok.kind,
+			value: literal{raw: valTok.text, isString: valTok.kind == tokString},
+		}, nil
+	default:
+		return nil, fmt.Errorf("query: expected an operator or EXISTS after %q", pathTok.text)
+	}
+}
@@ -0,0 +1,73 @@
+// Package query implements a small tag-query language for filtering
+// events.Event values, in the spirit of Tendermint's pubsub query
+// language: strings like
+//
+//	event.type='CONTENT_GENERATED' AND payload.priority>3
+//
+// are parsed into a predicate tree and evaluated against an Event without
+// hand-rolled if/else filtering at each call site. Three attribute
+// namespaces are supported:
+//
+//   - event.*    - Type, AggregateType, AggregateID, ID, Timestamp
+//   - metadata.* - Event.Metadata, a string-keyed map
+//   - payload.*  - Event.Payload decoded as map[string]any, with dotted
+//     paths (payload.address.city) walking nested objects
+//
+// Supported operators are =, !=, <, <=, >, >=, CONTAINS, and EXISTS, and
+// predicates combine with AND/OR (AND binds tighter) and parentheses.
+package query
+
+import "github.com/MalteBoehm/tall-affiliate-common/pkg/events"
+
+// Query is a parsed predicate, safe for concurrent use by Matches once
+// parsing completes.
+type Query struct {
+	expr expr
+}
+
+// Parse parses s into a Query. An empty or all-whitespace s parses to a
+// Query that matches every event.
+func Parse(s string) (*Query, error) {
+	tokens, err := lex(s)
+	if err != nil {
+		return nil, err
+	}
+	if len(tokens) == 1 { // just tokEOF: empty input
+		return &Query{}, nil
+	}
+
+	p := &parser{tokens: tokens}
+	e, err := p.parseOr()
+	if err != nil {
+		return nil, err
+	}
+	if p.peek().kind != tokEOF {
+		return nil, &parseError{near: p.peek().text}
+	}
+	return &Query{expr: e}, nil
+}
+
+// MustParse is like Parse but panics on error, for tests and static
+// query strings known to be valid at compile time.
+func MustParse(s string) *Query {
+	q, err := Parse(s)
+	if err != nil {
+		panic(err)
+	}
+	return q
+}
+
+// Matches reports whether e satisfies q. A nil Query, or one parsed from
+// an empty string, matches every event.
+func (q *Query) Matches(e *events.Event) (bool, error) {
+	if q == nil || q.expr == nil {
+		return true, nil
+	}
+	return q.expr.eval(&evalContext{event: e})
+}
+
+type parseError struct{ near string }
+
+func (e *parseError) Error() string {
+	return "query: unexpected trailing input near \"" + e.near + "\""
+}
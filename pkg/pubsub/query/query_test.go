@@ -0,0 +1,169 @@
+package query
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/MalteBoehm/tall-affiliate-common/pkg/events"
+)
+
+func testEvent() *events.Event {
+	return &events.Event{
+		ID:            "evt-1",
+		Type:          "CONTENT_GENERATED",
+		AggregateType: "content",
+		AggregateID:   "asin-1",
+		Timestamp:     time.Date(2026, 1, 2, 3, 4, 5, 0, time.UTC),
+		Metadata:      map[string]any{"tenant": "acme"},
+		Payload: map[string]any{
+			"priority": 5,
+			"title":    "a long product title",
+			"tags":     []any{"red", "blue"},
+			"address":  map[string]any{"city": "Berlin"},
+		},
+	}
+}
+
+func TestParseEmptyMatchesEverything(t *testing.T) {
+	q, err := Parse("")
+	require.NoError(t, err)
+	matched, err := q.Matches(testEvent())
+	require.NoError(t, err)
+	assert.True(t, matched)
+}
+
+func TestEventNamespaceComparison(t *testing.T) {
+	q := MustParse("event.type='CONTENT_GENERATED' AND event.aggregate_type='content'")
+	matched, err := q.Matches(testEvent())
+	require.NoError(t, err)
+	assert.True(t, matched)
+
+	q = MustParse("event.type='OTHER'")
+	matched, err = q.Matches(testEvent())
+	require.NoError(t, err)
+	assert.False(t, matched)
+}
+
+func TestNumericCoercion(t *testing.T) {
+	for _, tc := range []struct {
+		query string
+		want  bool
+	}{
+		{"payload.priority>3", true},
+		{"payload.priority>=5", true},
+		{"payload.priority<5", false},
+		{"payload.priority!=5", false},
+		{"payload.priority=5", true},
+	} {
+		q := MustParse(tc.query)
+		matched, err := q.Matches(testEvent())
+		require.NoError(t, err, tc.query)
+		assert.Equal(t, tc.want, matched, tc.query)
+	}
+}
+
+func TestTimestampComparisonRFC3339(t *testing.T) {
+	q := MustParse("event.timestamp>'2026-01-01T00:00:00Z'")
+	matched, err := q.Matches(testEvent())
+	require.NoError(t, err)
+	assert.True(t, matched)
+
+	q = MustParse("event.timestamp<'2026-01-01T00:00:00Z'")
+	matched, err = q.Matches(testEvent())
+	require.NoError(t, err)
+	assert.False(t, matched)
+}
+
+func TestMetadataAndNestedPayloadPath(t *testing.T) {
+	q := MustParse("metadata.tenant='acme' AND payload.address.city='Berlin'")
+	matched, err := q.Matches(testEvent())
+	require.NoError(t, err)
+	assert.True(t, matched)
+}
+
+func TestContainsOnStringAndSlice(t *testing.T) {
+	q := MustParse("payload.title CONTAINS 'product'")
+	matched, err := q.Matches(testEvent())
+	require.NoError(t, err)
+	assert.True(t, matched)
+
+	q = MustParse("payload.tags CONTAINS 'blue'")
+	matched, err = q.Matches(testEvent())
+	require.NoError(t, err)
+	assert.True(t, matched)
+
+	q = MustParse("payload.tags CONTAINS 'green'")
+	matched, err = q.Matches(testEvent())
+	require.NoError(t, err)
+	assert.False(t, matched)
+}
+
+func TestExists(t *testing.T) {
+	q := MustParse("metadata.tenant EXISTS")
+	matched, err := q.Matches(testEvent())
+	require.NoError(t, err)
+	assert.True(t, matched)
+
+	q = MustParse("metadata.missing EXISTS")
+	matched, err = q.Matches(testEvent())
+	require.NoError(t, err)
+	assert.False(t, matched)
+}
+
+func TestOrAndParentheses(t *testing.T) {
+	q := MustParse("event.type='OTHER' OR (payload.priority>3 AND metadata.tenant='acme')")
+	matched, err := q.Matches(testEvent())
+	require.NoError(t, err)
+	assert.True(t, matched)
+}
+
+// shortCircuitExpr records whether it was evaluated, to verify AND/OR
+// never evaluate a right-hand side once the outcome is already decided.
+type shortCircuitExpr struct {
+	called *bool
+	result bool
+}
+
+func (e *shortCircuitExpr) eval(ctx *evalContext) (bool, error) {
+	*e.called = true
+	return e.result, nil
+}
+
+func TestAndShortCircuits(t *testing.T) {
+	var rightCalled bool
+	e := &andExpr{
+		left:  &shortCircuitExpr{called: new(bool), result: false},
+		right: &shortCircuitExpr{called: &rightCalled, result: true},
+	}
+	matched, err := e.eval(&evalContext{event: testEvent()})
+	require.NoError(t, err)
+	assert.False(t, matched)
+	assert.False(t, rightCalled, "AND must not evaluate its right side once the left side is false")
+}
+
+func TestOrShortCircuits(t *testing.T) {
+	var rightCalled bool
+	e := &orExpr{
+		left:  &shortCircuitExpr{called: new(bool), result: true},
+		right: &shortCircuitExpr{called: &rightCalled, result: false},
+	}
+	matched, err := e.eval(&evalContext{event: testEvent()})
+	require.NoError(t, err)
+	assert.True(t, matched)
+	assert.False(t, rightCalled, "OR must not evaluate its right side once the left side is true")
+}
+
+func TestMissingFieldDoesNotMatch(t *testing.T) {
+	q := MustParse("payload.missing='x'")
+	matched, err := q.Matches(testEvent())
+	require.NoError(t, err)
+	assert.False(t, matched)
+}
+
+func TestParseInvalidSyntax(t *testing.T) {
+	_, err := Parse("event.type AND AND")
+	assert.Error(t, err)
+}
@@ -0,0 +1,292 @@
+// Package admin provides higher-level introspection over Redis streams
+// than pkg/redis.StreamProducer.StreamInfo's direct XINFO STREAM wrapper,
+// modeled on the kind of describe-cluster/describe-consumer-groups
+// tooling Kafka operators are used to: per-group lag and pending summaries,
+// multi-stream overviews via SCAN, cluster topology, and full XPENDING
+// detail for a specific consumer.
+package admin
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/redis/go-redis/v9"
+)
+
+// StreamAdmin answers introspection queries against a Redis deployment's
+// streams, for operators diagnosing stuck consumers or checking cluster
+// health without ad-hoc redis-cli sessions.
+type StreamAdmin struct {
+	client *redis.Client
+}
+
+// NewStreamAdmin creates a StreamAdmin backed by client.
+func NewStreamAdmin(client *redis.Client) *StreamAdmin {
+	return &StreamAdmin{client: client}
+}
+
+// GroupDescription summarizes one consumer group on a stream: its
+// consumers, how many messages are pending, how old the oldest and
+// newest pending messages are, and how far behind the stream's tail the
+// group's last-delivered entry is (Lag, as reported by Redis itself).
+type GroupDescription struct {
+	Name            string
+	Consumers       []ConsumerDescription
+	Pending         int64
+	MinPendingIdle  time.Duration
+	MaxPendingIdle  time.Duration
+	LastDeliveredID string
+	Lag             int64
+}
+
+// ConsumerDescription summarizes one consumer within a group.
+type ConsumerDescription struct {
+	Name     string
+	Pending  int64
+	Idle     time.Duration
+	Inactive time.Duration
+}
+
+// DescribeConsumerGroups returns a GroupDescription for every consumer
+// group registered on stream, in the order XINFO GROUPS reports them.
+func (a *StreamAdmin) DescribeConsumerGroups(ctx context.Context, stream string) ([]GroupDescription, error) {
+	groups, err := a.client.XInfoGroups(ctx, stream).Result()
+	if err != nil {
+		return nil, fmt.Errorf("failed to describe consumer groups for stream %s: %w", stream, err)
+	}
+
+	descriptions := make([]GroupDescription, 0, len(groups))
+	for _, g := range groups {
+		consumers, err := a.client.XInfoConsumers(ctx, stream, g.Name).Result()
+		if err != nil {
+			return nil, fmt.Errorf("failed to describe consumers for group %s on stream %s: %w", g.Name, stream, err)
+		}
+
+		desc := GroupDescription{
+			Name:            g.Name,
+			Pending:         g.Pending,
+			LastDeliveredID: g.LastDeliveredID,
+			Lag:             g.Lag,
+			Consumers:       make([]ConsumerDescription, 0, len(consumers)),
+		}
+		pending, err := a.PendingMessages(ctx, stream, g.Name, "", g.Pending)
+		if err != nil {
+			return nil, err
+		}
+		for i, p := range pending {
+			if i == 0 || p.Idle < desc.MinPendingIdle {
+				desc.MinPendingIdle = p.Idle
+			}
+			if p.Idle > desc.MaxPendingIdle {
+				desc.MaxPendingIdle = p.Idle
+			}
+		}
+		for _, c := range consumers {
+			desc.Consumers = append(desc.Consumers, ConsumerDescription{
+				Name:     c.Name,
+				Pending:  c.Pending,
+				Idle:     c.Idle,
+				Inactive: c.Inactive,
+			})
+		}
+		descriptions = append(descriptions, desc)
+	}
+	return descriptions, nil
+}
+
+// StreamDescription summarizes one stream: its length, first/last entry
+// IDs, and its consumer groups (via DescribeConsumerGroups).
+type StreamDescription struct {
+	Name    string
+	Length  int64
+	FirstID string
+	LastID  string
+	Groups  []GroupDescription
+}
+
+// DescribeStreams matches patterns against keys via SCAN (a pattern with
+// no glob characters is matched as a literal stream name) and returns a
+// StreamDescription for each match that is actually a stream. Streams
+// with no consumer groups get an empty Groups slice, not an error.
+func (a *StreamAdmin) DescribeStreams(ctx context.Context, patterns ...string) ([]StreamDescription, error) {
+	names, err := a.matchStreamNames(ctx, patterns)
+	if err != nil {
+		return nil, err
+	}
+
+	descriptions := make([]StreamDescription, 0, len(names))
+	for _, name := range names {
+		info, err := a.client.XInfoStream(ctx, name).Result()
+		if err != nil {
+			return nil, fmt.Errorf("failed to describe stream %s: %w", name, err)
+		}
+
+		groups, err := a.DescribeConsumerGroups(ctx, name)
+		if err != nil {
+			return nil, err
+		}
+
+		descriptions = append(descriptions, StreamDescription{
+			Name:    name,
+			Length:  info.Length,
+			FirstID: info.FirstEntry.ID,
+			LastID:  info.LastEntry.ID,
+			Groups:  groups,
+		})
+	}
+	return descriptions, nil
+}
+
+// matchStreamNames resolves patterns to concrete key names via SCAN,
+// deduplicating across overlapping patterns and preserving first-seen
+// order.
+func (a *StreamAdmin) matchStreamNames(ctx context.Context, patterns []string) ([]string, error) {
+	seen := make(map[string]bool)
+	var names []string
+	for _, pattern := range patterns {
+		var cursor uint64
+		for {
+			keys, next, err := a.client.ScanType(ctx, cursor, pattern, 100, "stream").Result()
+			if err != nil {
+				return nil, fmt.Errorf("failed to scan for pattern %s: %w", pattern, err)
+			}
+			for _, key := range keys {
+				if seen[key] {
+					continue
+				}
+				seen[key] = true
+				names = append(names, key)
+			}
+			cursor = next
+			if cursor == 0 {
+				break
+			}
+		}
+	}
+	return names, nil
+}
+
+// ClusterNode describes one node in a Redis cluster's topology.
+type ClusterNode struct {
+	ID    string
+	Addr  string
+	Role  string
+	Slots []string
+}
+
+// ClusterDescription is the result of ClusterInfo.
+type ClusterDescription struct {
+	State      string
+	KnownNodes int
+	Nodes      []ClusterNode
+}
+
+// ClusterInfo reports the cluster's state (via CLUSTER INFO) and node
+// topology with slot ranges (via CLUSTER SHARDS), for deployments running
+// Redis Cluster. It returns an error if the server isn't running in
+// cluster mode.
+func (a *StreamAdmin) ClusterInfo(ctx context.Context) (*ClusterDescription, error) {
+	infoText, err := a.client.ClusterInfo(ctx).Result()
+	if err != nil {
+		return nil, fmt.Errorf("failed to fetch cluster info: %w", err)
+	}
+
+	desc := &ClusterDescription{State: parseClusterInfoField(infoText, "cluster_state")}
+	if n, err := fmt.Sscanf(parseClusterInfoField(infoText, "cluster_known_nodes"), "%d", &desc.KnownNodes); err != nil || n != 1 {
+		desc.KnownNodes = 0
+	}
+
+	shards, err := a.client.ClusterShards(ctx).Result()
+	if err != nil {
+		return nil, fmt.Errorf("failed to fetch cluster shards: %w", err)
+	}
+	for _, shard := range shards {
+		slots := make([]string, 0, len(shard.Slots))
+		for _, s := range shard.Slots {
+			slots = append(slots, fmt.Sprintf("%d-%d", s.Start, s.End))
+		}
+		for _, node := range shard.Nodes {
+			addr := node.Endpoint
+			if node.Port != 0 {
+				addr = fmt.Sprintf("%s:%d", node.Endpoint, node.Port)
+			}
+			desc.Nodes = append(desc.Nodes, ClusterNode{
+				ID:    node.ID,
+				Addr:  addr,
+				Role:  node.Role,
+				Slots: slots,
+			})
+		}
+	}
+	return desc, nil
+}
+
+// parseClusterInfoField extracts field's value from CLUSTER INFO's
+// "field:value\r\n"-per-line output, returning "" if field isn't present.
+func parseClusterInfoField(infoText, field string) string {
+	lines := splitLines(infoText)
+	prefix := field + ":"
+	for _, line := range lines {
+		if len(line) > len(prefix) && line[:len(prefix)] == prefix {
+			return line[len(prefix):]
+		}
+	}
+	return ""
+}
+
+func splitLines(s string) []string {
+	var lines []string
+	start := 0
+	for i := 0; i < len(s); i++ {
+		if s[i] == '\n' {
+			line := s[start:i]
+			if len(line) > 0 && line[len(line)-1] == '\r' {
+				line = line[:len(line)-1]
+			}
+			lines = append(lines, line)
+			start = i + 1
+		}
+	}
+	if start < len(s) {
+		lines = append(lines, s[start:])
+	}
+	return lines
+}
+
+// PendingMessage is one entry from PendingMessages' XPENDING detail.
+type PendingMessage struct {
+	ID         string
+	Consumer   string
+	Idle       time.Duration
+	RetryCount int64
+}
+
+// PendingMessages returns full XPENDING detail for up to count messages
+// on stream/group, optionally filtered to a single consumer (pass "" for
+// all consumers).
+func (a *StreamAdmin) PendingMessages(ctx context.Context, stream, group, consumer string, count int64) ([]PendingMessage, error) {
+	args := &redis.XPendingExtArgs{
+		Stream:   stream,
+		Group:    group,
+		Start:    "-",
+		End:      "+",
+		Count:    count,
+		Consumer: consumer,
+	}
+	pending, err := a.client.XPendingExt(ctx, args).Result()
+	if err != nil {
+		return nil, fmt.Errorf("failed to fetch pending messages for stream %s group %s: %w", stream, group, err)
+	}
+
+	messages := make([]PendingMessage, 0, len(pending))
+	for _, p := range pending {
+		messages = append(messages, PendingMessage{
+			ID:         p.ID,
+			Consumer:   p.Consumer,
+			Idle:       p.Idle,
+			RetryCount: p.RetryCount,
+		})
+	}
+	return messages, nil
+}
@@ -0,0 +1,73 @@
+package admin
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/redis/go-redis/v9"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestDescribeConsumerGroupsAndStreams(t *testing.T) {
+	client := redis.NewClient(&redis.Options{Addr: "localhost:6379"})
+	defer client.Close()
+
+	ctx := context.Background()
+	if err := client.Ping(ctx).Err(); err != nil {
+		t.Skip("Redis not available")
+	}
+
+	streamName := "test:admin:" + time.Now().Format("20060102150405")
+	defer client.Del(ctx, streamName)
+
+	require.NoError(t, client.XGroupCreateMkStream(ctx, streamName, "test-group", "0").Err())
+	_, err := client.XAdd(ctx, &redis.XAddArgs{Stream: streamName, Values: map[string]interface{}{"foo": "bar"}}).Result()
+	require.NoError(t, err)
+
+	a := NewStreamAdmin(client)
+
+	groups, err := a.DescribeConsumerGroups(ctx, streamName)
+	require.NoError(t, err)
+	require.Len(t, groups, 1)
+	assert.Equal(t, "test-group", groups[0].Name)
+
+	streams, err := a.DescribeStreams(ctx, streamName)
+	require.NoError(t, err)
+	require.Len(t, streams, 1)
+	assert.Equal(t, streamName, streams[0].Name)
+	assert.Equal(t, int64(1), streams[0].Length)
+	require.Len(t, streams[0].Groups, 1)
+}
+
+func TestPendingMessages(t *testing.T) {
+	client := redis.NewClient(&redis.Options{Addr: "localhost:6379"})
+	defer client.Close()
+
+	ctx := context.Background()
+	if err := client.Ping(ctx).Err(); err != nil {
+		t.Skip("Redis not available")
+	}
+
+	streamName := "test:admin:pending:" + time.Now().Format("20060102150405")
+	defer client.Del(ctx, streamName)
+
+	require.NoError(t, client.XGroupCreateMkStream(ctx, streamName, "test-group", "0").Err())
+	_, err := client.XAdd(ctx, &redis.XAddArgs{Stream: streamName, Values: map[string]interface{}{"foo": "bar"}}).Result()
+	require.NoError(t, err)
+
+	_, err = client.XReadGroup(ctx, &redis.XReadGroupArgs{
+		Group:    "test-group",
+		Consumer: "test-consumer",
+		Streams:  []string{streamName, ">"},
+		Count:    10,
+	}).Result()
+	require.NoError(t, err)
+
+	a := NewStreamAdmin(client)
+	pending, err := a.PendingMessages(ctx, streamName, "test-group", "", 10)
+	require.NoError(t, err)
+	require.Len(t, pending, 1)
+	assert.Equal(t, "test-consumer", pending[0].Consumer)
+}
@@ -0,0 +1,33 @@
+package redis
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/redis/go-redis/v9"
+
+	"github.com/MalteBoehm/tall-affiliate-common/pkg/streamtest"
+)
+
+func TestStreamConformance(t *testing.T) {
+	client := redis.NewClient(&redis.Options{Addr: "localhost:6379"})
+	defer client.Close()
+
+	ctx := context.Background()
+	if err := client.Ping(ctx).Err(); err != nil {
+		t.Skip("Redis not available")
+	}
+
+	streamName := "conformance:stream:" + time.Now().Format("20060102150405.000000")
+	groupName := "conformance-group"
+	defer client.Del(ctx, streamName)
+
+	producer := NewStreamProducer(client, nil)
+	consumer := NewStreamConsumer(client, streamName, groupName, "conformance-consumer", nil)
+	if err := consumer.CreateConsumerGroup(ctx); err != nil {
+		t.Fatalf("CreateConsumerGroup: %v", err)
+	}
+
+	streamtest.RunConformanceSuite(t, producer, consumer, streamName, groupName, 10*time.Second)
+}
@@ -0,0 +1,147 @@
+package redis
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/MalteBoehm/tall-affiliate-common/pkg/events"
+	"github.com/MalteBoehm/tall-affiliate-common/pkg/interfaces"
+	"github.com/redis/go-redis/v9"
+)
+
+// defaultDLQPublisher is the interfaces.DLQPublisher ConsumeStream's
+// janitor uses unless a caller supplies one via WithDLQPublisher: it
+// XAdds the payload and headers onto originalStream + ":dlq".
+type defaultDLQPublisher struct {
+	client *redis.Client
+}
+
+func newDefaultDLQPublisher(client *redis.Client) *defaultDLQPublisher {
+	return &defaultDLQPublisher{client: client}
+}
+
+// PublishDeadLetter implements interfaces.DLQPublisher.
+func (p *defaultDLQPublisher) PublishDeadLetter(ctx context.Context, originalStream string, headers map[string]string, payload []byte) error {
+	values := map[string]interface{}{
+		"event":             string(payload),
+		"x-original-stream": originalStream,
+	}
+	for k, v := range headers {
+		values[k] = v
+	}
+	_, err := p.client.XAdd(ctx, &redis.XAddArgs{Stream: originalStream + ":dlq", Values: values}).Result()
+	if err != nil {
+		return fmt.Errorf("failed to publish dead letter for stream %s: %w", originalStream, err)
+	}
+	return nil
+}
+
+var _ interfaces.DLQPublisher = (*defaultDLQPublisher)(nil)
+
+// InspectDLQ returns up to n entries currently on dlqStream without
+// removing them, for operators checking what has accumulated before
+// deciding whether to RedriveDLQ or discard.
+func (c *StreamConsumer) InspectDLQ(ctx context.Context, dlqStream string, n int64) ([]redis.XMessage, error) {
+	msgs, err := c.client.XRangeN(ctx, dlqStream, "-", "+", n).Result()
+	if err != nil {
+		return nil, fmt.Errorf("failed to inspect dlq stream %s: %w", dlqStream, err)
+	}
+	return msgs, nil
+}
+
+// RedriveDLQ moves up to n entries from dlqStream back onto
+// targetStream, preserving the original "event" field byte-for-byte, then
+// removes each redriven entry from dlqStream via XDEL so it is not
+// redriven again on a later call. It returns the number of entries
+// redriven.
+func (c *StreamConsumer) RedriveDLQ(ctx context.Context, dlqStream, targetStream string, n int64) (int64, error) {
+	msgs, err := c.client.XRangeN(ctx, dlqStream, "-", "+", n).Result()
+	if err != nil {
+		return 0, fmt.Errorf("failed to read dlq stream %s: %w", dlqStream, err)
+	}
+
+	var redriven int64
+	for _, msg := range msgs {
+		eventData, ok := msg.Values["event"].(string)
+		if !ok {
+			continue
+		}
+
+		if _, err := c.client.XAdd(ctx, &redis.XAddArgs{
+			Stream: targetStream,
+			Values: map[string]interface{}{"event": eventData},
+		}).Result(); err != nil {
+			return redriven, fmt.Errorf("failed to redrive message %s: %w", msg.ID, err)
+		}
+		if err := c.client.XDel(ctx, dlqStream, msg.ID).Err(); err != nil {
+			return redriven, fmt.Errorf("failed to remove redriven message %s from dlq: %w", msg.ID, err)
+		}
+		redriven++
+	}
+	return redriven, nil
+}
+
+// DeadLetterConfig controls how StreamConsumer.Consume names a consumed
+// stream's dead-letter stream and which field carries the dead-lettering
+// reason, for services that want either to differ from the "<stream>:dlq"
+// / "x-error" defaults.
+type DeadLetterConfig struct {
+	// StreamPattern is the dead-letter stream name with "%s" replaced by
+	// the consumed stream's name. Defaults to "%s:dlq".
+	StreamPattern string
+	// ReasonHeader is the field name the dead-lettering reason is stored
+	// under in the DLQ entry. Defaults to "x-error".
+	ReasonHeader string
+}
+
+func (d DeadLetterConfig) streamFor(source string) string {
+	pattern := d.StreamPattern
+	if pattern == "" {
+		pattern = "%s:dlq"
+	}
+	return fmt.Sprintf(pattern, source)
+}
+
+func (d DeadLetterConfig) reasonHeader() string {
+	if d.ReasonHeader == "" {
+		return "x-error"
+	}
+	return d.ReasonHeader
+}
+
+// ReplayDLQ reads every message currently on dlqStream and republishes the
+// ones whose decoded event passes filter (a nil filter accepts everything)
+// onto targetStream, preserving the original "event" field byte-for-byte so
+// the consumer reading targetStream can decode it unmodified. It returns
+// the number of messages replayed. Replayed messages are left on
+// dlqStream; callers that want it cleared afterward can XDEL/XTRIM it
+// themselves once satisfied with the result.
+func (c *StreamConsumer) ReplayDLQ(ctx context.Context, dlqStream, targetStream string, filter func(*events.Event) bool) (int, error) {
+	msgs, err := c.client.XRange(ctx, dlqStream, "-", "+").Result()
+	if err != nil {
+		return 0, fmt.Errorf("failed to read dlq stream %s: %w", dlqStream, err)
+	}
+
+	var replayed int
+	for _, msg := range msgs {
+		eventData, ok := msg.Values["event"].(string)
+		if !ok {
+			continue
+		}
+		if filter != nil {
+			event, err := decodeStreamEvent([]byte(eventData))
+			if err != nil || !filter(event) {
+				continue
+			}
+		}
+
+		if _, err := c.client.XAdd(ctx, &redis.XAddArgs{
+			Stream: targetStream,
+			Values: map[string]interface{}{"event": eventData},
+		}).Result(); err != nil {
+			return replayed, fmt.Errorf("failed to replay message %s: %w", msg.ID, err)
+		}
+		replayed++
+	}
+	return replayed, nil
+}
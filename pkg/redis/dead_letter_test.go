@@ -0,0 +1,139 @@
+package redis
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/redis/go-redis/v9"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/MalteBoehm/tall-affiliate-common/pkg/events"
+)
+
+func TestDeadLetterConfigDefaults(t *testing.T) {
+	var cfg DeadLetterConfig
+	assert.Equal(t, "orders:dlq", cfg.streamFor("orders"))
+	assert.Equal(t, "x-error", cfg.reasonHeader())
+}
+
+func TestDeadLetterConfigCustomPatternAndReasonHeader(t *testing.T) {
+	cfg := DeadLetterConfig{StreamPattern: "dlq.%s", ReasonHeader: "failure_reason"}
+	assert.Equal(t, "dlq.orders", cfg.streamFor("orders"))
+	assert.Equal(t, "failure_reason", cfg.reasonHeader())
+}
+
+func TestReplayDLQRepublishesFilteredMessages(t *testing.T) {
+	client := redis.NewClient(&redis.Options{Addr: "localhost:6379"})
+	defer client.Close()
+
+	ctx := context.Background()
+	if err := client.Ping(ctx).Err(); err != nil {
+		t.Skip("Redis not available")
+	}
+
+	dlqStream := "test:dlq:" + time.Now().Format("20060102150405")
+	targetStream := "test:target:" + time.Now().Format("20060102150405")
+	defer client.Del(ctx, dlqStream, targetStream)
+
+	producer := NewStreamProducer(client, nil)
+	consumer := NewStreamConsumer(client, targetStream, "test-group", "test-consumer", nil)
+
+	keepEvt := &events.Event{ID: "keep-1", Type: "keep", AggregateID: "a1"}
+	dropEvt := &events.Event{ID: "drop-1", Type: "drop", AggregateID: "a2"}
+	require.NoError(t, producer.PublishEvent(ctx, dlqStream, keepEvt))
+	require.NoError(t, producer.PublishEvent(ctx, dlqStream, dropEvt))
+
+	replayed, err := consumer.ReplayDLQ(ctx, dlqStream, targetStream, func(e *events.Event) bool {
+		return e.Type == "keep"
+	})
+	require.NoError(t, err)
+	assert.Equal(t, 1, replayed)
+
+	msgs, err := client.XRange(ctx, targetStream, "-", "+").Result()
+	require.NoError(t, err)
+	require.Len(t, msgs, 1)
+	assert.Contains(t, msgs[0].Values["event"], "keep-1")
+}
+
+func TestReplayDLQWithoutFilterReplaysEverything(t *testing.T) {
+	client := redis.NewClient(&redis.Options{Addr: "localhost:6379"})
+	defer client.Close()
+
+	ctx := context.Background()
+	if err := client.Ping(ctx).Err(); err != nil {
+		t.Skip("Redis not available")
+	}
+
+	dlqStream := "test:dlq:" + time.Now().Format("20060102150405") + "-all"
+	targetStream := "test:target:" + time.Now().Format("20060102150405") + "-all"
+	defer client.Del(ctx, dlqStream, targetStream)
+
+	producer := NewStreamProducer(client, nil)
+	consumer := NewStreamConsumer(client, targetStream, "test-group", "test-consumer", nil)
+
+	require.NoError(t, producer.PublishEvent(ctx, dlqStream, &events.Event{ID: "e1"}))
+	require.NoError(t, producer.PublishEvent(ctx, dlqStream, &events.Event{ID: "e2"}))
+
+	replayed, err := consumer.ReplayDLQ(ctx, dlqStream, targetStream, nil)
+	require.NoError(t, err)
+	assert.Equal(t, 2, replayed)
+}
+
+func TestRedriveDLQMovesAndRemovesEntries(t *testing.T) {
+	client := redis.NewClient(&redis.Options{Addr: "localhost:6379"})
+	defer client.Close()
+
+	ctx := context.Background()
+	if err := client.Ping(ctx).Err(); err != nil {
+		t.Skip("Redis not available")
+	}
+
+	dlqStream := "test:dlq:" + time.Now().Format("20060102150405") + "-redrive"
+	targetStream := "test:target:" + time.Now().Format("20060102150405") + "-redrive"
+	defer client.Del(ctx, dlqStream, targetStream)
+
+	producer := NewStreamProducer(client, nil)
+	consumer := NewStreamConsumer(client, targetStream, "test-group", "test-consumer", nil)
+
+	require.NoError(t, producer.PublishEvent(ctx, dlqStream, &events.Event{ID: "r1"}))
+	require.NoError(t, producer.PublishEvent(ctx, dlqStream, &events.Event{ID: "r2"}))
+
+	redriven, err := consumer.RedriveDLQ(ctx, dlqStream, targetStream, 1)
+	require.NoError(t, err)
+	assert.Equal(t, int64(1), redriven)
+
+	remaining, err := client.XLen(ctx, dlqStream).Result()
+	require.NoError(t, err)
+	assert.Equal(t, int64(1), remaining)
+
+	moved, err := client.XLen(ctx, targetStream).Result()
+	require.NoError(t, err)
+	assert.Equal(t, int64(1), moved)
+}
+
+func TestInspectDLQReturnsWithoutRemoving(t *testing.T) {
+	client := redis.NewClient(&redis.Options{Addr: "localhost:6379"})
+	defer client.Close()
+
+	ctx := context.Background()
+	if err := client.Ping(ctx).Err(); err != nil {
+		t.Skip("Redis not available")
+	}
+
+	dlqStream := "test:dlq:" + time.Now().Format("20060102150405") + "-inspect"
+	defer client.Del(ctx, dlqStream)
+
+	producer := NewStreamProducer(client, nil)
+	consumer := NewStreamConsumer(client, "unused", "test-group", "test-consumer", nil)
+	require.NoError(t, producer.PublishEvent(ctx, dlqStream, &events.Event{ID: "i1"}))
+
+	msgs, err := consumer.InspectDLQ(ctx, dlqStream, 10)
+	require.NoError(t, err)
+	require.Len(t, msgs, 1)
+
+	remaining, err := client.XLen(ctx, dlqStream).Result()
+	require.NoError(t, err)
+	assert.Equal(t, int64(1), remaining)
+}
@@ -0,0 +1,152 @@
+package redis
+
+import (
+	"context"
+	"sync"
+	"testing"
+	"time"
+
+	goredis "github.com/redis/go-redis/v9"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/MalteBoehm/tall-affiliate-common/pkg/events"
+	"github.com/MalteBoehm/tall-affiliate-common/pkg/testsupport"
+)
+
+// newIntegrationClient returns a Redis client backed by a local server if
+// one is already running, falling back to an ephemeral
+// testsupport.Fixture container otherwise, so this suite runs for real in
+// CI rather than skipping whenever nothing happens to be listening on
+// localhost:6379.
+func newIntegrationClient(t *testing.T) *goredis.Client {
+	t.Helper()
+
+	client := goredis.NewClient(&goredis.Options{Addr: "localhost:6379"})
+	if err := client.Ping(context.Background()).Err(); err == nil {
+		return client
+	}
+	client.Close()
+
+	fixture := &testsupport.Fixture{}
+	fixture.Setup(t)
+	t.Cleanup(fixture.Teardown)
+	return fixture.Redis
+}
+
+func TestIntegrationCreateConsumerGroup(t *testing.T) {
+	client := newIntegrationClient(t)
+	ctx := context.Background()
+
+	streamName := "test:integration:group:" + time.Now().Format("20060102150405.000000000")
+	defer client.Del(ctx, streamName)
+
+	consumer := NewStreamConsumer(client, streamName, "integration-group", "consumer-1", nil)
+	require.NoError(t, consumer.CreateConsumerGroup(ctx))
+	// Creating it again must not error (BUSYGROUP is swallowed).
+	require.NoError(t, consumer.CreateConsumerGroup(ctx))
+}
+
+func TestIntegrationClaimStaleMessages(t *testing.T) {
+	client := newIntegrationClient(t)
+	ctx := context.Background()
+
+	streamName := "test:integration:stale:" + time.Now().Format("20060102150405.000000000")
+	groupName := "integration-group"
+	defer client.Del(ctx, streamName)
+
+	producer := NewStreamProducer(client, nil)
+	owner := NewStreamConsumer(client, streamName, groupName, "owner", nil)
+	require.NoError(t, owner.CreateConsumerGroup(ctx))
+	require.NoError(t, producer.PublishEvent(ctx, streamName, &events.Event{ID: "stale-1", AggregateID: "a1"}))
+
+	// owner reads but never acks, leaving the message pending.
+	_, err := client.XReadGroup(ctx, &goredis.XReadGroupArgs{
+		Group: groupName, Consumer: "owner", Streams: []string{streamName, ">"}, Count: 1,
+	}).Result()
+	require.NoError(t, err)
+
+	claimer := NewStreamConsumer(client, streamName, groupName, "claimer", nil)
+	claimed, err := claimer.ClaimStaleMessages(ctx, 0, 10)
+	require.NoError(t, err)
+	require.Len(t, claimed, 1)
+	assert.Contains(t, claimed[0].Values["event"], "stale-1")
+}
+
+func TestIntegrationDLQRouting(t *testing.T) {
+	client := newIntegrationClient(t)
+	ctx := context.Background()
+
+	streamName := "test:integration:dlq:" + time.Now().Format("20060102150405.000000000")
+	dlqName := streamName + ":dlq"
+	groupName := "integration-group"
+	defer client.Del(ctx, streamName, dlqName)
+
+	producer := NewStreamProducer(client, nil)
+	consumer := NewStreamConsumer(client, streamName, groupName, "consumer-1", nil,
+		WithMaxDeliveries(1),
+		WithClaimInterval(30*time.Millisecond),
+		WithMinIdleTime(0),
+	)
+	require.NoError(t, consumer.CreateConsumerGroup(ctx))
+	require.NoError(t, producer.PublishEvent(ctx, streamName, &events.Event{ID: "dlq-1", AggregateID: "a1"}))
+
+	consumeCtx, cancel := context.WithTimeout(ctx, 3*time.Second)
+	defer cancel()
+	failing := func(context.Context, *events.Event, string) error { return assert.AnError }
+	go func() { _ = consumer.ConsumeStream(consumeCtx, streamName, groupName, 10, 20*time.Millisecond, failing) }()
+
+	require.Eventually(t, func() bool {
+		msgs, err := client.XRange(ctx, dlqName, "-", "+").Result()
+		return err == nil && len(msgs) == 1
+	}, 2*time.Second, 20*time.Millisecond)
+}
+
+func TestIntegrationConcurrentConsumersDivideWork(t *testing.T) {
+	client := newIntegrationClient(t)
+	ctx := context.Background()
+
+	streamName := "test:integration:concurrent:" + time.Now().Format("20060102150405.000000000")
+	groupName := "integration-group"
+	defer client.Del(ctx, streamName)
+
+	producer := NewStreamProducer(client, nil)
+	setup := NewStreamConsumer(client, streamName, groupName, "setup", nil)
+	require.NoError(t, setup.CreateConsumerGroup(ctx))
+
+	const total = 50
+	for i := 0; i < total; i++ {
+		require.NoError(t, producer.PublishEvent(ctx, streamName, &events.Event{ID: "c-" + time.Now().Format("150405.000000000"), AggregateID: "a1"}))
+	}
+
+	var mu sync.Mutex
+	var processed int
+	handler := func(context.Context, *events.Event, string) error {
+		mu.Lock()
+		processed++
+		mu.Unlock()
+		return nil
+	}
+
+	consumeCtx, cancel := context.WithTimeout(ctx, 3*time.Second)
+	defer cancel()
+
+	var wg sync.WaitGroup
+	for i := 0; i < 3; i++ {
+		consumer := NewStreamConsumer(client, streamName, groupName, "consumer-"+time.Now().Format("150405")+string(rune('a'+i)), nil)
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			_ = consumer.ConsumeStream(consumeCtx, streamName, groupName, 10, 20*time.Millisecond, handler)
+		}()
+	}
+
+	require.Eventually(t, func() bool {
+		mu.Lock()
+		defer mu.Unlock()
+		return processed == total
+	}, 2*time.Second, 20*time.Millisecond)
+
+	cancel()
+	wg.Wait()
+}
@@ -0,0 +1,128 @@
+package redis
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/MalteBoehm/tall-affiliate-common/pkg/events"
+	"github.com/redis/go-redis/v9"
+)
+
+// janitorLoop runs janitorOnce every c.claimInterval until ctx is
+// cancelled or stop is closed. It is ConsumeStream's counterpart to
+// Consume's reclaimLoop, operating on *events.Event handlers instead of
+// raw StreamMessage ones.
+func (c *StreamConsumer) janitorLoop(
+	ctx context.Context,
+	streamName, groupName string,
+	batchSize int64,
+	handler func(context.Context, *events.Event, string) error,
+	stop <-chan struct{},
+) {
+	ticker := time.NewTicker(c.claimInterval)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case <-stop:
+			return
+		case <-ticker.C:
+			c.janitorOnce(ctx, streamName, groupName, batchSize, handler)
+		}
+	}
+}
+
+// janitorOnce lists pending messages idle beyond the configured
+// threshold, dead-lettering those that have exceeded c.maxDeliveries
+// (via c.dlqPublisher) and reclaiming (then dispatching) the rest.
+func (c *StreamConsumer) janitorOnce(
+	ctx context.Context,
+	streamName, groupName string,
+	batchSize int64,
+	handler func(context.Context, *events.Event, string) error,
+) {
+	pending, err := c.client.XPendingExt(ctx, &redis.XPendingExtArgs{
+		Stream: streamName,
+		Group:  groupName,
+		Start:  "-",
+		End:    "+",
+		Count:  batchSize,
+	}).Result()
+	if err != nil {
+		c.logger.Error("janitor: failed to list pending messages", "error", err)
+		return
+	}
+
+	for _, p := range pending {
+		if p.Idle < c.requiredIdleFor(p.RetryCount) {
+			continue
+		}
+
+		if c.maxDeliveries > 0 && p.RetryCount >= c.maxDeliveries {
+			c.janitorDeadLetter(ctx, streamName, p.ID, p.RetryCount)
+			continue
+		}
+
+		claimed, err := c.client.XClaim(ctx, &redis.XClaimArgs{
+			Stream:   streamName,
+			Group:    groupName,
+			Consumer: c.consumerName,
+			MinIdle:  c.minIdleTime,
+			Messages: []string{p.ID},
+		}).Result()
+		if err != nil {
+			c.logger.Error("janitor: failed to claim message", "messageID", p.ID, "error", err)
+			continue
+		}
+		for _, msg := range claimed {
+			if err := c.processMessage(ctx, msg, handler); err != nil {
+				c.logger.Error("janitor: failed to process reclaimed message", "messageID", msg.ID, "error", err)
+			}
+		}
+	}
+}
+
+// requiredIdleFor returns the idle duration a pending message with the
+// given delivery count must have accumulated before janitorOnce will
+// XCLAIM it again: c.retryPolicy's backoff for that delivery count if
+// set, otherwise the fixed c.minIdleTime.
+func (c *StreamConsumer) requiredIdleFor(deliveryCount int64) time.Duration {
+	if c.retryPolicy != nil {
+		return c.retryPolicy.Delay(int(deliveryCount))
+	}
+	return c.minIdleTime
+}
+
+// janitorDeadLetter reads messageID's original fields, publishes them via
+// c.dlqPublisher tagged with x-original-id, x-failure-reason, and
+// x-delivery-count, then XACKs the original so it stops blocking the PEL.
+func (c *StreamConsumer) janitorDeadLetter(ctx context.Context, streamName, messageID string, deliveryCount int64) {
+	msgs, err := c.client.XRange(ctx, streamName, messageID, messageID).Result()
+	if err != nil || len(msgs) == 0 {
+		c.logger.Error("janitor: failed to read message for dead-lettering", "messageID", messageID, "error", err)
+		return
+	}
+
+	eventData, _ := msgs[0].Values["event"].(string)
+	reason, _ := c.consumeFailure(messageID)
+
+	headers := map[string]string{
+		"x-original-id":    messageID,
+		"x-failure-reason": reason,
+		"x-delivery-count": fmt.Sprintf("%d", deliveryCount),
+	}
+
+	if err := c.dlqPublisher.PublishDeadLetter(ctx, streamName, headers, []byte(eventData)); err != nil {
+		c.logger.Error("janitor: failed to publish dead letter", "messageID", messageID, "error", err)
+		return
+	}
+	if err := c.client.XAck(ctx, streamName, c.groupName, messageID).Err(); err != nil {
+		c.logger.Error("janitor: failed to acknowledge dead-lettered message", "messageID", messageID, "error", err)
+		return
+	}
+	c.clearFailure(messageID)
+	c.logger.Info("janitor: message dead-lettered", "messageID", messageID, "deliveryCount", deliveryCount)
+}
@@ -0,0 +1,67 @@
+package redis
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/redis/go-redis/v9"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/MalteBoehm/tall-affiliate-common/pkg/events"
+)
+
+func TestConsumeStreamJanitorDeadLettersAfterMaxDeliveries(t *testing.T) {
+	client := redis.NewClient(&redis.Options{Addr: "localhost:6379"})
+	defer client.Close()
+
+	ctx := context.Background()
+	if err := client.Ping(ctx).Err(); err != nil {
+		t.Skip("Redis not available")
+	}
+
+	streamName := "test:janitor:" + time.Now().Format("20060102150405")
+	dlqName := streamName + ":dlq"
+	groupName := "test-group"
+	defer client.Del(ctx, streamName, dlqName)
+
+	producer := NewStreamProducer(client, nil)
+	consumer := NewStreamConsumer(client, streamName, groupName, "test-consumer", nil,
+		WithMaxDeliveries(1),
+		WithClaimInterval(50*time.Millisecond),
+		WithMinIdleTime(0),
+	)
+	require.NoError(t, consumer.CreateConsumerGroup(ctx))
+
+	evt := &events.Event{ID: "janitor-1", Type: "test", AggregateID: "a1"}
+	require.NoError(t, producer.PublishEvent(ctx, streamName, evt))
+
+	consumeCtx, cancel := context.WithTimeout(ctx, 3*time.Second)
+	defer cancel()
+
+	handler := func(context.Context, *events.Event, string) error {
+		return assert.AnError
+	}
+
+	go func() { _ = consumer.ConsumeStream(consumeCtx, streamName, groupName, 10, 20*time.Millisecond, handler) }()
+
+	require.Eventually(t, func() bool {
+		msgs, err := client.XRange(ctx, dlqName, "-", "+").Result()
+		return err == nil && len(msgs) == 1
+	}, 2*time.Second, 20*time.Millisecond)
+
+	msgs, err := client.XRange(ctx, dlqName, "-", "+").Result()
+	require.NoError(t, err)
+	require.Len(t, msgs, 1)
+	original, err := client.XRange(ctx, streamName, "-", "+").Result()
+	require.NoError(t, err)
+	require.Len(t, original, 1)
+	assert.Equal(t, original[0].ID, msgs[0].Values["x-original-id"])
+	assert.Equal(t, assert.AnError.Error(), msgs[0].Values["x-failure-reason"])
+	assert.Equal(t, "1", msgs[0].Values["x-delivery-count"])
+
+	pending, err := client.XPending(ctx, streamName, groupName).Result()
+	require.NoError(t, err)
+	assert.Equal(t, int64(0), pending.Count)
+}
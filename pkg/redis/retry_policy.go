@@ -0,0 +1,74 @@
+package redis
+
+import (
+	"math"
+	"math/rand"
+	"time"
+
+	"github.com/MalteBoehm/tall-affiliate-common/pkg/interfaces"
+)
+
+// RetryPolicy is an exponential backoff-with-jitter policy controlling how
+// long StreamConsumer.Consume's reclaimer waits before re-claiming a
+// still-pending message, as a function of how many times it has already
+// been delivered (Redis's own XPENDING RetryCount), and how many attempts
+// it tolerates before dead-lettering instead of reclaiming again.
+type RetryPolicy struct {
+	// MaxAttempts is the delivery count past which a pending message is
+	// dead-lettered instead of reclaimed. Zero falls back to
+	// ConsumeOptions.MaxDeliveries.
+	MaxAttempts int
+	// InitialBackoff is the un-jittered delay before the first retry.
+	// Defaults to one second.
+	InitialBackoff time.Duration
+	// MaxBackoff caps the un-jittered delay. Zero means uncapped.
+	MaxBackoff time.Duration
+	// Multiplier grows the delay each attempt. Defaults to 2.
+	Multiplier float64
+	// Jitter is the fraction (0-1) of the computed delay to randomize
+	// around, so many simultaneously-stalled messages don't all get
+	// reclaimed in the same instant.
+	Jitter float64
+}
+
+func (p RetryPolicy) withDefaults() RetryPolicy {
+	if p.InitialBackoff <= 0 {
+		p.InitialBackoff = time.Second
+	}
+	if p.Multiplier <= 1 {
+		p.Multiplier = 2
+	}
+	if p.Jitter < 0 {
+		p.Jitter = 0
+	}
+	if p.Jitter > 1 {
+		p.Jitter = 1
+	}
+	return p
+}
+
+// Delay returns the backoff delay before a message on delivery attempt
+// should next be reclaimed. attempt below 1 is treated as 1.
+func (p RetryPolicy) Delay(attempt int) time.Duration {
+	p = p.withDefaults()
+	if attempt < 1 {
+		attempt = 1
+	}
+
+	base := float64(p.InitialBackoff) * math.Pow(p.Multiplier, float64(attempt-1))
+	if p.MaxBackoff > 0 && base > float64(p.MaxBackoff) {
+		base = float64(p.MaxBackoff)
+	}
+	if p.Jitter == 0 {
+		return time.Duration(base)
+	}
+
+	delta := base * p.Jitter
+	jittered := base - delta + rand.Float64()*2*delta
+	if jittered < 0 {
+		jittered = 0
+	}
+	return time.Duration(jittered)
+}
+
+var _ interfaces.RetryPolicy = RetryPolicy{}
@@ -0,0 +1,39 @@
+package redis
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestRetryPolicyDelayGrowsExponentially(t *testing.T) {
+	p := RetryPolicy{InitialBackoff: time.Second, Multiplier: 2}
+
+	assert.Equal(t, time.Second, p.Delay(1))
+	assert.Equal(t, 2*time.Second, p.Delay(2))
+	assert.Equal(t, 4*time.Second, p.Delay(3))
+}
+
+func TestRetryPolicyDelayCapsAtMaxBackoff(t *testing.T) {
+	p := RetryPolicy{InitialBackoff: time.Second, Multiplier: 2, MaxBackoff: 3 * time.Second}
+
+	assert.Equal(t, 3*time.Second, p.Delay(5))
+}
+
+func TestRetryPolicyDelayAppliesJitterWithinBounds(t *testing.T) {
+	p := RetryPolicy{InitialBackoff: time.Second, Multiplier: 2, Jitter: 0.5}
+
+	for i := 0; i < 50; i++ {
+		d := p.Delay(2)
+		assert.GreaterOrEqual(t, d, time.Second)
+		assert.LessOrEqual(t, d, 3*time.Second)
+	}
+}
+
+func TestRetryPolicyDelayTreatsAttemptBelowOneAsOne(t *testing.T) {
+	p := RetryPolicy{InitialBackoff: time.Second, Multiplier: 2}
+
+	assert.Equal(t, p.Delay(1), p.Delay(0))
+	assert.Equal(t, p.Delay(1), p.Delay(-3))
+}
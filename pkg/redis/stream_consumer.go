@@ -2,13 +2,16 @@ package redis
 
 import (
 	"context"
-	"encoding/json"
 	"fmt"
+	"io"
 	"log/slog"
+	"sync"
 	"time"
 
 	"github.com/MalteBoehm/tall-affiliate-common/pkg/events"
 	"github.com/MalteBoehm/tall-affiliate-common/pkg/interfaces"
+	"github.com/MalteBoehm/tall-affiliate-common/pkg/pubsub/query"
+	"github.com/MalteBoehm/tall-affiliate-common/pkg/retry"
 	"github.com/redis/go-redis/v9"
 )
 
@@ -19,20 +22,172 @@ type StreamConsumer struct {
 	groupName    string
 	consumerName string
 	logger       *slog.Logger
+
+	mu       sync.Mutex
+	failures map[string]consumeFailure
+
+	maxDeliveries int64
+	claimInterval time.Duration
+	minIdleTime   time.Duration
+	retryPolicy   interfaces.RetryPolicy
+	dlqPublisher  interfaces.DLQPublisher
+
+	poolSize    int
+	partitioner func(*events.Event) string
+
+	readClassifier retry.Classifier
+	codecs         *events.CodecRegistry
+	filter         *query.Query
+}
+
+// ConsumerOption configures optional StreamConsumer behavior - the
+// ConsumeStream janitor and DLQ wiring - at construction time, since
+// ConsumeStream's own signature is fixed by interfaces.StreamConsumer.
+type ConsumerOption func(*StreamConsumer)
+
+// WithMaxDeliveries sets the delivery count past which ConsumeStream's
+// janitor dead-letters a pending message instead of reclaiming it again.
+// Zero (the default) disables dead-lettering.
+func WithMaxDeliveries(n int64) ConsumerOption {
+	return func(c *StreamConsumer) { c.maxDeliveries = n }
+}
+
+// WithClaimInterval sets how often ConsumeStream's janitor polls XPENDING
+// for stale messages. Zero (the default) disables the janitor entirely.
+func WithClaimInterval(d time.Duration) ConsumerOption {
+	return func(c *StreamConsumer) { c.claimInterval = d }
+}
+
+// WithMinIdleTime sets the idle threshold the janitor uses to decide a
+// pending message's previous owner has stalled, unless a RetryPolicy is
+// also set via WithRetryPolicy.
+func WithMinIdleTime(d time.Duration) ConsumerOption {
+	return func(c *StreamConsumer) { c.minIdleTime = d }
+}
+
+// WithRetryPolicy replaces the janitor's fixed MinIdleTime threshold with
+// an exponential backoff computed from the message's delivery count.
+func WithRetryPolicy(policy interfaces.RetryPolicy) ConsumerOption {
+	return func(c *StreamConsumer) { c.retryPolicy = policy }
+}
+
+// WithDLQPublisher overrides where the janitor sends messages that exceed
+// MaxDeliveries. The default publishes to the consumed stream's name with
+// a ":dlq" suffix via XAdd (see newDefaultDLQPublisher).
+func WithDLQPublisher(publisher interfaces.DLQPublisher) ConsumerOption {
+	return func(c *StreamConsumer) { c.dlqPublisher = publisher }
+}
+
+// WithWorkerPool makes ConsumeStream dispatch messages to size worker
+// goroutines instead of processing each batch strictly sequentially.
+// partitioner maps an event to a partition key (a nil partitioner
+// defaults to events.Event.AggregateID, falling back to Event.ID); all
+// messages sharing a key always run on the same worker and therefore in
+// the order they were read, while messages with different keys run
+// concurrently. XREADGROUP backs off from fetching a new batch whenever
+// every worker is busy, since dispatching into a full pool blocks.
+func WithWorkerPool(size int, partitioner func(*events.Event) string) ConsumerOption {
+	return func(c *StreamConsumer) {
+		c.poolSize = size
+		c.partitioner = partitioner
+	}
+}
+
+// WithReadClassifier overrides how ConsumeStream classifies an XREADGROUP
+// error: whether it's worth retrying at all, and an optional backoff
+// override. Defaults to retry.RedisClassifier.
+func WithReadClassifier(classifier retry.Classifier) ConsumerOption {
+	return func(c *StreamConsumer) { c.readClassifier = classifier }
 }
 
-// NewStreamConsumer creates a new Redis stream consumer
-func NewStreamConsumer(client *redis.Client, streamName, groupName, consumerName string, logger *slog.Logger) *StreamConsumer {
+// WithCodecRegistry overrides how ConsumeStream decodes a message's
+// "content-type" stream field into the events.Codec that understands it.
+// Defaults to events.NewCodecRegistry(). Messages with no "content-type"
+// field (written before a producer adopted a Codec) fall back to
+// decodeStreamEvent's auto-detected structured/binary wire mode, unchanged.
+func WithCodecRegistry(registry *events.CodecRegistry) ConsumerOption {
+	return func(c *StreamConsumer) { c.codecs = registry }
+}
+
+// WithQueryFilter makes ConsumeStream subscribe to a logical sub-topic of
+// streamName: messages whose decoded event does not satisfy q are
+// acknowledged and skipped without ever reaching handler. A nil q (the
+// default) disables filtering, so every message is dispatched.
+func WithQueryFilter(q *query.Query) ConsumerOption {
+	return func(c *StreamConsumer) { c.filter = q }
+}
+
+// consumeFailure records the most recent handler error for a pending
+// message, and when it was first seen, so a later dead-lettering of that
+// message can report both (see dispatch/recordFailure/deadLetter).
+type consumeFailure struct {
+	reason    string
+	firstSeen time.Time
+}
+
+// recordFailure notes that messageID's handler call failed with err,
+// preserving the first-seen time across repeated failures of the same
+// message.
+func (c *StreamConsumer) recordFailure(messageID string, err error) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	if c.failures == nil {
+		c.failures = make(map[string]consumeFailure)
+	}
+	firstSeen := time.Now()
+	if existing, ok := c.failures[messageID]; ok {
+		firstSeen = existing.firstSeen
+	}
+	c.failures[messageID] = consumeFailure{reason: err.Error(), firstSeen: firstSeen}
+}
+
+// consumeFailure returns the recorded failure reason and first-seen time
+// for messageID, or a placeholder reason and the current time if none was
+// recorded.
+func (c *StreamConsumer) consumeFailure(messageID string) (reason string, firstSeen time.Time) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	if f, ok := c.failures[messageID]; ok {
+		return f.reason, f.firstSeen
+	}
+	return "handler error unavailable", time.Now()
+}
+
+// clearFailure forgets messageID's recorded failure, once it has
+// succeeded or been dead-lettered.
+func (c *StreamConsumer) clearFailure(messageID string) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	delete(c.failures, messageID)
+}
+
+// NewStreamConsumer creates a new Redis stream consumer. opts configure
+// ConsumeStream's optional janitor (dead-letter-queue auto-claim) - by
+// default it is disabled, matching prior behavior.
+func NewStreamConsumer(client *redis.Client, streamName, groupName, consumerName string, logger *slog.Logger, opts ...ConsumerOption) *StreamConsumer {
 	if logger == nil {
 		logger = slog.Default()
 	}
-	return &StreamConsumer{
+	c := &StreamConsumer{
 		client:       client,
 		streamName:   streamName,
 		groupName:    groupName,
 		consumerName: consumerName,
 		logger:       logger.With("component", "stream-consumer", "stream", streamName),
 	}
+	for _, opt := range opts {
+		opt(c)
+	}
+	if c.dlqPublisher == nil {
+		c.dlqPublisher = newDefaultDLQPublisher(client)
+	}
+	if c.readClassifier == nil {
+		c.readClassifier = retry.RedisClassifier
+	}
+	if c.codecs == nil {
+		c.codecs = events.NewCodecRegistry()
+	}
+	return c
 }
 
 // CreateConsumerGroup creates the consumer group if it doesn't exist
@@ -59,6 +214,32 @@ func (c *StreamConsumer) ConsumeStream(
 		"batchSize", batchSize,
 		"pollInterval", pollInterval)
 
+	var wg sync.WaitGroup
+	stopJanitor := make(chan struct{})
+	if c.claimInterval > 0 {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			c.janitorLoop(ctx, streamName, groupName, batchSize, handler, stopJanitor)
+		}()
+	}
+	defer func() {
+		close(stopJanitor)
+		wg.Wait()
+	}()
+
+	var pool *workerPool
+	if c.poolSize > 0 {
+		pool = newWorkerPool(ctx, c.poolSize, c.partitioner, func(ctx context.Context, msg redis.XMessage) {
+			if err := c.processMessage(ctx, msg, handler); err != nil {
+				c.logger.Error("Failed to process message",
+					"messageID", msg.ID,
+					"error", err)
+			}
+		})
+		defer pool.Close()
+	}
+
 	for {
 		select {
 		case <-ctx.Done():
@@ -78,14 +259,34 @@ func (c *StreamConsumer) ConsumeStream(
 					// No messages available, continue polling
 					continue
 				}
-				c.logger.Error("Failed to read from stream", "error", err)
-				time.Sleep(pollInterval)
+
+				retryable, backoffHint := c.readClassifier(err)
+				if !retryable {
+					c.logger.Error("Unrecoverable error reading from stream", "error", err)
+					return fmt.Errorf("unrecoverable stream read error: %w", err)
+				}
+
+				delay := backoffHint
+				if delay <= 0 {
+					delay = pollInterval
+				}
+				c.logger.Error("Failed to read from stream, retrying", "error", err, "delay", delay)
+				time.Sleep(delay)
 				continue
 			}
 
-			// Process messages
+			// Process messages, either inline (default) or fanned out to
+			// the worker pool (WithWorkerPool), which also blocks Submit
+			// until a slot is idle, providing backpressure on the next
+			// XReadGroup call.
 			for _, stream := range messages {
 				for _, msg := range stream.Messages {
+					if pool != nil {
+						if err := pool.Submit(ctx, msg); err != nil {
+							return err
+						}
+						continue
+					}
 					if err := c.processMessage(ctx, msg, handler); err != nil {
 						c.logger.Error("Failed to process message",
 							"messageID", msg.ID,
@@ -108,13 +309,25 @@ func (c *StreamConsumer) processMessage(
 	// Extract event data from message
 	eventData, ok := msg.Values["event"].(string)
 	if !ok {
-		return fmt.Errorf("message does not contain event data")
+		c.deadLetterParseError(ctx, msg, fmt.Errorf("message does not contain event data"))
+		return nil
 	}
 
-	// Parse the event
-	var event events.Event
-	if err := json.Unmarshal([]byte(eventData), &event); err != nil {
-		return fmt.Errorf("failed to unmarshal event: %w", err)
+	event, err := c.decodeMessage(msg, eventData)
+	if err != nil {
+		c.deadLetterParseError(ctx, msg, fmt.Errorf("failed to unmarshal event: %w", err))
+		return nil
+	}
+
+	if c.filter != nil {
+		matched, err := c.filter.Matches(event)
+		if err != nil {
+			c.deadLetterParseError(ctx, msg, fmt.Errorf("failed to evaluate query filter: %w", err))
+			return nil
+		}
+		if !matched {
+			return c.AcknowledgeMessage(ctx, msg.ID)
+		}
 	}
 
 	// Execute handler
@@ -124,7 +337,8 @@ func (c *StreamConsumer) processMessage(
 		"aggregateID", event.AggregateID,
 		"messageID", msg.ID)
 
-	if err := handler(ctx, &event, msg.ID); err != nil {
+	if err := handler(ctx, event, msg.ID); err != nil {
+		c.recordFailure(msg.ID, err)
 		return fmt.Errorf("handler failed: %w", err)
 	}
 
@@ -136,6 +350,70 @@ func (c *StreamConsumer) processMessage(
 	return nil
 }
 
+// decodeMessage decodes msg's "event" field into an *events.Event. When
+// msg carries a "content-type" field, it dispatches to the matching Codec
+// from c.codecs; otherwise it falls back to decodeStreamEvent's
+// auto-detected structured/binary wire mode, so streams written before a
+// producer adopted a Codec keep decoding exactly as before.
+func (c *StreamConsumer) decodeMessage(msg redis.XMessage, eventData string) (*events.Event, error) {
+	contentType, _ := msg.Values["content-type"].(string)
+	if contentType == "" {
+		return decodeStreamEvent([]byte(eventData))
+	}
+
+	codec, ok := c.codecs.Get(contentType)
+	if !ok {
+		return nil, fmt.Errorf("no codec registered for content-type %q", contentType)
+	}
+	event := &events.Event{}
+	if err := codec.Unmarshal([]byte(eventData), event); err != nil {
+		return nil, err
+	}
+	return event, nil
+}
+
+// parseErrorStream is the DLQ a poison message - one whose "event" field
+// is missing or fails to unmarshal - is routed to, instead of being
+// dropped silently or left stuck pending forever.
+func (c *StreamConsumer) parseErrorStream() string {
+	return c.streamName + ":parse-errors"
+}
+
+// deadLetterParseError republishes msg onto parseErrorStream, tagging it
+// with the decode error and original stream, then acknowledges msg so it
+// doesn't stay pending indefinitely.
+func (c *StreamConsumer) deadLetterParseError(ctx context.Context, msg redis.XMessage, parseErr error) {
+	values := map[string]interface{}{
+		"x-original-stream": c.streamName,
+		"x-error":           parseErr.Error(),
+		"x-first-seen":      time.Now().Format(time.RFC3339Nano),
+	}
+	for k, v := range msg.Values {
+		values[k] = v
+	}
+
+	if _, err := c.client.XAdd(ctx, &redis.XAddArgs{Stream: c.parseErrorStream(), Values: values}).Result(); err != nil {
+		c.logger.Error("failed to publish poison message to parse-errors stream", "messageID", msg.ID, "error", err)
+		return
+	}
+	if err := c.AcknowledgeMessage(ctx, msg.ID); err != nil {
+		c.logger.Error("failed to acknowledge poison message", "messageID", msg.ID, "error", err)
+	}
+	c.logger.Warn("poison message routed to parse-errors stream", "messageID", msg.ID, "error", parseErr)
+}
+
+// EventReader returns an io.Reader over msg's payload/data bytes without
+// fully decoding the surrounding event envelope, so a large enrichment
+// result can be piped directly into a downstream JSON decoder instead of
+// being copied through an *events.Event's Payload field first.
+func (c *StreamConsumer) EventReader(msg redis.XMessage) (io.Reader, error) {
+	eventData, ok := msg.Values["event"].(string)
+	if !ok {
+		return nil, fmt.Errorf("message does not contain event data")
+	}
+	return streamEventPayloadReader([]byte(eventData))
+}
+
 // AcknowledgeMessage acknowledges a message in the consumer group
 func (c *StreamConsumer) AcknowledgeMessage(ctx context.Context, messageID string) error {
 	return c.client.XAck(ctx, c.streamName, c.groupName, messageID).Err()
@@ -0,0 +1,309 @@
+package redis
+
+import (
+	"context"
+	"math"
+	"sync"
+	"time"
+
+	"github.com/redis/go-redis/v9"
+)
+
+// Handler processes a single message read from a consumer group, in the
+// package's generic StreamMessage form rather than the *events.Event form
+// ConsumeStream's handler uses - for callers that want raw stream fields
+// without going through the events.Event envelope.
+type Handler func(context.Context, StreamMessage) error
+
+// ConsumeOptions configures StreamConsumer.Consume.
+type ConsumeOptions struct {
+	// BatchSize is the COUNT passed to XREADGROUP.
+	BatchSize int64
+	// BlockTimeout is the BLOCK duration passed to XREADGROUP, and the
+	// backoff delay Consume starts from after a read error.
+	BlockTimeout time.Duration
+	// MaxBackoff caps the exponential backoff applied after consecutive
+	// XREADGROUP errors. Backoff resets to BlockTimeout after the next
+	// successful read.
+	MaxBackoff time.Duration
+	// MaxDeliveries is the number of delivery attempts (Redis's per-message
+	// RetryCount, surfaced via XPENDING) allowed before a still-pending
+	// message is moved to DeadLetterStream instead of being reclaimed.
+	// Zero disables dead-lettering.
+	MaxDeliveries int64
+	// DeadLetterStream receives messages that exceed MaxDeliveries.
+	// Defaults to the consumed stream's name with a ":dlq" suffix. Takes
+	// precedence over DeadLetterConfig's StreamPattern when both are set.
+	DeadLetterStream string
+	// DeadLetterConfig names the dead-letter stream via a pattern and
+	// customizes the dead-lettering reason field, instead of hard-coding a
+	// single DeadLetterStream name.
+	DeadLetterConfig *DeadLetterConfig
+	// ClaimInterval is how often the stale-message reclaimer runs. Zero
+	// disables reclaiming.
+	ClaimInterval time.Duration
+	// MinIdleTime is the idle threshold the reclaimer uses, via
+	// XPENDING/XCLAIM, to decide a message's previous owner has stalled.
+	// Used directly unless RetryPolicy is set.
+	MinIdleTime time.Duration
+	// RetryPolicy, if set, replaces MinIdleTime's fixed idle threshold with
+	// an exponential backoff computed from the message's delivery count,
+	// and replaces MaxDeliveries with its own MaxAttempts.
+	RetryPolicy *RetryPolicy
+}
+
+// DefaultConsumeOptions returns reasonable defaults: a batch of 10, a 2s
+// block, backoff capped at 30s, reclaiming every 30s for messages idle
+// more than a minute, and no dead-lettering.
+func DefaultConsumeOptions() ConsumeOptions {
+	return ConsumeOptions{
+		BatchSize:     10,
+		BlockTimeout:  2 * time.Second,
+		MaxBackoff:    30 * time.Second,
+		ClaimInterval: 30 * time.Second,
+		MinIdleTime:   time.Minute,
+	}
+}
+
+// Consume joins the consumer group (via CreateConsumerGroup) and reads
+// messages in a loop, dispatching each to handler as a StreamMessage and
+// XACKing on success. Read errors back off exponentially up to
+// opts.MaxBackoff. If opts.ClaimInterval is non-zero, a background
+// reclaimer periodically XCLAIMs messages idle beyond opts.MinIdleTime,
+// dead-lettering them instead once they exceed opts.MaxDeliveries. Consume
+// returns once ctx is cancelled, after letting any in-flight handler calls
+// finish - callers get graceful shutdown by cancelling ctx and waiting for
+// Consume to return.
+func (c *StreamConsumer) Consume(ctx context.Context, opts ConsumeOptions, handler Handler) error {
+	if err := c.CreateConsumerGroup(ctx); err != nil {
+		return err
+	}
+
+	var wg sync.WaitGroup
+	stopClaim := make(chan struct{})
+	if opts.ClaimInterval > 0 {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			c.reclaimLoop(ctx, opts, handler, stopClaim)
+		}()
+	}
+
+	err := c.readLoop(ctx, opts, handler)
+
+	close(stopClaim)
+	wg.Wait()
+	return err
+}
+
+// readLoop reads and dispatches messages until ctx is cancelled. Dispatch
+// runs synchronously per message, so by construction a cancelled ctx is
+// only observed between messages - Consume never returns mid-dispatch.
+func (c *StreamConsumer) readLoop(ctx context.Context, opts ConsumeOptions, handler Handler) error {
+	backoff := opts.BlockTimeout
+	for {
+		select {
+		case <-ctx.Done():
+			return ctx.Err()
+		default:
+		}
+
+		result, err := c.client.XReadGroup(ctx, &redis.XReadGroupArgs{
+			Group:    c.groupName,
+			Consumer: c.consumerName,
+			Streams:  []string{c.streamName, ">"},
+			Count:    opts.BatchSize,
+			Block:    opts.BlockTimeout,
+		}).Result()
+		if err != nil {
+			if err == redis.Nil {
+				backoff = opts.BlockTimeout
+				continue
+			}
+			if ctx.Err() != nil {
+				return ctx.Err()
+			}
+			c.logger.Error("failed to read from stream", "error", err)
+			select {
+			case <-ctx.Done():
+				return ctx.Err()
+			case <-time.After(backoff):
+			}
+			backoff = nextBackoff(backoff, opts.MaxBackoff)
+			continue
+		}
+		backoff = opts.BlockTimeout
+
+		for _, stream := range result {
+			for _, msg := range stream.Messages {
+				c.dispatch(ctx, msg, handler)
+			}
+		}
+	}
+}
+
+// dispatch runs handler against msg and XACKs it on success, logging (but
+// not returning) errors so one bad message doesn't stop the read loop. A
+// handler error is recorded so a later dead-lettering of this message (see
+// reclaimOnce) can report why it failed.
+func (c *StreamConsumer) dispatch(ctx context.Context, msg redis.XMessage, handler Handler) {
+	if err := handler(ctx, StreamMessage{ID: msg.ID, Data: msg.Values}); err != nil {
+		c.logger.Error("handler failed", "messageID", msg.ID, "error", err)
+		c.recordFailure(msg.ID, err)
+		return
+	}
+	if err := c.AcknowledgeMessage(ctx, msg.ID); err != nil {
+		c.logger.Error("failed to acknowledge message", "messageID", msg.ID, "error", err)
+		return
+	}
+	c.clearFailure(msg.ID)
+}
+
+// nextBackoff grows current by 1.5x, capped at max (no cap if max <= 0).
+func nextBackoff(current, max time.Duration) time.Duration {
+	next := time.Duration(math.Ceil(float64(current) * 1.5))
+	if max > 0 && next > max {
+		return max
+	}
+	return next
+}
+
+// reclaimLoop runs reclaimOnce every opts.ClaimInterval until ctx is
+// cancelled or stop is closed.
+func (c *StreamConsumer) reclaimLoop(ctx context.Context, opts ConsumeOptions, handler Handler, stop <-chan struct{}) {
+	ticker := time.NewTicker(opts.ClaimInterval)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case <-stop:
+			return
+		case <-ticker.C:
+			c.reclaimOnce(ctx, opts, handler)
+		}
+	}
+}
+
+// reclaimOnce lists pending messages idle beyond opts.MinIdleTime,
+// dead-lettering those that have exceeded opts.MaxDeliveries and claiming
+// (then dispatching) the rest.
+func (c *StreamConsumer) reclaimOnce(ctx context.Context, opts ConsumeOptions, handler Handler) {
+	pending, err := c.client.XPendingExt(ctx, &redis.XPendingExtArgs{
+		Stream: c.streamName,
+		Group:  c.groupName,
+		Start:  "-",
+		End:    "+",
+		Count:  opts.BatchSize,
+	}).Result()
+	if err != nil {
+		c.logger.Error("failed to list pending messages", "error", err)
+		return
+	}
+
+	for _, p := range pending {
+		if p.Idle < c.requiredIdle(opts, p.RetryCount) {
+			continue
+		}
+
+		if max := c.maxAttempts(opts); max > 0 && p.RetryCount >= max {
+			c.deadLetter(ctx, opts, p.ID, p.RetryCount)
+			continue
+		}
+
+		claimed, err := c.client.XClaim(ctx, &redis.XClaimArgs{
+			Stream:   c.streamName,
+			Group:    c.groupName,
+			Consumer: c.consumerName,
+			MinIdle:  opts.MinIdleTime,
+			Messages: []string{p.ID},
+		}).Result()
+		if err != nil {
+			c.logger.Error("failed to claim message", "messageID", p.ID, "error", err)
+			continue
+		}
+		for _, msg := range claimed {
+			c.dispatch(ctx, msg, handler)
+		}
+	}
+}
+
+// requiredIdle returns the idle duration a pending message with the given
+// delivery count must have accumulated before reclaimOnce will XCLAIM it
+// again: opts.RetryPolicy's backoff for that delivery count if set,
+// otherwise the fixed opts.MinIdleTime.
+func (c *StreamConsumer) requiredIdle(opts ConsumeOptions, deliveryCount int64) time.Duration {
+	if opts.RetryPolicy != nil {
+		return opts.RetryPolicy.Delay(int(deliveryCount))
+	}
+	return opts.MinIdleTime
+}
+
+// maxAttempts returns the delivery count past which reclaimOnce
+// dead-letters a pending message instead of reclaiming it, preferring
+// opts.RetryPolicy's MaxAttempts over the legacy opts.MaxDeliveries when
+// both are set.
+func (c *StreamConsumer) maxAttempts(opts ConsumeOptions) int64 {
+	if opts.RetryPolicy != nil && opts.RetryPolicy.MaxAttempts > 0 {
+		return int64(opts.RetryPolicy.MaxAttempts)
+	}
+	return opts.MaxDeliveries
+}
+
+// deadLetterStreamName resolves the stream dead-lettered messages are
+// published to: opts.DeadLetterStream if set, else opts.DeadLetterConfig's
+// pattern, else streamName + ":dlq".
+func (c *StreamConsumer) deadLetterStreamName(opts ConsumeOptions) string {
+	if opts.DeadLetterStream != "" {
+		return opts.DeadLetterStream
+	}
+	if opts.DeadLetterConfig != nil {
+		return opts.DeadLetterConfig.streamFor(c.streamName)
+	}
+	return c.streamName + ":dlq"
+}
+
+// deadLetter republishes the message identified by messageID onto its
+// resolved dead-letter stream (see deadLetterStreamName), tagging it with
+// x-original-stream, x-attempts, x-first-seen, and the last handler error
+// recorded for it (see dispatch/recordFailure), then acknowledges the
+// original so it stops being redelivered.
+func (c *StreamConsumer) deadLetter(ctx context.Context, opts ConsumeOptions, messageID string, deliveryCount int64) {
+	deadLetterStream := c.deadLetterStreamName(opts)
+	reasonHeader := "x-error"
+	if opts.DeadLetterConfig != nil {
+		reasonHeader = opts.DeadLetterConfig.reasonHeader()
+	}
+
+	msgs, err := c.client.XRange(ctx, c.streamName, messageID, messageID).Result()
+	if err != nil || len(msgs) == 0 {
+		c.logger.Error("failed to read message for dead-lettering", "messageID", messageID, "error", err)
+		return
+	}
+
+	reason, firstSeen := c.consumeFailure(messageID)
+
+	values := map[string]interface{}{
+		"original_id":       messageID,
+		"original_stream":   c.streamName,
+		"x-original-stream": c.streamName,
+		"x-attempts":        deliveryCount,
+		"x-first-seen":      firstSeen.Format(time.RFC3339Nano),
+	}
+	values[reasonHeader] = reason
+	for k, v := range msgs[0].Values {
+		values[k] = v
+	}
+
+	if _, err := c.client.XAdd(ctx, &redis.XAddArgs{Stream: deadLetterStream, Values: values}).Result(); err != nil {
+		c.logger.Error("failed to publish to dead-letter stream", "messageID", messageID, "error", err)
+		return
+	}
+
+	if err := c.AcknowledgeMessage(ctx, messageID); err != nil {
+		c.logger.Error("failed to acknowledge dead-lettered message", "messageID", messageID, "error", err)
+	}
+	c.clearFailure(messageID)
+	c.logger.Info("message moved to dead-letter stream", "messageID", messageID, "deadLetterStream", deadLetterStream)
+}
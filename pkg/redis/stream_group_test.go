@@ -0,0 +1,185 @@
+package redis
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/redis/go-redis/v9"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/MalteBoehm/tall-affiliate-common/pkg/events"
+)
+
+func TestNextBackoff(t *testing.T) {
+	t.Run("grows by 1.5x", func(t *testing.T) {
+		assert.Equal(t, 150*time.Millisecond, nextBackoff(100*time.Millisecond, 0))
+	})
+
+	t.Run("caps at max", func(t *testing.T) {
+		assert.Equal(t, 5*time.Second, nextBackoff(4*time.Second, 5*time.Second))
+	})
+
+	t.Run("uncapped when max is zero", func(t *testing.T) {
+		assert.Equal(t, 15*time.Second, nextBackoff(10*time.Second, 0))
+	})
+}
+
+func TestStreamProducer_Publish(t *testing.T) {
+	client := redis.NewClient(&redis.Options{Addr: "localhost:6379"})
+	defer client.Close()
+
+	ctx := context.Background()
+	if err := client.Ping(ctx).Err(); err != nil {
+		t.Skip("Redis not available")
+	}
+
+	streamName := "test:stream:" + time.Now().Format("20060102150405")
+	defer client.Del(ctx, streamName)
+
+	producer := NewStreamProducer(client, nil)
+
+	t.Run("publishes arbitrary fields", func(t *testing.T) {
+		id, err := producer.Publish(ctx, streamName, map[string]interface{}{"foo": "bar"})
+		assert.NoError(t, err)
+		assert.NotEmpty(t, id)
+
+		messages, err := client.XRange(ctx, streamName, "-", "+").Result()
+		require.NoError(t, err)
+		require.Len(t, messages, 1)
+		assert.Equal(t, "bar", messages[0].Values["foo"])
+	})
+
+	t.Run("validates stream name", func(t *testing.T) {
+		_, err := producer.Publish(ctx, "", map[string]interface{}{"foo": "bar"})
+		assert.Error(t, err)
+	})
+}
+
+func TestStreamConsumer_ConsumeDispatchesAndAcks(t *testing.T) {
+	client := redis.NewClient(&redis.Options{Addr: "localhost:6379"})
+	defer client.Close()
+
+	ctx := context.Background()
+	if err := client.Ping(ctx).Err(); err != nil {
+		t.Skip("Redis not available")
+	}
+
+	streamName := "test:stream:" + time.Now().Format("20060102150405")
+	dlqName := streamName + ":dlq"
+	defer client.Del(ctx, streamName, dlqName)
+
+	producer := NewStreamProducer(client, nil)
+	consumer := NewStreamConsumer(client, streamName, "test-group", "test-consumer", nil)
+
+	t.Run("dispatches messages as StreamMessage and acks them", func(t *testing.T) {
+		_, err := producer.Publish(ctx, streamName, map[string]interface{}{"foo": "bar"})
+		require.NoError(t, err)
+
+		consumeCtx, cancel := context.WithTimeout(ctx, 2*time.Second)
+		defer cancel()
+
+		var got StreamMessage
+		handler := func(ctx context.Context, msg StreamMessage) error {
+			got = msg
+			cancel()
+			return nil
+		}
+
+		opts := DefaultConsumeOptions()
+		opts.ClaimInterval = 0
+		err = consumer.Consume(consumeCtx, opts, handler)
+		assert.Equal(t, context.Canceled, err)
+		assert.Equal(t, "bar", got.Data["foo"])
+
+		pending, err := client.XPending(ctx, streamName, "test-group").Result()
+		require.NoError(t, err)
+		assert.Equal(t, int64(0), pending.Count)
+	})
+}
+
+func TestStreamConsumer_ConsumeDeadLettersAfterRetryPolicyMaxAttempts(t *testing.T) {
+	client := redis.NewClient(&redis.Options{Addr: "localhost:6379"})
+	defer client.Close()
+
+	ctx := context.Background()
+	if err := client.Ping(ctx).Err(); err != nil {
+		t.Skip("Redis not available")
+	}
+
+	streamName := "test:stream:" + time.Now().Format("20060102150405")
+	dlqName := streamName + ":dlq"
+	defer client.Del(ctx, streamName, dlqName)
+
+	producer := NewStreamProducer(client, nil)
+	consumer := NewStreamConsumer(client, streamName, "test-group", "test-consumer", nil)
+
+	_, err := producer.Publish(ctx, streamName, map[string]interface{}{"foo": "bar"})
+	require.NoError(t, err)
+
+	consumeCtx, cancel := context.WithTimeout(ctx, 3*time.Second)
+	defer cancel()
+
+	handler := func(context.Context, StreamMessage) error {
+		return assert.AnError
+	}
+
+	opts := DefaultConsumeOptions()
+	opts.ClaimInterval = 50 * time.Millisecond
+	opts.MinIdleTime = 0
+	opts.RetryPolicy = &RetryPolicy{MaxAttempts: 1, InitialBackoff: time.Millisecond}
+
+	go func() { _ = consumer.Consume(consumeCtx, opts, handler) }()
+
+	require.Eventually(t, func() bool {
+		msgs, err := client.XRange(ctx, dlqName, "-", "+").Result()
+		return err == nil && len(msgs) == 1
+	}, 2*time.Second, 20*time.Millisecond)
+
+	msgs, err := client.XRange(ctx, dlqName, "-", "+").Result()
+	require.NoError(t, err)
+	require.Len(t, msgs, 1)
+	assert.Equal(t, streamName, msgs[0].Values["x-original-stream"])
+	assert.Equal(t, assert.AnError.Error(), msgs[0].Values["x-error"])
+	assert.NotEmpty(t, msgs[0].Values["x-first-seen"])
+}
+
+func TestStreamConsumer_PoisonMessageRoutedToParseErrorsDLQ(t *testing.T) {
+	client := redis.NewClient(&redis.Options{Addr: "localhost:6379"})
+	defer client.Close()
+
+	ctx := context.Background()
+	if err := client.Ping(ctx).Err(); err != nil {
+		t.Skip("Redis not available")
+	}
+
+	streamName := "test:stream:" + time.Now().Format("20060102150405")
+	parseErrStream := streamName + ":parse-errors"
+	defer client.Del(ctx, streamName, parseErrStream)
+
+	consumer := NewStreamConsumer(client, streamName, "test-group", "test-consumer", nil)
+	require.NoError(t, consumer.CreateConsumerGroup(ctx))
+
+	_, err := client.XAdd(ctx, &redis.XAddArgs{
+		Stream: streamName,
+		Values: map[string]interface{}{"event": "not valid json"},
+	}).Result()
+	require.NoError(t, err)
+
+	consumeCtx, cancel := context.WithTimeout(ctx, 2*time.Second)
+	defer cancel()
+
+	handler := func(context.Context, *events.Event, string) error { return nil }
+	err = consumer.ConsumeStream(consumeCtx, streamName, "test-group", 10, 100*time.Millisecond, handler)
+	assert.ErrorIs(t, err, context.DeadlineExceeded)
+
+	msgs, err := client.XRange(ctx, parseErrStream, "-", "+").Result()
+	require.NoError(t, err)
+	require.Len(t, msgs, 1)
+	assert.Equal(t, streamName, msgs[0].Values["x-original-stream"])
+
+	pending, err := client.XPending(ctx, streamName, "test-group").Result()
+	require.NoError(t, err)
+	assert.Equal(t, int64(0), pending.Count)
+}
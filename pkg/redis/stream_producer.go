@@ -2,30 +2,72 @@ package redis
 
 import (
 	"context"
-	"encoding/json"
 	"fmt"
 	"log/slog"
 
 	"github.com/redis/go-redis/v9"
-	"github.com/maltedev/tall-affiliate/tall-affiliate-common/pkg/events"
-	"github.com/maltedev/tall-affiliate/tall-affiliate-common/pkg/interfaces"
+
+	"github.com/MalteBoehm/tall-affiliate-common/pkg/events"
+	"github.com/MalteBoehm/tall-affiliate-common/pkg/interfaces"
 )
 
 // StreamProducer implements Redis stream event publishing
 type StreamProducer struct {
 	client *redis.Client
 	logger *slog.Logger
+	mode   WireMode
+	codec  events.Codec
 }
 
-// NewStreamProducer creates a new Redis stream producer
+// NewStreamProducer creates a new Redis stream producer. The wire mode is
+// read from WireModeFromEnv(); use NewStreamProducerWithMode to pick it
+// explicitly.
 func NewStreamProducer(client *redis.Client, logger *slog.Logger) *StreamProducer {
+	return NewStreamProducerWithMode(client, logger, WireModeFromEnv())
+}
+
+// NewStreamProducerWithMode creates a new Redis stream producer that always
+// serializes with mode, regardless of the MODE environment variable.
+func NewStreamProducerWithMode(client *redis.Client, logger *slog.Logger, mode WireMode) *StreamProducer {
 	if logger == nil {
 		logger = slog.Default()
 	}
 	return &StreamProducer{
 		client: client,
 		logger: logger.With("component", "stream-producer"),
+		mode:   mode,
+	}
+}
+
+// NewStreamProducerWithCodec creates a Redis stream producer that serializes
+// the full event envelope through codec instead of the pre-existing
+// WireMode paths, writing codec.ContentType() alongside the payload bytes
+// in a "content-type" stream field so a StreamConsumer built with a
+// matching CodecRegistry can pick the right events.Codec back out.
+func NewStreamProducerWithCodec(client *redis.Client, logger *slog.Logger, codec events.Codec) *StreamProducer {
+	if logger == nil {
+		logger = slog.Default()
+	}
+	return &StreamProducer{
+		client: client,
+		logger: logger.With("component", "stream-producer"),
+		codec:  codec,
+	}
+}
+
+// encode serializes event for the "event" stream field, via codec when one
+// was configured (also returning its content type), or via the pre-existing
+// WireMode path otherwise.
+func (p *StreamProducer) encode(event *events.Event) (data string, contentType string, err error) {
+	if p.codec != nil {
+		raw, err := p.codec.Marshal(event)
+		if err != nil {
+			return "", "", err
+		}
+		return string(raw), p.codec.ContentType(), nil
 	}
+	data, err = encodeStreamEvent(event, p.mode)
+	return data, "", err
 }
 
 // PublishEvent publishes an event to a Redis stream
@@ -37,19 +79,23 @@ func (p *StreamProducer) PublishEvent(ctx context.Context, streamName string, ev
 		return fmt.Errorf("event cannot be nil")
 	}
 
-	// Serialize event to JSON
-	eventData, err := json.Marshal(event)
+	// Serialize event per the configured wire mode, or via the configured
+	// Codec when one was set with NewStreamProducerWithCodec.
+	eventData, contentType, err := p.encode(event)
 	if err != nil {
 		return fmt.Errorf("failed to marshal event: %w", err)
 	}
 
+	values := map[string]interface{}{"event": eventData}
+	if contentType != "" {
+		values["content-type"] = contentType
+	}
+
 	// Publish to stream
 	messageID, err := p.client.XAdd(ctx, &redis.XAddArgs{
 		Stream: streamName,
 		ID:     "*", // Let Redis generate the ID
-		Values: map[string]interface{}{
-			"event": string(eventData),
-		},
+		Values: values,
 	}).Result()
 	if err != nil {
 		return fmt.Errorf("failed to publish event to stream: %w", err)
@@ -78,20 +124,24 @@ func (p *StreamProducer) PublishBatch(ctx context.Context, streamName string, ev
 			continue // Skip nil events
 		}
 
-		// Serialize event to JSON
-		eventData, err := json.Marshal(event)
+		// Serialize event per the configured wire mode, or via the
+		// configured Codec when one was set with NewStreamProducerWithCodec.
+		eventData, contentType, err := p.encode(event)
 		if err != nil {
 			p.logger.Error("Failed to marshal event in batch", "error", err, "eventID", event.ID)
 			continue
 		}
 
+		values := map[string]interface{}{"event": eventData}
+		if contentType != "" {
+			values["content-type"] = contentType
+		}
+
 		// Add to pipeline
 		pipe.XAdd(ctx, &redis.XAddArgs{
 			Stream: streamName,
 			ID:     "*",
-			Values: map[string]interface{}{
-				"event": string(eventData),
-			},
+			Values: values,
 		})
 	}
 
@@ -118,6 +168,28 @@ func (p *StreamProducer) PublishBatch(ctx context.Context, streamName string, ev
 	return messageIDs, nil
 }
 
+// Publish writes data directly as a stream message's fields and returns
+// the generated message ID. Unlike PublishEvent, which wraps an
+// events.Event in a single "event" field, Publish is for callers that
+// aren't producing an events.Event and want their map published as-is.
+func (p *StreamProducer) Publish(ctx context.Context, stream string, data map[string]interface{}) (string, error) {
+	if stream == "" {
+		return "", fmt.Errorf("stream name cannot be empty")
+	}
+
+	messageID, err := p.client.XAdd(ctx, &redis.XAddArgs{
+		Stream: stream,
+		ID:     "*",
+		Values: data,
+	}).Result()
+	if err != nil {
+		return "", fmt.Errorf("failed to publish to stream: %w", err)
+	}
+
+	p.logger.Debug("Message published to stream", "stream", stream, "messageID", messageID)
+	return messageID, nil
+}
+
 // StreamInfo returns information about a stream
 func (p *StreamProducer) StreamInfo(ctx context.Context, streamName string) (*redis.XInfoStream, error) {
 	return p.client.XInfoStream(ctx, streamName).Result()
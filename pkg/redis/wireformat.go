@@ -0,0 +1,110 @@
+package redis
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"io"
+	"os"
+	"strings"
+
+	"github.com/MalteBoehm/tall-affiliate-common/pkg/cloudevents"
+	"github.com/MalteBoehm/tall-affiliate-common/pkg/events"
+)
+
+// WireMode selects which CloudEvents content mode StreamProducer uses when
+// serializing an event onto the "event" stream field.
+type WireMode string
+
+const (
+	// WireModeStructured keeps the pre-existing behavior: the event is
+	// marshaled to JSON as-is, equivalent to the CloudEvents structured JSON
+	// content mode.
+	WireModeStructured WireMode = "structured"
+	// WireModeBinary serializes via the event's CloudEvents mapping and the
+	// binary content mode (a headers/data envelope), so non-Go consumers can
+	// read CloudEvents-standard attributes directly off ce-* headers.
+	WireModeBinary WireMode = "binary"
+
+	// WireModeEnvVar is the environment variable a service sets to pick its
+	// wire format, e.g. MODE=binary.
+	WireModeEnvVar = "MODE"
+)
+
+// WireModeFromEnv reads WireModeEnvVar, defaulting to WireModeStructured for
+// an unset or unrecognized value.
+func WireModeFromEnv() WireMode {
+	if strings.EqualFold(os.Getenv(WireModeEnvVar), string(WireModeBinary)) {
+		return WireModeBinary
+	}
+	return WireModeStructured
+}
+
+// encodeStreamEvent serializes event for the "event" stream field according
+// to mode.
+func encodeStreamEvent(event *events.Event, mode WireMode) (string, error) {
+	if mode == WireModeBinary {
+		ce, err := event.ToCloudEvent()
+		if err != nil {
+			return "", err
+		}
+		data, err := ce.Marshal(cloudevents.FormatBinary)
+		if err != nil {
+			return "", err
+		}
+		return string(data), nil
+	}
+
+	data, err := json.Marshal(event)
+	if err != nil {
+		return "", err
+	}
+	return string(data), nil
+}
+
+// decodeStreamEvent auto-detects whether raw is a WireModeStructured
+// (bespoke Event JSON) or WireModeBinary (CloudEvents binary-mode
+// headers/data envelope) payload and decodes it back into an *events.Event.
+func decodeStreamEvent(raw []byte) (*events.Event, error) {
+	var probe struct {
+		Headers map[string]string `json:"headers"`
+	}
+	if err := json.Unmarshal(raw, &probe); err == nil && probe.Headers != nil {
+		var ce cloudevents.Event
+		if err := ce.Unmarshal(cloudevents.FormatBinary, raw); err != nil {
+			return nil, err
+		}
+		return events.FromCloudEvent(&ce)
+	}
+
+	var event events.Event
+	if err := json.Unmarshal(raw, &event); err != nil {
+		return nil, err
+	}
+	return &event, nil
+}
+
+// streamEventPayloadReader returns an io.Reader over raw's payload/data bytes
+// without fully decoding them into an events.Event, so a large enrichment
+// result can be streamed straight into a downstream JSON decoder. It
+// auto-detects mode the same way decodeStreamEvent does.
+func streamEventPayloadReader(raw []byte) (io.Reader, error) {
+	var probe struct {
+		Headers map[string]string `json:"headers"`
+		Data    json.RawMessage   `json:"data"`
+	}
+	if err := json.Unmarshal(raw, &probe); err == nil && probe.Headers != nil {
+		return bytes.NewReader(probe.Data), nil
+	}
+
+	var envelope struct {
+		Payload json.RawMessage `json:"payload"`
+	}
+	if err := json.Unmarshal(raw, &envelope); err != nil {
+		return nil, fmt.Errorf("redis: decode stream event payload: %w", err)
+	}
+	if envelope.Payload == nil {
+		return nil, fmt.Errorf("redis: stream event has no payload field")
+	}
+	return bytes.NewReader(envelope.Payload), nil
+}
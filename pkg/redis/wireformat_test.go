@@ -0,0 +1,120 @@
+package redis
+
+import (
+	"encoding/json"
+	"io"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/MalteBoehm/tall-affiliate-common/pkg/events"
+)
+
+func TestEncodeDecodeStreamEventStructuredRoundTrip(t *testing.T) {
+	event := &events.Event{
+		ID:            "evt-1",
+		Type:          events.EventTypeProductCreated,
+		AggregateType: "product",
+		AggregateID:   "prod-123",
+		Payload: map[string]interface{}{
+			"asin": "B001234567",
+		},
+		Timestamp: time.Now().UTC().Truncate(time.Second),
+	}
+
+	raw, err := encodeStreamEvent(event, WireModeStructured)
+	require.NoError(t, err)
+
+	got, err := decodeStreamEvent([]byte(raw))
+	require.NoError(t, err)
+	assert.Equal(t, event.ID, got.ID)
+	assert.Equal(t, event.Type, got.Type)
+	assert.Equal(t, event.AggregateType, got.AggregateType)
+	assert.Equal(t, event.AggregateID, got.AggregateID)
+	assert.True(t, event.Timestamp.Equal(got.Timestamp))
+}
+
+func TestEncodeDecodeStreamEventBinaryRoundTrip(t *testing.T) {
+	event := &events.Event{
+		ID:            "evt-2",
+		Type:          events.EventTypeProductCreated,
+		AggregateType: "product",
+		AggregateID:   "prod-456",
+		Payload: map[string]interface{}{
+			"asin": "B007654321",
+		},
+		Timestamp: time.Now().UTC().Truncate(time.Second),
+	}
+
+	raw, err := encodeStreamEvent(event, WireModeBinary)
+	require.NoError(t, err)
+
+	got, err := decodeStreamEvent([]byte(raw))
+	require.NoError(t, err)
+	assert.Equal(t, event.ID, got.ID)
+	assert.Equal(t, event.Type, got.Type)
+	assert.Equal(t, event.AggregateType, got.AggregateType)
+	assert.Equal(t, event.AggregateID, got.AggregateID)
+	assert.True(t, event.Timestamp.Equal(got.Timestamp))
+}
+
+func TestStreamEventPayloadReaderStructured(t *testing.T) {
+	event := &events.Event{
+		ID:            "evt-3",
+		Type:          events.EventTypeProductCreated,
+		AggregateType: "product",
+		AggregateID:   "prod-789",
+		Payload:       map[string]interface{}{"asin": "B009999999"},
+		Timestamp:     time.Now().UTC().Truncate(time.Second),
+	}
+
+	raw, err := encodeStreamEvent(event, WireModeStructured)
+	require.NoError(t, err)
+
+	r, err := streamEventPayloadReader([]byte(raw))
+	require.NoError(t, err)
+
+	data, err := io.ReadAll(r)
+	require.NoError(t, err)
+
+	var payload map[string]string
+	require.NoError(t, json.Unmarshal(data, &payload))
+	assert.Equal(t, "B009999999", payload["asin"])
+}
+
+func TestStreamEventPayloadReaderBinary(t *testing.T) {
+	event := &events.Event{
+		ID:            "evt-4",
+		Type:          events.EventTypeProductCreated,
+		AggregateType: "product",
+		AggregateID:   "prod-000",
+		Payload:       map[string]interface{}{"asin": "B000000001"},
+		Timestamp:     time.Now().UTC().Truncate(time.Second),
+	}
+
+	raw, err := encodeStreamEvent(event, WireModeBinary)
+	require.NoError(t, err)
+
+	r, err := streamEventPayloadReader([]byte(raw))
+	require.NoError(t, err)
+
+	data, err := io.ReadAll(r)
+	require.NoError(t, err)
+
+	var payload map[string]string
+	require.NoError(t, json.Unmarshal(data, &payload))
+	assert.Equal(t, "B000000001", payload["asin"])
+}
+
+func TestWireModeFromEnvDefaultsToStructured(t *testing.T) {
+	t.Setenv(WireModeEnvVar, "")
+	assert.Equal(t, WireModeStructured, WireModeFromEnv())
+
+	t.Setenv(WireModeEnvVar, "binary")
+	assert.Equal(t, WireModeBinary, WireModeFromEnv())
+
+	t.Setenv(WireModeEnvVar, "nonsense")
+	assert.Equal(t, WireModeStructured, WireModeFromEnv())
+}
@@ -0,0 +1,130 @@
+package redis
+
+import (
+	"context"
+	"hash/fnv"
+	"sync"
+
+	"github.com/MalteBoehm/tall-affiliate-common/pkg/events"
+	"github.com/redis/go-redis/v9"
+)
+
+// workerPool dispatches messages to a fixed number of worker goroutines,
+// hash-partitioned on a caller-supplied key so messages sharing a key
+// (typically an aggregate ID) are always processed by the same worker and
+// therefore in order, while independent keys process concurrently. A
+// semaphore sized to the pool provides backpressure: Submit blocks once
+// every worker is busy, so ConsumeStream's read loop stops fetching new
+// batches until a slot frees up.
+type workerPool struct {
+	size        int
+	partitioner func(*events.Event) string
+	process     func(ctx context.Context, msg redis.XMessage)
+
+	sem     chan struct{}
+	buckets []chan redis.XMessage
+	wg      sync.WaitGroup
+}
+
+// newWorkerPool creates a workerPool of size workers. A nil partitioner
+// defaults to keying on events.Event.AggregateID. process is called for
+// every message, on the worker owning its partition key; it is
+// responsible for decoding, handling, and acknowledging the message.
+func newWorkerPool(ctx context.Context, size int, partitioner func(*events.Event) string, process func(context.Context, redis.XMessage)) *workerPool {
+	if size <= 0 {
+		size = 1
+	}
+	if partitioner == nil {
+		partitioner = defaultPartitioner
+	}
+
+	p := &workerPool{
+		size:        size,
+		partitioner: partitioner,
+		process:     process,
+		sem:         make(chan struct{}, size),
+		buckets:     make([]chan redis.XMessage, size),
+	}
+	for i := range p.buckets {
+		p.buckets[i] = make(chan redis.XMessage, size)
+	}
+	for i := range p.buckets {
+		p.wg.Add(1)
+		go p.runWorker(ctx, p.buckets[i])
+	}
+	return p
+}
+
+func (p *workerPool) runWorker(ctx context.Context, jobs <-chan redis.XMessage) {
+	defer p.wg.Done()
+	for msg := range jobs {
+		p.process(ctx, msg)
+		<-p.sem
+	}
+}
+
+// defaultPartitioner keys on event.AggregateID, falling back to event.ID
+// so events without an aggregate still partition deterministically rather
+// than all colliding on the same worker.
+func defaultPartitioner(evt *events.Event) string {
+	if evt.AggregateID != "" {
+		return evt.AggregateID
+	}
+	return evt.ID
+}
+
+// bucketFor hashes key to a bucket index in [0, p.size).
+func (p *workerPool) bucketFor(key string) int {
+	h := fnv.New32a()
+	_, _ = h.Write([]byte(key))
+	return int(h.Sum32() % uint32(p.size))
+}
+
+// partitionKey decodes msg's event just far enough to compute its
+// partition key, so Submit can route it before the worker itself
+// unmarshals and handles it. A message that fails to decode here gets the
+// empty key, so all such messages share the one bucket that key hashes to.
+func (p *workerPool) partitionKey(msg redis.XMessage) string {
+	eventData, ok := msg.Values["event"].(string)
+	if !ok {
+		return ""
+	}
+	evt, err := decodeStreamEvent([]byte(eventData))
+	if err != nil {
+		return ""
+	}
+	return p.partitioner(evt)
+}
+
+// Submit blocks until an idle worker slot is available, then routes msg
+// to the worker owning its partition key. Blocking here is what gives
+// ConsumeStream's read loop backpressure: it cannot fetch or dispatch a
+// new batch while the pool is saturated. Submit returns ctx.Err() if ctx
+// is canceled while waiting for a slot or for the target worker to drain,
+// so a saturated pool can't keep ConsumeStream from returning during
+// shutdown.
+func (p *workerPool) Submit(ctx context.Context, msg redis.XMessage) error {
+	select {
+	case p.sem <- struct{}{}:
+	case <-ctx.Done():
+		return ctx.Err()
+	}
+
+	bucket := p.bucketFor(p.partitionKey(msg))
+	select {
+	case p.buckets[bucket] <- msg:
+		return nil
+	case <-ctx.Done():
+		<-p.sem
+		return ctx.Err()
+	}
+}
+
+// Close stops accepting new work and waits for every in-flight message to
+// finish processing.
+func (p *workerPool) Close() {
+	for _, bucket := range p.buckets {
+		close(bucket)
+	}
+	p.wg.Wait()
+}
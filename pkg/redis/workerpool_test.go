@@ -0,0 +1,82 @@
+package redis
+
+import (
+	"context"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/redis/go-redis/v9"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/MalteBoehm/tall-affiliate-common/pkg/events"
+)
+
+func TestConsumeStreamWorkerPoolPreservesPerAggregateOrder(t *testing.T) {
+	client := redis.NewClient(&redis.Options{Addr: "localhost:6379"})
+	defer client.Close()
+
+	ctx := context.Background()
+	if err := client.Ping(ctx).Err(); err != nil {
+		t.Skip("Redis not available")
+	}
+
+	streamName := "test:workerpool:" + time.Now().Format("20060102150405")
+	groupName := "test-group"
+	defer client.Del(ctx, streamName)
+
+	producer := NewStreamProducer(client, nil)
+	consumer := NewStreamConsumer(client, streamName, groupName, "test-consumer", nil,
+		WithWorkerPool(4, nil),
+	)
+	require.NoError(t, consumer.CreateConsumerGroup(ctx))
+
+	const perAggregate = 20
+	for _, agg := range []string{"a1", "a2", "a3"} {
+		for i := 0; i < perAggregate; i++ {
+			evt := &events.Event{ID: agg + "-" + time.Now().Format("150405.000000000"), Type: "test", AggregateID: agg}
+			require.NoError(t, producer.PublishEvent(ctx, streamName, evt))
+		}
+	}
+
+	var mu sync.Mutex
+	seen := map[string][]string{}
+	handler := func(_ context.Context, evt *events.Event, _ string) error {
+		mu.Lock()
+		seen[evt.AggregateID] = append(seen[evt.AggregateID], evt.ID)
+		mu.Unlock()
+		return nil
+	}
+
+	consumeCtx, cancel := context.WithTimeout(ctx, 3*time.Second)
+	defer cancel()
+	go func() { _ = consumer.ConsumeStream(consumeCtx, streamName, groupName, 10, 20*time.Millisecond, handler) }()
+
+	require.Eventually(t, func() bool {
+		mu.Lock()
+		defer mu.Unlock()
+		total := 0
+		for _, ids := range seen {
+			total += len(ids)
+		}
+		return total == perAggregate*3
+	}, 2*time.Second, 20*time.Millisecond)
+
+	mu.Lock()
+	defer mu.Unlock()
+	for agg, ids := range seen {
+		assert.True(t, sortedByPublishOrder(ids), "aggregate %s processed out of order: %v", agg, ids)
+	}
+}
+
+// sortedByPublishOrder reports whether ids is non-decreasing, relying on
+// the fact that event IDs in this test are timestamp-prefixed.
+func sortedByPublishOrder(ids []string) bool {
+	for i := 1; i < len(ids); i++ {
+		if ids[i] < ids[i-1] {
+			return false
+		}
+	}
+	return true
+}
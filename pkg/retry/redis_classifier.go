@@ -0,0 +1,55 @@
+package retry
+
+import (
+	"errors"
+	"io"
+	"net"
+	"strings"
+	"time"
+)
+
+// redisTerminalSubstrings are Redis error messages that indicate a
+// programming error rather than a transient condition - retrying them
+// would just repeat the same failure.
+var redisTerminalSubstrings = []string{
+	"WRONGTYPE",
+	"NOGROUP",
+	"BUSYGROUP",
+}
+
+// redisRetryableSubstrings are Redis error messages for conditions that
+// typically clear on their own: the server is still loading its dataset
+// (LOADING) or a replica's master is unreachable (MASTERDOWN).
+var redisRetryableSubstrings = []string{
+	"LOADING",
+	"MASTERDOWN",
+}
+
+// RedisClassifier is the default Classifier for Redis stream operations:
+// it retries io.EOF and *net.OpError (connection drops, dial failures) and
+// the LOADING/MASTERDOWN server states, but treats WRONGTYPE, NOGROUP, and
+// BUSYGROUP as permanent, since they indicate the caller is misusing the
+// key or consumer group rather than hitting a transient condition.
+func RedisClassifier(err error) (retryable bool, backoffHint time.Duration) {
+	msg := err.Error()
+	for _, terminal := range redisTerminalSubstrings {
+		if strings.Contains(msg, terminal) {
+			return false, 0
+		}
+	}
+
+	if errors.Is(err, io.EOF) {
+		return true, 0
+	}
+	var opErr *net.OpError
+	if errors.As(err, &opErr) {
+		return true, 0
+	}
+	for _, transient := range redisRetryableSubstrings {
+		if strings.Contains(msg, transient) {
+			return true, 0
+		}
+	}
+
+	return false, 0
+}
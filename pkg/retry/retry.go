@@ -0,0 +1,102 @@
+// Package retry is a shared classified-retry utility, modeled on GAX-style
+// retryers: unlike a plain fixed-interval retry loop that treats every
+// error as transient, a Classifier tells Do whether an error is worth
+// retrying at all and, optionally, how long to wait before the next
+// attempt. This package also provides the domain-specific SQLClassifier
+// and RedisClassifier, which pkg/database and pkg/redis wire into
+// database/sql and Redis stream operations.
+package retry
+
+import (
+	"context"
+	"fmt"
+	"math"
+	"math/rand"
+	"time"
+)
+
+// Classifier decides whether err is worth retrying. When retryable is
+// true, backoffHint optionally overrides Policy's computed backoff cap for
+// this attempt (e.g. a Redis LOADING error suggesting a longer wait); zero
+// means "use the policy's own backoff".
+type Classifier func(err error) (retryable bool, backoffHint time.Duration)
+
+// Policy configures Do's backoff and termination behavior.
+type Policy struct {
+	// InitialBackoff is the backoff cap before the first retry; each
+	// subsequent retry's cap grows by Multiplier. Defaults to 100ms.
+	InitialBackoff time.Duration
+	// MaxBackoff caps the backoff cap growth. Zero means uncapped.
+	MaxBackoff time.Duration
+	// Multiplier grows the backoff cap each attempt. Defaults to 2.
+	Multiplier float64
+	// MaxElapsed is the total wall-clock time Do keeps retrying for,
+	// measured from the first attempt. Zero means unbounded (retries
+	// forever until fn succeeds, Classifier rejects the error, or ctx is
+	// cancelled).
+	MaxElapsed time.Duration
+	// Classifier decides whether an error from fn is worth retrying.
+	// Required; Do panics if it is nil.
+	Classifier Classifier
+}
+
+func (p Policy) withDefaults() Policy {
+	if p.InitialBackoff <= 0 {
+		p.InitialBackoff = 100 * time.Millisecond
+	}
+	if p.Multiplier <= 1 {
+		p.Multiplier = 2
+	}
+	return p
+}
+
+// Do calls fn, retrying per policy until it succeeds, policy.Classifier
+// rejects the error as non-retryable, policy.MaxElapsed is exceeded, or ctx
+// is cancelled. Backoff is full jitter: on attempt n (0-indexed), Do sleeps
+// a random duration in [0, min(policy.MaxBackoff, policy.InitialBackoff *
+// policy.Multiplier^n)], or [0, backoffHint] if the classifier supplied
+// one, so many callers retrying the same transient failure don't all wake
+// up at once.
+func Do(ctx context.Context, policy Policy, fn func() error) error {
+	if policy.Classifier == nil {
+		panic("retry: Policy.Classifier must not be nil")
+	}
+	policy = policy.withDefaults()
+
+	start := time.Now()
+	var err error
+	for attempt := 0; ; attempt++ {
+		if err = fn(); err == nil {
+			return nil
+		}
+
+		retryable, backoffHint := policy.Classifier(err)
+		if !retryable {
+			return err
+		}
+		if policy.MaxElapsed > 0 && time.Since(start) >= policy.MaxElapsed {
+			return fmt.Errorf("retry: giving up after %s: %w", time.Since(start).Round(time.Millisecond), err)
+		}
+
+		backoffCap := backoffHint
+		if backoffCap <= 0 {
+			backoffCap = fullJitterCap(policy, attempt)
+		}
+		select {
+		case <-ctx.Done():
+			return ctx.Err()
+		case <-time.After(time.Duration(rand.Int63n(int64(backoffCap) + 1))):
+		}
+	}
+}
+
+// fullJitterCap returns the backoff cap for attempt (0-indexed): the
+// un-jittered exponential delay policy.InitialBackoff *
+// policy.Multiplier^attempt, capped at policy.MaxBackoff.
+func fullJitterCap(policy Policy, attempt int) time.Duration {
+	backoffCap := float64(policy.InitialBackoff) * math.Pow(policy.Multiplier, float64(attempt))
+	if policy.MaxBackoff > 0 && backoffCap > float64(policy.MaxBackoff) {
+		backoffCap = float64(policy.MaxBackoff)
+	}
+	return time.Duration(backoffCap)
+}
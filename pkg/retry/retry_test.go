@@ -0,0 +1,112 @@
+package retry
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"net"
+	"testing"
+	"time"
+
+	"github.com/lib/pq"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestDoRetriesUntilSuccess(t *testing.T) {
+	attempts := 0
+	policy := Policy{
+		InitialBackoff: time.Millisecond,
+		Classifier:     func(error) (bool, time.Duration) { return true, 0 },
+	}
+
+	err := Do(context.Background(), policy, func() error {
+		attempts++
+		if attempts < 3 {
+			return errors.New("transient")
+		}
+		return nil
+	})
+
+	require.NoError(t, err)
+	assert.Equal(t, 3, attempts)
+}
+
+func TestDoReturnsImmediatelyOnNonRetryableError(t *testing.T) {
+	attempts := 0
+	sentinel := errors.New("permanent")
+	policy := Policy{
+		InitialBackoff: time.Millisecond,
+		Classifier:     func(error) (bool, time.Duration) { return false, 0 },
+	}
+
+	err := Do(context.Background(), policy, func() error {
+		attempts++
+		return sentinel
+	})
+
+	assert.ErrorIs(t, err, sentinel)
+	assert.Equal(t, 1, attempts)
+}
+
+func TestDoGivesUpAfterMaxElapsed(t *testing.T) {
+	policy := Policy{
+		InitialBackoff: 5 * time.Millisecond,
+		MaxElapsed:     20 * time.Millisecond,
+		Classifier:     func(error) (bool, time.Duration) { return true, 0 },
+	}
+
+	err := Do(context.Background(), policy, func() error {
+		return errors.New("still failing")
+	})
+
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), "giving up")
+}
+
+func TestDoReturnsContextErrorWhenCancelled(t *testing.T) {
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+
+	policy := Policy{
+		InitialBackoff: time.Second,
+		Classifier:     func(error) (bool, time.Duration) { return true, 0 },
+	}
+
+	err := Do(ctx, policy, func() error {
+		return errors.New("transient")
+	})
+
+	assert.ErrorIs(t, err, context.Canceled)
+}
+
+func TestSQLClassifier(t *testing.T) {
+	retryable, _ := SQLClassifier(sql.ErrConnDone)
+	assert.True(t, retryable)
+
+	retryable, _ = SQLClassifier(&pq.Error{Code: "40001"})
+	assert.True(t, retryable, "serialization failure should be retryable")
+
+	retryable, _ = SQLClassifier(&pq.Error{Code: "08006"})
+	assert.True(t, retryable, "connection-class errors should be retryable")
+
+	retryable, _ = SQLClassifier(&pq.Error{Code: "23505"})
+	assert.False(t, retryable, "unique violation should be terminal")
+}
+
+func TestRedisClassifier(t *testing.T) {
+	retryable, _ := RedisClassifier(errors.New("io: EOF wrapper"))
+	assert.False(t, retryable, "unrelated messages mentioning EOF as text are not matched, only errors.Is")
+
+	retryable, _ = RedisClassifier(&net.OpError{Op: "dial", Err: errors.New("connection refused")})
+	assert.True(t, retryable)
+
+	retryable, _ = RedisClassifier(errors.New("LOADING Redis is loading the dataset in memory"))
+	assert.True(t, retryable)
+
+	retryable, _ = RedisClassifier(errors.New("WRONGTYPE Operation against a key holding the wrong kind of value"))
+	assert.False(t, retryable)
+
+	retryable, _ = RedisClassifier(errors.New("NOGROUP No such key or consumer group"))
+	assert.False(t, retryable)
+}
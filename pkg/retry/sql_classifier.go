@@ -0,0 +1,43 @@
+package retry
+
+import (
+	"database/sql"
+	"errors"
+	"time"
+
+	"github.com/lib/pq"
+)
+
+// sqlRetryableStates are the SQLSTATE codes treated as transient outside
+// the connection-exception class: serialization_failure, deadlock_detected,
+// admin_shutdown, and cannot_connect_now.
+var sqlRetryableStates = map[pq.ErrorCode]bool{
+	"40001": true,
+	"40P01": true,
+	"57P01": true,
+	"57P03": true,
+}
+
+// SQLClassifier is the default Classifier for database/sql operations: it
+// retries sql.ErrConnDone, *pq.Error connection-class errors (SQLSTATE
+// class 08), serialization failures (40001), deadlocks (40P01), and admin
+// shutdown/cannot-connect-now (57P01/57P03). Any other error - including
+// constraint violations and syntax errors - is treated as permanent, since
+// retrying them can never change the outcome.
+func SQLClassifier(err error) (retryable bool, backoffHint time.Duration) {
+	if errors.Is(err, sql.ErrConnDone) {
+		return true, 0
+	}
+
+	var pqErr *pq.Error
+	if errors.As(err, &pqErr) {
+		if sqlRetryableStates[pqErr.Code] {
+			return true, 0
+		}
+		if pqErr.Code.Class() == "08" {
+			return true, 0
+		}
+	}
+
+	return false, 0
+}
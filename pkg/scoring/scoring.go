@@ -0,0 +1,227 @@
+// pkg/scoring/scoring.go
+package scoring
+
+import (
+	"regexp"
+	"strings"
+
+	"github.com/MalteBoehm/tall-affiliate-common/pkg/events"
+	"github.com/MalteBoehm/tall-affiliate-common/pkg/models"
+)
+
+// Version identifies the rule set that produced a ScoreResult, so previously
+// scored products can be detected for reindexing when it changes.
+const Version = "v1"
+
+// DefaultEpsilon is the minimum absolute score delta considered a material
+// change for purposes of emitting a catalog.product.scored.v1 event.
+const DefaultEpsilon = 0.1
+
+// Rule is a single weighted scoring rule. Eval returns a 0..1 subscore for
+// product plus a short human-readable explanation.
+type Rule struct {
+	Name   string
+	Weight float64
+	Eval   func(product *models.Product) (float64, string)
+}
+
+// ScoreReason is the per-rule contribution behind a ScoreResult, kept so
+// callers can explain why a product scored the way it did.
+type ScoreReason struct {
+	Rule        string  `json:"rule"`
+	Weight      float64 `json:"weight"`
+	Subscore    float64 `json:"subscore"`
+	Explanation string  `json:"explanation"`
+}
+
+// ScoreResult is the outcome of scoring a product.
+type ScoreResult struct {
+	Score   float64       `json:"score"`
+	Reasons []ScoreReason `json:"reasons"`
+	Version string        `json:"version"`
+}
+
+// TallFriendlyScorer scores products against a weighted set of rules.
+type TallFriendlyScorer struct {
+	rules []Rule
+}
+
+// NewTallFriendlyScorer creates a scorer with the given rules. If rules is
+// empty, DefaultRules() is used.
+func NewTallFriendlyScorer(rules ...Rule) *TallFriendlyScorer {
+	if len(rules) == 0 {
+		rules = DefaultRules()
+	}
+	return &TallFriendlyScorer{rules: rules}
+}
+
+// Score evaluates every rule against product and returns the weighted
+// 0..10 result: 10 * sum(weight*subscore) / sum(weight).
+func (s *TallFriendlyScorer) Score(product *models.Product) ScoreResult {
+	var weightedSum, weightTotal float64
+	reasons := make([]ScoreReason, 0, len(s.rules))
+
+	for _, rule := range s.rules {
+		subscore, explanation := rule.Eval(product)
+		subscore = clamp01(subscore)
+
+		weightedSum += rule.Weight * subscore
+		weightTotal += rule.Weight
+
+		reasons = append(reasons, ScoreReason{
+			Rule:        rule.Name,
+			Weight:      rule.Weight,
+			Subscore:    subscore,
+			Explanation: explanation,
+		})
+	}
+
+	var score float64
+	if weightTotal > 0 {
+		score = 10 * weightedSum / weightTotal
+	}
+
+	return ScoreResult{Score: score, Reasons: reasons, Version: Version}
+}
+
+// ScoreChangedEvent compares result against previousScore and returns a
+// catalog.product.scored.v1 event (and true) when the delta is at least
+// epsilon. A non-positive epsilon falls back to DefaultEpsilon.
+func ScoreChangedEvent(product *models.Product, result ScoreResult, previousScore float64, epsilon float64) (*events.Event, bool) {
+	if epsilon <= 0 {
+		epsilon = DefaultEpsilon
+	}
+	if abs(result.Score-previousScore) < epsilon {
+		return nil, false
+	}
+	return events.NewProductScoredEvent(product.ASIN, product.ID, result.Score, previousScore, result.Version), true
+}
+
+func clamp01(v float64) float64 {
+	if v < 0 {
+		return 0
+	}
+	if v > 1 {
+		return 1
+	}
+	return v
+}
+
+func abs(v float64) float64 {
+	if v < 0 {
+		return -v
+	}
+	return v
+}
+
+// tallSizeKeywords are AvailableSizes/Size tokens indicating a tall-friendly cut.
+var tallSizeKeywords = []string{"tall", "long", "xlt", "xxlt", "34", "36"}
+
+// tallSizeRegex matches a tall-friendly size token as a whole word so "34"
+// doesn't match inside an unrelated string like "341".
+var tallSizeRegex = regexp.MustCompile(`(?i)\b(tall|long|xlt|xxlt|34|36)\b`)
+
+// heightFloorByProductGroup holds the minimum HeightCm expected for a given
+// Amazon ProductGroup before the height-penalty rule considers an item
+// undersized for tall shoppers.
+var heightFloorByProductGroup = map[string]float64{
+	"shirt":   70,
+	"t-shirt": 70,
+	"pants":   100,
+	"trouser": 100,
+	"jacket":  80,
+}
+
+// DefaultRules returns the tall-friendly rule set derived from Product's
+// existing fields.
+func DefaultRules() []Rule {
+	return []Rule{
+		{
+			Name:   "length_bonus",
+			Weight: 0.3,
+			Eval:   lengthBonusRule,
+		},
+		{
+			Name:   "available_sizes",
+			Weight: 0.25,
+			Eval:   availableSizesRule,
+		},
+		{
+			Name:   "size_regex",
+			Weight: 0.2,
+			Eval:   sizeRegexRule,
+		},
+		{
+			Name:   "variation_attributes",
+			Weight: 0.15,
+			Eval:   variationAttributesRule,
+		},
+		{
+			Name:   "height_penalty",
+			Weight: 0.1,
+			Eval:   heightPenaltyRule,
+		},
+	}
+}
+
+// lengthBonusRule ramps from 0.0 at 78cm to 1.0 at 86cm and above, since a
+// longer inseam/length is the single strongest tall-friendly signal.
+func lengthBonusRule(p *models.Product) (float64, string) {
+	const rampStart, rampEnd = 78.0, 86.0
+	if p.LengthCm <= 0 {
+		return 0, "no length data available"
+	}
+	if p.LengthCm >= rampEnd {
+		return 1, "length meets or exceeds 86cm tall threshold"
+	}
+	if p.LengthCm <= rampStart {
+		return 0, "length below 78cm tall-friendly floor"
+	}
+	subscore := (p.LengthCm - rampStart) / (rampEnd - rampStart)
+	return subscore, "length within the 78-86cm tall ramp"
+}
+
+func availableSizesRule(p *models.Product) (float64, string) {
+	for _, size := range p.AvailableSizes {
+		lower := strings.ToLower(size)
+		for _, keyword := range tallSizeKeywords {
+			if strings.Contains(lower, keyword) {
+				return 1, "available sizes include a tall-friendly size (" + size + ")"
+			}
+		}
+	}
+	return 0, "no tall-friendly size in available sizes"
+}
+
+func sizeRegexRule(p *models.Product) (float64, string) {
+	if tallSizeRegex.MatchString(p.Size) {
+		return 1, "size field matches tall-friendly pattern (" + p.Size + ")"
+	}
+	return 0, "size field does not match tall-friendly pattern"
+}
+
+func variationAttributesRule(p *models.Product) (float64, string) {
+	for _, attr := range p.VariationAttributes {
+		if strings.EqualFold(attr.Name, "size_type") && strings.EqualFold(attr.Value, "tall") {
+			return 1, "variation attribute size_type=tall"
+		}
+	}
+	return 0, "no size_type=tall variation attribute"
+}
+
+// heightPenaltyRule penalizes products whose own HeightCm is small relative
+// to what's typical for their ProductGroup, signaling a non-tall-friendly cut
+// despite other positive signals.
+func heightPenaltyRule(p *models.Product) (float64, string) {
+	if p.HeightCm <= 0 {
+		return 1, "no height data; no penalty applied"
+	}
+	floor, ok := heightFloorByProductGroup[strings.ToLower(p.ProductGroup)]
+	if !ok {
+		return 1, "no height floor known for product group " + p.ProductGroup
+	}
+	if p.HeightCm < floor {
+		return 0, "height is small for product group " + p.ProductGroup
+	}
+	return 1, "height meets product group floor"
+}
@@ -0,0 +1,90 @@
+package scoring
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/MalteBoehm/tall-affiliate-common/pkg/models"
+)
+
+func TestTallFriendlyScorerScore(t *testing.T) {
+	tests := []struct {
+		name      string
+		product   *models.Product
+		wantScore float64
+	}{
+		{
+			name:      "no tall signals scores only the unpenalized height rule",
+			product:   &models.Product{},
+			wantScore: 1, // 0.1 height_penalty weight * 1 (no height data) / 1.0 total weight * 10
+		},
+		{
+			name: "long length and tall size scores high",
+			product: &models.Product{
+				LengthCm:       90,
+				AvailableSizes: []string{"Tall", "XL"},
+				Size:           "Tall",
+				VariationAttributes: []models.VariationAttribute{
+					{Name: "size_type", Value: "tall"},
+				},
+			},
+			wantScore: 10,
+		},
+		{
+			name: "partial length ramp scores between",
+			product: &models.Product{
+				LengthCm: 82, // midpoint of the 78-86 ramp
+			},
+			wantScore: 2.5, // (0.3 weight * 0.5 subscore + 0.1 height_penalty weight * 1) / 1.0 total weight * 10
+		},
+	}
+
+	scorer := NewTallFriendlyScorer()
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			result := scorer.Score(tt.product)
+			assert.InDelta(t, tt.wantScore, result.Score, 0.01)
+			assert.Equal(t, Version, result.Version)
+			assert.Len(t, result.Reasons, len(DefaultRules()))
+		})
+	}
+}
+
+func TestHeightPenaltyRule(t *testing.T) {
+	tests := []struct {
+		name     string
+		product  *models.Product
+		wantHigh bool
+	}{
+		{name: "no height data", product: &models.Product{}, wantHigh: true},
+		{name: "unknown product group", product: &models.Product{HeightCm: 10, ProductGroup: "mystery"}, wantHigh: true},
+		{name: "small height for shirts", product: &models.Product{HeightCm: 10, ProductGroup: "shirt"}, wantHigh: false},
+		{name: "adequate height for shirts", product: &models.Product{HeightCm: 75, ProductGroup: "shirt"}, wantHigh: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			subscore, _ := heightPenaltyRule(tt.product)
+			if tt.wantHigh {
+				assert.Equal(t, 1.0, subscore)
+			} else {
+				assert.Equal(t, 0.0, subscore)
+			}
+		})
+	}
+}
+
+func TestScoreChangedEvent(t *testing.T) {
+	product := &models.Product{ID: "p1", ASIN: "B0TEST"}
+	result := ScoreResult{Score: 8, Version: Version}
+
+	event, changed := ScoreChangedEvent(product, result, 7.95, 0.1)
+	assert.False(t, changed)
+	assert.Nil(t, event)
+
+	event, changed = ScoreChangedEvent(product, result, 5, 0.1)
+	assert.True(t, changed)
+	assert.NotNil(t, event)
+	assert.Equal(t, "catalog.product.scored.v1", event.Type)
+}
@@ -0,0 +1,69 @@
+// Package streamtest provides a backend-agnostic conformance suite for
+// interfaces.StreamProducer/StreamConsumer implementations, so pkg/redis
+// and pkg/kafka (and any future transport) can be tested against the same
+// behavioral contract instead of duplicating the same round-trip test per
+// backend.
+package streamtest
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/MalteBoehm/tall-affiliate-common/pkg/events"
+	"github.com/MalteBoehm/tall-affiliate-common/pkg/interfaces"
+)
+
+// RunConformanceSuite exercises producer/consumer against a live backend,
+// publishing one event to streamName/groupName and asserting the consumer
+// observes it within timeout. The caller builds producer and consumer, so
+// any t.Skip for an unreachable broker happens before the suite runs.
+func RunConformanceSuite(
+	t *testing.T,
+	producer interfaces.StreamProducer,
+	consumer interfaces.StreamConsumer,
+	streamName, groupName string,
+	timeout time.Duration,
+) {
+	t.Helper()
+
+	evt, err := events.NewEvent("01_PRODUCT_DETECTED", "product", "conformance-1", map[string]string{"asin": "B0"})
+	require.NoError(t, err)
+
+	ctx, cancel := context.WithTimeout(context.Background(), timeout)
+	defer cancel()
+
+	require.NoError(t, producer.PublishEvent(ctx, streamName, evt))
+
+	received := make(chan *events.Event, 1)
+	consumeCtx, stopConsume := context.WithCancel(ctx)
+	defer stopConsume()
+
+	errCh := make(chan error, 1)
+	go func() {
+		errCh <- consumer.ConsumeStream(consumeCtx, streamName, groupName, 10, 50*time.Millisecond,
+			func(_ context.Context, e *events.Event, _ string) error {
+				select {
+				case received <- e:
+				default:
+				}
+				stopConsume()
+				return nil
+			})
+	}()
+
+	select {
+	case got := <-received:
+		assert.Equal(t, evt.ID, got.ID)
+		assert.Equal(t, evt.Type, got.Type)
+	case <-ctx.Done():
+		t.Fatal("timed out waiting for event to be consumed")
+	}
+
+	if err := <-errCh; err != nil && err != context.Canceled {
+		t.Fatalf("ConsumeStream returned unexpected error: %v", err)
+	}
+}
@@ -0,0 +1,180 @@
+package streamview
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"log/slog"
+	"sync"
+	"time"
+
+	"github.com/MalteBoehm/tall-affiliate-common/pkg/events"
+	"github.com/MalteBoehm/tall-affiliate-common/pkg/interfaces"
+)
+
+// LastSeenIDTailOnly is the lastSeenID value naming a tail-only
+// subscription ("$"). Run only logs lastSeenID, so passing it does not
+// change where the consumer group starts reading.
+const LastSeenIDTailOnly = "$"
+
+// Store tails a single stream/consumer-group pair and keeps one View per
+// aggregate ID up to date. Many callers share the result - and the one
+// underlying consumer group - by calling Fetch instead of each opening
+// their own StreamConsumer subscription.
+type Store struct {
+	consumer   interfaces.StreamConsumer
+	streamName string
+	groupName  string
+	newView    func() View
+	snapshotFn SnapshotFn
+	logger     *slog.Logger
+
+	mu         sync.Mutex
+	aggregates map[string]*aggregateState
+}
+
+// aggregateState holds one aggregate's View plus the bookkeeping Fetch
+// blocks on: index counts events successfully applied to view so far, the
+// position Fetch waits for a minimum of.
+type aggregateState struct {
+	cond  *sync.Cond
+	view  View
+	index uint64
+	err   error
+}
+
+// NewStore creates a Store that will tail streamName under groupName once
+// Run is called, materializing one View (from newView) per aggregate ID
+// it sees. snapshotFn may be nil, in which case an aggregate's View
+// returning ErrOutOfOrder from Apply becomes a permanent error for that
+// aggregate. logger may be nil (slog.Default()).
+func NewStore(
+	consumer interfaces.StreamConsumer,
+	streamName string,
+	groupName string,
+	newView func() View,
+	snapshotFn SnapshotFn,
+	logger *slog.Logger,
+) *Store {
+	if logger == nil {
+		logger = slog.Default()
+	}
+	return &Store{
+		consumer:   consumer,
+		streamName: streamName,
+		groupName:  groupName,
+		newView:    newView,
+		snapshotFn: snapshotFn,
+		logger:     logger.With("component", "streamview-store"),
+		aggregates: make(map[string]*aggregateState),
+	}
+}
+
+// Run subscribes to streamName under groupName and applies every event it
+// receives to its aggregate's View, blocking until ctx is canceled or the
+// underlying StreamConsumer returns.
+//
+// lastSeenID is written to the subscription log line and not used
+// otherwise: interfaces.StreamConsumer has no notion of a starting offset,
+// so the position Run starts from is the one the backend already tracks
+// for groupName.
+func (s *Store) Run(ctx context.Context, lastSeenID string, batchSize int64, pollInterval time.Duration) error {
+	s.logger.Info("streamview: subscribing",
+		"stream", s.streamName, "group", s.groupName, "last_seen_id", lastSeenID)
+	return s.consumer.ConsumeStream(ctx, s.streamName, s.groupName, batchSize, pollInterval, s.handleEvent)
+}
+
+func (s *Store) handleEvent(ctx context.Context, ev *events.Event, messageID string) error {
+	state := s.stateFor(ev.AggregateID)
+
+	state.cond.L.Lock()
+	defer state.cond.L.Unlock()
+
+	err := state.view.Apply(ev)
+	if errors.Is(err, ErrOutOfOrder) {
+		if resyncErr := s.resyncLocked(ctx, ev.AggregateID, state); resyncErr != nil {
+			return s.failLocked(state, fmt.Errorf("streamview: resync aggregate %s: %w", ev.AggregateID, resyncErr))
+		}
+		err = state.view.Apply(ev)
+	}
+	if err != nil {
+		return s.failLocked(state, fmt.Errorf("streamview: apply event to aggregate %s: %w", ev.AggregateID, err))
+	}
+
+	state.index++
+	state.err = nil
+	state.cond.Broadcast()
+	return nil
+}
+
+// failLocked records err as state's permanent error, wakes any Fetch
+// callers waiting on it, and returns err. state.cond.L must already be
+// held.
+func (s *Store) failLocked(state *aggregateState, err error) error {
+	state.err = err
+	state.cond.Broadcast()
+	return err
+}
+
+// resyncLocked reloads aggregateID's snapshot via s.snapshotFn and resets
+// state.view from it. state.cond.L must already be held.
+func (s *Store) resyncLocked(ctx context.Context, aggregateID string, state *aggregateState) error {
+	if s.snapshotFn == nil {
+		return fmt.Errorf("streamview: aggregate %s out of order and no SnapshotFn configured", aggregateID)
+	}
+	snapshot, err := s.snapshotFn(ctx, aggregateID)
+	if err != nil {
+		return fmt.Errorf("streamview: load snapshot: %w", err)
+	}
+	return state.view.Reset(snapshot)
+}
+
+func (s *Store) stateFor(aggregateID string) *aggregateState {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	state, ok := s.aggregates[aggregateID]
+	if !ok {
+		view := s.newView()
+		_ = view.Reset(nil)
+		state = &aggregateState{cond: sync.NewCond(&sync.Mutex{}), view: view}
+		s.aggregates[aggregateID] = state
+	}
+	return state
+}
+
+// Fetch blocks until aggregateID's view has applied at least minIndex
+// events (pass 0 to return immediately with whatever state exists), then
+// returns its current Result along with the index actually reached.
+// Fetch returns ctx.Err() if ctx is canceled first, or the error Apply/
+// Reset last returned for this aggregate if resynchronization failed
+// permanently.
+func (s *Store) Fetch(ctx context.Context, aggregateID string, minIndex uint64) (any, uint64, error) {
+	state := s.stateFor(aggregateID)
+
+	stop := make(chan struct{})
+	defer close(stop)
+	go func() {
+		select {
+		case <-ctx.Done():
+			state.cond.L.Lock()
+			state.cond.Broadcast()
+			state.cond.L.Unlock()
+		case <-stop:
+		}
+	}()
+
+	state.cond.L.Lock()
+	defer state.cond.L.Unlock()
+	for state.index < minIndex && state.err == nil && ctx.Err() == nil {
+		state.cond.Wait()
+	}
+
+	if ctx.Err() != nil {
+		return nil, state.index, ctx.Err()
+	}
+	if state.err != nil {
+		return nil, state.index, state.err
+	}
+	return state.view.Result(), state.index, nil
+}
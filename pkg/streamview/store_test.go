@@ -0,0 +1,191 @@
+package streamview
+
+import (
+	"context"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/MalteBoehm/tall-affiliate-common/pkg/events"
+)
+
+// fakeConsumer delivers a fixed batch of events to handler, then blocks
+// until ctx is canceled, mimicking a long-polling StreamConsumer.
+type fakeConsumer struct {
+	events []*events.Event
+}
+
+func (c *fakeConsumer) ConsumeStream(
+	ctx context.Context,
+	streamName string,
+	groupName string,
+	batchSize int64,
+	pollInterval time.Duration,
+	handler func(context.Context, *events.Event, string) error,
+) error {
+	for i, ev := range c.events {
+		if err := handler(ctx, ev, string(rune('a'+i))); err != nil {
+			return err
+		}
+	}
+	<-ctx.Done()
+	return ctx.Err()
+}
+
+// counterView counts how many events it has applied and concatenates
+// their types into Result, for assertions on ordering.
+type counterView struct {
+	mu      sync.Mutex
+	applied []string
+}
+
+func (v *counterView) Reset(snapshot any) error {
+	v.mu.Lock()
+	defer v.mu.Unlock()
+	if types, ok := snapshot.([]string); ok {
+		v.applied = append([]string(nil), types...)
+	} else {
+		v.applied = nil
+	}
+	return nil
+}
+
+func (v *counterView) Apply(ev *events.Event) error {
+	v.mu.Lock()
+	defer v.mu.Unlock()
+	v.applied = append(v.applied, ev.Type)
+	return nil
+}
+
+func (v *counterView) Result() any {
+	v.mu.Lock()
+	defer v.mu.Unlock()
+	return append([]string(nil), v.applied...)
+}
+
+func TestStoreFetchAppliesEventsInOrder(t *testing.T) {
+	consumer := &fakeConsumer{events: []*events.Event{
+		{AggregateID: "p-1", Type: "created"},
+		{AggregateID: "p-1", Type: "priced"},
+	}}
+	store := NewStore(consumer, "stream:prices", "view-group", func() View { return &counterView{} }, nil, nil)
+
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+	go store.Run(ctx, LastSeenIDTailOnly, 10, time.Millisecond)
+
+	fetchCtx, fetchCancel := context.WithTimeout(context.Background(), time.Second)
+	defer fetchCancel()
+	result, index, err := store.Fetch(fetchCtx, "p-1", 2)
+	if err != nil {
+		t.Fatalf("Fetch: %v", err)
+	}
+	if index != 2 {
+		t.Errorf("index = %d, want 2", index)
+	}
+	applied := result.([]string)
+	if len(applied) != 2 || applied[0] != "created" || applied[1] != "priced" {
+		t.Errorf("Result = %v, want [created priced]", applied)
+	}
+}
+
+func TestStoreFetchTimesOutBeforeMinIndexReached(t *testing.T) {
+	consumer := &fakeConsumer{events: []*events.Event{{AggregateID: "p-1", Type: "created"}}}
+	store := NewStore(consumer, "stream:prices", "view-group", func() View { return &counterView{} }, nil, nil)
+
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+	go store.Run(ctx, LastSeenIDTailOnly, 10, time.Millisecond)
+
+	fetchCtx, fetchCancel := context.WithTimeout(context.Background(), 20*time.Millisecond)
+	defer fetchCancel()
+	_, _, err := store.Fetch(fetchCtx, "p-1", 5)
+	if err == nil {
+		t.Fatal("expected Fetch to time out waiting for an index that will never be reached")
+	}
+}
+
+// resyncingView returns ErrOutOfOrder exactly once, then applies
+// normally, to exercise Store's SnapshotFn-driven recovery.
+type resyncingView struct {
+	mu         sync.Mutex
+	reset      bool
+	failedOnce bool
+	applied    []string
+}
+
+func (v *resyncingView) Reset(snapshot any) error {
+	v.mu.Lock()
+	defer v.mu.Unlock()
+	if v.failedOnce {
+		v.reset = true
+	}
+	v.applied = nil
+	return nil
+}
+
+func (v *resyncingView) Apply(ev *events.Event) error {
+	v.mu.Lock()
+	defer v.mu.Unlock()
+	if !v.failedOnce {
+		v.failedOnce = true
+		return ErrOutOfOrder
+	}
+	v.applied = append(v.applied, ev.Type)
+	return nil
+}
+
+func (v *resyncingView) Result() any {
+	v.mu.Lock()
+	defer v.mu.Unlock()
+	return append([]string(nil), v.applied...)
+}
+
+func TestStoreResyncsOnOutOfOrder(t *testing.T) {
+	consumer := &fakeConsumer{events: []*events.Event{{AggregateID: "p-1", Type: "priced"}}}
+	view := &resyncingView{}
+	snapshotFn := func(ctx context.Context, aggregateID string) (any, error) { return nil, nil }
+	store := NewStore(consumer, "stream:prices", "view-group", func() View { return view }, snapshotFn, nil)
+
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+	go store.Run(ctx, LastSeenIDTailOnly, 10, time.Millisecond)
+
+	fetchCtx, fetchCancel := context.WithTimeout(context.Background(), time.Second)
+	defer fetchCancel()
+	result, _, err := store.Fetch(fetchCtx, "p-1", 1)
+	if err != nil {
+		t.Fatalf("Fetch: %v", err)
+	}
+	if !view.reset {
+		t.Error("expected Store to call View.Reset after ErrOutOfOrder")
+	}
+	applied := result.([]string)
+	if len(applied) != 1 || applied[0] != "priced" {
+		t.Errorf("Result = %v, want [priced]", applied)
+	}
+}
+
+// alwaysOutOfOrderView always returns ErrOutOfOrder, to exercise the
+// permanent-error path when no SnapshotFn is configured.
+type alwaysOutOfOrderView struct{}
+
+func (alwaysOutOfOrderView) Reset(snapshot any) error     { return nil }
+func (alwaysOutOfOrderView) Apply(ev *events.Event) error { return ErrOutOfOrder }
+func (alwaysOutOfOrderView) Result() any                  { return nil }
+
+func TestStoreFetchReturnsPermanentErrorWithoutSnapshotFn(t *testing.T) {
+	consumer := &fakeConsumer{events: []*events.Event{{AggregateID: "p-1", Type: "priced"}}}
+	store := NewStore(consumer, "stream:prices", "view-group", func() View { return alwaysOutOfOrderView{} }, nil, nil)
+
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+	go store.Run(ctx, LastSeenIDTailOnly, 10, time.Millisecond)
+
+	fetchCtx, fetchCancel := context.WithTimeout(context.Background(), time.Second)
+	defer fetchCancel()
+	_, _, err := store.Fetch(fetchCtx, "p-1", 1)
+	if err == nil {
+		t.Fatal("expected Fetch to return the permanent ErrOutOfOrder failure")
+	}
+}
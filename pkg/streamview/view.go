@@ -0,0 +1,45 @@
+// Package streamview is a client-side materialized-view layer on top of
+// interfaces.StreamConsumer: it tails an event stream and keeps one View
+// per aggregate ID up to date, so services can read "current price per
+// ASIN" or "latest enrichment status per product" without re-deriving it
+// from storage on every request. A single Store fans a shared view out to
+// many concurrent Fetch callers instead of each caller opening its own
+// consumer group - the pattern Consul's submatview.Store uses for its
+// streaming RPC cache.
+package streamview
+
+import (
+	"context"
+	"errors"
+
+	"github.com/MalteBoehm/tall-affiliate-common/pkg/events"
+)
+
+// View is a client-side materialized projection of one aggregate, kept in
+// sync by a Store tailing an event stream. Store serializes Reset/Apply
+// calls for a given aggregate ID, so implementations don't need their own
+// locking.
+type View interface {
+	// Reset replaces the view's state with snapshot, as produced by the
+	// Store's SnapshotFn. It is also called with a nil snapshot the first
+	// time Store sees an aggregate ID it has no state for yet.
+	Reset(snapshot any) error
+	// Apply folds ev into the view's state. It returns ErrOutOfOrder if ev
+	// can't be applied without first resynchronizing from a snapshot;
+	// Store reacts by calling SnapshotFn and Reset, then retrying Apply
+	// once.
+	Apply(ev *events.Event) error
+	// Result returns the view's current projection.
+	Result() any
+}
+
+// ErrOutOfOrder is returned by View.Apply when ev can't be folded into the
+// view's current state without first resynchronizing - for example, a gap
+// in a version sequence the view tracks internally.
+var ErrOutOfOrder = errors.New("streamview: event out of order")
+
+// SnapshotFn loads the current snapshot for aggregateID, for View.Reset to
+// resynchronize from after Apply returns ErrOutOfOrder. A Store configured
+// with a nil SnapshotFn treats ErrOutOfOrder as a permanent error for that
+// aggregate.
+type SnapshotFn func(ctx context.Context, aggregateID string) (any, error)
@@ -0,0 +1,38 @@
+package tracing
+
+import (
+	"context"
+
+	"github.com/MalteBoehm/tall-affiliate-common/pkg/events"
+)
+
+// WrapHandler wraps a ConsumeStream-style handler so every call starts a
+// SpanKindConsumer span named "event.process <event.type>", linked to the
+// producer's span via events.ExtractTraceContext(evt) when the event
+// carries a traceparent, before invoking handler.
+func WrapHandler(
+	tracer Tracer,
+	handler func(context.Context, *events.Event, string) error,
+) func(context.Context, *events.Event, string) error {
+	return func(ctx context.Context, evt *events.Event, messageID string) error {
+		var linked events.SpanContext
+		if traceCtx, err := events.ExtractTraceContext(evt); err == nil {
+			if sc, ok := events.SpanContextFromContext(traceCtx); ok {
+				linked = sc
+			}
+		}
+
+		spanCtx, span := tracer.StartSpan(ctx, "event.process "+evt.Type, SpanKindConsumer, linked)
+		defer span.End()
+
+		span.SetAttribute(AttrMessagingSystem, "redis-streams")
+		span.SetAttribute(AttrMessagingOperation, "process")
+		span.SetAttribute(AttrEventType, evt.Type)
+		span.SetAttribute(AttrEventAggregateID, evt.AggregateID)
+		span.SetAttribute(AttrMessagingMessageID, messageID)
+
+		err := handler(spanCtx, evt, messageID)
+		span.SetError(err)
+		return err
+	}
+}
@@ -0,0 +1,69 @@
+package tracing
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/MalteBoehm/tall-affiliate-common/pkg/events"
+)
+
+func TestWrapHandlerLinksProducerSpan(t *testing.T) {
+	tracer := &fakeTracer{}
+
+	evt := &events.Event{Type: "orders.placed.v1", AggregateID: "order-1"}
+	producerSC := events.SpanContext{TraceID: "4bf92f3577b34da6a3ce929d0e0e4736", SpanID: "00f067aa0ba902b7", Sampled: true}
+	events.InjectTraceContext(events.ContextWithSpanContext(context.Background(), producerSC), evt)
+
+	called := false
+	handler := WrapHandler(tracer, func(ctx context.Context, e *events.Event, messageID string) error {
+		called = true
+		return nil
+	})
+
+	if err := handler(context.Background(), evt, "msg-1"); err != nil {
+		t.Fatalf("handler: %v", err)
+	}
+	if !called {
+		t.Fatal("wrapped handler was not called")
+	}
+	if tracer.kind != SpanKindConsumer {
+		t.Errorf("span kind = %q, want %q", tracer.kind, SpanKindConsumer)
+	}
+	if tracer.linked.TraceID != producerSC.TraceID {
+		t.Errorf("linked trace ID = %q, want %q", tracer.linked.TraceID, producerSC.TraceID)
+	}
+	if !tracer.span.ended {
+		t.Error("span was not ended")
+	}
+}
+
+func TestWrapHandlerRecordsHandlerError(t *testing.T) {
+	tracer := &fakeTracer{}
+	wantErr := errors.New("boom")
+	handler := WrapHandler(tracer, func(ctx context.Context, e *events.Event, messageID string) error {
+		return wantErr
+	})
+
+	err := handler(context.Background(), &events.Event{Type: "x"}, "msg-1")
+	if err != wantErr {
+		t.Errorf("handler err = %v, want %v", err, wantErr)
+	}
+	if tracer.span.err != wantErr {
+		t.Errorf("span.err = %v, want %v", tracer.span.err, wantErr)
+	}
+}
+
+func TestWrapHandlerWithoutTraceparentStartsUnlinkedSpan(t *testing.T) {
+	tracer := &fakeTracer{}
+	handler := WrapHandler(tracer, func(ctx context.Context, e *events.Event, messageID string) error {
+		return nil
+	})
+
+	if err := handler(context.Background(), &events.Event{Type: "x"}, "msg-1"); err != nil {
+		t.Fatalf("handler: %v", err)
+	}
+	if tracer.linked.IsValid() {
+		t.Errorf("linked = %+v, want invalid SpanContext", tracer.linked)
+	}
+}
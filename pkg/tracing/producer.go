@@ -0,0 +1,49 @@
+package tracing
+
+import (
+	"context"
+
+	"github.com/MalteBoehm/tall-affiliate-common/pkg/events"
+	"github.com/MalteBoehm/tall-affiliate-common/pkg/interfaces"
+)
+
+// TracingProducer wraps a StreamProducer, starting a producer span named
+// "event.publish <event.type>" around each PublishEvent call and
+// propagating the span's trace context onto the outgoing event via
+// events.InjectTraceContext before the wrapped producer is called.
+type TracingProducer struct {
+	producer interfaces.StreamProducer
+	tracer   Tracer
+}
+
+// NewTracingProducer wraps producer so every PublishEvent is traced with
+// tracer. Pass NoopTracer{} to keep tracing disabled while still going
+// through this type (e.g. so it can be swapped for a real Tracer later
+// without touching call sites).
+func NewTracingProducer(producer interfaces.StreamProducer, tracer Tracer) *TracingProducer {
+	return &TracingProducer{producer: producer, tracer: tracer}
+}
+
+// PublishEvent starts a SpanKindProducer span named
+// "event.publish <event.type>", tagged with the messaging semantic
+// convention attributes, injects the resulting trace context into event,
+// then delegates to the wrapped producer.
+func (p *TracingProducer) PublishEvent(ctx context.Context, streamName string, event *events.Event) error {
+	spanCtx, span := p.tracer.StartSpan(ctx, "event.publish "+event.Type, SpanKindProducer, events.SpanContext{})
+	defer span.End()
+
+	span.SetAttribute(AttrMessagingSystem, "redis-streams")
+	span.SetAttribute(AttrMessagingDestination, streamName)
+	span.SetAttribute(AttrMessagingOperation, "publish")
+	span.SetAttribute(AttrEventType, event.Type)
+	span.SetAttribute(AttrEventAggregateID, event.AggregateID)
+	span.SetAttribute(AttrMessagingMessageID, event.ID)
+
+	events.InjectTraceContext(spanCtx, event)
+
+	err := p.producer.PublishEvent(spanCtx, streamName, event)
+	span.SetError(err)
+	return err
+}
+
+var _ interfaces.StreamProducer = (*TracingProducer)(nil)
@@ -0,0 +1,90 @@
+package tracing
+
+import (
+	"context"
+	"testing"
+
+	"github.com/MalteBoehm/tall-affiliate-common/pkg/events"
+)
+
+type fakeSpan struct {
+	attrs map[string]any
+	err   error
+	ended bool
+}
+
+func newFakeSpan() *fakeSpan { return &fakeSpan{attrs: make(map[string]any)} }
+
+func (s *fakeSpan) SetAttribute(key string, value any) { s.attrs[key] = value }
+func (s *fakeSpan) SetError(err error)                 { s.err = err }
+func (s *fakeSpan) End()                               { s.ended = true }
+
+type fakeTracer struct {
+	name   string
+	kind   string
+	linked events.SpanContext
+	span   *fakeSpan
+}
+
+func (t *fakeTracer) StartSpan(ctx context.Context, name, kind string, linked events.SpanContext) (context.Context, Span) {
+	t.name, t.kind, t.linked = name, kind, linked
+	t.span = newFakeSpan()
+	sc := events.SpanContext{TraceID: "4bf92f3577b34da6a3ce929d0e0e4736", SpanID: "00f067aa0ba902b7", Sampled: true}
+	return events.ContextWithSpanContext(ctx, sc), t.span
+}
+
+type recordingProducer struct {
+	published *events.Event
+}
+
+func (p *recordingProducer) PublishEvent(ctx context.Context, streamName string, evt *events.Event) error {
+	p.published = evt
+	return nil
+}
+
+func TestTracingProducerStartsSpanAndInjectsTraceContext(t *testing.T) {
+	producer := &recordingProducer{}
+	tracer := &fakeTracer{}
+	tp := NewTracingProducer(producer, tracer)
+
+	evt := &events.Event{ID: "evt-1", Type: "orders.placed.v1", AggregateID: "order-1"}
+	if err := tp.PublishEvent(context.Background(), "stream:orders", evt); err != nil {
+		t.Fatalf("PublishEvent: %v", err)
+	}
+
+	if tracer.name != "event.publish orders.placed.v1" {
+		t.Errorf("span name = %q, want %q", tracer.name, "event.publish orders.placed.v1")
+	}
+	if tracer.kind != SpanKindProducer {
+		t.Errorf("span kind = %q, want %q", tracer.kind, SpanKindProducer)
+	}
+	if !tracer.span.ended {
+		t.Error("span was not ended")
+	}
+	if got := tracer.span.attrs[AttrMessagingSystem]; got != "redis-streams" {
+		t.Errorf("attrs[%s] = %v, want redis-streams", AttrMessagingSystem, got)
+	}
+	if got := tracer.span.attrs[AttrMessagingDestination]; got != "stream:orders" {
+		t.Errorf("attrs[%s] = %v, want stream:orders", AttrMessagingDestination, got)
+	}
+
+	if producer.published == nil {
+		t.Fatal("wrapped producer was not called")
+	}
+	if _, ok := producer.published.Metadata[events.MetadataTraceParent]; !ok {
+		t.Error("published event has no traceparent metadata")
+	}
+}
+
+func TestNoopTracerIsZeroOverhead(t *testing.T) {
+	producer := &recordingProducer{}
+	tp := NewTracingProducer(producer, NoopTracer{})
+
+	evt := &events.Event{ID: "evt-1", Type: "orders.placed.v1"}
+	if err := tp.PublishEvent(context.Background(), "stream:orders", evt); err != nil {
+		t.Fatalf("PublishEvent: %v", err)
+	}
+	if evt.Metadata != nil {
+		t.Errorf("Metadata = %v, want nil (NoopTracer should attach no SpanContext)", evt.Metadata)
+	}
+}
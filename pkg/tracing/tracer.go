@@ -0,0 +1,82 @@
+// Package tracing provides a minimal, SDK-agnostic span abstraction so a
+// StreamProducer/ConsumeStream handler can be wrapped to emit producer and
+// consumer spans - following the OpenTelemetry messaging semantic
+// conventions - without this module depending on a specific tracing SDK.
+// Trace propagation itself (W3C traceparent/tracestate/baggage) lives in
+// pkg/events (events.InjectTraceContext/events.ExtractTraceContext);
+// TracingProducer and WrapHandler here only decide when to start/end a
+// span and what to name it, mirroring how pkg/metering wraps a producer
+// and a ConsumeStream handler for usage accounting.
+//
+// Services that use a real tracing SDK (OpenTelemetry, Datadog, ...)
+// implement Tracer against it; NoopTracer is the default for everyone
+// else, so wiring TracingProducer/WrapHandler in costs nothing until a
+// real Tracer is supplied.
+package tracing
+
+import (
+	"context"
+
+	"github.com/MalteBoehm/tall-affiliate-common/pkg/events"
+)
+
+// Messaging semantic convention attribute keys, matching the OpenTelemetry
+// messaging spec's naming (messaging.system, messaging.destination, ...).
+const (
+	AttrMessagingSystem      = "messaging.system"
+	AttrMessagingDestination = "messaging.destination"
+	AttrMessagingOperation   = "messaging.operation"
+	AttrMessagingMessageID   = "messaging.message_id"
+	AttrEventType            = "event.type"
+	AttrEventAggregateID     = "event.aggregate_id"
+)
+
+// Span kinds, per OpenTelemetry's SpanKind for messaging: a producer span
+// covers the publish call; a consumer span covers one handler invocation.
+const (
+	SpanKindProducer = "producer"
+	SpanKindConsumer = "consumer"
+)
+
+// Span is the handle StartSpan returns: attributes discovered only while
+// or after the wrapped call runs (an error, a resolved message ID) are
+// set on it before End.
+type Span interface {
+	// SetAttribute records one span tag, e.g. AttrMessagingMessageID.
+	SetAttribute(key string, value any)
+	// SetError records err on the span, if err is non-nil.
+	SetError(err error)
+	// End completes the span.
+	End()
+}
+
+// Tracer starts spans and attaches their events.SpanContext to the
+// returned context.Context (via events.ContextWithSpanContext) so
+// events.InjectTraceContext can propagate it onto an outgoing event.
+type Tracer interface {
+	// StartSpan starts a span named name of the given kind (SpanKindProducer
+	// or SpanKindConsumer), as a child of ctx's current span if any. linked
+	// is the producer's events.SpanContext to continue for a consumer span
+	// (its IsValid() is false when there is nothing to link, e.g. for a
+	// producer span or an event with no traceparent).
+	StartSpan(ctx context.Context, name, kind string, linked events.SpanContext) (context.Context, Span)
+}
+
+// NoopTracer starts spans that record nothing and leave ctx unchanged -
+// the default so wrapping a producer/handler with this package costs
+// nothing until a real Tracer is supplied.
+type NoopTracer struct{}
+
+// StartSpan implements Tracer.
+func (NoopTracer) StartSpan(ctx context.Context, _, _ string, _ events.SpanContext) (context.Context, Span) {
+	return ctx, noopSpan{}
+}
+
+type noopSpan struct{}
+
+func (noopSpan) SetAttribute(string, any) {}
+func (noopSpan) SetError(error)           {}
+func (noopSpan) End()                     {}
+
+var _ Tracer = NoopTracer{}
+var _ Span = noopSpan{}
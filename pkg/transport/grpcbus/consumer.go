@@ -0,0 +1,98 @@
+package grpcbus
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/MalteBoehm/tall-affiliate-common/pkg/events"
+	"github.com/MalteBoehm/tall-affiliate-common/pkg/interfaces"
+)
+
+// GRPCConsumer pulls events over a FetchStream (the client side of
+// EventBus.Subscribe), granting delivery credit via FetchRequest and
+// persisting its replay position via a ReplayStore, implementing
+// interfaces.StreamConsumer so it can replace a pkg/redis consumer in
+// NewServiceEventAdapter without other code changes.
+type GRPCConsumer struct {
+	stream      FetchStream
+	replayStore ReplayStore
+}
+
+// NewGRPCConsumer creates a GRPCConsumer that fetches over stream and
+// persists its replay position in replayStore.
+func NewGRPCConsumer(stream FetchStream, replayStore ReplayStore) *GRPCConsumer {
+	return &GRPCConsumer{stream: stream, replayStore: replayStore}
+}
+
+// ConsumeStream implements interfaces.StreamConsumer. It resumes from the
+// replay ID last saved for streamName/groupName (or ReplayLatest on first
+// run), then loops every pollInterval granting batchSize more credit via
+// a FetchRequest and calling handler for each event in the FetchResponse
+// that follows, saving the replay position after each response.
+// FetchResponse.PendingNumRequested (credit granted but not yet used,
+// because fewer than batchSize events were available) carries over: the
+// server is expected to honor it against a smaller NumRequested on a
+// later request rather than the client double-granting it. ctx
+// cancellation stops the loop.
+func (c *GRPCConsumer) ConsumeStream(
+	ctx context.Context,
+	streamName string,
+	groupName string,
+	batchSize int64,
+	pollInterval time.Duration,
+	handler func(context.Context, *events.Event, string) error,
+) error {
+	key := SubscriptionKey(streamName, groupName)
+	startReplayID := ReplayLatest
+	if saved, ok, err := c.replayStore.Load(ctx, key); err == nil && ok {
+		startReplayID = saved
+	}
+
+	first := true
+	for {
+		select {
+		case <-ctx.Done():
+			return ctx.Err()
+		default:
+		}
+
+		req := &FetchRequest{Stream: streamName, Group: groupName, NumRequested: batchSize}
+		if first {
+			req.StartReplayID = startReplayID
+			first = false
+		}
+		if err := c.stream.Send(req); err != nil {
+			return fmt.Errorf("grpcbus: failed to send fetch request: %w", err)
+		}
+
+		resp, err := c.stream.Recv()
+		if err != nil {
+			return fmt.Errorf("grpcbus: failed to receive fetch response: %w", err)
+		}
+
+		for _, wire := range resp.Events {
+			evt, err := fromWireEvent(wire)
+			if err != nil {
+				return err
+			}
+			if err := handler(ctx, evt, evt.ID); err != nil {
+				return fmt.Errorf("grpcbus: handler failed for event %s: %w", evt.ID, err)
+			}
+		}
+
+		if resp.LatestReplayID != "" {
+			if err := c.replayStore.Save(ctx, key, resp.LatestReplayID); err != nil {
+				return fmt.Errorf("grpcbus: failed to save replay position: %w", err)
+			}
+		}
+
+		select {
+		case <-ctx.Done():
+			return ctx.Err()
+		case <-time.After(pollInterval):
+		}
+	}
+}
+
+var _ interfaces.StreamConsumer = (*GRPCConsumer)(nil)
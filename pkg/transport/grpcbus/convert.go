@@ -0,0 +1,75 @@
+package grpcbus
+
+import (
+	"encoding/json"
+	"fmt"
+	"time"
+
+	"github.com/MalteBoehm/tall-affiliate-common/pkg/events"
+)
+
+// toWireEvent converts evt to its wire representation, translating evt's
+// orchestrator code to a canonical CloudEvents type via events.CodeToCE
+// when one is registered, so polyglot subscribers see event.type values
+// they can match against without knowing this repo's internal codes.
+func toWireEvent(evt *events.Event) (*Event, error) {
+	payload, err := json.Marshal(evt.Payload)
+	if err != nil {
+		return nil, fmt.Errorf("grpcbus: failed to marshal payload for event %s: %w", evt.ID, err)
+	}
+
+	wireType := evt.Type
+	if ceType, ok := events.CodeToCE[evt.Type]; ok {
+		wireType = ceType
+	}
+
+	metadata := make(map[string]string, len(evt.Metadata))
+	for k, v := range evt.Metadata {
+		if s, ok := v.(string); ok {
+			metadata[k] = s
+		}
+	}
+
+	return &Event{
+		ID:              evt.ID,
+		Type:            wireType,
+		AggregateType:   evt.AggregateType,
+		AggregateID:     evt.AggregateID,
+		Payload:         payload,
+		TimestampUnixNs: evt.Timestamp.UnixNano(),
+		Metadata:        metadata,
+	}, nil
+}
+
+// fromWireEvent is the inverse of toWireEvent: it translates a wire
+// event's type back to this repo's orchestrator code via events.CEToCode
+// when one is registered, falling back to the wire type unchanged
+// otherwise (e.g. for a type this repo doesn't have a code for yet).
+func fromWireEvent(wire *Event) (*events.Event, error) {
+	var payload any
+	if len(wire.Payload) > 0 {
+		if err := json.Unmarshal(wire.Payload, &payload); err != nil {
+			return nil, fmt.Errorf("grpcbus: failed to unmarshal payload for event %s: %w", wire.ID, err)
+		}
+	}
+
+	eventType := wire.Type
+	if code, ok := events.CEToCode[wire.Type]; ok {
+		eventType = code
+	}
+
+	metadata := make(map[string]any, len(wire.Metadata))
+	for k, v := range wire.Metadata {
+		metadata[k] = v
+	}
+
+	return &events.Event{
+		ID:            wire.ID,
+		Type:          eventType,
+		AggregateType: wire.AggregateType,
+		AggregateID:   wire.AggregateID,
+		Payload:       payload,
+		Timestamp:     time.Unix(0, wire.TimestampUnixNs).UTC(),
+		Metadata:      metadata,
+	}, nil
+}
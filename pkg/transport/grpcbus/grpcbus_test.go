@@ -0,0 +1,86 @@
+package grpcbus
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/MalteBoehm/tall-affiliate-common/pkg/events"
+)
+
+func TestProducerConsumerRoundTrip(t *testing.T) {
+	bus := NewLocalBus()
+	producer := NewGRPCProducer(bus.PublishStream())
+
+	store := NewInMemoryReplayStore()
+	store.Save(context.Background(), SubscriptionKey("stream:product_lifecycle", "test-group"), ReplayEarliest)
+	consumer := NewGRPCConsumer(bus.FetchStream(), store)
+
+	evt, err := events.NewEvent("01_PRODUCT_DETECTED", "product", "acme-1", map[string]string{"asin": "B0"})
+	if err != nil {
+		t.Fatalf("NewEvent: %v", err)
+	}
+	if err := producer.PublishEvent(context.Background(), "stream:product_lifecycle", evt); err != nil {
+		t.Fatalf("PublishEvent: %v", err)
+	}
+
+	ctx, cancel := context.WithCancel(context.Background())
+	received := make(chan *events.Event, 1)
+	handler := func(_ context.Context, e *events.Event, _ string) error {
+		received <- e
+		cancel()
+		return nil
+	}
+
+	err = consumer.ConsumeStream(ctx, "stream:product_lifecycle", "test-group", 10, 10*time.Millisecond, handler)
+	if err != context.Canceled {
+		t.Fatalf("ConsumeStream returned %v, want context.Canceled", err)
+	}
+
+	select {
+	case got := <-received:
+		if got.ID != evt.ID {
+			t.Errorf("got event ID %q, want %q", got.ID, evt.ID)
+		}
+		if got.Type != "01_PRODUCT_DETECTED" {
+			t.Errorf("got type %q, want 01_PRODUCT_DETECTED", got.Type)
+		}
+	default:
+		t.Fatal("handler was never called")
+	}
+}
+
+func TestConsumerResumesFromSavedReplayID(t *testing.T) {
+	bus := NewLocalBus()
+	producer := NewGRPCProducer(bus.PublishStream())
+
+	for i := 0; i < 3; i++ {
+		evt, _ := events.NewEvent("01_PRODUCT_DETECTED", "product", "acme-1", nil)
+		if err := producer.PublishEvent(context.Background(), "s", evt); err != nil {
+			t.Fatalf("PublishEvent %d: %v", i, err)
+		}
+	}
+
+	store := NewInMemoryReplayStore()
+	store.Save(context.Background(), SubscriptionKey("s", "g"), "0")
+
+	consumer := NewGRPCConsumer(bus.FetchStream(), store)
+
+	var gotCount int
+	ctx, cancel := context.WithCancel(context.Background())
+	handler := func(_ context.Context, _ *events.Event, _ string) error {
+		gotCount++
+		if gotCount == 2 {
+			cancel()
+		}
+		return nil
+	}
+
+	err := consumer.ConsumeStream(ctx, "s", "g", 10, 10*time.Millisecond, handler)
+	if err != context.Canceled {
+		t.Fatalf("ConsumeStream returned %v, want context.Canceled", err)
+	}
+	if gotCount != 2 {
+		t.Errorf("handler called %d times, want 2 (events at replay id 1 and 2)", gotCount)
+	}
+}
@@ -0,0 +1,43 @@
+package grpcbus
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/MalteBoehm/tall-affiliate-common/pkg/events"
+	"github.com/MalteBoehm/tall-affiliate-common/pkg/interfaces"
+)
+
+// GRPCProducer publishes events over a PublishStream (the client side of
+// EventBus.Publish), implementing interfaces.StreamProducer so it can
+// replace a pkg/redis producer in NewServiceEventAdapter without other
+// code changes.
+type GRPCProducer struct {
+	stream PublishStream
+}
+
+// NewGRPCProducer creates a GRPCProducer that publishes over stream.
+func NewGRPCProducer(stream PublishStream) *GRPCProducer {
+	return &GRPCProducer{stream: stream}
+}
+
+// PublishEvent implements interfaces.StreamProducer: it sends evt as a
+// PublishRequest and waits for its PublishResponse before returning, so a
+// caller that gets a nil error knows the event has a replay ID assigned.
+func (p *GRPCProducer) PublishEvent(ctx context.Context, streamName string, evt *events.Event) error {
+	wire, err := toWireEvent(evt)
+	if err != nil {
+		return err
+	}
+
+	if err := p.stream.Send(&PublishRequest{Stream: streamName, Event: wire}); err != nil {
+		return fmt.Errorf("grpcbus: failed to send publish request for event %s: %w", evt.ID, err)
+	}
+
+	if _, err := p.stream.Recv(); err != nil {
+		return fmt.Errorf("grpcbus: failed to receive publish response for event %s: %w", evt.ID, err)
+	}
+	return nil
+}
+
+var _ interfaces.StreamProducer = (*GRPCProducer)(nil)
@@ -0,0 +1,55 @@
+package grpcbus
+
+import (
+	"context"
+	"fmt"
+	"sync"
+)
+
+// ReplayStore persists the last replay ID a subscription has consumed up
+// to, so GRPCConsumer can resume a fetch loop (after a restart, or a
+// second call to ConsumeStream) from where it left off instead of always
+// starting at ReplayLatest.
+type ReplayStore interface {
+	// Load returns the last saved replay ID for key, or ("", false) if
+	// none has been saved yet.
+	Load(ctx context.Context, key string) (string, bool, error)
+	// Save persists replayID as key's new replay position.
+	Save(ctx context.Context, key, replayID string) error
+}
+
+// SubscriptionKey derives the ReplayStore key for a stream/group pair.
+func SubscriptionKey(stream, group string) string {
+	return fmt.Sprintf("%s/%s", stream, group)
+}
+
+// InMemoryReplayStore is a ReplayStore backed by a map, for tests and
+// single-process services that don't need replay position to survive a
+// restart.
+type InMemoryReplayStore struct {
+	mu  sync.Mutex
+	ids map[string]string
+}
+
+// NewInMemoryReplayStore creates an empty InMemoryReplayStore.
+func NewInMemoryReplayStore() *InMemoryReplayStore {
+	return &InMemoryReplayStore{ids: make(map[string]string)}
+}
+
+// Load implements ReplayStore.
+func (s *InMemoryReplayStore) Load(ctx context.Context, key string) (string, bool, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	id, ok := s.ids[key]
+	return id, ok, nil
+}
+
+// Save implements ReplayStore.
+func (s *InMemoryReplayStore) Save(ctx context.Context, key, replayID string) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.ids[key] = replayID
+	return nil
+}
+
+var _ ReplayStore = (*InMemoryReplayStore)(nil)
@@ -0,0 +1,79 @@
+// Package grpcbus wires pkg/events' Event onto the credit-based streaming
+// pub/sub transport described by eventbus.proto: a Publish stream of
+// request/acknowledgement pairs, and a Subscribe pull loop where the
+// client grants delivery credit via FetchRequest.NumRequested and the
+// server never sends more events than that outstanding credit covers.
+// GRPCProducer/GRPCConsumer implement interfaces.StreamProducer/
+// StreamConsumer so a service can swap this in for pkg/redis's stream
+// transport via NewServiceEventAdapter without other code changes.
+//
+// protoc/protoc-gen-go-grpc aren't available in this environment, so the
+// wire types below are hand-written Go mirrors of eventbus.proto's
+// messages rather than protoc-generated code; PublishStream/FetchStream
+// abstract the bidirectional gRPC streams so GRPCProducer/GRPCConsumer
+// compile and are fully testable today (see local_bus.go) against
+// anything satisfying those two interfaces - including the real
+// generated grpc.ClientStream once eventbus.proto is compiled.
+package grpcbus
+
+// Event mirrors eventbus.proto's Event message: Payload is carried as
+// already-JSON-encoded bytes, Timestamp as Unix nanoseconds, so the wire
+// type doesn't need to know about every events.Event.Payload type.
+type Event struct {
+	ID              string
+	Type            string
+	AggregateType   string
+	AggregateID     string
+	Payload         []byte
+	TimestampUnixNs int64
+	Metadata        map[string]string
+}
+
+// PublishRequest mirrors eventbus.proto's PublishRequest.
+type PublishRequest struct {
+	Stream string
+	Event  *Event
+}
+
+// PublishResponse mirrors eventbus.proto's PublishResponse.
+type PublishResponse struct {
+	EventID  string
+	ReplayID string
+}
+
+// FetchRequest mirrors eventbus.proto's FetchRequest.
+type FetchRequest struct {
+	Stream        string
+	Group         string
+	NumRequested  int64
+	StartReplayID string
+}
+
+// FetchResponse mirrors eventbus.proto's FetchResponse.
+type FetchResponse struct {
+	Events              []*Event
+	LatestReplayID      string
+	PendingNumRequested int64
+}
+
+// Replay ID sentinels accepted as FetchRequest.StartReplayID.
+const (
+	ReplayLatest   = "latest"
+	ReplayEarliest = "earliest"
+)
+
+// PublishStream is the subset of a generated EventBus_PublishClient (or
+// EventBus_PublishServer, from the server side) GRPCProducer needs: send
+// one PublishRequest per publish and receive back its PublishResponse.
+type PublishStream interface {
+	Send(*PublishRequest) error
+	Recv() (*PublishResponse, error)
+}
+
+// FetchStream is the subset of a generated EventBus_SubscribeClient (or
+// EventBus_SubscribeServer) GRPCConsumer needs: send credit-granting
+// FetchRequest frames and receive FetchResponse batches.
+type FetchStream interface {
+	Send(*FetchRequest) error
+	Recv() (*FetchResponse, error)
+}